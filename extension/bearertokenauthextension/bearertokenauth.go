@@ -16,25 +16,42 @@ package bearertokenauthextension // import "github.com/open-telemetry/openteleme
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 
+	"github.com/fsnotify/fsnotify"
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/credentials"
+	"gopkg.in/yaml.v3"
+)
+
+const bearerScheme = "bearer "
+
+var (
+	errMissingAuthorizationHeader = errors.New("missing authorization header")
+	errInvalidAuthorizationHeader = errors.New("invalid authorization header format, expected a bearer token")
+	errInvalidToken               = errors.New("invalid bearer token")
 )
 
 var _ credentials.PerRPCCredentials = (*PerRPCAuth)(nil)
 
 // PerRPCAuth is a gRPC credentials.PerRPCCredentials implementation that returns an 'authorization' header.
 type PerRPCAuth struct {
-	metadata map[string]string
+	auth *BearerTokenAuth
 }
 
 // GetRequestMetadata returns the request metadata to be used with the RPC.
 func (c *PerRPCAuth) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
-	return c.metadata, nil
+	return map[string]string{"authorization": c.auth.bearerToken()}, nil
 }
 
 // RequireTransportSecurity always returns true for this implementation. Passing bearer tokens in plain-text connections is a bad idea.
@@ -42,54 +59,203 @@ func (c *PerRPCAuth) RequireTransportSecurity() bool {
 	return true
 }
 
-// BearerTokenAuth is an implementation of configauth.GRPCClientAuthenticator. It embeds a static authorization "bearer" token in every rpc call.
+// BearerTokenAuth is an implementation of configauth.ClientAuthenticator and configauth.ServerAuthenticator.
+// It embeds an authorization "bearer" token in every rpc call, and checks the bearer token of incoming requests.
 type BearerTokenAuth struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	mu          sync.RWMutex
 	tokenString string
-	logger      *zap.Logger
+	tokens      []TokenConfig
+
+	watcher    *fsnotify.Watcher
+	shutdownWG sync.WaitGroup
 }
 
 var _ configauth.ClientAuthenticator = (*BearerTokenAuth)(nil)
+var _ configauth.ServerAuthenticator = (*BearerTokenAuth)(nil)
 
 func newBearerTokenAuth(cfg *Config, logger *zap.Logger) *BearerTokenAuth {
 	return &BearerTokenAuth{
-		tokenString: cfg.BearerToken,
+		cfg:         cfg,
 		logger:      logger,
+		tokenString: cfg.BearerToken,
+		tokens:      cfg.Tokens,
 	}
 }
 
-// Start of BearerTokenAuth does nothing and returns nil
+// Start of BearerTokenAuth reads the token files and starts watching them for changes.
 func (b *BearerTokenAuth) Start(ctx context.Context, host component.Host) error {
+	if b.cfg.Filename == "" && b.cfg.TokensFile == "" {
+		return nil
+	}
+
+	if err := b.reload(); err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	// Watch the directories rather than the files, so that files replaced by a
+	// rename or a symlink swap, as done for Kubernetes projected volumes, are
+	// still watched.
+	for _, file := range []string{b.cfg.Filename, b.cfg.TokensFile} {
+		if file == "" {
+			continue
+		}
+		if err = watcher.Add(filepath.Dir(file)); err != nil {
+			_ = watcher.Close()
+			return fmt.Errorf("failed to watch %q: %w", file, err)
+		}
+	}
+	b.watcher = watcher
+
+	b.shutdownWG.Add(1)
+	go b.watch()
 	return nil
 }
 
-// Shutdown of BearerTokenAuth does nothing and returns nil
+// Shutdown of BearerTokenAuth stops watching the token files.
 func (b *BearerTokenAuth) Shutdown(ctx context.Context) error {
+	if b.watcher == nil {
+		return nil
+	}
+	err := b.watcher.Close()
+	b.shutdownWG.Wait()
+	return err
+}
+
+func (b *BearerTokenAuth) watch() {
+	defer b.shutdownWG.Done()
+	for {
+		select {
+		case _, ok := <-b.watcher.Events:
+			if !ok {
+				return
+			}
+			if err := b.reload(); err != nil {
+				b.logger.Warn("Failed to reload bearer tokens, keeping the previous ones", zap.Error(err))
+			}
+		case err, ok := <-b.watcher.Errors:
+			if !ok {
+				return
+			}
+			b.logger.Warn("Error watching bearer token files", zap.Error(err))
+		}
+	}
+}
+
+// reload reads the token files. The current tokens are kept if any of them
+// can't be read.
+func (b *BearerTokenAuth) reload() error {
+	tokenString := b.cfg.BearerToken
+	if b.cfg.Filename != "" {
+		content, err := os.ReadFile(b.cfg.Filename)
+		if err != nil {
+			return fmt.Errorf("failed to read token file: %w", err)
+		}
+		if tokenString = strings.TrimSpace(string(content)); tokenString == "" {
+			return fmt.Errorf("token file %q is empty", b.cfg.Filename)
+		}
+	}
+
+	tokens := b.cfg.Tokens
+	if b.cfg.TokensFile != "" {
+		fileTokens, err := readTokensFile(b.cfg.TokensFile)
+		if err != nil {
+			return err
+		}
+		tokens = append(append([]TokenConfig{}, tokens...), fileTokens...)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokenString = tokenString
+	b.tokens = tokens
 	return nil
 }
 
+func readTokensFile(filename string) ([]TokenConfig, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tokens file: %w", err)
+	}
+	var tokens []TokenConfig
+	if err = yaml.Unmarshal(content, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse tokens file %q: %w", filename, err)
+	}
+	if err = validateTokens(tokens); err != nil {
+		return nil, fmt.Errorf("invalid tokens file %q: %w", filename, err)
+	}
+	return tokens, nil
+}
+
 // PerRPCCredentials returns PerRPCAuth an implementation of credentials.PerRPCCredentials that
+// adds the current bearer token to the metadata of every RPC.
 func (b *BearerTokenAuth) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
-	return &PerRPCAuth{
-		metadata: map[string]string{"authorization": b.bearerToken()},
-	}, nil
+	return &PerRPCAuth{auth: b}, nil
 }
 
 func (b *BearerTokenAuth) bearerToken() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
 	return fmt.Sprintf("Bearer %s", b.tokenString)
 }
 
-// RoundTripper is not implemented by BearerTokenAuth
+// RoundTripper returns a BearerAuthRoundTripper wrapping base.
 func (b *BearerTokenAuth) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
 	return &BearerAuthRoundTripper{
 		baseTransport: base,
-		bearerToken:   b.bearerToken(),
+		auth:          b,
 	}, nil
 }
 
+// Authenticate checks the bearer token of the authorization header is one of the
+// configured tokens, and adds the name and tenant of the token to the client info.
+func (b *BearerTokenAuth) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	var authHeaders []string
+	for name, values := range headers {
+		if strings.EqualFold(name, "authorization") {
+			authHeaders = values
+			break
+		}
+	}
+	if len(authHeaders) == 0 {
+		return ctx, errMissingAuthorizationHeader
+	}
+
+	// we only use the first header, if multiple values exist
+	header := authHeaders[0]
+	if len(header) <= len(bearerScheme) || !strings.EqualFold(header[:len(bearerScheme)], bearerScheme) {
+		return ctx, errInvalidAuthorizationHeader
+	}
+	received := []byte(strings.TrimSpace(header[len(bearerScheme):]))
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	var matched *TokenConfig
+	for i := range b.tokens {
+		// compare with all the tokens, in constant time, not to leak which ones are close
+		if subtle.ConstantTimeCompare(received, []byte(b.tokens[i].Token)) == 1 && matched == nil {
+			matched = &b.tokens[i]
+		}
+	}
+	if matched == nil {
+		return ctx, errInvalidToken
+	}
+
+	cl := client.FromContext(ctx)
+	cl.Auth = &authData{name: matched.Name, tenant: matched.Tenant}
+	return client.NewContext(ctx, cl), nil
+}
+
 // BearerAuthRoundTripper intercepts and adds Bearer token Authorization headers to each http request.
 type BearerAuthRoundTripper struct {
 	baseTransport http.RoundTripper
-	bearerToken   string
+	auth          *BearerTokenAuth
 }
 
 // RoundTrip modifies the original request and adds Bearer token Authorization headers.
@@ -98,6 +264,6 @@ func (interceptor *BearerAuthRoundTripper) RoundTrip(req *http.Request) (*http.R
 	if req2.Header == nil {
 		req2.Header = make(http.Header)
 	}
-	req2.Header.Set("Authorization", interceptor.bearerToken)
+	req2.Header.Set("Authorization", interceptor.auth.bearerToken())
 	return interceptor.baseTransport.RoundTrip(req2)
 }
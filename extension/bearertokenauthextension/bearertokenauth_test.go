@@ -18,10 +18,16 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
 )
 
 func TestPerRPCAuth(t *testing.T) {
@@ -30,7 +36,7 @@ func TestPerRPCAuth(t *testing.T) {
 	}
 
 	// test meta data is properly
-	perRPCAuth := &PerRPCAuth{metadata: metadata}
+	perRPCAuth := &PerRPCAuth{auth: newBearerTokenAuth(&Config{BearerToken: "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."}, nil)}
 	md, err := perRPCAuth.GetRequestMetadata(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, md, metadata)
@@ -104,3 +110,198 @@ func TestBearerAuthenticator(t *testing.T) {
 	assert.Equal(t, expectedHeaders, resp.Header)
 	assert.Nil(t, bauth.Shutdown(context.Background()))
 }
+
+// writeFile replaces the content of filename atomically, the way Kubernetes updates projected volumes.
+func writeFile(t *testing.T, filename string, content string) {
+	tmp := filename + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte(content), 0600))
+	require.NoError(t, os.Rename(tmp, filename))
+}
+
+func TestBearerTokenFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "token")
+	writeFile(t, filename, "token1\n")
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Filename = filename
+	require.NoError(t, cfg.Validate())
+
+	bauth := newBearerTokenAuth(cfg, zap.NewNop())
+	require.NoError(t, bauth.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, bauth.Shutdown(context.Background())) }()
+
+	credential, err := bauth.PerRPCCredentials()
+	require.NoError(t, err)
+	roundTripper, err := bauth.RoundTripper(&mockRoundTripper{})
+	require.NoError(t, err)
+
+	md, err := credential.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer token1"}, md)
+
+	writeFile(t, filename, "token2")
+	assert.Eventually(t, func() bool {
+		md, err = credential.GetRequestMetadata(context.Background())
+		return err == nil && md["authorization"] == "Bearer token2"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	resp, err := roundTripper.RoundTrip(&http.Request{})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token2", resp.Header.Get("Authorization"))
+
+	// an empty file is ignored
+	writeFile(t, filename, "")
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, "Bearer token2", bauth.bearerToken())
+}
+
+func TestBearerTokenFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty")
+	writeFile(t, empty, " \n")
+	invalid := filepath.Join(dir, "invalid.yaml")
+	writeFile(t, invalid, "- name: no-token\n")
+
+	tests := []struct {
+		name        string
+		cfg         *Config
+		expectedErr string
+	}{
+		{
+			name:        "missing token file",
+			cfg:         &Config{Filename: filepath.Join(dir, "missing")},
+			expectedErr: "failed to read token file",
+		},
+		{
+			name:        "empty token file",
+			cfg:         &Config{Filename: empty},
+			expectedErr: "is empty",
+		},
+		{
+			name:        "missing tokens file",
+			cfg:         &Config{TokensFile: filepath.Join(dir, "missing")},
+			expectedErr: "failed to read tokens file",
+		},
+		{
+			name:        "invalid tokens file",
+			cfg:         &Config{TokensFile: invalid},
+			expectedErr: `token 0 ("no-token") is empty`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bauth := newBearerTokenAuth(tt.cfg, zap.NewNop())
+			err := bauth.Start(context.Background(), componenttest.NewNopHost())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+			assert.NoError(t, bauth.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Tokens = []TokenConfig{
+		{Name: "team-a", Tenant: "acme", Token: "token-a"},
+		{Name: "team-b", Token: "token-b"},
+	}
+	require.NoError(t, cfg.Validate())
+
+	bauth := newBearerTokenAuth(cfg, zap.NewNop())
+	require.NoError(t, bauth.Start(context.Background(), componenttest.NewNopHost()))
+
+	tests := []struct {
+		name           string
+		headers        map[string][]string
+		expectedName   string
+		expectedTenant string
+		expectedErr    error
+	}{
+		{
+			name:           "grpc metadata",
+			headers:        map[string][]string{"authorization": {"Bearer token-a"}},
+			expectedName:   "team-a",
+			expectedTenant: "acme",
+		},
+		{
+			name:         "http header",
+			headers:      map[string][]string{"Authorization": {"bearer token-b"}},
+			expectedName: "team-b",
+		},
+		{
+			name:        "missing header",
+			headers:     map[string][]string{"x-scope-orgid": {"acme"}},
+			expectedErr: errMissingAuthorizationHeader,
+		},
+		{
+			name:        "basic auth",
+			headers:     map[string][]string{"Authorization": {"Basic dXNlcjpwYXNz"}},
+			expectedErr: errInvalidAuthorizationHeader,
+		},
+		{
+			name:        "unknown token",
+			headers:     map[string][]string{"authorization": {"Bearer token-c"}},
+			expectedErr: errInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := bauth.Authenticate(context.Background(), tt.headers)
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				assert.Nil(t, client.FromContext(ctx).Auth)
+				return
+			}
+			require.NoError(t, err)
+			auth := client.FromContext(ctx).Auth
+			require.NotNil(t, auth)
+			assert.Equal(t, tt.expectedName, auth.GetAttribute("name"))
+			assert.Equal(t, tt.expectedTenant, auth.GetAttribute("tenant"))
+			assert.Equal(t, []string{"name", "tenant"}, auth.GetAttributeNames())
+		})
+	}
+}
+
+func TestAuthenticateTokensFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "tokens.yaml")
+	writeFile(t, filename, "- name: team-a\n  tenant: acme\n  token: token-a\n")
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Tokens = []TokenConfig{{Name: "static", Token: "token-static"}}
+	cfg.TokensFile = filename
+
+	bauth := newBearerTokenAuth(cfg, zap.NewNop())
+	require.NoError(t, bauth.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, bauth.Shutdown(context.Background())) }()
+
+	authenticate := func(token string) (string, error) {
+		ctx, err := bauth.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer " + token}})
+		if err != nil {
+			return "", err
+		}
+		return client.FromContext(ctx).Auth.GetAttribute("tenant").(string), nil
+	}
+
+	tenant, err := authenticate("token-a")
+	require.NoError(t, err)
+	assert.Equal(t, "acme", tenant)
+	_, err = authenticate("token-static")
+	require.NoError(t, err)
+
+	// rotate the token of team-a
+	writeFile(t, filename, "- name: team-a\n  tenant: acme\n  token: token-a2\n")
+	assert.Eventually(t, func() bool {
+		_, err = authenticate("token-a2")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = authenticate("token-a")
+	assert.Equal(t, errInvalidToken, err)
+	_, err = authenticate("token-static")
+	assert.NoError(t, err)
+
+	// an invalid file is ignored
+	writeFile(t, filename, "not: a list")
+	time.Sleep(100 * time.Millisecond)
+	_, err = authenticate("token-a2")
+	assert.NoError(t, err)
+}
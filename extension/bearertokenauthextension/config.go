@@ -16,6 +16,7 @@ package bearertokenauthextension // import "github.com/open-telemetry/openteleme
 
 import (
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/config"
 )
@@ -26,15 +27,50 @@ type Config struct {
 
 	// BearerToken specifies the bearer token to use for every RPC.
 	BearerToken string `mapstructure:"token,omitempty"`
+
+	// Filename points to a file containing the bearer token to use for every RPC,
+	// as an alternative to BearerToken. The file is read again when it changes.
+	Filename string `mapstructure:"filename,omitempty"`
+
+	// Tokens are the tokens accepted when authenticating incoming requests.
+	Tokens []TokenConfig `mapstructure:"tokens,omitempty"`
+
+	// TokensFile points to a YAML file containing a list of tokens accepted when
+	// authenticating incoming requests, in addition to Tokens. The file is read
+	// again when it changes.
+	TokensFile string `mapstructure:"tokens_file,omitempty"`
+}
+
+// TokenConfig is a token accepted when authenticating incoming requests.
+type TokenConfig struct {
+	// Name identifies the token, it is added to the authentication data of the requests.
+	Name string `mapstructure:"name" yaml:"name"`
+	// Tenant is added to the authentication data of the requests.
+	Tenant string `mapstructure:"tenant" yaml:"tenant"`
+	// Token is the bearer token expected in the requests.
+	Token string `mapstructure:"token" yaml:"token"`
 }
 
 var _ config.Extension = (*Config)(nil)
 var errNoTokenProvided = errors.New("no bearer token provided")
+var errTokenAndFilename = errors.New("either token or filename can be set, not both")
 
 // Validate checks if the extension configuration is valid
 func (cfg *Config) Validate() error {
-	if cfg.BearerToken == "" {
+	if cfg.BearerToken != "" && cfg.Filename != "" {
+		return errTokenAndFilename
+	}
+	if cfg.BearerToken == "" && cfg.Filename == "" && len(cfg.Tokens) == 0 && cfg.TokensFile == "" {
 		return errNoTokenProvided
 	}
+	return validateTokens(cfg.Tokens)
+}
+
+func validateTokens(tokens []TokenConfig) error {
+	for i, token := range tokens {
+		if token.Token == "" {
+			return fmt.Errorf("token %d (%q) is empty", i, token.Name)
+		}
+	}
 	return nil
 }
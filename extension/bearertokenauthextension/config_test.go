@@ -43,6 +43,29 @@ func TestLoadConfig(t *testing.T) {
 				BearerToken:       "sometoken",
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "filename"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				Filename:          "file-containing.token",
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "both"),
+			expectedErr: true,
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "server"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				Tokens:            []TokenConfig{{Name: "team-a", Tenant: "acme", Token: "token-a"}},
+				TokensFile:        "tokens.yaml",
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "emptytoken"),
+			expectedErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
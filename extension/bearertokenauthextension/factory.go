@@ -26,7 +26,7 @@ const (
 	typeStr = "bearertokenauth"
 )
 
-// NewFactory creates a factory for the static bearer token Authenticator extension.
+// NewFactory creates a factory for the bearer token Authenticator extension.
 func NewFactory() component.ExtensionFactory {
 	return component.NewExtensionFactory(
 		typeStr,
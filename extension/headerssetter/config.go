@@ -23,8 +23,8 @@ import (
 var (
 	errMissingHeader        = fmt.Errorf("missing header name")
 	errMissingHeadersConfig = fmt.Errorf("missing headers configuration")
-	errMissingSource        = fmt.Errorf("missing header source, must be 'from_context' or 'value'")
-	errConflictingSources   = fmt.Errorf("invalid header source, must either 'from_context' or 'value'")
+	errMissingSource        = fmt.Errorf("missing header source, must be 'value', 'from_context', 'from_env', 'from_file' or 'from_attribute'")
+	errConflictingSources   = fmt.Errorf("invalid header source, must be only one of 'value', 'from_context', 'from_env', 'from_file' or 'from_attribute'")
 )
 
 type Config struct {
@@ -33,9 +33,12 @@ type Config struct {
 }
 
 type HeaderConfig struct {
-	Key         *string `mapstructure:"key"`
-	Value       *string `mapstructure:"value"`
-	FromContext *string `mapstructure:"from_context"`
+	Key           *string `mapstructure:"key"`
+	Value         *string `mapstructure:"value"`
+	FromContext   *string `mapstructure:"from_context"`
+	FromEnv       *string `mapstructure:"from_env"`
+	FromFile      *string `mapstructure:"from_file"`
+	FromAttribute *string `mapstructure:"from_attribute"`
 }
 
 // Validate checks if the extension configuration is valid
@@ -47,10 +50,16 @@ func (cfg *Config) Validate() error {
 		if header.Key == nil || *header.Key == "" {
 			return errMissingHeader
 		}
-		if header.FromContext == nil && header.Value == nil {
+		sources := 0
+		for _, s := range []*string{header.Value, header.FromContext, header.FromEnv, header.FromFile, header.FromAttribute} {
+			if s != nil {
+				sources++
+			}
+		}
+		if sources == 0 {
 			return errMissingSource
 		}
-		if header.FromContext != nil && header.Value != nil {
+		if sources > 1 {
 			return errConflictingSources
 		}
 	}
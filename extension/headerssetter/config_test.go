@@ -53,6 +53,26 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "2"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				HeadersConfig: []HeaderConfig{
+					{
+						Key:           stringp("X-Scope-OrgID"),
+						FromAttribute: stringp("tenant"),
+					},
+					{
+						Key:      stringp("Authorization"),
+						FromFile: stringp("/var/run/secrets/token"),
+					},
+					{
+						Key:     stringp("X-Cluster"),
+						FromEnv: stringp("CLUSTER_NAME"),
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -94,6 +114,27 @@ func TestValidateConfig(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"header value from resource attribute",
+			[]HeaderConfig{
+				{
+					Key:           stringp("name"),
+					FromAttribute: stringp("tenant"),
+				},
+			},
+			nil,
+		},
+		{
+			"header value from environment and file",
+			[]HeaderConfig{
+				{
+					Key:      stringp("name"),
+					FromEnv:  stringp("TOKEN"),
+					FromFile: stringp("/var/run/secrets/token"),
+				},
+			},
+			errConflictingSources,
+		},
 		{
 			"missing header name for from value",
 			[]HeaderConfig{
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
 
 	"go.opentelemetry.io/collector/config/configauth"
 	"google.golang.org/grpc/credentials"
@@ -39,14 +40,34 @@ func newHeadersSetterExtension(cfg *Config) (configauth.ClientAuthenticator, err
 	headers := make([]Header, 0, len(cfg.HeadersConfig))
 	for _, header := range cfg.HeadersConfig {
 		var s source.Source
-		if header.Value != nil {
+		switch {
+		case header.Value != nil:
 			s = &source.StaticSource{
 				Value: *header.Value,
 			}
-		} else if header.FromContext != nil {
+		case header.FromContext != nil:
 			s = &source.ContextSource{
 				Key: *header.FromContext,
 			}
+		case header.FromEnv != nil:
+			if _, ok := os.LookupEnv(*header.FromEnv); !ok {
+				return nil, fmt.Errorf("environment variable %q of header %q is not set", *header.FromEnv, *header.Key)
+			}
+			s = &source.EnvSource{
+				Name: *header.FromEnv,
+			}
+		case header.FromFile != nil:
+			s = &source.FileSource{
+				Path: *header.FromFile,
+			}
+			// Fail early when the file can't be read.
+			if _, err := s.Get(context.Background()); err != nil {
+				return nil, err
+			}
+		case header.FromAttribute != nil:
+			s = &source.AttributeSource{
+				Key: *header.FromAttribute,
+			}
 		}
 		headers = append(headers, Header{key: *header.Key, source: s})
 	}
@@ -17,10 +17,15 @@ package headerssetter
 import (
 	"context"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/client"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchperresourceattr"
 )
 
 type mockRoundTripper struct{}
@@ -98,6 +103,88 @@ func TestPerRPCCredentials(t *testing.T) {
 	}
 }
 
+func TestResourceAttributeHeader(t *testing.T) {
+	ext, err := newHeadersSetterExtension(&Config{
+		HeadersConfig: []HeaderConfig{
+			{
+				Key:           &header,
+				FromAttribute: stringp("tenant"),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	roundTripper, err := ext.RoundTripper(mrt)
+	require.NoError(t, err)
+	perRPC, err := ext.PerRPCCredentials()
+	require.NoError(t, err)
+
+	for _, tenant := range []string{"acme", "globex"} {
+		ctx := batchperresourceattr.NewContext(context.Background(), "tenant", tenant)
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "", nil)
+		require.NoError(t, err)
+		resp, err := roundTripper.RoundTrip(req)
+		require.NoError(t, err)
+		assert.Equal(t, tenant, resp.Header.Get(header))
+
+		metadata, err := perRPC.GetRequestMetadata(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, tenant, metadata[header])
+	}
+}
+
+func TestEnvAndFileHeaders(t *testing.T) {
+	t.Setenv("OTEL_CLUSTER_NAME", "cluster-1")
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("Bearer token\n"), 0600))
+
+	ext, err := newHeadersSetterExtension(&Config{
+		HeadersConfig: []HeaderConfig{
+			{
+				Key:     &header,
+				FromEnv: stringp("OTEL_CLUSTER_NAME"),
+			},
+			{
+				Key:      &anotherHeader,
+				FromFile: stringp(tokenFile),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	perRPC, err := ext.PerRPCCredentials()
+	require.NoError(t, err)
+	metadata, err := perRPC.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		header:        "cluster-1",
+		anotherHeader: "Bearer token",
+	}, metadata)
+}
+
+func TestInvalidSources(t *testing.T) {
+	_, err := newHeadersSetterExtension(&Config{
+		HeadersConfig: []HeaderConfig{
+			{
+				Key:     &header,
+				FromEnv: stringp("OTEL_HEADERS_SETTER_UNSET"),
+			},
+		},
+	})
+	assert.EqualError(t, err, `environment variable "OTEL_HEADERS_SETTER_UNSET" of header "header_name" is not set`)
+
+	_, err = newHeadersSetterExtension(&Config{
+		HeadersConfig: []HeaderConfig{
+			{
+				Key:      &header,
+				FromFile: stringp(filepath.Join(t.TempDir(), "token")),
+			},
+		},
+	})
+	assert.Error(t, err)
+}
+
 var (
 	mrt           = &mockRoundTripper{}
 	header        = "header_name"
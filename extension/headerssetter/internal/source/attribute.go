@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetter/internal/source"
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchperresourceattr"
+)
+
+var _ Source = (*AttributeSource)(nil)
+
+// AttributeSource reads the value of a resource attribute shared by all the
+// resources of the exported batch, which must have been split per value of
+// the attribute, e.g. by the routing processor.
+type AttributeSource struct {
+	Key string
+}
+
+func (ts *AttributeSource) Get(ctx context.Context) (string, error) {
+	value, _ := batchperresourceattr.FromContext(ctx, ts.Key)
+	return value, nil
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchperresourceattr"
+)
+
+func TestAttributeSource(t *testing.T) {
+	ts := &AttributeSource{Key: "tenant"}
+	ctx := batchperresourceattr.NewContext(context.Background(), "tenant", "acme")
+
+	tenant, err := ts.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", tenant)
+}
+
+func TestAttributeSourceNotFound(t *testing.T) {
+	ts := &AttributeSource{Key: "tenant"}
+	ctx := batchperresourceattr.NewContext(context.Background(), "namespace", "acme")
+
+	tenant, err := ts.Get(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, tenant)
+}
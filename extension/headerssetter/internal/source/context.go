@@ -17,10 +17,15 @@ package source // import "github.com/open-telemetry/opentelemetry-collector-cont
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/collector/client"
 )
 
+// authPrefix is the prefix of the keys looked up from the authentication data
+// of the request, e.g. `auth.spiffe_id`, instead of its metadata.
+const authPrefix = "auth."
+
 var _ Source = (*ContextSource)(nil)
 
 type ContextSource struct {
@@ -29,7 +34,24 @@ type ContextSource struct {
 
 func (ts *ContextSource) Get(ctx context.Context) (string, error) {
 	cl := client.FromContext(ctx)
-	ss := cl.Metadata.Get(ts.Key)
+
+	var ss []string
+	if attrName := strings.TrimPrefix(ts.Key, authPrefix); attrName != ts.Key {
+		if cl.Auth == nil {
+			return "", nil
+		}
+		switch attr := cl.Auth.GetAttribute(attrName).(type) {
+		case string:
+			return attr, nil
+		case []string:
+			ss = attr
+		case nil:
+		default:
+			return "", fmt.Errorf("unsupported type %T of auth attribute %q", attr, attrName)
+		}
+	} else {
+		ss = cl.Metadata.Get(ts.Key)
+	}
 
 	if len(ss) == 0 {
 		return "", nil
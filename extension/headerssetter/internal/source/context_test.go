@@ -57,3 +57,62 @@ func TestContextSourceMultipleFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Empty(t, header)
 }
+
+type authData map[string]interface{}
+
+func (a authData) GetAttribute(name string) interface{} {
+	return a[name]
+}
+
+func (a authData) GetAttributeNames() []string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestContextSourceAuth(t *testing.T) {
+	cl := client.FromContext(context.Background())
+	cl.Auth = authData{
+		"spiffe_id": "spiffe://example.org/ns/prod/sa/collector",
+		"dns_names": []string{"collector-1.otel.example.org"},
+		"uris":      []string{"spiffe://example.org/a", "spiffe://example.org/b"},
+		"expiry":    42,
+	}
+	ctx := client.NewContext(context.Background(), cl)
+
+	tests := []struct {
+		key      string
+		expected string
+		err      bool
+	}{
+		{key: "auth.spiffe_id", expected: "spiffe://example.org/ns/prod/sa/collector"},
+		{key: "auth.dns_names", expected: "collector-1.otel.example.org"},
+		{key: "auth.unknown", expected: ""},
+		{key: "auth.uris", err: true},
+		{key: "auth.expiry", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			header, err := (&ContextSource{Key: tt.key}).Get(ctx)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, header)
+		})
+	}
+}
+
+func TestContextSourceAuthMissing(t *testing.T) {
+	cl := client.FromContext(context.Background())
+	cl.Metadata = client.NewMetadata(map[string][]string{"auth.spiffe_id": {"spoofed"}})
+	ctx := client.NewContext(context.Background(), cl)
+
+	header, err := (&ContextSource{Key: "auth.spiffe_id"}).Get(ctx)
+
+	assert.NoError(t, err)
+	assert.Empty(t, header)
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetter/internal/source"
+
+import (
+	"context"
+	"os"
+)
+
+var _ Source = (*EnvSource)(nil)
+
+type EnvSource struct {
+	Name string
+}
+
+func (ts *EnvSource) Get(_ context.Context) (string, error) {
+	return os.Getenv(ts.Name), nil
+}
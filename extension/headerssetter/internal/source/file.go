@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetter/internal/source"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ Source = (*FileSource)(nil)
+
+// FileSource reads the value from a file, which is read again whenever it
+// changes, e.g. when a mounted secret is rotated.
+type FileSource struct {
+	Path string
+
+	mu      sync.Mutex
+	value   string
+	modTime time.Time
+	size    int64
+}
+
+func (ts *FileSource) Get(_ context.Context) (string, error) {
+	info, err := os.Stat(ts.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the header value from %q: %w", ts.Path, err)
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if info.ModTime().Equal(ts.modTime) && info.Size() == ts.size {
+		return ts.value, nil
+	}
+
+	b, err := os.ReadFile(ts.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the header value from %q: %w", ts.Path, err)
+	}
+	ts.value = strings.TrimSpace(string(b))
+	ts.modTime = info.ModTime()
+	ts.size = info.Size()
+	return ts.value, nil
+}
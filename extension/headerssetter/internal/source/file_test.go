@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("token-1\n"), 0600))
+
+	ts := &FileSource{Path: path}
+	token, err := ts.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// the file is read again once rotated
+	require.NoError(t, os.WriteFile(path, []byte("token-22\n"), 0600))
+	token, err = ts.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-22", token)
+
+	require.NoError(t, os.WriteFile(path, []byte("token-33\n"), 0600))
+	modTime := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	token, err = ts.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-33", token)
+}
+
+func TestFileSourceNotFound(t *testing.T) {
+	ts := &FileSource{Path: filepath.Join(t.TempDir(), "token")}
+	token, err := ts.Get(context.Background())
+	assert.Error(t, err)
+	assert.Empty(t, token)
+}
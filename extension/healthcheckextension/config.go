@@ -35,6 +35,15 @@ type Config struct {
 
 	// CheckCollectorPipeline contains the list of settings of collector pipeline health check
 	CheckCollectorPipeline checkCollectorPipelineSettings `mapstructure:"check_collector_pipeline"`
+
+	// Liveness contains the settings of the liveness endpoint, which only reflects whether the collector is running.
+	Liveness livenessSettings `mapstructure:"liveness"`
+
+	// Readiness contains the settings of the readiness endpoint, which reflects whether the collector can take data.
+	Readiness readinessSettings `mapstructure:"readiness"`
+
+	// Status contains the settings of the endpoint serving the status of each component as JSON.
+	Status statusSettings `mapstructure:"status"`
 }
 
 var _ config.Extension = (*Config)(nil)
@@ -42,6 +51,8 @@ var (
 	errNoEndpointProvided                      = errors.New("bad config: endpoint must be specified")
 	errInvalidExporterFailureThresholdProvided = errors.New("bad config: exporter_failure_threshold expects a positive number")
 	errInvalidPath                             = errors.New("bad config: path must start with /")
+	errDuplicatePath                           = errors.New("bad config: path, liveness, readiness and status paths must be different")
+	errInvalidQueueSaturation                  = errors.New("bad config: exporter_queue_saturation must be between 0 and 1")
 )
 
 // Validate checks if the extension configuration is valid
@@ -59,6 +70,22 @@ func (cfg *Config) Validate() error {
 	if !strings.HasPrefix(cfg.Path, "/") {
 		return errInvalidPath
 	}
+	paths := map[string]struct{}{cfg.Path: {}}
+	for _, path := range []string{cfg.Liveness.Path, cfg.Readiness.Path, cfg.Status.Path} {
+		if path == "" {
+			continue
+		}
+		if !strings.HasPrefix(path, "/") {
+			return errInvalidPath
+		}
+		if _, ok := paths[path]; ok {
+			return errDuplicatePath
+		}
+		paths[path] = struct{}{}
+	}
+	if cfg.Readiness.ExporterQueueSaturation < 0 || cfg.Readiness.ExporterQueueSaturation > 1 {
+		return errInvalidQueueSaturation
+	}
 	return nil
 }
 
@@ -70,3 +97,21 @@ type checkCollectorPipelineSettings struct {
 	// ExporterFailureThreshold is the threshold of exporter failure numbers during the Interval
 	ExporterFailureThreshold int `mapstructure:"exporter_failure_threshold"`
 }
+
+type livenessSettings struct {
+	// Path is the path of the liveness endpoint. The endpoint is disabled when empty.
+	Path string `mapstructure:"path"`
+}
+
+type readinessSettings struct {
+	// Path is the path of the readiness endpoint. The endpoint is disabled when empty.
+	Path string `mapstructure:"path"`
+	// ExporterQueueSaturation is the fraction of an exporter's sending queue capacity above which
+	// the collector is reported as not ready. Zero disables the check.
+	ExporterQueueSaturation float64 `mapstructure:"exporter_queue_saturation"`
+}
+
+type statusSettings struct {
+	// Path is the path of the component status endpoint. The endpoint is disabled when empty.
+	Path string `mapstructure:"path"`
+}
@@ -56,6 +56,27 @@ func TestLoadConfig(t *testing.T) {
 				Path:                   "/",
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "probes"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: "localhost:13",
+				},
+				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				Path:                   "/",
+				Liveness: livenessSettings{
+					Path: "/livez",
+				},
+				Readiness: readinessSettings{
+					Path:                    "/readyz",
+					ExporterQueueSaturation: 0.8,
+				},
+				Status: statusSettings{
+					Path: "/status",
+				},
+			},
+		},
 		{
 			id:          config.NewComponentIDWithName(typeStr, "missingendpoint"),
 			expectedErr: errNoEndpointProvided,
@@ -68,6 +89,18 @@ func TestLoadConfig(t *testing.T) {
 			id:          config.NewComponentIDWithName(typeStr, "invalidpath"),
 			expectedErr: errInvalidPath,
 		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "duplicatepath"),
+			expectedErr: errDuplicatePath,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalidlivenesspath"),
+			expectedErr: errInvalidPath,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalidqueuesaturation"),
+			expectedErr: errInvalidQueueSaturation,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -16,14 +16,17 @@ package healthcheckextension // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/jaegertracing/jaeger/pkg/healthcheck"
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.uber.org/zap"
 )
 
@@ -34,7 +37,12 @@ type healthCheckExtension struct {
 	server   *http.Server
 	stopCh   chan struct{}
 	exporter *healthCheckExporter
+	status   *statusExporter
 	settings component.TelemetrySettings
+	upSince  time.Time
+
+	// readQueues returns the usage of the exporters' sending queues
+	readQueues func() map[string]queueUsage
 }
 
 var _ component.PipelineWatcher = (*healthCheckExtension)(nil)
@@ -51,43 +59,52 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 	if err != nil {
 		return err
 	}
+	hc.upSince = time.Now()
 
+	mux := http.NewServeMux()
+	var interval time.Duration
 	if !hc.config.CheckCollectorPipeline.Enabled {
 		// Mount HC handler
-		mux := http.NewServeMux()
 		mux.Handle(hc.config.Path, hc.state.Handler())
-		hc.server.Handler = mux
-		hc.stopCh = make(chan struct{})
-		go func() {
-			defer close(hc.stopCh)
-
-			// The listener ownership goes to the server.
-			if err = hc.server.Serve(ln); !errors.Is(err, http.ErrServerClosed) && err != nil {
-				host.ReportFatalError(err)
-			}
-		}()
 	} else {
 		// collector pipeline health check
-		hc.exporter = newHealthCheckExporter()
-		view.RegisterExporter(hc.exporter)
-
-		interval, err := time.ParseDuration(hc.config.CheckCollectorPipeline.Interval)
+		interval, err = time.ParseDuration(hc.config.CheckCollectorPipeline.Interval)
 		if err != nil {
 			return err
 		}
 
-		// ticker used by collector pipeline health check for rotation
-		ticker := time.NewTicker(time.Second)
-
-		mux := http.NewServeMux()
+		hc.exporter = newHealthCheckExporter()
+		view.RegisterExporter(hc.exporter)
 		mux.Handle(hc.config.Path, hc.handler())
-		hc.server.Handler = mux
-		hc.stopCh = make(chan struct{})
-		go func() {
-			defer close(hc.stopCh)
+	}
+
+	if hc.config.Liveness.Path != "" {
+		mux.Handle(hc.config.Liveness.Path, hc.livenessHandler())
+	}
+	if hc.config.Readiness.Path != "" {
+		mux.Handle(hc.config.Readiness.Path, hc.readinessHandler())
+	}
+	if hc.config.Status.Path != "" {
+		hc.status = newStatusExporter(exportersByDataType(host))
+		view.RegisterExporter(hc.status)
+		mux.Handle(hc.config.Status.Path, hc.statusHandler())
+	}
+
+	hc.server.Handler = mux
+	hc.stopCh = make(chan struct{})
+	go func() {
+		defer close(hc.stopCh)
+		if hc.status != nil {
+			defer view.UnregisterExporter(hc.status)
+		}
+
+		if hc.exporter != nil {
 			defer view.UnregisterExporter(hc.exporter)
 
+			// ticker used by collector pipeline health check for rotation
+			ticker := time.NewTicker(time.Second)
 			go func() {
+				defer ticker.Stop()
 				for {
 					select {
 					case <-ticker.C:
@@ -97,18 +114,17 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 					}
 				}
 			}()
+		}
 
-			if errHTTP := hc.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
-				host.ReportFatalError(errHTTP)
-			}
-
-		}()
-	}
+		// The listener ownership goes to the server.
+		if errHTTP := hc.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
 
 	return nil
 }
 
-// new handler function used for check collector pipeline
 func (hc *healthCheckExtension) handler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		if hc.check() && hc.state.Get() == healthcheck.Ready {
@@ -123,6 +139,94 @@ func (hc *healthCheckExtension) check() bool {
 	return hc.exporter.checkHealthStatus(hc.config.CheckCollectorPipeline.ExporterFailureThreshold)
 }
 
+// livenessHandler reports whether the collector is running, regardless of the state of its pipelines,
+// so that failures of the exporters' destinations don't cause the collector to be restarted.
+func (hc *healthCheckExtension) livenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, probeResponse{Status: "alive", UpSince: hc.upSince})
+	})
+}
+
+// readinessHandler reports whether the collector's pipelines are ready to take data.
+func (hc *healthCheckExtension) readinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		ready, reason := hc.ready()
+		if !ready {
+			writeJSON(w, http.StatusServiceUnavailable, probeResponse{Status: "not ready", Reason: reason, UpSince: hc.upSince})
+			return
+		}
+		writeJSON(w, http.StatusOK, probeResponse{Status: "ready", UpSince: hc.upSince})
+	})
+}
+
+// statusHandler serves the status of each data type and component.
+func (hc *healthCheckExtension) statusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		report := hc.status.report(hc.readQueues())
+		report.UpSince = hc.upSince
+		report.Status = "ready"
+		if ready, reason := hc.ready(); !ready {
+			report.Status = "not ready"
+			report.Reason = reason
+		}
+		writeJSON(w, http.StatusOK, report)
+	})
+}
+
+// ready returns whether the collector is ready to take data, or the reason why it isn't.
+func (hc *healthCheckExtension) ready() (bool, string) {
+	if hc.state.Get() != healthcheck.Ready {
+		return false, "the pipelines aren't ready"
+	}
+	if hc.exporter != nil && !hc.check() {
+		return false, "the exporter failures are above the threshold"
+	}
+
+	threshold := hc.config.Readiness.ExporterQueueSaturation
+	if threshold <= 0 {
+		return true, ""
+	}
+	queues := hc.readQueues()
+	ids := make([]string, 0, len(queues))
+	for id := range queues {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if q := queues[id]; q.saturation() >= threshold {
+			return false, fmt.Sprintf("the sending queue of the exporter %q is saturated (%d/%d)", id, q.size, q.capacity)
+		}
+	}
+	return true, ""
+}
+
+// probeResponse is the body of the liveness and readiness responses.
+type probeResponse struct {
+	Status  string    `json:"status"`
+	Reason  string    `json:"reason,omitempty"`
+	UpSince time.Time `json:"up_since"`
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// exportersByDataType returns the IDs of the exporters for each data type, as known by the host.
+func exportersByDataType(host component.Host) map[config.DataType][]string {
+	dataTypes := map[config.DataType][]string{}
+	for dataType, exporters := range host.GetExporters() {
+		ids := make([]string, 0, len(exporters))
+		for id := range exporters {
+			ids = append(ids, id.String())
+		}
+		sort.Strings(ids)
+		dataTypes[dataType] = ids
+	}
+	return dataTypes
+}
+
 func (hc *healthCheckExtension) Shutdown(context.Context) error {
 	if hc.server == nil {
 		return nil
@@ -150,6 +254,8 @@ func newServer(config Config, settings component.TelemetrySettings) *healthCheck
 		logger:   settings.Logger,
 		state:    healthcheck.New(),
 		settings: settings,
+
+		readQueues: exporterQueues,
 	}
 
 	hc.state.SetLogger(settings.Logger)
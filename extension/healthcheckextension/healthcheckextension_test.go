@@ -16,6 +16,7 @@ package healthcheckextension
 
 import (
 	"context"
+	"encoding/json"
 	"net"
 	"net/http"
 	"runtime"
@@ -27,6 +28,7 @@ import (
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
@@ -319,6 +321,126 @@ func TestHealthCheckShutdownWithoutStart(t *testing.T) {
 	require.NoError(t, hcExt.Shutdown(context.Background()))
 }
 
+func TestHealthCheckExtensionProbes(t *testing.T) {
+	cfg := Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		Path:                   "/",
+		Liveness:               livenessSettings{Path: "/livez"},
+		Readiness:              readinessSettings{Path: "/readyz", ExporterQueueSaturation: 0.8},
+		Status:                 statusSettings{Path: "/status"},
+	}
+
+	hcExt := newServer(cfg, componenttest.NewNopTelemetrySettings())
+	require.NotNil(t, hcExt)
+
+	queues := map[string]queueUsage{"otlp": {size: 0, capacity: 10}}
+	hcExt.readQueues = func() map[string]queueUsage { return queues }
+
+	host := &exportersHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.TracesDataType: {config.NewComponentID("otlp"): nil},
+		},
+	}
+	require.NoError(t, hcExt.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, hcExt.Shutdown(context.Background())) })
+	require.Eventuallyf(t, ensureServerRunning(cfg.Endpoint), 30*time.Second, 1*time.Second, "Failed to start the testing server.")
+
+	get := func(path string, body interface{}) int {
+		resp, err := http.Get("http://" + cfg.Endpoint + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(body))
+		return resp.StatusCode
+	}
+
+	// the collector is alive before the pipelines are ready
+	var probe probeResponse
+	assert.Equal(t, http.StatusOK, get("/livez", &probe))
+	assert.Equal(t, "alive", probe.Status)
+	assert.Equal(t, http.StatusServiceUnavailable, get("/readyz", &probe))
+	assert.Equal(t, "the pipelines aren't ready", probe.Reason)
+
+	require.NoError(t, hcExt.Ready())
+	assert.Equal(t, http.StatusOK, get("/readyz", &probe))
+	assert.Equal(t, "ready", probe.Status)
+
+	// a saturated exporter queue makes the collector not ready, but it's still alive
+	queues["otlp"] = queueUsage{size: 8, capacity: 10}
+	assert.Equal(t, http.StatusServiceUnavailable, get("/readyz", &probe))
+	assert.Equal(t, `the sending queue of the exporter "otlp" is saturated (8/10)`, probe.Reason)
+	assert.Equal(t, http.StatusOK, get("/livez", &probe))
+
+	var report statusReport
+	assert.Equal(t, http.StatusOK, get("/status", &report))
+	assert.Equal(t, "not ready", report.Status)
+	assert.Equal(t, map[string]dataTypeStatus{"traces": {Status: statusOK, Exporters: []string{"otlp"}}}, report.DataTypes)
+	require.Contains(t, report.Exporters, "otlp")
+	assert.Equal(t, statusUnknown, report.Exporters["otlp"].Status)
+	assert.Equal(t, int64(8), *report.Exporters["otlp"].QueueSize)
+	assert.Equal(t, int64(10), *report.Exporters["otlp"].QueueCapacity)
+
+	// the original endpoint is unchanged
+	resp, err := http.Get("http://" + cfg.Endpoint + cfg.Path)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestHealthCheckExtensionReadinessWithCheckCollectorPipeline(t *testing.T) {
+	cfg := Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		CheckCollectorPipeline: checkCollectorPipelineSettings{
+			Enabled:                  true,
+			Interval:                 "5m",
+			ExporterFailureThreshold: 0,
+		},
+		Path:      "/",
+		Liveness:  livenessSettings{Path: "/livez"},
+		Readiness: readinessSettings{Path: "/readyz"},
+	}
+
+	hcExt := newServer(cfg, componenttest.NewNopTelemetrySettings())
+	require.NotNil(t, hcExt)
+
+	require.NoError(t, hcExt.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, hcExt.Shutdown(context.Background())) })
+	require.Eventuallyf(t, ensureServerRunning(cfg.Endpoint), 30*time.Second, 1*time.Second, "Failed to start the testing server.")
+	require.NoError(t, hcExt.Ready())
+
+	hcExt.exporter.exporterFailureQueue = append(hcExt.exporter.exporterFailureQueue, &view.Data{
+		View:  &view.View{Name: exporterFailureView},
+		Start: time.Now(),
+		End:   time.Now(),
+	})
+
+	resp, err := http.Get("http://" + cfg.Endpoint + "/readyz")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	resp, err = http.Get("http://" + cfg.Endpoint + "/livez")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// exportersHost implements a component.Host returning the given exporters.
+type exportersHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *exportersHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
 // assertNoErrorHost implements a component.Host that asserts that there were no errors.
 type assertNoErrorHost struct {
 	component.Host
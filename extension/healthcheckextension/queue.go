@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+
+import "go.opencensus.io/metric/metricproducer"
+
+const (
+	queueSizeMetric     = "exporter/queue_size"
+	queueCapacityMetric = "exporter/queue_capacity"
+)
+
+// queueUsage is the number of batches in an exporter's sending queue, along with the queue's capacity.
+type queueUsage struct {
+	size     int64
+	capacity int64
+}
+
+// saturation returns the fraction of the queue capacity in use.
+func (q queueUsage) saturation() float64 {
+	if q.capacity <= 0 {
+		return 0
+	}
+	return float64(q.size) / float64(q.capacity)
+}
+
+// exporterQueues reads the sending queue gauges reported by the exporter helper, keyed by exporter.
+func exporterQueues() map[string]queueUsage {
+	queues := map[string]queueUsage{}
+	for _, producer := range metricproducer.GlobalManager().GetAll() {
+		for _, m := range producer.Read() {
+			if m.Descriptor.Name != queueSizeMetric && m.Descriptor.Name != queueCapacityMetric {
+				continue
+			}
+
+			labelIdx := -1
+			for i, key := range m.Descriptor.LabelKeys {
+				if key.Key == kindExporter {
+					labelIdx = i
+				}
+			}
+			if labelIdx < 0 {
+				continue
+			}
+
+			for _, ts := range m.TimeSeries {
+				if labelIdx >= len(ts.LabelValues) || len(ts.Points) == 0 {
+					continue
+				}
+				value, ok := ts.Points[len(ts.Points)-1].Value.(int64)
+				if !ok {
+					continue
+				}
+
+				id := ts.LabelValues[labelIdx].Value
+				q := queues[id]
+				if m.Descriptor.Name == queueSizeMetric {
+					q.size = value
+				} else {
+					q.capacity = value
+				}
+				queues[id] = q
+			}
+		}
+	}
+	return queues
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric"
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricproducer"
+)
+
+func TestExporterQueues(t *testing.T) {
+	registry := metric.NewRegistry()
+	queueSize, err := registry.AddInt64DerivedGauge(queueSizeMetric, metric.WithLabelKeys(kindExporter))
+	require.NoError(t, err)
+	queueCapacity, err := registry.AddInt64DerivedGauge(queueCapacityMetric, metric.WithLabelKeys(kindExporter))
+	require.NoError(t, err)
+
+	require.NoError(t, queueSize.UpsertEntry(func() int64 { return 80 }, metricdata.NewLabelValue("otlp")))
+	require.NoError(t, queueCapacity.UpsertEntry(func() int64 { return 100 }, metricdata.NewLabelValue("otlp")))
+	require.NoError(t, queueSize.UpsertEntry(func() int64 { return 0 }, metricdata.NewLabelValue("otlp/2")))
+	require.NoError(t, queueCapacity.UpsertEntry(func() int64 { return 50 }, metricdata.NewLabelValue("otlp/2")))
+
+	metricproducer.GlobalManager().AddProducer(registry)
+	defer metricproducer.GlobalManager().DeleteProducer(registry)
+
+	queues := exporterQueues()
+	assert.Equal(t, map[string]queueUsage{
+		"otlp":   {size: 80, capacity: 100},
+		"otlp/2": {size: 0, capacity: 50},
+	}, queues)
+	assert.Equal(t, 0.8, queues["otlp"].saturation())
+	assert.Equal(t, 0.0, queueUsage{size: 1}.saturation())
+}
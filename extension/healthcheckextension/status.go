@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	statusUnknown = "unknown"
+	statusOK      = "ok"
+	statusError   = "error"
+
+	kindReceiver  = "receiver"
+	kindProcessor = "processor"
+	kindExporter  = "exporter"
+)
+
+// statusView describes how the data of one of the collector's internal views affects the component status.
+type statusView struct {
+	kind    string
+	failure bool
+	// message formats the number of items affected by a failure
+	message string
+}
+
+// statusViews lists the internal views recording the outcome of the operations of each component.
+var statusViews = map[string]statusView{
+	"receiver/accepted_spans":            {kind: kindReceiver},
+	"receiver/accepted_metric_points":    {kind: kindReceiver},
+	"receiver/accepted_log_records":      {kind: kindReceiver},
+	"receiver/refused_spans":             {kind: kindReceiver, failure: true, message: "refused %d spans"},
+	"receiver/refused_metric_points":     {kind: kindReceiver, failure: true, message: "refused %d metric points"},
+	"receiver/refused_log_records":       {kind: kindReceiver, failure: true, message: "refused %d log records"},
+	"scraper/scraped_metric_points":      {kind: kindReceiver},
+	"scraper/errored_metric_points":      {kind: kindReceiver, failure: true, message: "failed to scrape %d metric points"},
+	"processor/accepted_spans":           {kind: kindProcessor},
+	"processor/accepted_metric_points":   {kind: kindProcessor},
+	"processor/accepted_log_records":     {kind: kindProcessor},
+	"processor/refused_spans":            {kind: kindProcessor, failure: true, message: "refused %d spans"},
+	"processor/refused_metric_points":    {kind: kindProcessor, failure: true, message: "refused %d metric points"},
+	"processor/refused_log_records":      {kind: kindProcessor, failure: true, message: "refused %d log records"},
+	"processor/dropped_spans":            {kind: kindProcessor, failure: true, message: "dropped %d spans"},
+	"processor/dropped_metric_points":    {kind: kindProcessor, failure: true, message: "dropped %d metric points"},
+	"processor/dropped_log_records":      {kind: kindProcessor, failure: true, message: "dropped %d log records"},
+	"exporter/sent_spans":                {kind: kindExporter},
+	"exporter/sent_metric_points":        {kind: kindExporter},
+	"exporter/sent_log_records":          {kind: kindExporter},
+	"exporter/send_failed_spans":         {kind: kindExporter, failure: true, message: "failed to send %d spans"},
+	"exporter/send_failed_metric_points": {kind: kindExporter, failure: true, message: "failed to send %d metric points"},
+	"exporter/send_failed_log_records":   {kind: kindExporter, failure: true, message: "failed to send %d log records"},
+}
+
+// componentStatus is the status of a single component, as served by the status endpoint.
+type componentStatus struct {
+	Status          string     `json:"status"`
+	LastFailure     string     `json:"last_failure,omitempty"`
+	LastFailureTime *time.Time `json:"last_failure_time,omitempty"`
+	LastSuccessTime *time.Time `json:"last_success_time,omitempty"`
+	QueueSize       *int64     `json:"queue_size,omitempty"`
+	QueueCapacity   *int64     `json:"queue_capacity,omitempty"`
+}
+
+// dataTypeStatus is the status of the exporters handling one type of telemetry.
+type dataTypeStatus struct {
+	Status    string   `json:"status"`
+	Exporters []string `json:"exporters"`
+}
+
+// statusReport is the document served by the status endpoint.
+type statusReport struct {
+	Status     string                     `json:"status"`
+	Reason     string                     `json:"reason,omitempty"`
+	UpSince    time.Time                  `json:"up_since"`
+	DataTypes  map[string]dataTypeStatus  `json:"data_types"`
+	Receivers  map[string]componentStatus `json:"receivers"`
+	Processors map[string]componentStatus `json:"processors"`
+	Exporters  map[string]componentStatus `json:"exporters"`
+}
+
+type componentState struct {
+	// lastFailure describes the last failure. The collector's metrics only count the failed items, so
+	// the description holds that count, not the error itself.
+	lastFailure     string
+	lastFailureTime time.Time
+	lastSuccessTime time.Time
+}
+
+func (s *componentState) status() componentStatus {
+	cs := componentStatus{Status: statusUnknown}
+	if !s.lastSuccessTime.IsZero() {
+		cs.Status = statusOK
+		t := s.lastSuccessTime
+		cs.LastSuccessTime = &t
+	}
+	if !s.lastFailureTime.IsZero() {
+		t := s.lastFailureTime
+		cs.LastFailureTime = &t
+		cs.LastFailure = s.lastFailure
+		if !t.Before(s.lastSuccessTime) {
+			cs.Status = statusError
+		}
+	}
+	return cs
+}
+
+// statusExporter is a view exporter keeping track of the last successful and failed operations of each component,
+// based on the changes of the cumulative values reported by the collector's internal views.
+type statusExporter struct {
+	mu         sync.Mutex
+	components map[string]map[string]*componentState
+	// previous holds the last cumulative value of each row, keyed by view name and tags
+	previous map[string]float64
+	// dataTypes holds the exporters for each data type
+	dataTypes map[config.DataType][]string
+}
+
+var _ view.Exporter = (*statusExporter)(nil)
+
+func newStatusExporter(dataTypes map[config.DataType][]string) *statusExporter {
+	e := &statusExporter{
+		components: map[string]map[string]*componentState{
+			kindReceiver:  {},
+			kindProcessor: {},
+			kindExporter:  {},
+		},
+		previous:  map[string]float64{},
+		dataTypes: dataTypes,
+	}
+	for _, exporters := range dataTypes {
+		for _, id := range exporters {
+			e.components[kindExporter][id] = &componentState{}
+		}
+	}
+	return e
+}
+
+func (e *statusExporter) ExportView(vd *view.Data) {
+	sv, ok := statusViews[vd.View.Name]
+	if !ok {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, row := range vd.Rows {
+		id := tagValue(row.Tags, sv.kind)
+		if id == "" {
+			continue
+		}
+
+		var value float64
+		switch data := row.Data.(type) {
+		case *view.SumData:
+			value = data.Value
+		case *view.CountData:
+			value = float64(data.Value)
+		default:
+			continue
+		}
+
+		key := rowKey(vd.View.Name, row.Tags)
+		delta := value - e.previous[key]
+		e.previous[key] = value
+		if delta <= 0 {
+			continue
+		}
+
+		state, ok := e.components[sv.kind][id]
+		if !ok {
+			state = &componentState{}
+			e.components[sv.kind][id] = state
+		}
+		if sv.failure {
+			state.lastFailureTime = vd.End
+			state.lastFailure = fmt.Sprintf(sv.message, int64(delta))
+		} else if vd.End.After(state.lastSuccessTime) {
+			state.lastSuccessTime = vd.End
+		}
+	}
+}
+
+// report returns the status of all known components and data types.
+func (e *statusExporter) report(queues map[string]queueUsage) statusReport {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	r := statusReport{
+		DataTypes:  map[string]dataTypeStatus{},
+		Receivers:  map[string]componentStatus{},
+		Processors: map[string]componentStatus{},
+		Exporters:  map[string]componentStatus{},
+	}
+	for id, state := range e.components[kindReceiver] {
+		r.Receivers[id] = state.status()
+	}
+	for id, state := range e.components[kindProcessor] {
+		r.Processors[id] = state.status()
+	}
+	for id, state := range e.components[kindExporter] {
+		cs := state.status()
+		if q, ok := queues[id]; ok {
+			size, capacity := q.size, q.capacity
+			cs.QueueSize = &size
+			cs.QueueCapacity = &capacity
+		}
+		r.Exporters[id] = cs
+	}
+
+	for dataType, exporters := range e.dataTypes {
+		ds := dataTypeStatus{Status: statusOK, Exporters: exporters}
+		for _, id := range exporters {
+			if r.Exporters[id].Status == statusError {
+				ds.Status = statusError
+			}
+		}
+		r.DataTypes[string(dataType)] = ds
+	}
+	return r
+}
+
+func tagValue(tags []tag.Tag, key string) string {
+	for _, t := range tags {
+		if t.Key.Name() == key {
+			return t.Value
+		}
+	}
+	return ""
+}
+
+func rowKey(viewName string, tags []tag.Tag) string {
+	parts := make([]string, 0, len(tags))
+	for _, t := range tags {
+		parts = append(parts, t.Key.Name()+"="+t.Value)
+	}
+	sort.Strings(parts)
+	return viewName + "{" + strings.Join(parts, ",") + "}"
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckextension
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/config"
+)
+
+func newStatusViewData(t *testing.T, name string, end time.Time, kind string, values map[string]float64) *view.Data {
+	key, err := tag.NewKey(kind)
+	require.NoError(t, err)
+
+	vd := &view.Data{
+		View: &view.View{Name: name},
+		End:  end,
+	}
+	for id, value := range values {
+		vd.Rows = append(vd.Rows, &view.Row{
+			Tags: []tag.Tag{{Key: key, Value: id}},
+			Data: &view.SumData{Value: value},
+		})
+	}
+	return vd
+}
+
+func TestStatusExporter_ExportView(t *testing.T) {
+	exporter := newStatusExporter(map[config.DataType][]string{
+		config.TracesDataType:  {"otlp"},
+		config.MetricsDataType: {"otlp", "prometheusremotewrite"},
+	})
+
+	t0 := time.Now().Add(-time.Minute)
+	t1 := t0.Add(10 * time.Second)
+	t2 := t1.Add(10 * time.Second)
+
+	exporter.ExportView(newStatusViewData(t, "receiver/accepted_spans", t0, kindReceiver, map[string]float64{"otlp": 10}))
+	exporter.ExportView(newStatusViewData(t, "processor/dropped_spans", t0, kindProcessor, map[string]float64{"memory_limiter": 5}))
+	exporter.ExportView(newStatusViewData(t, "exporter/sent_spans", t0, kindExporter, map[string]float64{"otlp": 10}))
+	exporter.ExportView(newStatusViewData(t, "exporter/send_failed_metric_points", t1, kindExporter, map[string]float64{"prometheusremotewrite": 3}))
+	// unchanged cumulative values aren't new failures
+	exporter.ExportView(newStatusViewData(t, "processor/dropped_spans", t2, kindProcessor, map[string]float64{"memory_limiter": 5}))
+	exporter.ExportView(newStatusViewData(t, "processor/accepted_spans", t2, kindProcessor, map[string]float64{"memory_limiter": 5}))
+	// views unrelated to the component status are ignored
+	exporter.ExportView(newStatusViewData(t, "exporter/send_failed_requests", t2, kindExporter, map[string]float64{"otlp": 1}))
+
+	report := exporter.report(map[string]queueUsage{"otlp": {size: 2, capacity: 10}})
+
+	assert.Equal(t, componentStatus{Status: statusOK, LastSuccessTime: &t0}, report.Receivers["otlp"])
+	assert.Equal(t, componentStatus{
+		Status:          statusOK,
+		LastFailure:     "dropped 5 spans",
+		LastFailureTime: &t0,
+		LastSuccessTime: &t2,
+	}, report.Processors["memory_limiter"])
+
+	size, capacity := int64(2), int64(10)
+	assert.Equal(t, componentStatus{
+		Status:          statusOK,
+		LastSuccessTime: &t0,
+		QueueSize:       &size,
+		QueueCapacity:   &capacity,
+	}, report.Exporters["otlp"])
+	assert.Equal(t, componentStatus{
+		Status:          statusError,
+		LastFailure:     "failed to send 3 metric points",
+		LastFailureTime: &t1,
+	}, report.Exporters["prometheusremotewrite"])
+
+	assert.Equal(t, map[string]dataTypeStatus{
+		"traces":  {Status: statusOK, Exporters: []string{"otlp"}},
+		"metrics": {Status: statusError, Exporters: []string{"otlp", "prometheusremotewrite"}},
+	}, report.DataTypes)
+}
+
+func TestStatusExporter_UnknownStatus(t *testing.T) {
+	exporter := newStatusExporter(map[config.DataType][]string{
+		config.LogsDataType: {"loki"},
+	})
+
+	report := exporter.report(nil)
+
+	assert.Equal(t, componentStatus{Status: statusUnknown}, report.Exporters["loki"])
+	assert.Equal(t, dataTypeStatus{Status: statusOK, Exporters: []string{"loki"}}, report.DataTypes["logs"])
+	assert.Empty(t, report.Receivers)
+	assert.Empty(t, report.Processors)
+}
+
+func TestStatusExporter_RecoversAfterFailure(t *testing.T) {
+	exporter := newStatusExporter(nil)
+
+	t0 := time.Now().Add(-time.Minute)
+	t1 := t0.Add(10 * time.Second)
+
+	exporter.ExportView(newStatusViewData(t, "scraper/errored_metric_points", t0, kindReceiver, map[string]float64{"hostmetrics": 4}))
+	assert.Equal(t, statusError, exporter.report(nil).Receivers["hostmetrics"].Status)
+
+	exporter.ExportView(newStatusViewData(t, "scraper/scraped_metric_points", t1, kindReceiver, map[string]float64{"hostmetrics": 100}))
+	status := exporter.report(nil).Receivers["hostmetrics"]
+	assert.Equal(t, statusOK, status.Status)
+	assert.Equal(t, "failed to scrape 4 metric points", status.LastFailure)
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension"
+
+import (
+	"crypto/x509"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+var _ client.AuthData = (*identity)(nil)
+
+// identity is the authentication data of the requests authenticated with a client certificate.
+type identity struct {
+	subject        string
+	commonName     string
+	dnsNames       []string
+	uris           []string
+	emailAddresses []string
+	ipAddresses    []string
+	spiffeID       string
+}
+
+func newIdentity(cert *x509.Certificate) *identity {
+	id := &identity{
+		subject:        cert.Subject.String(),
+		commonName:     cert.Subject.CommonName,
+		dnsNames:       cert.DNSNames,
+		emailAddresses: cert.EmailAddresses,
+	}
+	for _, ip := range cert.IPAddresses {
+		id.ipAddresses = append(id.ipAddresses, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		id.uris = append(id.uris, uri.String())
+		// SPIFFE IDs are the URIs with the spiffe scheme, X.509 SVIDs having a single one
+		if uri.Scheme == "spiffe" && id.spiffeID == "" {
+			id.spiffeID = uri.String()
+		}
+	}
+	return id
+}
+
+func (id *identity) GetAttribute(name string) interface{} {
+	switch name {
+	case "subject":
+		return id.subject
+	case "common_name":
+		return id.commonName
+	case "dns_names":
+		return id.dnsNames
+	case "uris":
+		return id.uris
+	case "email_addresses":
+		return id.emailAddresses
+	case "ip_addresses":
+		return id.ipAddresses
+	case "spiffe_id":
+		return id.spiffeID
+	default:
+		return nil
+	}
+}
+
+func (*identity) GetAttributeNames() []string {
+	return []string{"subject", "common_name", "dns_names", "uris", "email_addresses", "ip_addresses", "spiffe_id"}
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+var (
+	errEmptyAllowRule      = errors.New("allow rules must specify at least one of common_name, dns_name, uri, email or spiffe_id")
+	errMissingClientCAFile = errors.New("client_ca_file is required with certificate_header")
+)
+
+// Config has the configuration for the mTLS Authenticator extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// CertificateHeader is the header in which a TLS terminating proxy forwards the verified client
+	// certificate, URL-encoded in PEM format. It is required for the HTTP receivers, whose client
+	// certificates aren't available to the authenticators, and only used when the client didn't present a
+	// certificate to the receiver itself. The receiver must only be reachable through the proxy.
+	// Optional.
+	CertificateHeader string `mapstructure:"certificate_header"`
+
+	// ClientCAFile is the path of the CA certificates verifying the client certificates forwarded in
+	// CertificateHeader, which are rejected unless they are valid for client authentication.
+	// Required with CertificateHeader.
+	ClientCAFile string `mapstructure:"client_ca_file"`
+
+	// Allow is the list of rules matching the identities allowed to connect. A client certificate is
+	// accepted when it matches any of the rules, any verified client certificate being accepted if there are none.
+	// Optional.
+	Allow []AllowRule `mapstructure:"allow"`
+}
+
+// AllowRule matches the identities of the client certificates. Its values are regular expressions, which
+// must match the whole value of the certificate field. A certificate matches the rule when all of
+// the specified expressions match.
+type AllowRule struct {
+	// CommonName matches the common name of the certificate subject.
+	CommonName string `mapstructure:"common_name"`
+
+	// DNSName matches any of the DNS names of the certificate.
+	DNSName string `mapstructure:"dns_name"`
+
+	// URI matches any of the URIs of the certificate.
+	URI string `mapstructure:"uri"`
+
+	// Email matches any of the email addresses of the certificate.
+	Email string `mapstructure:"email"`
+
+	// SPIFFEID matches the SPIFFE ID of the certificate, e.g. "spiffe://example.org/ns/prod/sa/.+".
+	SPIFFEID string `mapstructure:"spiffe_id"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.CertificateHeader != "" && cfg.ClientCAFile == "" {
+		return errMissingClientCAFile
+	}
+	for i, rule := range cfg.Allow {
+		if _, err := compileRule(rule); err != nil {
+			return fmt.Errorf("invalid allow rule %d: %w", i, err)
+		}
+	}
+	return nil
+}
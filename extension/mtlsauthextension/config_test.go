@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id          config.ComponentID
+		expected    config.Extension
+		expectedErr string
+	}{
+		{
+			id:       config.NewComponentID(typeStr),
+			expected: NewFactory().CreateDefaultConfig(),
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "rules"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				CertificateHeader: "X-SSL-Client-Cert",
+				ClientCAFile:      "/etc/otelcol/tls/proxy-ca.crt",
+				Allow: []AllowRule{
+					{SPIFFEID: "spiffe://example.org/ns/prod/sa/.+"},
+					{CommonName: "collector-.+", DNSName: `.+\.otel\.example\.org`},
+				},
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "missingca"),
+			expectedErr: errMissingClientCAFile.Error(),
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "emptyrule"),
+			expectedErr: "invalid allow rule 0: " + errEmptyAllowRule.Error(),
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalidrule"),
+			expectedErr: "invalid allow rule 0: invalid common_name expression: error parsing regexp: missing closing ): `^(?:collector-()$`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalExtension(sub, cfg))
+			if tt.expectedErr != "" {
+				assert.EqualError(t, cfg.Validate(), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
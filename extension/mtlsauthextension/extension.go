@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension"
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+var (
+	errNoClientCertificate      = errors.New("no verified client certificate")
+	errInvalidCertificateHeader = errors.New("invalid client certificate header")
+	errUntrustedCertificate     = errors.New("untrusted forwarded client certificate")
+	errNotAllowed               = errors.New("the client certificate doesn't match any allow rule")
+)
+
+type mtlsExtension struct {
+	cfg    *Config
+	rules  []*rule
+	logger *zap.Logger
+	// clientCAs verifies the certificates forwarded in the certificate header
+	clientCAs *x509.CertPool
+}
+
+func newExtension(cfg *Config, logger *zap.Logger) (configauth.ServerAuthenticator, error) {
+	rules, err := compileRules(cfg.Allow)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &mtlsExtension{
+		cfg:    cfg,
+		rules:  rules,
+		logger: logger,
+	}
+	if cfg.CertificateHeader != "" {
+		if e.clientCAs, err = loadCertPool(cfg.ClientCAFile); err != nil {
+			return nil, err
+		}
+	}
+	return configauth.NewServerAuthenticator(configauth.WithAuthenticate(e.authenticate)), nil
+}
+
+// authenticate checks the client certificate against the allow rules, and adds its identity to the client info.
+func (e *mtlsExtension) authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	cert, err := e.clientCertificate(ctx, headers)
+	if err != nil {
+		return ctx, err
+	}
+
+	id := newIdentity(cert)
+	if !e.allowed(id) {
+		e.logger.Debug("Rejected client certificate", zap.String("subject", id.subject), zap.String("spiffe_id", id.spiffeID))
+		return ctx, errNotAllowed
+	}
+
+	cl := client.FromContext(ctx)
+	cl.Auth = id
+	return client.NewContext(ctx, cl), nil
+}
+
+func (e *mtlsExtension) allowed(id *identity) bool {
+	if len(e.rules) == 0 {
+		return true
+	}
+	for _, r := range e.rules {
+		if r.matches(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client_ca_file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM encoded certificate found in client_ca_file %q", path)
+	}
+	return pool, nil
+}
+
+// clientCertificate returns the certificate verified by the gRPC receivers, or the one forwarded by the TLS
+// terminating proxy. The header is ignored when the client connected over TLS, as it then didn't go through the proxy.
+func (e *mtlsExtension) clientCertificate(ctx context.Context, headers map[string][]string) (*x509.Certificate, error) {
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			if len(tlsInfo.State.VerifiedChains) > 0 && len(tlsInfo.State.VerifiedChains[0]) > 0 {
+				return tlsInfo.State.VerifiedChains[0][0], nil
+			}
+			return nil, errNoClientCertificate
+		}
+	}
+
+	if e.cfg.CertificateHeader == "" {
+		return nil, errNoClientCertificate
+	}
+	values := headerValues(headers, e.cfg.CertificateHeader)
+	if len(values) == 0 || values[0] == "" {
+		return nil, errNoClientCertificate
+	}
+	return e.verifyForwardedCertificate(values[0])
+}
+
+// headerValues returns the values of the header, whose name is in canonical format in
+// the HTTP headers and in lower case in the gRPC metadata.
+func headerValues(headers map[string][]string, name string) []string {
+	if values, ok := headers[http.CanonicalHeaderKey(name)]; ok {
+		return values
+	}
+	return headers[strings.ToLower(name)]
+}
+
+// verifyForwardedCertificate parses the certificate forwarded by the proxy, and verifies it against client_ca_file,
+// as any client reaching the receiver without going through the proxy could set the header.
+func (e *mtlsExtension) verifyForwardedCertificate(value string) (*x509.Certificate, error) {
+	unescaped, err := url.QueryUnescape(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidCertificateHeader, err)
+	}
+	block, _ := pem.Decode([]byte(unescaped))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("%w: no PEM encoded certificate found", errInvalidCertificateHeader)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidCertificateHeader, err)
+	}
+	if _, err = cert.Verify(x509.VerifyOptions{
+		Roots:     e.clientCAs,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %v", errUntrustedCertificate, err)
+	}
+	return cert, nil
+}
@@ -0,0 +1,337 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type testCertificates struct {
+	caPool *x509.CertPool
+	// caFile is the path of the CA certificate in PEM format
+	caFile string
+	server tls.Certificate
+	client tls.Certificate
+}
+
+func newCertificate(t *testing.T, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func generateCertificates(t *testing.T) testCertificates {
+	now := time.Now()
+	ca, caKey := newCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+
+	server, serverKey := newCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+
+	spiffeID, err := url.Parse("spiffe://example.org/ns/prod/sa/collector")
+	require.NoError(t, err)
+	clientCert, clientKey := newCertificate(t, &x509.Certificate{
+		SerialNumber:   big.NewInt(3),
+		Subject:        pkix.Name{CommonName: "collector-1", Organization: []string{"OpenTelemetry"}},
+		DNSNames:       []string{"collector-1.otel.example.org"},
+		EmailAddresses: []string{"otel@example.org"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:           []*url.URL{spiffeID},
+		NotBefore:      now.Add(-time.Hour),
+		NotAfter:       now.Add(time.Hour),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}), 0600))
+	return testCertificates{
+		caPool: pool,
+		caFile: caFile,
+		server: tls.Certificate{Certificate: [][]byte{server.Raw}, PrivateKey: serverKey, Leaf: server},
+		client: tls.Certificate{Certificate: [][]byte{clientCert.Raw}, PrivateKey: clientKey, Leaf: clientCert},
+	}
+}
+
+// checkHealth calls a gRPC server requiring client certificates, whose requests are authenticated by
+// the authenticator like the ones of the receivers, and returns the authentication data of the request.
+func checkHealth(t *testing.T, certs testCertificates, authenticator configauth.ServerAuthenticator) (client.AuthData, error) {
+	var authData client.AuthData
+	srv := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{certs.server},
+			ClientCAs:    certs.caPool,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			MinVersion:   tls.VersionTLS12,
+		})),
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			ctx, err := authenticator.Authenticate(ctx, md)
+			if err != nil {
+				return nil, status.Error(codes.Unauthenticated, err.Error())
+			}
+			authData = client.FromContext(ctx).Auth
+			return handler(ctx, req)
+		}),
+	)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(ln.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{certs.client},
+		RootCAs:      certs.caPool,
+		ServerName:   "localhost",
+		MinVersion:   tls.VersionTLS12,
+	})))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	return authData, err
+}
+
+func TestAuthenticateGRPC(t *testing.T) {
+	certs := generateCertificates(t)
+	authenticator, err := newExtension(&Config{
+		Allow: []AllowRule{
+			{CommonName: "other"},
+			{SPIFFEID: "spiffe://example.org/ns/prod/sa/.+"},
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	authData, err := checkHealth(t, certs, authenticator)
+	require.NoError(t, err)
+	require.NotNil(t, authData)
+
+	assert.Equal(t, "CN=collector-1,O=OpenTelemetry", authData.GetAttribute("subject"))
+	assert.Equal(t, "collector-1", authData.GetAttribute("common_name"))
+	assert.Equal(t, []string{"collector-1.otel.example.org"}, authData.GetAttribute("dns_names"))
+	assert.Equal(t, []string{"spiffe://example.org/ns/prod/sa/collector"}, authData.GetAttribute("uris"))
+	assert.Equal(t, []string{"otel@example.org"}, authData.GetAttribute("email_addresses"))
+	assert.Equal(t, []string{"10.0.0.1"}, authData.GetAttribute("ip_addresses"))
+	assert.Equal(t, "spiffe://example.org/ns/prod/sa/collector", authData.GetAttribute("spiffe_id"))
+	assert.Nil(t, authData.GetAttribute("unknown"))
+	for _, name := range authData.GetAttributeNames() {
+		assert.NotNil(t, authData.GetAttribute(name), name)
+	}
+}
+
+func TestAuthenticateGRPCNotAllowed(t *testing.T) {
+	certs := generateCertificates(t)
+	authenticator, err := newExtension(&Config{
+		Allow: []AllowRule{{SPIFFEID: "spiffe://example.org/ns/dev/sa/.+"}},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = checkHealth(t, certs, authenticator)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Contains(t, err.Error(), errNotAllowed.Error())
+}
+
+func forwardCertificate(cert *x509.Certificate) string {
+	return url.QueryEscape(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})))
+}
+
+func TestAuthenticateForwardedCertificate(t *testing.T) {
+	certs := generateCertificates(t)
+	authenticator, err := newExtension(&Config{
+		CertificateHeader: "X-SSL-Client-Cert",
+		ClientCAFile:      certs.caFile,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	forwarded := forwardCertificate(certs.client.Leaf)
+	for name, headers := range map[string]map[string][]string{
+		"http": {"X-Ssl-Client-Cert": {forwarded}},
+		"grpc": {"x-ssl-client-cert": {forwarded}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, err := authenticator.Authenticate(context.Background(), headers)
+			require.NoError(t, err)
+			auth := client.FromContext(ctx).Auth
+			require.NotNil(t, auth)
+			assert.Equal(t, "spiffe://example.org/ns/prod/sa/collector", auth.GetAttribute("spiffe_id"))
+		})
+	}
+}
+
+func TestAuthenticateForgedCertificate(t *testing.T) {
+	certs := generateCertificates(t)
+	authenticator, err := newExtension(&Config{
+		CertificateHeader: "X-SSL-Client-Cert",
+		ClientCAFile:      certs.caFile,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	// A self-signed certificate with the identity of a trusted client certificate.
+	trusted := certs.client.Leaf
+	forged, _ := newCertificate(t, &x509.Certificate{
+		SerialNumber: trusted.SerialNumber,
+		Subject:      trusted.Subject,
+		DNSNames:     trusted.DNSNames,
+		URIs:         trusted.URIs,
+		NotBefore:    trusted.NotBefore,
+		NotAfter:     trusted.NotAfter,
+		KeyUsage:     trusted.KeyUsage,
+		ExtKeyUsage:  trusted.ExtKeyUsage,
+	}, nil, nil)
+
+	_, err = authenticator.Authenticate(context.Background(), map[string][]string{"X-Ssl-Client-Cert": {forwardCertificate(forged)}})
+	assert.ErrorIs(t, err, errUntrustedCertificate)
+}
+
+func TestAuthenticateServerCertificateForwarded(t *testing.T) {
+	certs := generateCertificates(t)
+	authenticator, err := newExtension(&Config{
+		CertificateHeader: "X-SSL-Client-Cert",
+		ClientCAFile:      certs.caFile,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	// The server certificate is signed by the CA, but not valid for client authentication.
+	_, err = authenticator.Authenticate(context.Background(), map[string][]string{"X-Ssl-Client-Cert": {forwardCertificate(certs.server.Leaf)}})
+	assert.ErrorIs(t, err, errUntrustedCertificate)
+}
+
+func TestAuthenticateTLSPeerIgnoresHeader(t *testing.T) {
+	certs := generateCertificates(t)
+	authenticator, err := newExtension(&Config{
+		CertificateHeader: "X-SSL-Client-Cert",
+		ClientCAFile:      certs.caFile,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	// The client connected over TLS without a certificate, so it didn't go through the proxy.
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}})
+	_, err = authenticator.Authenticate(ctx, map[string][]string{"x-ssl-client-cert": {forwardCertificate(certs.client.Leaf)}})
+	assert.ErrorIs(t, err, errNoClientCertificate)
+}
+
+func TestNewExtensionInvalidClientCAFile(t *testing.T) {
+	notPEM := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(notPEM, []byte("certificate"), 0600))
+
+	for _, caFile := range []string{filepath.Join(t.TempDir(), "missing.crt"), notPEM} {
+		_, err := newExtension(&Config{CertificateHeader: "X-SSL-Client-Cert", ClientCAFile: caFile}, zap.NewNop())
+		assert.Error(t, err, caFile)
+	}
+}
+
+func TestAuthenticateFailures(t *testing.T) {
+	certs := generateCertificates(t)
+	invalidPEM := url.QueryEscape(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("invalid")})))
+
+	tests := []struct {
+		name              string
+		certificateHeader string
+		headers           map[string][]string
+		expectedErr       error
+	}{
+		{
+			name:        "no certificate",
+			expectedErr: errNoClientCertificate,
+		},
+		{
+			name:              "forwarded certificate missing",
+			certificateHeader: "X-SSL-Client-Cert",
+			headers:           map[string][]string{"Authorization": {"Bearer token"}},
+			expectedErr:       errNoClientCertificate,
+		},
+		{
+			name:        "header not configured",
+			headers:     map[string][]string{"X-Ssl-Client-Cert": {forwardCertificate(certs.client.Leaf)}},
+			expectedErr: errNoClientCertificate,
+		},
+		{
+			name:              "not pem",
+			certificateHeader: "X-SSL-Client-Cert",
+			headers:           map[string][]string{"X-Ssl-Client-Cert": {"certificate"}},
+			expectedErr:       errInvalidCertificateHeader,
+		},
+		{
+			name:              "invalid certificate",
+			certificateHeader: "X-SSL-Client-Cert",
+			headers:           map[string][]string{"X-Ssl-Client-Cert": {invalidPEM}},
+			expectedErr:       errInvalidCertificateHeader,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authenticator, err := newExtension(&Config{CertificateHeader: tt.certificateHeader, ClientCAFile: certs.caFile}, zap.NewNop())
+			require.NoError(t, err)
+
+			_, err = authenticator.Authenticate(context.Background(), tt.headers)
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension"
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// rule is a compiled allow rule, its nil expressions matching any identity.
+type rule struct {
+	commonName *regexp.Regexp
+	dnsName    *regexp.Regexp
+	uri        *regexp.Regexp
+	email      *regexp.Regexp
+	spiffeID   *regexp.Regexp
+}
+
+func compileRule(r AllowRule) (*rule, error) {
+	if r == (AllowRule{}) {
+		return nil, errEmptyAllowRule
+	}
+
+	compiled := &rule{}
+	for _, expr := range []struct {
+		name  string
+		value string
+		re    **regexp.Regexp
+	}{
+		{"common_name", r.CommonName, &compiled.commonName},
+		{"dns_name", r.DNSName, &compiled.dnsName},
+		{"uri", r.URI, &compiled.uri},
+		{"email", r.Email, &compiled.email},
+		{"spiffe_id", r.SPIFFEID, &compiled.spiffeID},
+	} {
+		if expr.value == "" {
+			continue
+		}
+		// the expressions must match the whole values
+		re, err := regexp.Compile("^(?:" + expr.value + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s expression: %w", expr.name, err)
+		}
+		*expr.re = re
+	}
+	return compiled, nil
+}
+
+func compileRules(rules []AllowRule) ([]*rule, error) {
+	compiled := make([]*rule, 0, len(rules))
+	for _, r := range rules {
+		c, err := compileRule(r)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+// matches returns whether all the expressions of the rule match the identity.
+func (r *rule) matches(id *identity) bool {
+	return matchesAny(r.commonName, id.commonName) &&
+		matchesAny(r.dnsName, id.dnsNames...) &&
+		matchesAny(r.uri, id.uris...) &&
+		matchesAny(r.email, id.emailAddresses...) &&
+		matchesAny(r.spiffeID, id.spiffeID)
+}
+
+func matchesAny(re *regexp.Regexp, values ...string) bool {
+	if re == nil {
+		return true
+	}
+	for _, value := range values {
+		if value != "" && re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
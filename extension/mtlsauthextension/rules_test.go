@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleMatches(t *testing.T) {
+	id := &identity{
+		commonName:     "collector-1",
+		dnsNames:       []string{"collector-1", "collector-1.otel.example.org"},
+		uris:           []string{"spiffe://example.org/ns/prod/sa/collector"},
+		emailAddresses: []string{"otel@example.org"},
+		spiffeID:       "spiffe://example.org/ns/prod/sa/collector",
+	}
+
+	tests := []struct {
+		name     string
+		rule     AllowRule
+		expected bool
+	}{
+		{"common name", AllowRule{CommonName: "collector-[0-9]+"}, true},
+		{"partial common name", AllowRule{CommonName: "collector"}, false},
+		{"any dns name", AllowRule{DNSName: `.+\.otel\.example\.org`}, true},
+		{"uri", AllowRule{URI: "spiffe://example.org/.+"}, true},
+		{"email", AllowRule{Email: "otel@example.org"}, true},
+		{"spiffe id", AllowRule{SPIFFEID: "spiffe://example.org/ns/prod/sa/.+"}, true},
+		{"other trust domain", AllowRule{SPIFFEID: "spiffe://example.com/.+"}, false},
+		{"all expressions", AllowRule{CommonName: "collector-1", SPIFFEID: "spiffe://example.org/ns/prod/.+"}, true},
+		{"one expression not matching", AllowRule{CommonName: "collector-1", SPIFFEID: "spiffe://example.org/ns/dev/.+"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := compileRule(tt.rule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, r.matches(id))
+		})
+	}
+}
+
+func TestRuleMatchesMissingValues(t *testing.T) {
+	r, err := compileRule(AllowRule{SPIFFEID: ".*"})
+	require.NoError(t, err)
+	assert.False(t, r.matches(&identity{commonName: "collector-1"}))
+}
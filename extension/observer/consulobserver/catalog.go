@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consulobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/consulobserver"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// catalogClient reads the service instances from the Consul HTTP API.
+type catalogClient struct {
+	client     *http.Client
+	endpoint   string
+	token      string
+	datacenter string
+}
+
+// serviceEntry is a service instance, as returned by the /v1/health/service/:service endpoint.
+type serviceEntry struct {
+	Node struct {
+		Node       string            `json:"Node"`
+		Address    string            `json:"Address"`
+		Datacenter string            `json:"Datacenter"`
+		Meta       map[string]string `json:"Meta"`
+	} `json:"Node"`
+	Service struct {
+		ID      string            `json:"ID"`
+		Service string            `json:"Service"`
+		Tags    []string          `json:"Tags"`
+		Address string            `json:"Address"`
+		Port    uint16            `json:"Port"`
+		Meta    map[string]string `json:"Meta"`
+	} `json:"Service"`
+}
+
+// services returns the names of the services registered in the catalog, along with their tags.
+func (c *catalogClient) services(ctx context.Context) (map[string][]string, error) {
+	var services map[string][]string
+	err := c.get(ctx, "/v1/catalog/services", nil, &services)
+	return services, err
+}
+
+// serviceEntries returns the instances of the service, only the ones passing their health checks if passing is set.
+func (c *catalogClient) serviceEntries(ctx context.Context, service string, passing bool) ([]serviceEntry, error) {
+	query := url.Values{}
+	if passing {
+		query.Set("passing", "true")
+	}
+	var entries []serviceEntry
+	err := c.get(ctx, "/v1/health/service/"+url.PathEscape(service), query, &entries)
+	return entries, err
+}
+
+func (c *catalogClient) get(ctx context.Context, path string, query url.Values, result interface{}) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	if c.datacenter != "" {
+		query.Set("dc", c.datacenter)
+	}
+	u := strings.TrimSuffix(c.endpoint, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	if c.token != "" {
+		req.Header.Set("X-Consul-Token", c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s returned %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
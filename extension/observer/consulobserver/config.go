@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consulobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/consulobserver"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config defines configuration for Consul observer.
+type Config struct {
+	config.ExtensionSettings      `mapstructure:",squash"`
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+
+	// Token is the ACL token used to read the Consul catalog.
+	Token string `mapstructure:"token"`
+
+	// Datacenter whose catalog is observed. The datacenter of the agent is used by default.
+	Datacenter string `mapstructure:"datacenter"`
+
+	// Services is the list of the names of the services to observe. All the services are observed by default.
+	Services []string `mapstructure:"services"`
+
+	// Tags is the list of tags the observed service instances must all have.
+	Tags []string `mapstructure:"tags"`
+
+	// OnlyPassing restricts the observed service instances to the ones passing their health checks.
+	OnlyPassing bool `mapstructure:"only_passing"`
+
+	// RefreshInterval determines how often the catalog is read to look for
+	// changes in the service instances.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	if _, err := url.Parse(cfg.Endpoint); err != nil {
+		return fmt.Errorf("failed to parse consul endpoint %q: %w", cfg.Endpoint, err)
+	}
+	if cfg.RefreshInterval <= 0 {
+		return errors.New("refresh_interval must be positive")
+	}
+	return nil
+}
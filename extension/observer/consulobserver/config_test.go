@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consulobserver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id       config.ComponentID
+		expected config.Extension
+	}{
+		{
+			id:       config.NewComponentID(typeStr),
+			expected: NewFactory().CreateDefaultConfig(),
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "all_settings"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "https://consul.example.com:8501",
+					Timeout:  10 * time.Second,
+				},
+				Token:           "my-token",
+				Datacenter:      "dc2",
+				Services:        []string{"node-exporter", "postgres-exporter"},
+				Tags:            []string{"metrics"},
+				OnlyPassing:     false,
+				RefreshInterval: time.Minute,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalExtension(sub, cfg))
+
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	for name, cfg := range map[string]*Config{
+		"no-endpoint":      {RefreshInterval: time.Second},
+		"invalid-endpoint": {HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://[::1"}, RefreshInterval: time.Second},
+		"refresh-interval": {HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: defaultEndpoint}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, cfg.Validate())
+		})
+	}
+}
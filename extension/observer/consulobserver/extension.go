@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consulobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/consulobserver"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+var _ component.Extension = (*consulObserver)(nil)
+var _ observer.EndpointsLister = (*consulObserver)(nil)
+var _ observer.Observable = (*consulObserver)(nil)
+
+type consulObserver struct {
+	*observer.EndpointsWatcher
+	config    *Config
+	telemetry component.TelemetrySettings
+
+	catalog *catalogClient
+	ctx     context.Context
+	cancel  context.CancelFunc
+
+	// endpoints holds the endpoints last listed, which are kept when the catalog can't be read,
+	// so that the receivers aren't stopped while Consul is unavailable.
+	endpoints []observer.Endpoint
+	mu        sync.Mutex
+}
+
+func newObserver(telemetry component.TelemetrySettings, config *Config) *consulObserver {
+	o := &consulObserver{
+		config:    config,
+		telemetry: telemetry,
+	}
+	o.ctx, o.cancel = context.WithCancel(context.Background())
+	o.EndpointsWatcher = observer.NewEndpointsWatcher(o, config.RefreshInterval, telemetry.Logger)
+	return o
+}
+
+func (o *consulObserver) Start(_ context.Context, host component.Host) error {
+	client, err := o.config.HTTPClientSettings.ToClient(host, o.telemetry)
+	if err != nil {
+		return fmt.Errorf("failed to create consul client: %w", err)
+	}
+	o.catalog = &catalogClient{
+		client:     client,
+		endpoint:   o.config.Endpoint,
+		token:      o.config.Token,
+		datacenter: o.config.Datacenter,
+	}
+	return nil
+}
+
+func (o *consulObserver) Shutdown(context.Context) error {
+	o.cancel()
+	o.StopListAndWatch()
+	return nil
+}
+
+// ListEndpoints is invoked by an observer.EndpointsWatcher helper to report the service instance endpoints.
+// It's required to implement observer.EndpointsLister
+func (o *consulObserver) ListEndpoints() []observer.Endpoint {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.catalog == nil {
+		return nil
+	}
+	endpoints, err := o.listEndpoints(o.ctx)
+	if err != nil {
+		o.telemetry.Logger.Warn("Could not read the consul catalog (keeping the previous endpoints)", zap.Error(err))
+		return o.endpoints
+	}
+	o.endpoints = endpoints
+	return endpoints
+}
+
+func (o *consulObserver) listEndpoints(ctx context.Context) ([]observer.Endpoint, error) {
+	services, err := o.catalog.services(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(services))
+	for name, tags := range services {
+		if o.observesService(name) && hasTags(tags, o.config.Tags) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var endpoints []observer.Endpoint
+	for _, name := range names {
+		entries, err := o.catalog.serviceEntries(ctx, name, o.config.OnlyPassing)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !hasTags(entry.Service.Tags, o.config.Tags) {
+				continue
+			}
+			endpoints = append(endpoints, o.newEndpoint(entry))
+		}
+	}
+	return endpoints, nil
+}
+
+func (o *consulObserver) observesService(name string) bool {
+	if len(o.config.Services) == 0 {
+		return true
+	}
+	for _, service := range o.config.Services {
+		if service == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTags returns whether tags contains all the required tags
+func hasTags(tags []string, required []string) bool {
+	for _, r := range required {
+		found := false
+		for _, tag := range tags {
+			if tag == r {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *consulObserver) newEndpoint(entry serviceEntry) observer.Endpoint {
+	// the service address is only set when it differs from the address of the node
+	host := entry.Service.Address
+	if host == "" {
+		host = entry.Node.Address
+	}
+
+	datacenter := entry.Node.Datacenter
+	if datacenter == "" {
+		datacenter = o.config.Datacenter
+	}
+
+	return observer.Endpoint{
+		ID:     observer.EndpointID(fmt.Sprintf("(%s)%s/%s", o.config.ID().String(), entry.Node.Node, entry.Service.ID)),
+		Target: net.JoinHostPort(host, strconv.Itoa(int(entry.Service.Port))),
+		Details: &observer.ConsulService{
+			Name:       entry.Service.Service,
+			ServiceID:  entry.Service.ID,
+			Node:       entry.Node.Node,
+			Datacenter: datacenter,
+			Port:       entry.Service.Port,
+			Tags:       entry.Service.Tags,
+			Meta:       entry.Service.Meta,
+			NodeMeta:   entry.Node.Meta,
+		},
+	}
+}
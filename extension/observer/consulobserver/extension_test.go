@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consulobserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// catalogServices is the response of the /v1/catalog/services endpoint
+const catalogServices = `{
+	"consul": [],
+	"node-exporter": ["metrics"],
+	"web": ["http"]
+}`
+
+// nodeExporterEntries is the response of the /v1/health/service/node-exporter endpoint
+const nodeExporterEntries = `[
+	{
+		"Node": {"Node": "vm-1", "Address": "10.0.0.1", "Datacenter": "dc1", "Meta": {"rack": "r1"}},
+		"Service": {"ID": "node-exporter-1", "Service": "node-exporter", "Tags": ["metrics"], "Address": "", "Port": 9100, "Meta": {"metrics_path": "/metrics"}},
+		"Checks": []
+	},
+	{
+		"Node": {"Node": "vm-2", "Address": "10.0.0.2", "Datacenter": "dc1"},
+		"Service": {"ID": "node-exporter-2", "Service": "node-exporter", "Tags": ["metrics"], "Address": "fd00::2", "Port": 9100},
+		"Checks": []
+	},
+	{
+		"Node": {"Node": "vm-3", "Address": "10.0.0.3", "Datacenter": "dc1"},
+		"Service": {"ID": "node-exporter-3", "Service": "node-exporter", "Tags": [], "Port": 9100},
+		"Checks": []
+	}
+]`
+
+type fakeConsul struct {
+	*httptest.Server
+	requests []*http.Request
+	failing  atomic.Value
+}
+
+func newFakeConsul(t *testing.T) *fakeConsul {
+	f := &fakeConsul{}
+	f.failing.Store(false)
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.requests = append(f.requests, r)
+		if f.failing.Load().(bool) {
+			http.Error(w, "No cluster leader", http.StatusInternalServerError)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/catalog/services":
+			_, _ = w.Write([]byte(catalogServices))
+		case "/v1/health/service/node-exporter":
+			_, _ = w.Write([]byte(nodeExporterEntries))
+		case "/v1/health/service/consul", "/v1/health/service/web", "/v1/health/service/missing":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func newTestObserver(t *testing.T, endpoint string, configure func(*Config)) *consulObserver {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	if configure != nil {
+		configure(cfg)
+	}
+	o := newObserver(componenttest.NewNopTelemetrySettings(), cfg)
+	require.NoError(t, o.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, o.Shutdown(context.Background()))
+	})
+	return o
+}
+
+func TestListEndpoints(t *testing.T) {
+	consul := newFakeConsul(t)
+	o := newTestObserver(t, consul.URL, func(cfg *Config) {
+		cfg.Token = "my-token"
+		cfg.Datacenter = "dc1"
+		cfg.Tags = []string{"metrics"}
+	})
+
+	assert.Equal(t, []observer.Endpoint{
+		{
+			ID:     "(consul_observer)vm-1/node-exporter-1",
+			Target: "10.0.0.1:9100",
+			Details: &observer.ConsulService{
+				Name:       "node-exporter",
+				ServiceID:  "node-exporter-1",
+				Node:       "vm-1",
+				Datacenter: "dc1",
+				Port:       9100,
+				Tags:       []string{"metrics"},
+				Meta:       map[string]string{"metrics_path": "/metrics"},
+				NodeMeta:   map[string]string{"rack": "r1"},
+			},
+		},
+		{
+			ID:     "(consul_observer)vm-2/node-exporter-2",
+			Target: "[fd00::2]:9100",
+			Details: &observer.ConsulService{
+				Name:       "node-exporter",
+				ServiceID:  "node-exporter-2",
+				Node:       "vm-2",
+				Datacenter: "dc1",
+				Port:       9100,
+				Tags:       []string{"metrics"},
+			},
+		},
+	}, o.ListEndpoints())
+
+	// only the services with the tags are read
+	require.Len(t, consul.requests, 2)
+	for _, r := range consul.requests {
+		assert.Equal(t, "my-token", r.Header.Get("X-Consul-Token"))
+		assert.Equal(t, "dc1", r.URL.Query().Get("dc"))
+	}
+	assert.Equal(t, "/v1/health/service/node-exporter", consul.requests[1].URL.Path)
+	assert.Equal(t, "true", consul.requests[1].URL.Query().Get("passing"))
+}
+
+func TestListEndpointsServices(t *testing.T) {
+	consul := newFakeConsul(t)
+	o := newTestObserver(t, consul.URL, func(cfg *Config) {
+		cfg.Services = []string{"web", "missing"}
+		cfg.OnlyPassing = false
+	})
+
+	assert.Empty(t, o.ListEndpoints())
+	require.Len(t, consul.requests, 2)
+	assert.Equal(t, "/v1/health/service/web", consul.requests[1].URL.Path)
+	assert.Empty(t, consul.requests[1].URL.Query().Get("passing"))
+}
+
+func TestListEndpointsKeptWhileConsulFails(t *testing.T) {
+	consul := newFakeConsul(t)
+	o := newTestObserver(t, consul.URL, nil)
+
+	endpoints := o.ListEndpoints()
+	require.Len(t, endpoints, 3)
+
+	consul.failing.Store(true)
+	assert.Equal(t, endpoints, o.ListEndpoints())
+}
@@ -36,18 +36,27 @@ const (
 	PodType EndpointType = "pod"
 	// K8sNodeType is a Kubernetes Node endpoint.
 	K8sNodeType EndpointType = "k8s.node"
+	// K8sServiceType is a Kubernetes Service endpoint.
+	K8sServiceType EndpointType = "k8s.service"
+	// K8sIngressType is a Kubernetes Ingress endpoint.
+	K8sIngressType EndpointType = "k8s.ingress"
 	// HostPortType is a hostport endpoint.
 	HostPortType EndpointType = "hostport"
 	// ContainerType is a container endpoint.
 	ContainerType EndpointType = "container"
+	// ConsulServiceType is a Consul service instance endpoint.
+	ConsulServiceType EndpointType = "consul.service"
 )
 
 var (
 	_ EndpointDetails = (*Pod)(nil)
 	_ EndpointDetails = (*Port)(nil)
 	_ EndpointDetails = (*K8sNode)(nil)
+	_ EndpointDetails = (*K8sService)(nil)
+	_ EndpointDetails = (*K8sIngress)(nil)
 	_ EndpointDetails = (*HostPort)(nil)
 	_ EndpointDetails = (*Container)(nil)
+	_ EndpointDetails = (*ConsulService)(nil)
 )
 
 // EndpointDetails provides additional context about an endpoint such as a Pod or Port.
@@ -170,6 +179,9 @@ type HostPort struct {
 	Transport Transport
 	// IsIPv6 indicates whether or not the Endpoint is IPv6.
 	IsIPv6 bool
+	// Labels is a map of user-specified metadata, e.g. the labels of a
+	// statically configured target.
+	Labels map[string]string
 }
 
 func (h *HostPort) Env() EndpointEnv {
@@ -179,6 +191,7 @@ func (h *HostPort) Env() EndpointEnv {
 		"is_ipv6":      h.IsIPv6,
 		"port":         h.Port,
 		"transport":    h.Transport,
+		"labels":       h.Labels,
 	}
 }
 
@@ -273,3 +286,145 @@ func (n *K8sNode) Env() EndpointEnv {
 func (n *K8sNode) Type() EndpointType {
 	return K8sNodeType
 }
+
+// K8sService represents a Kubernetes Service object.
+type K8sService struct {
+	// Name of the service.
+	Name string
+	// UID is the unique ID in the cluster for the service.
+	UID string
+	// Labels is a map of user-specified metadata.
+	Labels map[string]string
+	// Annotations is a map of user-specified metadata.
+	Annotations map[string]string
+	// Namespace must be unique for services with same name.
+	Namespace string
+	// ServiceType is the type of the service: ClusterIP, NodePort, LoadBalancer or ExternalName.
+	ServiceType string
+	// ClusterIP is the IP under which the service is available within the cluster.
+	// It is "None" for headless services and empty for ExternalName services.
+	ClusterIP string
+	// Ports are the ports exposed by the service.
+	Ports []K8sServicePort
+}
+
+// K8sServicePort is a port exposed by a Kubernetes Service.
+type K8sServicePort struct {
+	// Name of the service port.
+	Name string
+	// Port number exposed by the service.
+	Port uint16
+	// TargetPort is the number or name of the port to access on the pods targeted by the service.
+	TargetPort string
+	// NodePort is the port on each node on which the service is exposed, if any.
+	NodePort uint16
+	// Transport is the transport protocol used by the port. (TCP or UDP).
+	Transport Transport
+}
+
+func (s *K8sService) Env() EndpointEnv {
+	ports := make([]EndpointEnv, 0, len(s.Ports))
+	for _, p := range s.Ports {
+		ports = append(ports, EndpointEnv{
+			"name":        p.Name,
+			"port":        p.Port,
+			"target_port": p.TargetPort,
+			"node_port":   p.NodePort,
+			"transport":   p.Transport,
+		})
+	}
+	return map[string]interface{}{
+		"uid":          s.UID,
+		"name":         s.Name,
+		"labels":       s.Labels,
+		"annotations":  s.Annotations,
+		"namespace":    s.Namespace,
+		"service_type": s.ServiceType,
+		"cluster_ip":   s.ClusterIP,
+		"ports":        ports,
+	}
+}
+
+func (s *K8sService) Type() EndpointType {
+	return K8sServiceType
+}
+
+// K8sIngress represents a single host and path rule of a Kubernetes Ingress object.
+type K8sIngress struct {
+	// Name of the ingress.
+	Name string
+	// UID is the unique ID in the cluster for the ingress.
+	UID string
+	// Labels is a map of user-specified metadata.
+	Labels map[string]string
+	// Annotations is a map of user-specified metadata.
+	Annotations map[string]string
+	// Namespace must be unique for ingresses with same name.
+	Namespace string
+	// Scheme is "https" if the host is covered by the ingress TLS configuration, "http" otherwise.
+	Scheme string
+	// Host is the fully qualified domain name of the ingress rule.
+	Host string
+	// Path of the ingress rule.
+	Path string
+	// ServiceName is the name of the backend service of the path, if any.
+	ServiceName string
+	// ServicePort is the name or number of the backend service port of the path, if any.
+	ServicePort string
+}
+
+func (i *K8sIngress) Env() EndpointEnv {
+	return map[string]interface{}{
+		"uid":          i.UID,
+		"name":         i.Name,
+		"labels":       i.Labels,
+		"annotations":  i.Annotations,
+		"namespace":    i.Namespace,
+		"scheme":       i.Scheme,
+		"host":         i.Host,
+		"path":         i.Path,
+		"service_name": i.ServiceName,
+		"service_port": i.ServicePort,
+	}
+}
+
+func (i *K8sIngress) Type() EndpointType {
+	return K8sIngressType
+}
+
+// ConsulService represents an instance of a service registered in the Consul catalog.
+type ConsulService struct {
+	// Name of the service.
+	Name string
+	// ServiceID is the ID of the service instance, unique on its node.
+	ServiceID string
+	// Node is the name of the node on which the service instance is registered.
+	Node string
+	// Datacenter of the node.
+	Datacenter string
+	// Port number of the service instance.
+	Port uint16
+	// Tags is the list of tags of the service instance.
+	Tags []string
+	// Meta is a map of user-specified metadata on the service instance.
+	Meta map[string]string
+	// NodeMeta is a map of user-specified metadata on the node.
+	NodeMeta map[string]string
+}
+
+func (c *ConsulService) Env() EndpointEnv {
+	return map[string]interface{}{
+		"name":       c.Name,
+		"service_id": c.ServiceID,
+		"node":       c.Node,
+		"datacenter": c.Datacenter,
+		"port":       c.Port,
+		"tags":       c.Tags,
+		"meta":       c.Meta,
+		"node_meta":  c.NodeMeta,
+	}
+}
+
+func (c *ConsulService) Type() EndpointType {
+	return ConsulServiceType
+}
@@ -115,6 +115,9 @@ func TestEndpointEnv(t *testing.T) {
 					Port:        2379,
 					Transport:   ProtocolUDP,
 					IsIPv6:      true,
+					Labels: map[string]string{
+						"job": "etcd",
+					},
 				},
 			},
 			want: EndpointEnv{
@@ -126,6 +129,9 @@ func TestEndpointEnv(t *testing.T) {
 				"is_ipv6":      true,
 				"port":         uint16(2379),
 				"transport":    ProtocolUDP,
+				"labels": map[string]string{
+					"job": "etcd",
+				},
 			},
 			wantErr: false,
 		},
@@ -211,6 +217,127 @@ func TestEndpointEnv(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Kubernetes Service",
+			endpoint: Endpoint{
+				ID:     EndpointID("k8s_service_endpoint_id"),
+				Target: "10.0.0.12",
+				Details: &K8sService{
+					Name:        "service_name",
+					UID:         "service-uid",
+					Namespace:   "service-namespace",
+					ServiceType: "ClusterIP",
+					ClusterIP:   "10.0.0.12",
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+					Annotations: map[string]string{
+						"annotation_1": "value_1",
+					},
+					Ports: []K8sServicePort{
+						{Name: "http", Port: 80, TargetPort: "8080", Transport: ProtocolTCP},
+					},
+				},
+			},
+			want: EndpointEnv{
+				"type":         "k8s.service",
+				"endpoint":     "10.0.0.12",
+				"id":           "k8s_service_endpoint_id",
+				"name":         "service_name",
+				"uid":          "service-uid",
+				"namespace":    "service-namespace",
+				"service_type": "ClusterIP",
+				"cluster_ip":   "10.0.0.12",
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+				"annotations": map[string]string{
+					"annotation_1": "value_1",
+				},
+				"ports": []EndpointEnv{
+					{
+						"name":        "http",
+						"port":        uint16(80),
+						"target_port": "8080",
+						"node_port":   uint16(0),
+						"transport":   ProtocolTCP,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Kubernetes Ingress",
+			endpoint: Endpoint{
+				ID:     EndpointID("k8s_ingress_endpoint_id"),
+				Target: "https://host-1/api",
+				Details: &K8sIngress{
+					Name:        "ingress_name",
+					UID:         "ingress-uid",
+					Namespace:   "ingress-namespace",
+					Scheme:      "https",
+					Host:        "host-1",
+					Path:        "/api",
+					ServiceName: "service_name",
+					ServicePort: "80",
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+				},
+			},
+			want: EndpointEnv{
+				"type":         "k8s.ingress",
+				"endpoint":     "https://host-1/api",
+				"id":           "k8s_ingress_endpoint_id",
+				"name":         "ingress_name",
+				"uid":          "ingress-uid",
+				"namespace":    "ingress-namespace",
+				"scheme":       "https",
+				"host":         "host-1",
+				"path":         "/api",
+				"service_name": "service_name",
+				"service_port": "80",
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+				"annotations": map[string]string(nil),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Consul service",
+			endpoint: Endpoint{
+				ID:     EndpointID("consul_service_endpoint_id"),
+				Target: "10.0.0.5:9100",
+				Details: &ConsulService{
+					Name:       "node-exporter",
+					ServiceID:  "node-exporter-1",
+					Node:       "vm-1",
+					Datacenter: "dc1",
+					Port:       9100,
+					Tags:       []string{"metrics"},
+					Meta: map[string]string{
+						"metrics_path": "/metrics",
+					},
+				},
+			},
+			want: EndpointEnv{
+				"type":       "consul.service",
+				"endpoint":   "10.0.0.5:9100",
+				"id":         "consul_service_endpoint_id",
+				"name":       "node-exporter",
+				"service_id": "node-exporter-1",
+				"node":       "vm-1",
+				"datacenter": "dc1",
+				"port":       uint16(9100),
+				"tags":       []string{"metrics"},
+				"meta": map[string]string{
+					"metrics_path": "/metrics",
+				},
+				"node_meta": map[string]string(nil),
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
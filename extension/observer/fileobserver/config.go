@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver"
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for file observer.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// Files is the list of files listing the targets to observe, in the Prometheus file_sd format.
+	// The last element of each path can be a glob pattern, e.g. /etc/otelcol/targets/*.yaml
+	Files []string `mapstructure:"files"`
+
+	// RefreshInterval determines how often the files are read to look for
+	// changes in the targets.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+func (cfg *Config) Validate() error {
+	if len(cfg.Files) == 0 {
+		return errors.New("files must be specified")
+	}
+	for _, pattern := range cfg.Files {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid files pattern %q: %w", pattern, err)
+		}
+	}
+	if cfg.RefreshInterval <= 0 {
+		return errors.New("refresh_interval must be positive")
+	}
+	return nil
+}
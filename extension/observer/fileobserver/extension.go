@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// filePathLabel is added to the labels of the targets, like Prometheus does for file_sd targets.
+const filePathLabel = "__meta_filepath"
+
+type fileObserver struct {
+	*observer.EndpointsWatcher
+}
+
+// targetGroup is a group of targets sharing the same labels, in the Prometheus file_sd format.
+type targetGroup struct {
+	Targets []string          `json:"targets" yaml:"targets"`
+	Labels  map[string]string `json:"labels" yaml:"labels"`
+}
+
+type endpointsLister struct {
+	logger       *zap.Logger
+	observerName string
+	files        []string
+
+	// groups holds the target groups last read from each file, which are kept
+	// when the file can't be read, e.g. while it is being written.
+	groups map[string][]targetGroup
+	mu     sync.Mutex
+}
+
+var _ component.Extension = (*fileObserver)(nil)
+
+func newObserver(logger *zap.Logger, config *Config) component.Extension {
+	return &fileObserver{
+		EndpointsWatcher: observer.NewEndpointsWatcher(
+			&endpointsLister{
+				logger:       logger,
+				observerName: config.ID().String(),
+				files:        config.Files,
+				groups:       map[string][]targetGroup{},
+			}, config.RefreshInterval, logger,
+		),
+	}
+}
+
+func (f *fileObserver) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (f *fileObserver) Shutdown(context.Context) error {
+	f.StopListAndWatch()
+	return nil
+}
+
+func (e *endpointsLister) ListEndpoints() []observer.Endpoint {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	paths := e.matchingPaths()
+	groups := make(map[string][]targetGroup, len(paths))
+	for _, path := range paths {
+		fileGroups, err := readTargetGroups(path)
+		if err != nil {
+			e.logger.Warn("Could not read targets (keeping the previous ones)", zap.String("path", path), zap.Error(err))
+			fileGroups = e.groups[path]
+		}
+		groups[path] = fileGroups
+	}
+	e.groups = groups
+
+	var endpoints []observer.Endpoint
+	for _, path := range paths {
+		for i, group := range groups[path] {
+			for _, target := range group.Targets {
+				endpoint, err := e.newEndpoint(path, i, target, group.Labels)
+				if err != nil {
+					e.logger.Warn("Invalid target (skipping)", zap.String("path", path), zap.String("target", target), zap.Error(err))
+					continue
+				}
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+	}
+	return endpoints
+}
+
+// matchingPaths returns the sorted paths of the files matching the configured patterns
+func (e *endpointsLister) matchingPaths() []string {
+	unique := map[string]struct{}{}
+	for _, pattern := range e.files {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			e.logger.Error("Invalid files pattern", zap.String("pattern", pattern), zap.Error(err))
+			continue
+		}
+		for _, match := range matches {
+			unique[match] = struct{}{}
+		}
+	}
+
+	paths := make([]string, 0, len(unique))
+	for path := range unique {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func readTargetGroups(path string) ([]targetGroup, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []targetGroup
+	switch ext := filepath.Ext(path); ext {
+	case ".json":
+		err = json.Unmarshal(content, &groups)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &groups)
+	default:
+		return nil, fmt.Errorf("unsupported file extension %q, expected .json, .yaml or .yml", ext)
+	}
+	return groups, err
+}
+
+func (e *endpointsLister) newEndpoint(path string, group int, target string, groupLabels map[string]string) (observer.Endpoint, error) {
+	host := target
+	var port uint16
+	if h, p, err := net.SplitHostPort(target); err == nil {
+		parsed, err := strconv.ParseUint(p, 10, 16)
+		if err != nil {
+			return observer.Endpoint{}, fmt.Errorf("invalid port %q: %w", p, err)
+		}
+		host, port = h, uint16(parsed)
+	}
+	if host == "" {
+		return observer.Endpoint{}, errors.New("missing host")
+	}
+	ip := net.ParseIP(host)
+
+	labels := make(map[string]string, len(groupLabels)+1)
+	for k, v := range groupLabels {
+		labels[k] = v
+	}
+	labels[filePathLabel] = path
+
+	return observer.Endpoint{
+		ID:     observer.EndpointID(fmt.Sprintf("(%s)%s:%d/%s", e.observerName, path, group, target)),
+		Target: target,
+		Details: &observer.HostPort{
+			Port:      port,
+			Transport: observer.ProtocolTCP,
+			IsIPv6:    ip != nil && ip.To4() == nil,
+			Labels:    labels,
+		},
+	}, nil
+}
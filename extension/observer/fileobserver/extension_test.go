@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileobserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func newTestLister(files ...string) *endpointsLister {
+	return &endpointsLister{
+		logger:       zap.NewNop(),
+		observerName: "file_observer",
+		files:        files,
+		groups:       map[string][]targetGroup{},
+	}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestListEndpoints(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "nodes.yaml")
+	writeFile(t, yamlPath, `
+- targets: ["10.0.0.1:9100", "[::1]:9100"]
+  labels:
+    job: node
+- targets: ["db-1.example.com"]
+`)
+	jsonPath := filepath.Join(dir, "exporters.json")
+	writeFile(t, jsonPath, `[{"targets": ["10.0.0.2:9187"], "labels": {"job": "postgres"}}]`)
+
+	lister := newTestLister(filepath.Join(dir, "*.yaml"), filepath.Join(dir, "*.json"))
+	assert.Equal(t, []observer.Endpoint{
+		{
+			ID:     observer.EndpointID("(file_observer)" + jsonPath + ":0/10.0.0.2:9187"),
+			Target: "10.0.0.2:9187",
+			Details: &observer.HostPort{
+				Port:      9187,
+				Transport: observer.ProtocolTCP,
+				Labels:    map[string]string{"job": "postgres", filePathLabel: jsonPath},
+			},
+		},
+		{
+			ID:     observer.EndpointID("(file_observer)" + yamlPath + ":0/10.0.0.1:9100"),
+			Target: "10.0.0.1:9100",
+			Details: &observer.HostPort{
+				Port:      9100,
+				Transport: observer.ProtocolTCP,
+				Labels:    map[string]string{"job": "node", filePathLabel: yamlPath},
+			},
+		},
+		{
+			ID:     observer.EndpointID("(file_observer)" + yamlPath + ":0/[::1]:9100"),
+			Target: "[::1]:9100",
+			Details: &observer.HostPort{
+				Port:      9100,
+				Transport: observer.ProtocolTCP,
+				IsIPv6:    true,
+				Labels:    map[string]string{"job": "node", filePathLabel: yamlPath},
+			},
+		},
+		{
+			ID:     observer.EndpointID("(file_observer)" + yamlPath + ":1/db-1.example.com"),
+			Target: "db-1.example.com",
+			Details: &observer.HostPort{
+				Transport: observer.ProtocolTCP,
+				Labels:    map[string]string{filePathLabel: yamlPath},
+			},
+		},
+	}, lister.ListEndpoints())
+}
+
+func TestListEndpointsInvalidTargets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "targets.yaml")
+	writeFile(t, path, `[{"targets": ["10.0.0.1:http", ":9100", "10.0.0.1:9100"]}]`)
+
+	endpoints := newTestLister(path).ListEndpoints()
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "10.0.0.1:9100", endpoints[0].Target)
+}
+
+func TestListEndpointsFileChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "targets.yml")
+	writeFile(t, path, `[{"targets": ["10.0.0.1:9100"]}]`)
+	lister := newTestLister(path)
+	require.Len(t, lister.ListEndpoints(), 1)
+
+	writeFile(t, path, `[{"targets": ["10.0.0.1:9100", "10.0.0.2:9100"]}]`)
+	require.Len(t, lister.ListEndpoints(), 2)
+
+	// the previous targets are kept while the file is invalid
+	writeFile(t, path, `[{"targets": ["10.0.0.3:9100"]`)
+	require.Len(t, lister.ListEndpoints(), 2)
+
+	require.NoError(t, os.Remove(path))
+	assert.Empty(t, lister.ListEndpoints())
+}
+
+func TestListEndpointsUnsupportedExtension(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "targets.txt")
+	writeFile(t, path, `[{"targets": ["10.0.0.1:9100"]}]`)
+	assert.Empty(t, newTestLister(path).ListEndpoints())
+}
+
+type mockNotify struct {
+	added chan []observer.Endpoint
+}
+
+func (m *mockNotify) ID() observer.NotifyID {
+	return "mock"
+}
+
+func (m *mockNotify) OnAdd(added []observer.Endpoint) {
+	m.added <- added
+}
+
+func (m *mockNotify) OnRemove([]observer.Endpoint) {}
+
+func (m *mockNotify) OnChange([]observer.Endpoint) {}
+
+func TestListAndWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "targets.json")
+	writeFile(t, path, `[{"targets": ["10.0.0.1:9100"]}]`)
+
+	ext, err := NewFactory().CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), &Config{
+		ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+		Files:             []string{path},
+		RefreshInterval:   defaultRefreshInterval,
+	})
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, ext.Shutdown(context.Background()))
+	})
+
+	notify := &mockNotify{added: make(chan []observer.Endpoint, 1)}
+	ext.(observer.Observable).ListAndWatch(notify)
+	added := <-notify.added
+	require.Len(t, added, 1)
+	assert.Equal(t, "10.0.0.1:9100", added[0].Target)
+}
@@ -203,3 +203,61 @@ func TestNodeEndpointsChanged(t *testing.T) {
 		},
 	}, th.ListEndpoints())
 }
+
+func TestServiceEndpointsAdded(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(service1V1)
+	endpoints := th.ListEndpoints()
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, observer.EndpointID("test-1/service1-UID"), endpoints[0].ID)
+	assert.Equal(t, "service1.default.svc", endpoints[0].Target)
+}
+
+func TestServiceEndpointsRemoved(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(service1V1)
+	th.OnDelete(service1V1)
+	assert.Empty(t, th.ListEndpoints())
+}
+
+func TestServiceEndpointsChanged(t *testing.T) {
+	th := newTestHandler()
+	// Nothing changed.
+	th.OnUpdate(service1V1, service1V1)
+	require.Empty(t, th.ListEndpoints())
+
+	// Labels changed.
+	th.OnUpdate(service1V1, service1V2)
+	endpoints := th.ListEndpoints()
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, map[string]string{"env": "prod", "service-version": "2"},
+		endpoints[0].Details.(*observer.K8sService).Labels)
+}
+
+func TestIngressEndpointsAdded(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingress1V1)
+	endpoints := th.ListEndpoints()
+	require.ElementsMatch(t,
+		[]observer.EndpointID{"test-1/ingress1-UID/secure.example.com/api", "test-1/ingress1-UID/www.example.com/"},
+		[]observer.EndpointID{endpoints[0].ID, endpoints[1].ID},
+	)
+}
+
+func TestIngressEndpointsRemoved(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingress1V1)
+	th.OnDelete(ingress1V1)
+	assert.Empty(t, th.ListEndpoints())
+}
+
+func TestIngressEndpointsChanged(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingress1V1)
+
+	// One rule removed.
+	th.OnUpdate(ingress1V1, ingress1V2)
+	endpoints := th.ListEndpoints()
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, observer.EndpointID("test-1/ingress1-UID/secure.example.com/api"), endpoints[0].ID)
+}
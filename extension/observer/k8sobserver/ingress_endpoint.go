@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver"
+
+import (
+	"fmt"
+	"strconv"
+
+	netv1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// convertIngressToEndpoints converts an ingress instance into a slice of k8s.ingress endpoints,
+// one for each host and path of its rules. The Target of each endpoint is the URL of the path.
+func convertIngressToEndpoints(idNamespace string, ingress *netv1.Ingress) []observer.Endpoint {
+	tlsHosts := map[string]bool{}
+	for _, tls := range ingress.Spec.TLS {
+		for _, host := range tls.Hosts {
+			tlsHosts[host] = true
+		}
+	}
+
+	var endpoints []observer.Endpoint
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+
+		scheme := "http"
+		if tlsHosts[rule.Host] {
+			scheme = "https"
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			var serviceName, servicePort string
+			if path.Backend.Service != nil {
+				serviceName = path.Backend.Service.Name
+				if path.Backend.Service.Port.Name != "" {
+					servicePort = path.Backend.Service.Port.Name
+				} else {
+					servicePort = strconv.Itoa(int(path.Backend.Service.Port.Number))
+				}
+			}
+
+			endpoints = append(endpoints, observer.Endpoint{
+				ID:     observer.EndpointID(fmt.Sprintf("%s/%s/%s%s", idNamespace, ingress.UID, rule.Host, path.Path)),
+				Target: fmt.Sprintf("%s://%s%s", scheme, rule.Host, path.Path),
+				Details: &observer.K8sIngress{
+					UID:         string(ingress.UID),
+					Annotations: ingress.Annotations,
+					Labels:      ingress.Labels,
+					Name:        ingress.Name,
+					Namespace:   ingress.Namespace,
+					Scheme:      scheme,
+					Host:        rule.Host,
+					Path:        path.Path,
+					ServiceName: serviceName,
+					ServicePort: servicePort,
+				},
+			})
+		}
+	}
+
+	return endpoints
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	netv1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestIngressObjectToK8sIngressEndpoints(t *testing.T) {
+	expectedEndpoints := []observer.Endpoint{
+		{
+			ID:     "namespace/ingress1-UID/secure.example.com/api",
+			Target: "https://secure.example.com/api",
+			Details: &observer.K8sIngress{
+				Name:        "ingress1",
+				Namespace:   "default",
+				UID:         "ingress1-UID",
+				Labels:      map[string]string{"env": "prod"},
+				Scheme:      "https",
+				Host:        "secure.example.com",
+				Path:        "/api",
+				ServiceName: "service1",
+				ServicePort: "80",
+			},
+		},
+		{
+			ID:     "namespace/ingress1-UID/www.example.com/",
+			Target: "http://www.example.com/",
+			Details: &observer.K8sIngress{
+				Name:        "ingress1",
+				Namespace:   "default",
+				UID:         "ingress1-UID",
+				Labels:      map[string]string{"env": "prod"},
+				Scheme:      "http",
+				Host:        "www.example.com",
+				Path:        "/",
+				ServiceName: "service1",
+				ServicePort: "http",
+			},
+		},
+	}
+
+	endpoints := convertIngressToEndpoints("namespace", ingress1V1)
+	require.Equal(t, expectedEndpoints, endpoints)
+}
+
+func TestIngressWithoutHTTPRulesHasNoEndpoints(t *testing.T) {
+	ingress := ingress1V1.DeepCopy()
+	ingress.Spec.Rules = []netv1.IngressRule{{Host: "www.example.com"}}
+	require.Empty(t, convertIngressToEndpoints("namespace", ingress))
+}
@@ -16,8 +16,10 @@ package k8sobserver
 
 import (
 	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // NewPod is a helper function for creating Pods for testing.
@@ -164,3 +166,91 @@ var node1V2 = func() *v1.Node {
 	node.Labels["node-version"] = "2"
 	return node
 }()
+
+// NewService is a helper function for creating Services for testing.
+func NewService(name string) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name + "-UID"),
+			Labels: map[string]string{
+				"env": "prod",
+			},
+		},
+		Spec: v1.ServiceSpec{
+			Type:      v1.ServiceTypeClusterIP,
+			ClusterIP: "10.0.0.12",
+			Ports: []v1.ServicePort{
+				{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080), Protocol: v1.ProtocolTCP},
+				{Name: "dns", Port: 53, TargetPort: intstr.FromString("dns"), Protocol: v1.ProtocolUDP},
+			},
+		},
+	}
+}
+
+var service1V1 = NewService("service1")
+var service1V2 = func() *v1.Service {
+	service := service1V1.DeepCopy()
+	service.Labels["service-version"] = "2"
+	return service
+}()
+
+// NewIngress is a helper function for creating Ingresses for testing.
+func NewIngress(name string) *netv1.Ingress {
+	pathType := netv1.PathTypePrefix
+	return &netv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name + "-UID"),
+			Labels: map[string]string{
+				"env": "prod",
+			},
+		},
+		Spec: netv1.IngressSpec{
+			TLS: []netv1.IngressTLS{
+				{Hosts: []string{"secure.example.com"}},
+			},
+			Rules: []netv1.IngressRule{
+				{
+					Host: "secure.example.com",
+					IngressRuleValue: netv1.IngressRuleValue{HTTP: &netv1.HTTPIngressRuleValue{
+						Paths: []netv1.HTTPIngressPath{
+							{
+								Path:     "/api",
+								PathType: &pathType,
+								Backend: netv1.IngressBackend{Service: &netv1.IngressServiceBackend{
+									Name: "service1",
+									Port: netv1.ServiceBackendPort{Number: 80},
+								}},
+							},
+						},
+					}},
+				},
+				{
+					Host: "www.example.com",
+					IngressRuleValue: netv1.IngressRuleValue{HTTP: &netv1.HTTPIngressRuleValue{
+						Paths: []netv1.HTTPIngressPath{
+							{
+								Path:     "/",
+								PathType: &pathType,
+								Backend: netv1.IngressBackend{Service: &netv1.IngressServiceBackend{
+									Name: "service1",
+									Port: netv1.ServiceBackendPort{Name: "http"},
+								}},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+var ingress1V1 = NewIngress("ingress1")
+var ingress1V2 = func() *netv1.Ingress {
+	ingress := ingress1V1.DeepCopy()
+	ingress.Spec.Rules = ingress.Spec.Rules[:1]
+	return ingress
+}()
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestServiceObjectToK8sServiceEndpoint(t *testing.T) {
+	expectedService := observer.Endpoint{
+		ID:     "namespace/service1-UID",
+		Target: "service1.default.svc",
+		Details: &observer.K8sService{
+			Name:        "service1",
+			Namespace:   "default",
+			UID:         "service1-UID",
+			Labels:      map[string]string{"env": "prod"},
+			ServiceType: "ClusterIP",
+			ClusterIP:   "10.0.0.12",
+			Ports: []observer.K8sServicePort{
+				{Name: "http", Port: 80, TargetPort: "8080", Transport: observer.ProtocolTCP},
+				{Name: "dns", Port: 53, TargetPort: "dns", Transport: observer.ProtocolUDP},
+			},
+		},
+	}
+
+	endpoint := convertServiceToEndpoint("namespace", service1V1)
+	require.Equal(t, expectedService, endpoint)
+}
@@ -20,6 +20,7 @@ var _ client.AuthData = (*authData)(nil)
 
 type authData struct {
 	raw        string
+	issuer     string
 	subject    string
 	membership []string
 }
@@ -32,11 +33,13 @@ func (a *authData) GetAttribute(name string) interface{} {
 		return a.membership
 	case "raw":
 		return a.raw
+	case "issuer":
+		return a.issuer
 	default:
 		return nil
 	}
 }
 
 func (*authData) GetAttributeNames() []string {
-	return []string{"subject", "membership", "raw"}
+	return []string{"subject", "membership", "raw", "issuer"}
 }
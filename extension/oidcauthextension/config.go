@@ -24,11 +24,48 @@ type Config struct {
 	Attribute string `mapstructure:"attribute"`
 
 	// IssuerURL is the base URL for the OIDC provider.
-	// Required.
+	// Required, unless Providers is set.
 	IssuerURL string `mapstructure:"issuer_url"`
 
 	// Audience of the token, used during the verification.
 	// For example: "https://accounts.google.com" or "https://login.salesforce.com".
+	// Required, unless Providers is set.
+	Audience string `mapstructure:"audience"`
+
+	// The local path for the issuer CA's TLS server cert.
+	// Optional.
+	IssuerCAPath string `mapstructure:"issuer_ca_path"`
+
+	// The claim to use as the username, in case the token's 'sub' isn't the suitable source.
+	// Optional.
+	UsernameClaim string `mapstructure:"username_claim"`
+
+	// The claim that holds the subject's group membership information.
+	// Optional.
+	GroupsClaim string `mapstructure:"groups_claim"`
+
+	// JWKSFile is the local path to a JSON Web Key Set used to verify the tokens' signatures.
+	// When set, the issuer's discovery document isn't fetched, making it suitable for air-gapped environments.
+	// Optional.
+	JWKSFile string `mapstructure:"jwks_file"`
+
+	// AuthorizationRules are expressions evaluated against the token's claims, such as `groups contains "otel-writers"`.
+	// Tokens are rejected unless all rules match.
+	// Optional.
+	AuthorizationRules []string `mapstructure:"authorization_rules"`
+
+	// Providers holds additional trusted issuers. Each token is verified by the provider matching its "iss" claim.
+	// Optional.
+	Providers []ProviderConfig `mapstructure:"providers"`
+}
+
+// ProviderConfig has the configuration for a single trusted OIDC issuer.
+type ProviderConfig struct {
+	// IssuerURL is the base URL for the OIDC provider.
+	// Required.
+	IssuerURL string `mapstructure:"issuer_url"`
+
+	// Audience of the token, used during the verification.
 	// Required.
 	Audience string `mapstructure:"audience"`
 
@@ -36,6 +73,10 @@ type Config struct {
 	// Optional.
 	IssuerCAPath string `mapstructure:"issuer_ca_path"`
 
+	// JWKSFile is the local path to a JSON Web Key Set used to verify the tokens' signatures.
+	// Optional.
+	JWKSFile string `mapstructure:"jwks_file"`
+
 	// The claim to use as the username, in case the token's 'sub' isn't the suitable source.
 	// Optional.
 	UsernameClaim string `mapstructure:"username_claim"`
@@ -43,4 +84,25 @@ type Config struct {
 	// The claim that holds the subject's group membership information.
 	// Optional.
 	GroupsClaim string `mapstructure:"groups_claim"`
+
+	// AuthorizationRules are expressions evaluated against the token's claims. Tokens are rejected unless all rules match.
+	// Optional.
+	AuthorizationRules []string `mapstructure:"authorization_rules"`
+}
+
+// providers returns the top-level provider, if configured, followed by the additional ones.
+func (cfg *Config) providers() []ProviderConfig {
+	var providers []ProviderConfig
+	if cfg.IssuerURL != "" || cfg.Audience != "" || len(cfg.Providers) == 0 {
+		providers = append(providers, ProviderConfig{
+			IssuerURL:          cfg.IssuerURL,
+			Audience:           cfg.Audience,
+			IssuerCAPath:       cfg.IssuerCAPath,
+			JWKSFile:           cfg.JWKSFile,
+			UsernameClaim:      cfg.UsernameClaim,
+			GroupsClaim:        cfg.GroupsClaim,
+			AuthorizationRules: cfg.AuthorizationRules,
+		})
+	}
+	return append(providers, cfg.Providers...)
 }
@@ -18,6 +18,8 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -38,29 +40,52 @@ import (
 type oidcExtension struct {
 	cfg *Config
 
-	provider *oidc.Provider
-	verifier *oidc.IDTokenVerifier
+	// issuers maps the issuer URL to the provider trusting it
+	issuers map[string]*issuer
 
 	logger *zap.Logger
 }
 
+// issuer holds the verification and authorization settings for a single trusted issuer.
+type issuer struct {
+	cfg      ProviderConfig
+	rules    []*rule
+	verifier *oidc.IDTokenVerifier
+}
+
 var (
 	errNoAudienceProvided                = errors.New("no Audience provided for the OIDC configuration")
 	errNoIssuerURL                       = errors.New("no IssuerURL provided for the OIDC configuration")
+	errDuplicateIssuerURL                = errors.New("the same IssuerURL is provided more than once in the OIDC configuration")
 	errInvalidAuthenticationHeaderFormat = errors.New("invalid authorization header format")
 	errFailedToObtainClaimsFromToken     = errors.New("failed to get the subject from the token issued by the OIDC provider")
 	errClaimNotFound                     = errors.New("username claim from the OIDC configuration not found on the token returned by the OIDC provider")
 	errUsernameNotString                 = errors.New("the username returned by the OIDC provider isn't a regular string")
 	errGroupsClaimNotFound               = errors.New("groups claim from the OIDC configuration not found on the token returned by the OIDC provider")
 	errNotAuthenticated                  = errors.New("authentication didn't succeed")
+	errMalformedToken                    = errors.New("malformed token")
+	errUnknownIssuer                     = errors.New("the token wasn't issued by any of the trusted issuers")
+	errNotAuthorized                     = errors.New("the token's claims don't match the authorization rules")
 )
 
 func newExtension(cfg *Config, logger *zap.Logger) (configauth.ServerAuthenticator, error) {
-	if cfg.Audience == "" {
-		return nil, errNoAudienceProvided
-	}
-	if cfg.IssuerURL == "" {
-		return nil, errNoIssuerURL
+	issuers := map[string]*issuer{}
+	for _, p := range cfg.providers() {
+		if p.Audience == "" {
+			return nil, errNoAudienceProvided
+		}
+		if p.IssuerURL == "" {
+			return nil, errNoIssuerURL
+		}
+		if _, ok := issuers[p.IssuerURL]; ok {
+			return nil, fmt.Errorf("%w: %q", errDuplicateIssuerURL, p.IssuerURL)
+		}
+
+		rules, err := parseRules(p.AuthorizationRules)
+		if err != nil {
+			return nil, err
+		}
+		issuers[p.IssuerURL] = &issuer{cfg: p, rules: rules}
 	}
 
 	if cfg.Attribute == "" {
@@ -68,22 +93,21 @@ func newExtension(cfg *Config, logger *zap.Logger) (configauth.ServerAuthenticat
 	}
 
 	oe := &oidcExtension{
-		cfg:    cfg,
-		logger: logger,
+		cfg:     cfg,
+		issuers: issuers,
+		logger:  logger,
 	}
 	return configauth.NewServerAuthenticator(configauth.WithStart(oe.start), configauth.WithAuthenticate(oe.authenticate)), nil
 }
 
 func (e *oidcExtension) start(context.Context, component.Host) error {
-	provider, err := getProviderForConfig(e.cfg)
-	if err != nil {
-		return fmt.Errorf("failed to get configuration from the auth server: %w", err)
+	for _, iss := range e.issuers {
+		verifier, err := getVerifierForConfig(&iss.cfg)
+		if err != nil {
+			return err // the errors from this path have enough context already
+		}
+		iss.verifier = verifier
 	}
-	e.provider = provider
-
-	e.verifier = e.provider.Verifier(&oidc.Config{
-		ClientID: e.cfg.Audience,
-	})
 
 	return nil
 }
@@ -102,7 +126,12 @@ func (e *oidcExtension) authenticate(ctx context.Context, headers map[string][]s
 	}
 
 	raw := parts[1]
-	idToken, err := e.verifier.Verify(ctx, raw)
+	iss, err := e.issuerForToken(raw)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to verify token: %w", err)
+	}
+
+	idToken, err := iss.verifier.Verify(ctx, raw)
 	if err != nil {
 		return ctx, fmt.Errorf("failed to verify token: %w", err)
 	}
@@ -118,24 +147,58 @@ func (e *oidcExtension) authenticate(ctx context.Context, headers map[string][]s
 		return ctx, errFailedToObtainClaimsFromToken
 	}
 
-	subject, err := getSubjectFromClaims(claims, e.cfg.UsernameClaim, idToken.Subject)
+	subject, err := getSubjectFromClaims(claims, iss.cfg.UsernameClaim, idToken.Subject)
 	if err != nil {
 		return ctx, fmt.Errorf("failed to get subject from claims in the token: %w", err)
 	}
-	membership, err := getGroupsFromClaims(claims, e.cfg.GroupsClaim)
+	membership, err := getGroupsFromClaims(claims, iss.cfg.GroupsClaim)
 	if err != nil {
 		return ctx, fmt.Errorf("failed to get groups from claims in the token: %w", err)
 	}
 
+	for _, r := range iss.rules {
+		if !r.matches(claims) {
+			return ctx, fmt.Errorf("%w: %s", errNotAuthorized, r.expr)
+		}
+	}
+
 	cl := client.FromContext(ctx)
 	cl.Auth = &authData{
 		raw:        raw,
+		issuer:     idToken.Issuer,
 		subject:    subject,
 		membership: membership,
 	}
 	return client.NewContext(ctx, cl), nil
 }
 
+// issuerForToken returns the trusted issuer matching the token's "iss" claim. The claim is read before the token
+// is verified, as it's only used to select the verifier, which then validates it again along with the signature.
+func (e *oidcExtension) issuerForToken(raw string) (*issuer, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, errMalformedToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errMalformedToken, err)
+	}
+
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", errMalformedToken, err)
+	}
+
+	iss, ok := e.issuers[claims.Issuer]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownIssuer, claims.Issuer)
+	}
+	return iss, nil
+}
+
 func getSubjectFromClaims(claims map[string]interface{}, usernameClaim string, fallback string) (string, error) {
 	if len(usernameClaim) > 0 {
 		username, found := claims[usernameClaim]
@@ -179,7 +242,27 @@ func getGroupsFromClaims(claims map[string]interface{}, groupsClaim string) ([]s
 	return []string{}, nil
 }
 
-func getProviderForConfig(config *Config) (*oidc.Provider, error) {
+func getVerifierForConfig(config *ProviderConfig) (*oidc.IDTokenVerifier, error) {
+	verifierConfig := &oidc.Config{
+		ClientID: config.Audience,
+	}
+
+	if config.JWKSFile != "" {
+		keySet, err := newStaticKeySetFromFile(config.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		return oidc.NewVerifier(config.IssuerURL, keySet, verifierConfig), nil
+	}
+
+	provider, err := getProviderForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configuration from the auth server: %w", err)
+	}
+	return provider.Verifier(verifierConfig), nil
+}
+
+func getProviderForConfig(config *ProviderConfig) (*oidc.Provider, error) {
 	t := &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
@@ -22,15 +22,18 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"math/big"
 	"net"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.uber.org/zap"
 )
@@ -110,7 +113,7 @@ func TestOIDCProviderForConfigWithTLS(t *testing.T) {
 	oidcServer.StartTLS()
 
 	// prepare the processor configuration
-	config := &Config{
+	config := &ProviderConfig{
 		IssuerURL:    oidcServer.URL,
 		IssuerCAPath: caFile.Name(),
 		Audience:     "unit-test",
@@ -185,7 +188,7 @@ func TestOIDCFailedToLoadIssuerCAFromPathInvalidContent(t *testing.T) {
 	_, err = file.Write([]byte("foobar"))
 	require.NoError(t, err)
 
-	config := &Config{
+	config := &ProviderConfig{
 		IssuerCAPath: file.Name(),
 	}
 
@@ -455,3 +458,328 @@ func TestShutdown(t *testing.T) {
 	// verify
 	assert.NoError(t, err)
 }
+
+func TestOIDCMultipleIssuers(t *testing.T) {
+	// prepare
+	internal, err := newOIDCServer()
+	require.NoError(t, err)
+	internal.Start()
+	defer internal.Close()
+
+	workload, err := newOIDCServer()
+	require.NoError(t, err)
+	workload.Start()
+	defer workload.Close()
+
+	untrusted, err := newOIDCServer()
+	require.NoError(t, err)
+	untrusted.Start()
+	defer untrusted.Close()
+
+	p, err := newExtension(&Config{
+		IssuerURL:   internal.URL,
+		Audience:    "internal",
+		GroupsClaim: "groups",
+		Providers: []ProviderConfig{
+			{
+				IssuerURL:     workload.URL,
+				Audience:      "workload",
+				UsernameClaim: "email",
+			},
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	for _, tt := range []struct {
+		casename        string
+		server          *oidcServer
+		audience        string
+		expectedSubject string
+		expectedGroups  []string
+		expectedError   error
+	}{
+		{
+			casename:        "internal",
+			server:          internal,
+			audience:        "internal",
+			expectedSubject: "jdoe",
+			expectedGroups:  []string{"otel-writers"},
+		},
+		{
+			casename:        "workload",
+			server:          workload,
+			audience:        "workload",
+			expectedSubject: "collector@example.com",
+			expectedGroups:  []string{},
+		},
+		{
+			casename:      "audience-from-another-issuer",
+			server:        workload,
+			audience:      "internal",
+			expectedError: errors.New("expected audience"),
+		},
+		{
+			casename:      "untrusted",
+			server:        untrusted,
+			audience:      "internal",
+			expectedError: errUnknownIssuer,
+		},
+	} {
+		t.Run(tt.casename, func(t *testing.T) {
+			payload, _ := json.Marshal(map[string]interface{}{
+				"sub":    "jdoe",
+				"email":  "collector@example.com",
+				"groups": []string{"otel-writers"},
+				"iss":    tt.server.URL,
+				"aud":    tt.audience,
+				"exp":    time.Now().Add(time.Minute).Unix(),
+			})
+			token, err := tt.server.token(payload)
+			require.NoError(t, err)
+
+			// test
+			ctx, err := p.Authenticate(context.Background(), map[string][]string{"authorization": {fmt.Sprintf("Bearer %s", token)}})
+
+			// verify
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				if errors.Is(tt.expectedError, errUnknownIssuer) {
+					assert.ErrorIs(t, err, errUnknownIssuer)
+				} else {
+					assert.Contains(t, err.Error(), tt.expectedError.Error())
+				}
+				return
+			}
+			require.NoError(t, err)
+
+			auth := client.FromContext(ctx).Auth
+			require.NotNil(t, auth)
+			assert.Equal(t, tt.server.URL, auth.GetAttribute("issuer"))
+			assert.Equal(t, tt.expectedSubject, auth.GetAttribute("subject"))
+			assert.Equal(t, tt.expectedGroups, auth.GetAttribute("membership"))
+		})
+	}
+}
+
+func TestOIDCJWKSFile(t *testing.T) {
+	// prepare
+	oidcServer, err := newOIDCServer()
+	require.NoError(t, err)
+	// the server is never started: the keys come from the file and no discovery happens
+	issuerURL := "https://idp.example.com"
+
+	p, err := newExtension(&Config{
+		IssuerURL: issuerURL,
+		Audience:  "unit-test",
+		JWKSFile:  oidcServer.jwksFile(t),
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"sub": "jdoe",
+		"iss": issuerURL,
+		"aud": "unit-test",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	})
+	token, err := oidcServer.token(payload)
+	require.NoError(t, err)
+
+	// test
+	ctx, err := p.Authenticate(context.Background(), map[string][]string{"authorization": {fmt.Sprintf("Bearer %s", token)}})
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe", client.FromContext(ctx).Auth.GetAttribute("subject"))
+
+	// a token signed by another key isn't accepted
+	other, err := newOIDCServer()
+	require.NoError(t, err)
+	token, err = other.token(payload)
+	require.NoError(t, err)
+
+	_, err = p.Authenticate(context.Background(), map[string][]string{"authorization": {fmt.Sprintf("Bearer %s", token)}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), errFailedToVerifySignature.Error()) // go-oidc doesn't wrap the key set errors
+}
+
+func TestOIDCInvalidJWKSFile(t *testing.T) {
+	emptyFile := filepath.Join(t.TempDir(), "empty.json")
+	require.NoError(t, os.WriteFile(emptyFile, []byte(`{"keys": []}`), 0600))
+	invalidFile := filepath.Join(t.TempDir(), "invalid.json")
+	require.NoError(t, os.WriteFile(invalidFile, []byte("foobar"), 0600))
+
+	for _, path := range []string{"some-non-existing-file", emptyFile, invalidFile} {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			p, err := newExtension(&Config{
+				IssuerURL: "https://idp.example.com",
+				Audience:  "unit-test",
+				JWKSFile:  path,
+			}, zap.NewNop())
+			require.NoError(t, err)
+
+			// test
+			err = p.Start(context.Background(), componenttest.NewNopHost())
+
+			// verify
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestOIDCAuthorizationRules(t *testing.T) {
+	// prepare
+	oidcServer, err := newOIDCServer()
+	require.NoError(t, err)
+	oidcServer.Start()
+	defer oidcServer.Close()
+
+	for _, tt := range []struct {
+		casename      string
+		rules         []string
+		expectedError error
+	}{
+		{
+			casename: "no-rules",
+		},
+		{
+			casename: "all-match",
+			rules:    []string{`groups contains "otel-writers"`, `tenant == "acme"`},
+		},
+		{
+			casename:      "group-missing",
+			rules:         []string{`groups contains "otel-admins"`},
+			expectedError: errNotAuthorized,
+		},
+		{
+			casename:      "one-rule-fails",
+			rules:         []string{`groups contains "otel-writers"`, `tenant != "acme"`},
+			expectedError: errNotAuthorized,
+		},
+		{
+			casename:      "claim-missing",
+			rules:         []string{`environment == "production"`},
+			expectedError: errNotAuthorized,
+		},
+	} {
+		t.Run(tt.casename, func(t *testing.T) {
+			p, err := newExtension(&Config{
+				IssuerURL:          oidcServer.URL,
+				Audience:           "unit-test",
+				AuthorizationRules: tt.rules,
+			}, zap.NewNop())
+			require.NoError(t, err)
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+			payload, _ := json.Marshal(map[string]interface{}{
+				"sub":    "jdoe",
+				"tenant": "acme",
+				"groups": []string{"department-1", "otel-writers"},
+				"iss":    oidcServer.URL,
+				"aud":    "unit-test",
+				"exp":    time.Now().Add(time.Minute).Unix(),
+			})
+			token, err := oidcServer.token(payload)
+			require.NoError(t, err)
+
+			// test
+			ctx, err := p.Authenticate(context.Background(), map[string][]string{"authorization": {fmt.Sprintf("Bearer %s", token)}})
+
+			// verify
+			assert.NotNil(t, ctx)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, client.FromContext(ctx).Auth)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, client.FromContext(ctx).Auth)
+			}
+		})
+	}
+}
+
+func TestOIDCMalformedToken(t *testing.T) {
+	// prepare
+	p, err := newExtension(&Config{
+		Audience:  "some-audience",
+		IssuerURL: "http://example.com",
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	for _, token := range []string{"some-token", "a.b.c", "a.bm90LWpzb24.c"} {
+		// test
+		_, err = p.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer " + token}})
+
+		// verify
+		assert.ErrorIs(t, err, errMalformedToken)
+	}
+}
+
+func TestInvalidProvidersConfig(t *testing.T) {
+	for _, tt := range []struct {
+		casename      string
+		config        *Config
+		expectedError error
+	}{
+		{
+			casename: "provider-without-audience",
+			config: &Config{
+				Providers: []ProviderConfig{{IssuerURL: "http://example.com"}},
+			},
+			expectedError: errNoAudienceProvided,
+		},
+		{
+			casename: "provider-without-issuer",
+			config: &Config{
+				Providers: []ProviderConfig{{Audience: "some-audience"}},
+			},
+			expectedError: errNoIssuerURL,
+		},
+		{
+			casename: "duplicate-issuer",
+			config: &Config{
+				IssuerURL: "http://example.com",
+				Audience:  "some-audience",
+				Providers: []ProviderConfig{{IssuerURL: "http://example.com", Audience: "another-audience"}},
+			},
+			expectedError: errDuplicateIssuerURL,
+		},
+		{
+			casename: "invalid-rule",
+			config: &Config{
+				IssuerURL:          "http://example.com",
+				Audience:           "some-audience",
+				AuthorizationRules: []string{"groups contains otel-writers"},
+			},
+			expectedError: errInvalidRule,
+		},
+	} {
+		t.Run(tt.casename, func(t *testing.T) {
+			// test
+			p, err := newExtension(tt.config, zap.NewNop())
+
+			// verify
+			assert.Nil(t, p)
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestProvidersOnly(t *testing.T) {
+	// prepare
+	config := &Config{
+		Providers: []ProviderConfig{
+			{IssuerURL: "http://example.com", Audience: "some-audience"},
+			{IssuerURL: "https://container.googleapis.com/v1/projects/p/locations/l/clusters/c", Audience: "another-audience"},
+		},
+	}
+
+	// test
+	p, err := newExtension(config, zap.NewNop())
+
+	// verify
+	assert.NoError(t, err)
+	assert.NotNil(t, p)
+	assert.Len(t, config.providers(), 2)
+}
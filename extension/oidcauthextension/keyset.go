@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidcauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/oidcauthextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/coreos/go-oidc"
+	"gopkg.in/square/go-jose.v2"
+)
+
+var errFailedToVerifySignature = errors.New("failed to verify the token signature with the keys from the JWKS file")
+
+var _ oidc.KeySet = (*staticKeySet)(nil)
+
+// staticKeySet verifies token signatures using a fixed set of keys, loaded from a local JWKS file.
+type staticKeySet struct {
+	keys []jose.JSONWebKey
+}
+
+func newStaticKeySetFromFile(path string) (*staticKeySet, error) {
+	raw, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("could not read the JWKS file %q: %w", path, err)
+	}
+
+	var jwks jose.JSONWebKeySet
+	if err = json.Unmarshal(raw, &jwks); err != nil {
+		return nil, fmt.Errorf("could not parse the JWKS file %q: %w", path, err)
+	}
+
+	if len(jwks.Keys) == 0 {
+		return nil, fmt.Errorf("the JWKS file %q has no keys", path)
+	}
+
+	return &staticKeySet{keys: jwks.Keys}, nil
+}
+
+func (s *staticKeySet) VerifySignature(_ context.Context, jwt string) ([]byte, error) {
+	jws, err := jose.ParseSigned(jwt)
+	if err != nil {
+		return nil, fmt.Errorf("malformed jwt: %w", err)
+	}
+
+	// like the remote key set from go-oidc, tokens with multiple signatures aren't supported
+	keyID := ""
+	if len(jws.Signatures) > 0 {
+		keyID = jws.Signatures[0].Header.KeyID
+	}
+
+	for i := range s.keys {
+		if keyID != "" && s.keys[i].KeyID != keyID {
+			continue
+		}
+		if payload, err := jws.Verify(&s.keys[i]); err == nil {
+			return payload, nil
+		}
+	}
+	return nil, errFailedToVerifySignature
+}
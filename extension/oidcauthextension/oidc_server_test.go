@@ -30,7 +30,12 @@ import (
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
 	"time"
+
+	"github.com/stretchr/testify/require"
 )
 
 // oidcServer is an overly simplified OIDC mock server, good enough to sign the tokens required by the test
@@ -39,6 +44,7 @@ type oidcServer struct {
 	*httptest.Server
 	x509Cert   []byte
 	privateKey *rsa.PrivateKey
+	jwks       map[string]interface{}
 }
 
 func newOIDCServer() (*oidcServer, error) {
@@ -93,7 +99,17 @@ func newOIDCServer() (*oidcServer, error) {
 		"x5t": base64.RawURLEncoding.EncodeToString(sum[:]),
 	}}
 
-	return &oidcServer{server, x509Cert, privateKey}, nil
+	return &oidcServer{server, x509Cert, privateKey, jwks}, nil
+}
+
+// jwksFile writes the server's key set to a temporary file, returning its path
+func (s *oidcServer) jwksFile(t *testing.T) string {
+	raw, err := json.Marshal(s.jwks)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, raw, 0600))
+	return path
 }
 
 func (s *oidcServer) token(jsonPayload []byte) (string, error) {
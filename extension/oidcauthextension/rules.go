@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidcauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/oidcauthextension"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	opEquals    = "=="
+	opNotEquals = "!="
+	opContains  = "contains"
+)
+
+var errInvalidRule = errors.New("invalid authorization rule, expected format is `<claim> <==|!=|contains> \"<value>\"`")
+
+// rule is a parsed authorization rule, matching a single claim against a value.
+type rule struct {
+	expr     string
+	claim    string
+	operator string
+	value    string
+}
+
+func parseRule(expr string) (*rule, error) {
+	trimmed := strings.TrimSpace(expr)
+	idx := strings.IndexByte(trimmed, ' ')
+	if idx < 0 {
+		return nil, fmt.Errorf("%w: %q", errInvalidRule, expr)
+	}
+	claim := trimmed[:idx]
+	rest := strings.TrimSpace(trimmed[idx:])
+
+	idx = strings.IndexByte(rest, ' ')
+	if idx < 0 {
+		return nil, fmt.Errorf("%w: %q", errInvalidRule, expr)
+	}
+	operator := rest[:idx]
+	switch operator {
+	case opEquals, opNotEquals, opContains:
+	default:
+		return nil, fmt.Errorf("%w: unknown operator %q in %q", errInvalidRule, operator, expr)
+	}
+
+	value, err := strconv.Unquote(strings.TrimSpace(rest[idx:]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: the value in %q must be a double-quoted string", errInvalidRule, expr)
+	}
+
+	return &rule{
+		expr:     expr,
+		claim:    claim,
+		operator: operator,
+		value:    value,
+	}, nil
+}
+
+func parseRules(exprs []string) ([]*rule, error) {
+	rules := make([]*rule, 0, len(exprs))
+	for _, expr := range exprs {
+		r, err := parseRule(expr)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// matches evaluates the rule against the claims. Rules referring to claims that aren't present never match.
+func (r *rule) matches(claims map[string]interface{}) bool {
+	v, found := lookupClaim(claims, r.claim)
+	if !found {
+		return false
+	}
+
+	switch r.operator {
+	case opEquals:
+		return isScalar(v) && fmt.Sprintf("%v", v) == r.value
+	case opNotEquals:
+		return isScalar(v) && fmt.Sprintf("%v", v) != r.value
+	case opContains:
+		switch values := v.(type) {
+		case []interface{}:
+			for _, item := range values {
+				if fmt.Sprintf("%v", item) == r.value {
+					return true
+				}
+			}
+			return false
+		case string:
+			// single-valued claims are treated as a list with one element, like the groups claim
+			return values == r.value
+		}
+	}
+	return false
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case []interface{}, map[string]interface{}, nil:
+		return false
+	}
+	return true
+}
+
+// lookupClaim returns the value for the given claim name. Dots in the name can be used to reach nested claims,
+// while claim names that contain dots themselves, like "kubernetes.io", are still matched as a whole.
+func lookupClaim(claims map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := claims[name]; ok {
+		return v, true
+	}
+
+	for idx := strings.IndexByte(name, '.'); idx >= 0; {
+		if nested, ok := claims[name[:idx]].(map[string]interface{}); ok {
+			if v, found := lookupClaim(nested, name[idx+1:]); found {
+				return v, true
+			}
+		}
+
+		next := strings.IndexByte(name[idx+1:], '.')
+		if next < 0 {
+			break
+		}
+		idx += next + 1
+	}
+	return nil, false
+}
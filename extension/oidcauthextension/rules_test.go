@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidcauthextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRule(t *testing.T) {
+	for _, tt := range []struct {
+		expr     string
+		expected *rule
+	}{
+		{
+			expr:     `groups contains "otel-writers"`,
+			expected: &rule{expr: `groups contains "otel-writers"`, claim: "groups", operator: opContains, value: "otel-writers"},
+		},
+		{
+			expr:     `  email   ==   "collector@example.com" `,
+			expected: &rule{expr: `  email   ==   "collector@example.com" `, claim: "email", operator: opEquals, value: "collector@example.com"},
+		},
+		{
+			expr:     `kubernetes.io.namespace != "kube system"`,
+			expected: &rule{expr: `kubernetes.io.namespace != "kube system"`, claim: "kubernetes.io.namespace", operator: opNotEquals, value: "kube system"},
+		},
+	} {
+		t.Run(tt.expr, func(t *testing.T) {
+			r, err := parseRule(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, r)
+		})
+	}
+}
+
+func TestParseInvalidRule(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"groups",
+		`groups contains`,
+		`groups has "otel-writers"`,
+		`groups contains otel-writers`,
+		`groups contains "otel-writers`,
+	} {
+		t.Run(expr, func(t *testing.T) {
+			r, err := parseRule(expr)
+			assert.Nil(t, r)
+			assert.ErrorIs(t, err, errInvalidRule)
+		})
+	}
+}
+
+func TestRuleMatches(t *testing.T) {
+	claims := map[string]interface{}{
+		"sub":      "jdoe",
+		"groups":   []interface{}{"otel-writers", "department-1"},
+		"role":     "writer",
+		"verified": true,
+		"level":    float64(3),
+		"kubernetes.io": map[string]interface{}{
+			"namespace": "observability",
+		},
+		"org": map[string]interface{}{
+			"team": map[string]interface{}{"name": "platform"},
+		},
+	}
+
+	for _, tt := range []struct {
+		expr     string
+		expected bool
+	}{
+		{`groups contains "otel-writers"`, true},
+		{`groups contains "otel-admins"`, false},
+		{`role contains "writer"`, true},
+		{`role contains "write"`, false},
+		{`role == "writer"`, true},
+		{`role != "writer"`, false},
+		{`role != "reader"`, true},
+		{`verified == "true"`, true},
+		{`level == "3"`, true},
+		{`groups == "otel-writers"`, false},
+		{`kubernetes.io.namespace == "observability"`, true},
+		{`org.team.name == "platform"`, true},
+		{`org.team == "platform"`, false},
+		{`missing != "anything"`, false},
+		{`missing contains "anything"`, false},
+	} {
+		t.Run(tt.expr, func(t *testing.T) {
+			r, err := parseRule(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, r.matches(claims))
+		})
+	}
+}
@@ -15,6 +15,10 @@
 package pprofextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension"
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confignet"
 )
@@ -41,11 +45,120 @@ type Config struct {
 	// Optional file name to save the CPU profile to. The profiling starts when the
 	// Collector starts and is saved to the file when the Collector is terminated.
 	SaveToFile string `mapstructure:"save_to_file"`
+
+	// Snapshots configures the profiles periodically saved to a directory, and
+	// the ones saved when the resident memory of the process crosses a threshold.
+	Snapshots SnapshotsConfig `mapstructure:"snapshots"`
+}
+
+// SnapshotsConfig has the configuration of the profile snapshots.
+type SnapshotsConfig struct {
+	// Directory in which the snapshots are saved, each one in a sub-directory named
+	// after the time it was taken at. The snapshots are disabled if empty.
+	Directory string `mapstructure:"directory"`
+
+	// Interval between two periodic snapshots. A value of 0 disables the periodic snapshots.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Profiles is the list of the profiles saved in each snapshot, among
+	// cpu, heap, goroutine, mutex and block. All of them are saved by default, except
+	// the mutex and block profiles when they aren't collected.
+	Profiles []string `mapstructure:"profiles"`
+
+	// CPUDuration is the duration for which the CPU profile of each snapshot is collected.
+	CPUDuration time.Duration `mapstructure:"cpu_duration"`
+
+	// Retention is the number of snapshots kept in the directory, the oldest ones
+	// being removed after a new snapshot is saved.
+	Retention int `mapstructure:"retention"`
+
+	// RSSThresholdMiB is the resident memory size of the process, in MiB, above which
+	// a snapshot is taken. A snapshot is taken each time the threshold is crossed,
+	// a value of 0 disables these snapshots.
+	RSSThresholdMiB uint64 `mapstructure:"rss_threshold_mib"`
+
+	// RSSCheckInterval is how often the resident memory size is compared to the threshold.
+	RSSCheckInterval time.Duration `mapstructure:"rss_check_interval"`
 }
 
 var _ config.Extension = (*Config)(nil)
 
 // Validate checks if the extension configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.Snapshots.Directory != "" {
+		for _, profile := range cfg.snapshotProfiles() {
+			switch {
+			case profile == cpuProfile && cfg.SaveToFile != "":
+				return errors.New("snapshots: the cpu profile can't be included when save_to_file is specified")
+			case profile == mutexProfile && cfg.MutexProfileFraction <= 0:
+				return errors.New("snapshots: the mutex profile can't be included unless mutex_profile_fraction is positive")
+			case profile == blockProfile && cfg.BlockProfileFraction <= 0:
+				return errors.New("snapshots: the block profile can't be included unless block_profile_fraction is positive")
+			}
+		}
+	}
+	return cfg.Snapshots.Validate()
+}
+
+// snapshotProfiles returns the profiles saved in each snapshot. The mutex and
+// block profiles are left out of the default ones when they aren't collected.
+func (cfg *Config) snapshotProfiles() []string {
+	if len(cfg.Snapshots.Profiles) > 0 {
+		return cfg.Snapshots.Profiles
+	}
+	var profiles []string
+	for _, profile := range cfg.Snapshots.snapshotProfiles() {
+		if (profile == mutexProfile && cfg.MutexProfileFraction <= 0) ||
+			(profile == blockProfile && cfg.BlockProfileFraction <= 0) {
+			continue
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles
+}
+
+// Validate checks if the snapshots configuration is valid
+func (cfg *SnapshotsConfig) Validate() error {
+	if cfg.Directory == "" {
+		if cfg.Interval != 0 || cfg.RSSThresholdMiB != 0 {
+			return errors.New("snapshots: directory must be specified to take snapshots")
+		}
+		return nil
+	}
+
+	if cfg.Interval < 0 {
+		return errors.New("snapshots: interval must not be negative")
+	}
+	if cfg.Interval == 0 && cfg.RSSThresholdMiB == 0 {
+		return errors.New("snapshots: either interval or rss_threshold_mib must be specified")
+	}
+	if cfg.RSSThresholdMiB != 0 && cfg.RSSCheckInterval <= 0 {
+		return errors.New("snapshots: rss_check_interval must be positive")
+	}
+	if cfg.Retention < 1 {
+		return errors.New("snapshots: retention must be at least 1")
+	}
+	for _, profile := range cfg.snapshotProfiles() {
+		switch profile {
+		case cpuProfile:
+			if cfg.CPUDuration <= 0 {
+				return errors.New("snapshots: cpu_duration must be positive")
+			}
+			if cfg.Interval > 0 && cfg.CPUDuration >= cfg.Interval {
+				return errors.New("snapshots: cpu_duration must be shorter than interval")
+			}
+		case heapProfile, goroutineProfile, mutexProfile, blockProfile:
+		default:
+			return fmt.Errorf("snapshots: unknown profile %q", profile)
+		}
+	}
 	return nil
 }
+
+// snapshotProfiles returns the profiles saved in each snapshot.
+func (cfg *SnapshotsConfig) snapshotProfiles() []string {
+	if len(cfg.Profiles) == 0 {
+		return []string{cpuProfile, heapProfile, goroutineProfile, mutexProfile, blockProfile}
+	}
+	return cfg.Profiles
+}
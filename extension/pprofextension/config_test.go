@@ -17,6 +17,7 @@ package pprofextension
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,6 +44,23 @@ func TestLoadConfig(t *testing.T) {
 				TCPAddr:              confignet.TCPAddr{Endpoint: "0.0.0.0:1777"},
 				BlockProfileFraction: 3,
 				MutexProfileFraction: 5,
+				Snapshots:            createDefaultConfig().(*Config).Snapshots,
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "snapshots"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				TCPAddr:           confignet.TCPAddr{Endpoint: defaultEndpoint},
+				Snapshots: SnapshotsConfig{
+					Directory:        "/var/lib/otelcol/pprof",
+					Interval:         15 * time.Minute,
+					Profiles:         []string{cpuProfile, heapProfile, goroutineProfile},
+					CPUDuration:      30 * time.Second,
+					Retention:        96,
+					RSSThresholdMiB:  1536,
+					RSSCheckInterval: time.Second,
+				},
 			},
 		},
 	}
@@ -61,3 +79,118 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotProfiles(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, []string{cpuProfile, heapProfile, goroutineProfile}, cfg.snapshotProfiles())
+
+	cfg.MutexProfileFraction = 5
+	assert.Equal(t, []string{cpuProfile, heapProfile, goroutineProfile, mutexProfile}, cfg.snapshotProfiles())
+
+	cfg.BlockProfileFraction = 5
+	assert.Equal(t, []string{cpuProfile, heapProfile, goroutineProfile, mutexProfile, blockProfile}, cfg.snapshotProfiles())
+
+	cfg.Snapshots.Profiles = []string{heapProfile}
+	assert.Equal(t, []string{heapProfile}, cfg.snapshotProfiles())
+}
+
+func TestInvalidConfig(t *testing.T) {
+	validSnapshots := func() SnapshotsConfig {
+		snapshots := createDefaultConfig().(*Config).Snapshots
+		snapshots.Directory = "pprof"
+		snapshots.Interval = time.Minute
+		return snapshots
+	}
+
+	tests := []struct {
+		name      string
+		configure func(*Config)
+		expected  string
+	}{
+		{
+			name:      "no directory",
+			configure: func(cfg *Config) { cfg.Snapshots.Interval = time.Minute },
+			expected:  "snapshots: directory must be specified to take snapshots",
+		},
+		{
+			name: "no trigger",
+			configure: func(cfg *Config) {
+				cfg.Snapshots = validSnapshots()
+				cfg.Snapshots.Interval = 0
+			},
+			expected: "snapshots: either interval or rss_threshold_mib must be specified",
+		},
+		{
+			name: "negative interval",
+			configure: func(cfg *Config) {
+				cfg.Snapshots = validSnapshots()
+				cfg.Snapshots.Interval = -time.Minute
+			},
+			expected: "snapshots: interval must not be negative",
+		},
+		{
+			name: "rss check interval",
+			configure: func(cfg *Config) {
+				cfg.Snapshots = validSnapshots()
+				cfg.Snapshots.RSSThresholdMiB = 1024
+				cfg.Snapshots.RSSCheckInterval = 0
+			},
+			expected: "snapshots: rss_check_interval must be positive",
+		},
+		{
+			name: "retention",
+			configure: func(cfg *Config) {
+				cfg.Snapshots = validSnapshots()
+				cfg.Snapshots.Retention = 0
+			},
+			expected: "snapshots: retention must be at least 1",
+		},
+		{
+			name: "unknown profile",
+			configure: func(cfg *Config) {
+				cfg.Snapshots = validSnapshots()
+				cfg.Snapshots.Profiles = []string{"threadcreate"}
+			},
+			expected: `snapshots: unknown profile "threadcreate"`,
+		},
+		{
+			name: "cpu duration",
+			configure: func(cfg *Config) {
+				cfg.Snapshots = validSnapshots()
+				cfg.Snapshots.CPUDuration = time.Minute
+			},
+			expected: "snapshots: cpu_duration must be shorter than interval",
+		},
+		{
+			name: "mutex profile",
+			configure: func(cfg *Config) {
+				cfg.Snapshots = validSnapshots()
+				cfg.Snapshots.Profiles = []string{heapProfile, mutexProfile}
+			},
+			expected: "snapshots: the mutex profile can't be included unless mutex_profile_fraction is positive",
+		},
+		{
+			name: "block profile",
+			configure: func(cfg *Config) {
+				cfg.Snapshots = validSnapshots()
+				cfg.Snapshots.Profiles = []string{blockProfile}
+			},
+			expected: "snapshots: the block profile can't be included unless block_profile_fraction is positive",
+		},
+		{
+			name: "save to file",
+			configure: func(cfg *Config) {
+				cfg.Snapshots = validSnapshots()
+				cfg.SaveToFile = "cpu.pprof"
+			},
+			expected: "snapshots: the cpu profile can't be included when save_to_file is specified",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.configure(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.expected)
+		})
+	}
+}
@@ -17,6 +17,7 @@ package pprofextension // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -28,6 +29,10 @@ const (
 	typeStr = "pprof"
 
 	defaultEndpoint = "localhost:1777"
+
+	defaultCPUDuration      = 10 * time.Second
+	defaultRetention        = 10
+	defaultRSSCheckInterval = 5 * time.Second
 )
 
 // NewFactory creates a factory for pprof extension.
@@ -46,6 +51,11 @@ func createDefaultConfig() config.Extension {
 		TCPAddr: confignet.TCPAddr{
 			Endpoint: defaultEndpoint,
 		},
+		Snapshots: SnapshotsConfig{
+			CPUDuration:      defaultCPUDuration,
+			Retention:        defaultRetention,
+			RSSCheckInterval: defaultRSSCheckInterval,
+		},
 	}
 }
 
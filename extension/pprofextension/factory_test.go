@@ -33,6 +33,11 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 	assert.Equal(t, &Config{
 		ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
 		TCPAddr:           confignet.TCPAddr{Endpoint: defaultEndpoint},
+		Snapshots: SnapshotsConfig{
+			CPUDuration:      defaultCPUDuration,
+			Retention:        defaultRetention,
+			RSSCheckInterval: defaultRSSCheckInterval,
+		},
 	},
 		cfg)
 
@@ -37,6 +37,8 @@ type pprofExtension struct {
 	file   *os.File
 	server http.Server
 	stopCh chan struct{}
+
+	snapshotter *snapshotter
 }
 
 func (p *pprofExtension) Start(_ context.Context, host component.Host) error {
@@ -90,6 +92,16 @@ func (p *pprofExtension) Start(_ context.Context, host component.Host) error {
 		}
 		p.file = f
 		startErr = pprof.StartCPUProfile(f)
+		if startErr != nil {
+			return startErr
+		}
+	}
+
+	if p.config.Snapshots.Directory != "" {
+		snapshots := p.config.Snapshots
+		snapshots.Profiles = p.config.snapshotProfiles()
+		p.snapshotter = newSnapshotter(snapshots, p.logger)
+		startErr = p.snapshotter.start()
 	}
 
 	return startErr
@@ -97,6 +109,9 @@ func (p *pprofExtension) Start(_ context.Context, host component.Host) error {
 
 func (p *pprofExtension) Shutdown(context.Context) error {
 	defer running.Store(false)
+	if p.snapshotter != nil {
+		p.snapshotter.shutdown()
+	}
 	if p.file != nil {
 		pprof.StopCPUProfile()
 		_ = p.file.Close() // ignore the error
@@ -19,8 +19,10 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -142,3 +144,29 @@ func TestPerformanceProfilerLifecycleWithFile(t *testing.T) {
 	require.NoError(t, pprofExt.Start(context.Background(), componenttest.NewNopHost()))
 	require.NoError(t, pprofExt.Shutdown(context.Background()))
 }
+
+func TestPerformanceProfilerLifecycleWithSnapshots(t *testing.T) {
+	config := Config{
+		TCPAddr: confignet.TCPAddr{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		Snapshots: SnapshotsConfig{
+			Directory:   filepath.Join(t.TempDir(), "pprof"),
+			Interval:    10 * time.Millisecond,
+			Profiles:    []string{heapProfile},
+			CPUDuration: defaultCPUDuration,
+			Retention:   1,
+		},
+	}
+
+	pprofExt := newServer(config, zap.NewNop())
+	require.NotNil(t, pprofExt)
+
+	require.NoError(t, pprofExt.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		entries, err := os.ReadDir(config.Snapshots.Directory)
+		return err == nil && len(entries) == 1 && entries[0].IsDir()
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, pprofExt.Shutdown(context.Background()))
+	require.NoError(t, pprofExt.Shutdown(context.Background()))
+}
@@ -0,0 +1,257 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pprofextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension"
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+	"go.uber.org/zap"
+)
+
+const (
+	cpuProfile       = "cpu"
+	heapProfile      = "heap"
+	goroutineProfile = "goroutine"
+	mutexProfile     = "mutex"
+	blockProfile     = "block"
+
+	triggerInterval = "interval"
+	triggerRSS      = "rss"
+
+	// snapshotTimeFormat is the format of the time prefixing the snapshot names,
+	// so that the snapshots sort in the order they were taken.
+	snapshotTimeFormat = "20060102T150405.000Z"
+
+	mib = 1024 * 1024
+)
+
+// snapshotter periodically saves the profiles of the process to a directory, and
+// when the resident memory size of the process crosses a threshold.
+type snapshotter struct {
+	config SnapshotsConfig
+	logger *zap.Logger
+
+	// rss returns the resident memory size of the process, in bytes.
+	rss func() (uint64, error)
+	now func() time.Time
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+func newSnapshotter(config SnapshotsConfig, logger *zap.Logger) *snapshotter {
+	return &snapshotter{
+		config: config,
+		logger: logger,
+		rss:    processRSS,
+		now:    time.Now,
+	}
+}
+
+func processRSS() (uint64, error) {
+	proc, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		return 0, err
+	}
+	info, err := proc.MemoryInfo()
+	if err != nil {
+		return 0, err
+	}
+	return info.RSS, nil
+}
+
+func (s *snapshotter) start() error {
+	if err := os.MkdirAll(s.config.Directory, 0750); err != nil {
+		return fmt.Errorf("failed to create the snapshots directory: %w", err)
+	}
+	s.stopCh = make(chan struct{})
+	s.doneCh = make(chan struct{})
+	go s.run()
+	return nil
+}
+
+func (s *snapshotter) shutdown() {
+	if s.stopCh == nil {
+		return
+	}
+	close(s.stopCh)
+	<-s.doneCh
+	s.stopCh = nil
+}
+
+func (s *snapshotter) run() {
+	defer close(s.doneCh)
+
+	var intervalCh, rssCh <-chan time.Time
+	if s.config.Interval > 0 {
+		ticker := time.NewTicker(s.config.Interval)
+		defer ticker.Stop()
+		intervalCh = ticker.C
+	}
+	if s.config.RSSThresholdMiB > 0 {
+		ticker := time.NewTicker(s.config.RSSCheckInterval)
+		defer ticker.Stop()
+		rssCh = ticker.C
+	}
+
+	// aboveThreshold is set once a snapshot is taken for the RSS crossing the threshold,
+	// and cleared when it goes back below, so that a single snapshot is taken each time.
+	aboveThreshold := false
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-intervalCh:
+			s.snapshot(triggerInterval)
+		case <-rssCh:
+			rss, err := s.rss()
+			if err != nil {
+				s.logger.Warn("Failed to read the resident memory size of the process", zap.Error(err))
+				continue
+			}
+			if rss < s.config.RSSThresholdMiB*mib {
+				aboveThreshold = false
+				continue
+			}
+			if !aboveThreshold {
+				aboveThreshold = true
+				s.logger.Info("Resident memory size crossed the threshold, taking a profiles snapshot",
+					zap.Uint64("rss_mib", rss/mib), zap.Uint64("rss_threshold_mib", s.config.RSSThresholdMiB))
+				s.snapshot(triggerRSS)
+			}
+		}
+	}
+}
+
+// snapshot saves the profiles in a new snapshot, then removes the snapshots beyond the retention.
+func (s *snapshotter) snapshot(trigger string) {
+	name := s.now().UTC().Format(snapshotTimeFormat) + "-" + trigger
+	if err := s.save(name); err != nil {
+		s.logger.Error("Failed to take a profiles snapshot", zap.String("snapshot", name), zap.Error(err))
+		return
+	}
+	s.logger.Info("Took a profiles snapshot", zap.String("path", filepath.Join(s.config.Directory, name)))
+
+	if err := s.prune(); err != nil {
+		s.logger.Warn("Failed to remove the old profiles snapshots", zap.Error(err))
+	}
+}
+
+// save writes the profiles to a hidden directory renamed once they are all written,
+// so that incomplete snapshots are never found in the snapshots directory.
+func (s *snapshotter) save(name string) error {
+	tmp := filepath.Join(s.config.Directory, "."+name)
+	if err := os.Mkdir(tmp, 0750); err != nil {
+		return err
+	}
+	if err := s.writeProfiles(tmp); err != nil {
+		_ = os.RemoveAll(tmp)
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(s.config.Directory, name))
+}
+
+func (s *snapshotter) writeProfiles(dir string) error {
+	// The CPU profile is collected last, so that the other profiles reflect
+	// the state of the process when the snapshot was triggered.
+	cpu := false
+	for _, profile := range s.config.snapshotProfiles() {
+		if profile == cpuProfile {
+			cpu = true
+			continue
+		}
+		p := pprof.Lookup(profile)
+		if err := writeProfile(dir, profile, func(w io.Writer) error { return p.WriteTo(w, 0) }); err != nil {
+			return err
+		}
+	}
+	if !cpu {
+		return nil
+	}
+
+	err := writeProfile(dir, cpuProfile, s.writeCPUProfile)
+	if err != nil {
+		// The CPU profile can only be collected once at a time, so it is missing
+		// from the snapshot when it is being collected through the pprof endpoint.
+		s.logger.Warn("Failed to collect the CPU profile of the snapshot", zap.Error(err))
+		_ = os.Remove(filepath.Join(dir, cpuProfile+".pprof"))
+	}
+	return nil
+}
+
+func (s *snapshotter) writeCPUProfile(w io.Writer) error {
+	if err := pprof.StartCPUProfile(w); err != nil {
+		return err
+	}
+	timer := time.NewTimer(s.config.CPUDuration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-s.stopCh:
+	}
+	pprof.StopCPUProfile()
+	return nil
+}
+
+func writeProfile(dir string, profile string, write func(io.Writer) error) error {
+	f, err := os.Create(filepath.Join(dir, profile+".pprof"))
+	if err != nil {
+		return err
+	}
+	if err = write(f); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write the %s profile: %w", profile, err)
+	}
+	return f.Close()
+}
+
+// prune removes the oldest snapshots, keeping only the configured number of them.
+func (s *snapshotter) prune() error {
+	entries, err := os.ReadDir(s.config.Directory)
+	if err != nil {
+		return err
+	}
+
+	// The entries are sorted by name, hence from the oldest to the newest snapshot.
+	var snapshots []string
+	for _, entry := range entries {
+		if entry.IsDir() && isSnapshot(entry.Name()) {
+			snapshots = append(snapshots, entry.Name())
+		}
+	}
+	for len(snapshots) > s.config.Retention {
+		if err = os.RemoveAll(filepath.Join(s.config.Directory, snapshots[0])); err != nil {
+			return err
+		}
+		snapshots = snapshots[1:]
+	}
+	return nil
+}
+
+func isSnapshot(name string) bool {
+	timestamp, _, found := strings.Cut(name, "-")
+	if !found {
+		return false
+	}
+	_, err := time.Parse(snapshotTimeFormat, timestamp)
+	return err == nil
+}
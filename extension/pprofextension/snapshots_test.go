@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pprofextension
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+func newTestSnapshotter(t *testing.T, configure func(*SnapshotsConfig)) *snapshotter {
+	config := createDefaultConfig().(*Config).Snapshots
+	config.Directory = t.TempDir()
+	config.CPUDuration = 10 * time.Millisecond
+	if configure != nil {
+		configure(&config)
+	}
+	require.NoError(t, config.Validate())
+
+	s := newSnapshotter(config, zap.NewNop())
+	now := time.Date(2022, 9, 1, 3, 0, 0, 0, time.UTC)
+	s.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	return s
+}
+
+func snapshotNames(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestSnapshot(t *testing.T) {
+	s := newTestSnapshotter(t, func(config *SnapshotsConfig) {
+		config.Interval = time.Minute
+	})
+	s.snapshot(triggerInterval)
+
+	require.Equal(t, []string{"20220901T030001.000Z-interval"}, snapshotNames(t, s.config.Directory))
+	for _, profile := range []string{cpuProfile, heapProfile, goroutineProfile, mutexProfile, blockProfile} {
+		info, err := os.Stat(filepath.Join(s.config.Directory, "20220901T030001.000Z-interval", profile+".pprof"))
+		require.NoError(t, err)
+		assert.NotZero(t, info.Size(), profile)
+	}
+}
+
+func TestSnapshotCPUProfileInUse(t *testing.T) {
+	require.NoError(t, pprof.StartCPUProfile(io.Discard))
+	defer pprof.StopCPUProfile()
+
+	s := newTestSnapshotter(t, func(config *SnapshotsConfig) {
+		config.Interval = time.Minute
+		config.Profiles = []string{cpuProfile, heapProfile}
+	})
+	s.snapshot(triggerInterval)
+
+	assert.Equal(t, []string{heapProfile + ".pprof"}, snapshotNames(t, filepath.Join(s.config.Directory, "20220901T030001.000Z-interval")))
+}
+
+func TestSnapshotsRetention(t *testing.T) {
+	s := newTestSnapshotter(t, func(config *SnapshotsConfig) {
+		config.Interval = time.Minute
+		config.Profiles = []string{goroutineProfile}
+		config.Retention = 2
+	})
+	// entries which aren't snapshots are kept
+	require.NoError(t, os.Mkdir(filepath.Join(s.config.Directory, "other"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(s.config.Directory, "20220901T000000.000Z-interval"), nil, 0600))
+
+	for i := 0; i < 3; i++ {
+		s.snapshot(triggerInterval)
+	}
+	s.snapshot(triggerRSS)
+
+	assert.Equal(t, []string{
+		"20220901T000000.000Z-interval",
+		"20220901T030003.000Z-interval",
+		"20220901T030004.000Z-rss",
+		"other",
+	}, snapshotNames(t, s.config.Directory))
+}
+
+func TestSnapshotsInterval(t *testing.T) {
+	s := newTestSnapshotter(t, func(config *SnapshotsConfig) {
+		config.Interval = 20 * time.Millisecond
+		config.Profiles = []string{heapProfile}
+	})
+	require.NoError(t, s.start())
+	defer s.shutdown()
+
+	assert.Eventually(t, func() bool {
+		return len(snapshotNames(t, s.config.Directory)) >= 2
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestSnapshotsRSSThreshold(t *testing.T) {
+	rss := atomic.NewUint64(100 * mib)
+	s := newTestSnapshotter(t, func(config *SnapshotsConfig) {
+		config.Profiles = []string{goroutineProfile}
+		config.RSSThresholdMiB = 1024
+		config.RSSCheckInterval = 5 * time.Millisecond
+	})
+	s.rss = func() (uint64, error) { return rss.Load(), nil }
+	require.NoError(t, s.start())
+	defer s.shutdown()
+
+	snapshots := func(n int) func() bool {
+		return func() bool { return len(snapshotNames(t, s.config.Directory)) == n }
+	}
+	assert.Never(t, snapshots(1), 50*time.Millisecond, 5*time.Millisecond)
+
+	// a single snapshot is taken while the RSS stays above the threshold
+	rss.Store(1024 * mib)
+	assert.Eventually(t, snapshots(1), 10*time.Second, 5*time.Millisecond)
+	assert.Never(t, snapshots(2), 50*time.Millisecond, 5*time.Millisecond)
+
+	rss.Store(1000 * mib)
+	time.Sleep(50 * time.Millisecond)
+	rss.Store(2048 * mib)
+	assert.Eventually(t, snapshots(2), 10*time.Second, 5*time.Millisecond)
+	for _, name := range snapshotNames(t, s.config.Directory) {
+		assert.Regexp(t, `^\d{8}T\d{6}\.\d{3}Z-rss$`, name)
+	}
+}
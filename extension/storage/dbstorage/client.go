@@ -18,92 +18,144 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"fmt"
+	"sync"
+	"time"
 
-	// Postgres driver
-	_ "github.com/jackc/pgx/v4/stdlib"
-	// SQLite driver
-	_ "github.com/mattn/go-sqlite3"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/internal/quota"
 )
 
 const (
-	createTable     = "create table if not exists %s (key text primary key, value blob)"
-	getQueryText    = "select value from %s where key=?"
-	setQueryText    = "insert into %s(key, value) values(?,?) on conflict(key) do update set value=?"
-	deleteQueryText = "delete from %s where key=?"
+	getQueryText = "select value from otelcol_storage where collector_id=$1 and client=$2 and key=$3"
+	setQueryText = `insert into otelcol_storage (collector_id, client, key, value, updated_at) values ($1, $2, $3, $4, $5)
+		on conflict (collector_id, client, key) do update set value=excluded.value, updated_at=excluded.updated_at`
+	deleteQueryText = "delete from otelcol_storage where collector_id=$1 and client=$2 and key=$3"
 )
 
 type dbStorageClient struct {
 	db          *sql.DB
+	dialect     *dialect
+	collectorID string
+	name        string
 	getQuery    *sql.Stmt
 	setQuery    *sql.Stmt
 	deleteQuery *sql.Stmt
+
+	// mutex serializes the writes of clients with limits, keeping usage consistent with the database
+	mutex       sync.Mutex
+	limits      *quota.Config
+	recorder    *quota.Recorder
+	usage       quota.Usage
+	logger      *zap.Logger
+	stopSweeper context.CancelFunc
+	closed      bool
 }
 
-func newClient(ctx context.Context, db *sql.DB, tableName string) (*dbStorageClient, error) {
-	var err error
-	_, err = db.ExecContext(ctx, fmt.Sprintf(createTable, tableName))
-	if err != nil {
+// newClient returns a client storing its entries under the given collector ID and name, after importing
+// the entries stored by previous versions in a table of the same name
+func newClient(ctx context.Context, db *sql.DB, d *dialect, collectorID string, name string) (*dbStorageClient, error) {
+	if err := importLegacyTable(ctx, db, d, collectorID, name); err != nil {
 		return nil, err
 	}
 
-	selectQuery, err := db.PrepareContext(ctx, fmt.Sprintf(getQueryText, tableName))
+	getQuery, err := db.PrepareContext(ctx, getQueryText)
 	if err != nil {
 		return nil, err
 	}
-	setQuery, err := db.PrepareContext(ctx, fmt.Sprintf(setQueryText, tableName))
+	setQuery, err := db.PrepareContext(ctx, setQueryText)
 	if err != nil {
 		return nil, err
 	}
-	deleteQuery, err := db.PrepareContext(ctx, fmt.Sprintf(deleteQueryText, tableName))
+	deleteQuery, err := db.PrepareContext(ctx, deleteQueryText)
 	if err != nil {
 		return nil, err
 	}
-	return &dbStorageClient{db, selectQuery, setQuery, deleteQuery}, nil
+	return &dbStorageClient{
+		db:          db,
+		dialect:     d,
+		collectorID: collectorID,
+		name:        name,
+		getQuery:    getQuery,
+		setQuery:    setQuery,
+		deleteQuery: deleteQuery,
+	}, nil
 }
 
 // Get will retrieve data from storage that corresponds to the specified key
 func (c *dbStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
-	rows, err := c.getQuery.QueryContext(ctx, key)
-	if err != nil {
-		return nil, err
-	}
-	if !rows.Next() {
-		return nil, nil
-	}
+	return c.get(ctx, c.getQuery, key)
+}
+
+func (c *dbStorageClient) get(ctx context.Context, getQuery *sql.Stmt, key string) ([]byte, error) {
 	var result []byte
-	err = rows.Scan(&result)
-	if err != nil {
-		return result, err
+	err := getQuery.QueryRowContext(ctx, c.collectorID, c.name, key).Scan(&result)
+	if err == sql.ErrNoRows {
+		return nil, nil
 	}
-	err = rows.Close()
 	return result, err
 }
 
 // Set will store data. The data can be retrieved using the same key
 func (c *dbStorageClient) Set(ctx context.Context, key string, value []byte) error {
-	_, err := c.setQuery.ExecContext(ctx, key, value, value)
-	return err
+	return c.Batch(ctx, storage.SetOperation(key, value))
 }
 
 // Delete will delete data associated with the specified key
 func (c *dbStorageClient) Delete(ctx context.Context, key string) error {
-	_, err := c.deleteQuery.ExecContext(ctx, key)
-	return err
+	return c.Batch(ctx, storage.DeleteOperation(key))
 }
 
-// Batch executes the specified operations in order. Get operation results are updated in place
+// Batch executes the specified operations in order, in a single transaction. Get operation results are updated in place
 func (c *dbStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
-	var err error
+	if c.limits != nil {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+	}
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	getQuery := tx.StmtContext(ctx, c.getQuery)
+	setQuery := tx.StmtContext(ctx, c.setQuery)
+	deleteQuery := tx.StmtContext(ctx, c.deleteQuery)
+
+	// delta is the change of the usage caused by the operations, only tracked for clients with limits
+	var delta, evicted quota.Usage
+	var written map[string]struct{}
+	now := time.Now().UnixNano()
 	for _, op := range ops {
 		switch op.Type {
 		case storage.Get:
-			op.Value, err = c.Get(ctx, op.Key)
+			op.Value, err = c.get(ctx, getQuery, op.Key)
 		case storage.Set:
-			err = c.Set(ctx, op.Key, op.Value)
+			if c.limits != nil {
+				var previous quota.Usage
+				if previous, err = c.entryUsage(ctx, tx, op.Key); err != nil {
+					return err
+				}
+				delta = delta.Sub(previous).Add(quota.Usage{Bytes: quota.EntrySize(op.Key, op.Value), Keys: 1})
+				if written == nil {
+					written = map[string]struct{}{}
+				}
+				written[op.Key] = struct{}{}
+			}
+			_, err = setQuery.ExecContext(ctx, c.collectorID, c.name, op.Key, op.Value, now)
 		case storage.Delete:
-			err = c.Delete(ctx, op.Key)
+			if c.limits != nil {
+				var previous quota.Usage
+				if previous, err = c.entryUsage(ctx, tx, op.Key); err != nil {
+					return err
+				}
+				delta = delta.Sub(previous)
+			}
+			_, err = deleteQuery.ExecContext(ctx, c.collectorID, c.name, op.Key)
 		default:
 			return errors.New("wrong operation type")
 		}
@@ -112,11 +164,33 @@ func (c *dbStorageClient) Batch(ctx context.Context, ops ...storage.Operation) e
 			return err
 		}
 	}
-	return err
+
+	if c.limits != nil {
+		if evicted, err = c.enforceLimits(ctx, tx, delta, written); err != nil {
+			return err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	if c.limits != nil {
+		c.usage = c.usage.Add(delta).Sub(evicted)
+		c.recorder.RecordUsage(c.usage)
+		c.recorder.RecordEvicted(quota.EvictionReasonLimits, evicted.Keys)
+	}
+	return nil
 }
 
 // Close will close the database
 func (c *dbStorageClient) Close(_ context.Context) error {
+	if c.stopSweeper != nil {
+		c.stopSweeper()
+	}
+	c.mutex.Lock()
+	c.closed = true
+	c.mutex.Unlock()
+
 	if err := c.setQuery.Close(); err != nil {
 		return err
 	}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 
 	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/internal/quota"
 )
 
 // Config defines configuration for dbstorage extension.
@@ -25,8 +27,14 @@ type Config struct {
 	config.ExtensionSettings `mapstructure:",squash"`
 	DriverName               string `mapstructure:"driver,omitempty"`
 	DataSource               string `mapstructure:"datasource,omitempty"`
+	// CollectorID namespaces the data of the collectors sharing the same database
+	CollectorID string        `mapstructure:"collector_id,omitempty"`
+	Limits      *LimitsConfig `mapstructure:"limits,omitempty"`
 }
 
+// LimitsConfig defines the limits applied to each storage client
+type LimitsConfig = quota.Config
+
 func (cfg *Config) Validate() error {
 	if cfg.DataSource == "" {
 		return fmt.Errorf(fmt.Sprintf("missing datasource for %s", cfg.ID()))
@@ -34,6 +42,25 @@ func (cfg *Config) Validate() error {
 	if cfg.DriverName == "" {
 		return fmt.Errorf(fmt.Sprintf("missing driver name for %s", cfg.ID()))
 	}
+	d, err := dialectFor(cfg.DriverName)
+	if err != nil {
+		return fmt.Errorf("%w for %s", err, cfg.ID())
+	}
+	// A default collector ID would either be shared by all the collectors, which overwrite each other's state, or
+	// change when the collector is rescheduled, e.g. with the hostname of a Kubernetes pod, which loses its state.
+	if d.shared && cfg.CollectorID == "" {
+		return fmt.Errorf("missing collector_id for %s, required by the %q driver", cfg.ID(), cfg.DriverName)
+	}
+	if d.checkDataSource != nil {
+		if err := d.checkDataSource(cfg.DataSource); err != nil {
+			return fmt.Errorf("%w for %s", err, cfg.ID())
+		}
+	}
+	if cfg.Limits != nil {
+		if err := cfg.Limits.Validate(); err != nil {
+			return fmt.Errorf("invalid limits for %s: %w", cfg.ID(), err)
+		}
+	}
 
 	return nil
 }
@@ -20,6 +20,7 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -41,8 +42,44 @@ func TestConfig_Validate(t *testing.T) {
 			errors.New("missing datasource for /blah"),
 		},
 		{
-			"valid",
+			"Unsupported driver",
 			Config{DriverName: "foo", DataSource: "bar"},
+			errors.New(`unsupported driver "foo", expected "sqlite" or "postgresql" for /blah`),
+		},
+		{
+			"valid",
+			Config{DriverName: "sqlite", DataSource: "bar"},
+			nil,
+		},
+		{
+			"valid sqlite pragmas",
+			Config{DriverName: "sqlite", DataSource: "file:bar?_pragma=busy_timeout(10000)&_txlock=immediate&mode=rwc"},
+			nil,
+		},
+		{
+			"Unsupported sqlite parameters",
+			Config{DriverName: "sqlite3", DataSource: "file:bar?_journal=WAL&_busy_timeout=10000&_pragma=synchronous(1)"},
+			errors.New("unsupported datasource parameters _busy_timeout, _journal, set pragmas with _pragma parameters instead, " +
+				"e.g. _pragma=busy_timeout(10000)&_pragma=journal_mode(WAL) for /blah"),
+		},
+		{
+			"Missing collector ID",
+			Config{DriverName: "postgresql", DataSource: "bar"},
+			errors.New(`missing collector_id for /blah, required by the "postgresql" driver`),
+		},
+		{
+			"valid with legacy driver name",
+			Config{DriverName: "pgx", DataSource: "bar", CollectorID: "collector-1"},
+			nil,
+		},
+		{
+			"Invalid limits",
+			Config{DriverName: "sqlite", DataSource: "bar", Limits: &LimitsConfig{MaxKeys: -1}},
+			errors.New("invalid limits for /blah: max_keys cannot be negative"),
+		},
+		{
+			"valid with limits",
+			Config{DriverName: "sqlite", DataSource: "bar", Limits: &LimitsConfig{MaxKeys: 10, Policy: "evict_oldest", TTL: time.Hour}},
 			nil,
 		},
 	}
@@ -53,7 +90,7 @@ func TestConfig_Validate(t *testing.T) {
 		if test.errWanted == nil {
 			assert.NoError(t, err)
 		} else {
-			assert.Equal(t, test.errWanted, err)
+			assert.EqualError(t, err, test.errWanted.Error())
 		}
 	}
 }
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	// PostgreSQL driver
+	_ "github.com/jackc/pgx/v4/stdlib"
+	// SQLite driver, written in pure Go
+	_ "modernc.org/sqlite"
+)
+
+const (
+	// DriverSQLite stores the data in a local SQLite database file
+	DriverSQLite = "sqlite"
+	// DriverPostgreSQL stores the data in a PostgreSQL database, which can be shared by several collectors
+	DriverPostgreSQL = "postgresql"
+)
+
+// driverAliases maps the names of the drivers previously linked into the collector to the bundled ones
+var driverAliases = map[string]string{
+	"sqlite3":  DriverSQLite,
+	"pgx":      DriverPostgreSQL,
+	"postgres": DriverPostgreSQL,
+}
+
+// dialect holds what differs between the supported databases. The queries use $N placeholders,
+// which both the SQLite and PostgreSQL drivers support.
+type dialect struct {
+	// driverName is the name of the bundled database/sql driver
+	driverName string
+	// maxOpenConns limits the connections to the database, zero meaning no limit
+	maxOpenConns int
+	// shared is whether the database can be shared by several collectors, which must then set their collector ID
+	shared bool
+	// migrations are the statements creating the schema, the Nth migration upgrading it to version N
+	migrations []string
+	// lockQuery serializes the migrations of the collectors sharing the database
+	lockQuery string
+	// tableExistsQuery returns the number of tables with the name given as first parameter
+	tableExistsQuery string
+	// sizeExpr is the size in bytes of the key and value of an entry
+	sizeExpr string
+	// foldsIdentifiers is whether unquoted identifiers are stored in lower case
+	foldsIdentifiers bool
+	// checkDataSource, if set, rejects the datasources the driver would not handle as expected
+	checkDataSource func(dataSource string) error
+}
+
+var dialects = map[string]*dialect{
+	DriverSQLite: {
+		driverName: "sqlite",
+		// SQLite only allows a single writer, so writes from several connections only add lock contention
+		maxOpenConns: 1,
+		migrations: []string{
+			`create table otelcol_storage (
+				collector_id text not null,
+				client text not null,
+				key text not null,
+				value blob,
+				updated_at bigint not null,
+				primary key (collector_id, client, key)
+			)`,
+			"create index otelcol_storage_updated_at on otelcol_storage (collector_id, client, updated_at)",
+		},
+		tableExistsQuery: "select count(*) from sqlite_master where type='table' and name=$1",
+		sizeExpr:         "length(cast(key as blob)) + coalesce(length(value), 0)",
+		checkDataSource:  checkSQLiteDataSource,
+	},
+	DriverPostgreSQL: {
+		driverName: "pgx",
+		shared:     true,
+		migrations: []string{
+			`create table otelcol_storage (
+				collector_id text not null,
+				client text not null,
+				key text not null,
+				value bytea,
+				updated_at bigint not null,
+				primary key (collector_id, client, key)
+			)`,
+			"create index otelcol_storage_updated_at on otelcol_storage (collector_id, client, updated_at)",
+		},
+		// the lock is released when the transaction running the migrations ends
+		lockQuery:        "select pg_advisory_xact_lock(7420183512)",
+		tableExistsQuery: "select count(*) from information_schema.tables where table_schema=current_schema() and table_name=$1",
+		sizeExpr:         "octet_length(key) + coalesce(octet_length(value), 0)",
+		foldsIdentifiers: true,
+	},
+}
+
+// dialectFor returns the dialect of the given driver, or one of its aliases
+func dialectFor(driver string) (*dialect, error) {
+	if alias, ok := driverAliases[driver]; ok {
+		driver = alias
+	}
+	d, ok := dialects[driver]
+	if !ok {
+		return nil, fmt.Errorf("unsupported driver %q, expected %q or %q", driver, DriverSQLite, DriverPostgreSQL)
+	}
+	return d, nil
+}
+
+// sqliteQueryParams are the underscore-prefixed query parameters of the datasource handled by the SQLite driver.
+// The others are silently ignored, such as the _busy_timeout, _journal or _sync parameters of the cgo driver
+// previously linked into the collector under the sqlite3 name.
+var sqliteQueryParams = map[string]bool{
+	"_pragma":      true,
+	"_time_format": true,
+	"_txlock":      true,
+}
+
+// checkSQLiteDataSource rejects the underscore-prefixed query parameters the SQLite driver would ignore
+func checkSQLiteDataSource(dataSource string) error {
+	i := strings.IndexByte(dataSource, '?')
+	if i < 0 {
+		return nil
+	}
+	query, err := url.ParseQuery(dataSource[i+1:])
+	if err != nil {
+		return fmt.Errorf("invalid datasource query: %w", err)
+	}
+	var unsupported []string
+	for name := range query {
+		if strings.HasPrefix(name, "_") && !sqliteQueryParams[name] {
+			unsupported = append(unsupported, name)
+		}
+	}
+	if len(unsupported) == 0 {
+		return nil
+	}
+	sort.Strings(unsupported)
+	return fmt.Errorf("unsupported datasource parameters %s, set pragmas with _pragma parameters instead, "+
+		"e.g. _pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)", strings.Join(unsupported, ", "))
+}
+
+// withSize returns the query with the size expression of the dialect in place of %s
+func (d *dialect) withSize(query string) string {
+	return fmt.Sprintf(query, d.sizeExpr)
+}
+
+// legacyTableName returns the name of the table the client used before the schema was versioned,
+// when each client had its own table, created with an unquoted name
+func (d *dialect) legacyTableName(name string) string {
+	if d.foldsIdentifiers {
+		return strings.ToLower(name)
+	}
+	return name
+}
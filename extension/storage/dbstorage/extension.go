@@ -24,13 +24,19 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/internal/quota"
 )
 
 type databaseStorage struct {
 	driverName     string
 	datasourceName string
+	collectorID    string
+	dialect        *dialect
 	logger         *zap.Logger
 	db             *sql.DB
+	id             config.ComponentID
+	limits         *quota.Config
 }
 
 // Ensure this storage extension implements the appropriate interface
@@ -40,21 +46,35 @@ func newDBStorage(logger *zap.Logger, config *Config) (component.Extension, erro
 	return &databaseStorage{
 		driverName:     config.DriverName,
 		datasourceName: config.DataSource,
+		collectorID:    config.CollectorID,
 		logger:         logger,
+		id:             config.ID(),
+		limits:         config.Limits,
 	}, nil
 }
 
 // Start opens a connection to the database
-func (ds *databaseStorage) Start(context.Context, component.Host) error {
-	db, err := sql.Open(ds.driverName, ds.datasourceName)
+func (ds *databaseStorage) Start(ctx context.Context, _ component.Host) error {
+	d, err := dialectFor(ds.driverName)
 	if err != nil {
 		return err
 	}
+	db, err := sql.Open(d.driverName, ds.datasourceName)
+	if err != nil {
+		return err
+	}
+	db.SetMaxOpenConns(d.maxOpenConns)
 
-	if err := db.Ping(); err != nil {
+	if err = db.Ping(); err != nil {
+		_ = db.Close()
+		return err
+	}
+	if err = migrate(ctx, db, d); err != nil {
+		_ = db.Close()
 		return err
 	}
 	ds.db = db
+	ds.dialect = d
 	return nil
 }
 
@@ -72,7 +92,16 @@ func (ds *databaseStorage) GetClient(ctx context.Context, kind component.Kind, e
 		fullName = fmt.Sprintf("%s_%s_%s_%s", kindString(kind), ent.Type(), ent.Name(), name)
 	}
 	fullName = strings.ReplaceAll(fullName, " ", "")
-	return newClient(ctx, ds.db, fullName)
+	client, err := newClient(ctx, ds.db, ds.dialect, ds.collectorID, fullName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = client.initLimits(ctx, ds.limits, quota.NewRecorder(ds.id.String(), fullName), ds.logger); err != nil {
+		_ = client.Close(ctx)
+		return nil, err
+	}
+	return client, nil
 }
 
 func kindString(k component.Kind) string {
@@ -115,8 +115,8 @@ func TestExtensionIntegrity(t *testing.T) {
 func newTestExtension(t *testing.T) storage.Extension {
 	f := NewFactory()
 	cfg := f.CreateDefaultConfig().(*Config)
-	cfg.DriverName = "sqlite3"
-	cfg.DataSource = fmt.Sprintf("file:%s/foo.db?_busy_timeout=10000&_journal=WAL&_sync=NORMAL", t.TempDir())
+	cfg.DriverName = DriverSQLite
+	cfg.DataSource = fmt.Sprintf("file:%s/foo.db?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)", t.TempDir())
 
 	extension, err := f.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
 	require.NoError(t, err)
@@ -17,8 +17,11 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"context"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/internal/quota"
 )
 
 // The value of extension "type" in configuration.
@@ -26,6 +29,8 @@ const typeStr config.Type = "db_storage"
 
 // NewFactory creates a factory for DBStorage extension.
 func NewFactory() component.ExtensionFactory {
+	_ = view.Register(quota.MetricViews()...)
+
 	return component.NewExtensionFactory(
 		typeStr,
 		createDefaultConfig,
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/internal/quota"
+)
+
+// the queries below take the size expression of the dialect in place of %s
+const (
+	usageQueryText        = "select count(*), coalesce(sum(%s), 0) from otelcol_storage where collector_id=$1 and client=$2"
+	entryUsageQueryText   = "select %s from otelcol_storage where collector_id=$1 and client=$2 and key=$3"
+	oldestQueryText       = "select key, %s from otelcol_storage where collector_id=$1 and client=$2 order by updated_at, key"
+	expiredUsageQueryText = "select count(*), coalesce(sum(%s), 0) from otelcol_storage where collector_id=$1 and client=$2 and updated_at<$3"
+	deleteExpiredText     = "delete from otelcol_storage where collector_id=$1 and client=$2 and updated_at<$3"
+)
+
+// initLimits computes the current usage of the client, and starts deleting expired keys when they expire
+func (c *dbStorageClient) initLimits(ctx context.Context, limits *quota.Config, recorder *quota.Recorder, logger *zap.Logger) error {
+	if limits == nil {
+		return nil
+	}
+
+	var usage quota.Usage
+	err := c.db.QueryRowContext(ctx, c.dialect.withSize(usageQueryText), c.collectorID, c.name).Scan(&usage.Keys, &usage.Bytes)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.limits = limits
+	c.recorder = recorder
+	c.logger = logger
+	c.usage = usage
+	c.recorder.RecordUsage(usage)
+
+	if limits.TTL > 0 {
+		c.startSweeper(context.Background())
+	}
+	return nil
+}
+
+// entryUsage returns the usage of the key currently stored, if any
+func (c *dbStorageClient) entryUsage(ctx context.Context, tx *sql.Tx, key string) (quota.Usage, error) {
+	var size int64
+	err := tx.QueryRowContext(ctx, c.dialect.withSize(entryUsageQueryText), c.collectorID, c.name, key).Scan(&size)
+	switch {
+	case err == sql.ErrNoRows:
+		return quota.Usage{}, nil
+	case err != nil:
+		return quota.Usage{}, err
+	default:
+		return quota.Usage{Bytes: size, Keys: 1}, nil
+	}
+}
+
+// enforceLimits rejects the writes of the transaction, or evicts the oldest keys not written by it, when they
+// exceed the limits. It returns the usage freed by the evicted keys.
+func (c *dbStorageClient) enforceLimits(ctx context.Context, tx *sql.Tx, delta quota.Usage, written map[string]struct{}) (quota.Usage, error) {
+	// writes are only limited when they increase the usage, so that the usage can always be reduced
+	usage := c.usage.Add(delta)
+	if (delta.Bytes <= 0 && delta.Keys <= 0) || !c.limits.Exceeds(usage) {
+		return quota.Usage{}, nil
+	}
+	if !c.limits.Evicts() {
+		c.recorder.RecordRejected()
+		return quota.Usage{}, quota.ErrExceeded
+	}
+
+	evicted, err := c.evictOldest(ctx, tx, usage, written)
+	if err == quota.ErrExceeded {
+		c.recorder.RecordRejected()
+	}
+	return evicted, err
+}
+
+// evictOldest deletes the least recently written keys, other than the ones in keep, until the usage
+// is within the limits. It returns the usage freed by the deleted keys.
+func (c *dbStorageClient) evictOldest(ctx context.Context, tx *sql.Tx, usage quota.Usage, keep map[string]struct{}) (quota.Usage, error) {
+	rows, err := tx.QueryContext(ctx, c.dialect.withSize(oldestQueryText), c.collectorID, c.name)
+	if err != nil {
+		return quota.Usage{}, err
+	}
+
+	var freed quota.Usage
+	var evicted []string
+	for c.limits.Exceeds(usage.Sub(freed)) && rows.Next() {
+		var key string
+		var size int64
+		if err = rows.Scan(&key, &size); err != nil {
+			_ = rows.Close()
+			return quota.Usage{}, err
+		}
+		if _, ok := keep[key]; ok {
+			continue
+		}
+		freed = freed.Add(quota.Usage{Bytes: size, Keys: 1})
+		evicted = append(evicted, key)
+	}
+	if err = rows.Close(); err != nil {
+		return quota.Usage{}, err
+	}
+
+	if c.limits.Exceeds(usage.Sub(freed)) {
+		return quota.Usage{}, quota.ErrExceeded
+	}
+	deleteQuery := tx.StmtContext(ctx, c.deleteQuery)
+	for _, key := range evicted {
+		if _, err = deleteQuery.ExecContext(ctx, c.collectorID, c.name, key); err != nil {
+			return quota.Usage{}, err
+		}
+	}
+	return freed, nil
+}
+
+// deleteExpired deletes the keys that weren't written for longer than the TTL
+func (c *dbStorageClient) deleteExpired(ctx context.Context, now time.Time) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return nil
+	}
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	cutoff := now.Add(-c.limits.TTL).UnixNano()
+	var freed quota.Usage
+	err = tx.QueryRowContext(ctx, c.dialect.withSize(expiredUsageQueryText), c.collectorID, c.name, cutoff).Scan(&freed.Keys, &freed.Bytes)
+	if err != nil {
+		return err
+	}
+	if freed.Keys == 0 {
+		return nil
+	}
+	if _, err = tx.ExecContext(ctx, deleteExpiredText, c.collectorID, c.name, cutoff); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	c.usage = c.usage.Sub(freed)
+	c.recorder.RecordUsage(c.usage)
+	c.recorder.RecordEvicted(quota.EvictionReasonTTL, freed.Keys)
+	return nil
+}
+
+func (c *dbStorageClient) startSweeper(ctx context.Context) {
+	ctx, c.stopSweeper = context.WithCancel(ctx)
+
+	go func() {
+		ticker := time.NewTicker(c.limits.Interval())
+		defer ticker.Stop()
+
+		for {
+			select {
+			case now := <-ticker.C:
+				if err := c.deleteExpired(ctx, now); err != nil {
+					c.logger.Error("failed to delete expired keys", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Skip tests on Windows temporarily, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/11451
+//go:build !windows
+// +build !windows
+
+package dbstorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/internal/quota"
+)
+
+func newLimitedClient(t *testing.T, db *sql.DB, limits *quota.Config) *dbStorageClient {
+	ctx := context.Background()
+	client, err := newClient(ctx, db, dialects[DriverSQLite], "", "receiver_nop_test")
+	require.NoError(t, err)
+	require.NoError(t, client.initLimits(ctx, limits, quota.NewRecorder("db_storage", t.Name()), zap.NewNop()))
+	return client
+}
+
+func newTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s/foo.db", t.TempDir()))
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	require.NoError(t, migrate(context.Background(), db, dialects[DriverSQLite]))
+	return db
+}
+
+func TestClientLimitsReject(t *testing.T) {
+	ctx := context.Background()
+	client := newLimitedClient(t, newTestDB(t), &quota.Config{MaxKeys: 2, MaxBytes: 20})
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+
+	require.NoError(t, client.Set(ctx, "a", []byte("1234")))
+	require.NoError(t, client.Set(ctx, "b", []byte("1234")))
+	assert.Equal(t, quota.Usage{Bytes: 10, Keys: 2}, client.usage)
+
+	// too many keys
+	assert.ErrorIs(t, client.Set(ctx, "c", []byte("1234")), quota.ErrExceeded)
+	value, err := client.Get(ctx, "c")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	// too many bytes
+	assert.ErrorIs(t, client.Set(ctx, "a", make([]byte, 20)), quota.ErrExceeded)
+	value, err = client.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1234"), value)
+
+	// overwriting keys within the limits, and making room, is allowed
+	require.NoError(t, client.Set(ctx, "a", []byte("123456789")))
+	require.NoError(t, client.Delete(ctx, "b"))
+	require.NoError(t, client.Delete(ctx, "missing"))
+	require.NoError(t, client.Set(ctx, "c", []byte("1234")))
+	assert.Equal(t, quota.Usage{Bytes: 15, Keys: 2}, client.usage)
+}
+
+func TestClientLimitsEvictOldest(t *testing.T) {
+	ctx := context.Background()
+	client := newLimitedClient(t, newTestDB(t), &quota.Config{MaxKeys: 3, MaxBytes: 60, Policy: quota.PolicyEvictOldest})
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("key-%d", i), []byte("value")))
+	}
+	// writing a key again makes it the most recent one
+	require.NoError(t, client.Set(ctx, "key-0", []byte("value")))
+
+	require.NoError(t, client.Set(ctx, "key-3", []byte("value")))
+	require.NoError(t, client.Set(ctx, "key-4", []byte("value")))
+
+	for key, expected := range map[string][]byte{
+		"key-0": []byte("value"),
+		"key-1": nil,
+		"key-2": nil,
+		"key-3": []byte("value"),
+		"key-4": []byte("value"),
+	} {
+		value, err := client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, value, key)
+	}
+	assert.Equal(t, quota.Usage{Bytes: 30, Keys: 3}, client.usage)
+
+	// values that don't fit even after evicting all the other keys are rejected
+	assert.ErrorIs(t, client.Set(ctx, "key-5", make([]byte, 60)), quota.ErrExceeded)
+	value, err := client.Get(ctx, "key-4")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	assert.Equal(t, quota.Usage{Bytes: 30, Keys: 3}, client.usage)
+}
+
+func TestClientLimitsTTL(t *testing.T) {
+	ctx := context.Background()
+	client := newLimitedClient(t, newTestDB(t), &quota.Config{TTL: time.Hour})
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+
+	require.NoError(t, client.Set(ctx, "old", []byte("value")))
+	require.NoError(t, client.Set(ctx, "deleted", []byte("value")))
+	require.NoError(t, client.Delete(ctx, "deleted"))
+
+	// nothing expired yet
+	require.NoError(t, client.deleteExpired(ctx, time.Now()))
+	assert.Equal(t, quota.Usage{Bytes: 8, Keys: 1}, client.usage)
+
+	require.NoError(t, client.deleteExpired(ctx, time.Now().Add(2*time.Hour)))
+	value, err := client.Get(ctx, "old")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	assert.Equal(t, quota.Usage{}, client.usage)
+}
+
+func TestClientLimitsSweeper(t *testing.T) {
+	ctx := context.Background()
+	client := newLimitedClient(t, newTestDB(t), &quota.Config{
+		TTL:           10 * time.Millisecond,
+		SweepInterval: 10 * time.Millisecond,
+	})
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	require.Eventually(t, func() bool {
+		value, err := client.Get(ctx, "key")
+		require.NoError(t, err)
+		return value == nil
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestClientLimitsExistingKeys(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	client := newLimitedClient(t, db, nil)
+	require.NoError(t, client.Set(ctx, "key-0", []byte("value")))
+	require.NoError(t, client.Set(ctx, "key-1", []byte("value")))
+	require.NoError(t, client.Close(ctx))
+
+	// the keys stored without limits count towards them, and expire
+	client = newLimitedClient(t, db, &quota.Config{MaxKeys: 2, TTL: time.Hour})
+	assert.Equal(t, quota.Usage{Bytes: 20, Keys: 2}, client.usage)
+	assert.ErrorIs(t, client.Set(ctx, "key-2", []byte("value")), quota.ErrExceeded)
+	require.NoError(t, client.deleteExpired(ctx, time.Now()))
+	assert.Equal(t, quota.Usage{Bytes: 20, Keys: 2}, client.usage)
+	require.NoError(t, client.deleteExpired(ctx, time.Now().Add(2*time.Hour)))
+	assert.Equal(t, quota.Usage{}, client.usage)
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestClientLimitsBatch(t *testing.T) {
+	ctx := context.Background()
+	client := newLimitedClient(t, newTestDB(t), &quota.Config{MaxKeys: 2, Policy: quota.PolicyEvictOldest})
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+
+	require.NoError(t, client.Set(ctx, "old", []byte("value")))
+
+	// the keys written by the batch are not evicted to make room for each other
+	err := client.Batch(ctx,
+		storage.SetOperation("key-0", []byte("value")),
+		storage.SetOperation("key-1", []byte("value")),
+		storage.SetOperation("key-2", []byte("value")),
+	)
+	assert.ErrorIs(t, err, quota.ErrExceeded)
+	value, err := client.Get(ctx, "old")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	require.NoError(t, client.Batch(ctx,
+		storage.SetOperation("key-0", []byte("value")),
+		storage.DeleteOperation("missing"),
+		storage.SetOperation("key-1", []byte("value")),
+	))
+	value, err = client.Get(ctx, "old")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	assert.Equal(t, quota.Usage{Bytes: 20, Keys: 2}, client.usage)
+}
+
+func TestExtensionLimits(t *testing.T) {
+	ctx := context.Background()
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.DriverName = DriverSQLite
+	cfg.DataSource = fmt.Sprintf("file:%s/foo.db", t.TempDir())
+	cfg.Limits = &LimitsConfig{MaxKeys: 1}
+
+	ext, err := f.CreateExtension(ctx, componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(ctx, componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, ext.Shutdown(ctx))
+	})
+
+	client, err := ext.(*databaseStorage).GetClient(ctx, component.KindReceiver, newTestEntity("limited"), "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+
+	require.NoError(t, client.Set(ctx, "key-0", []byte("value")))
+	assert.ErrorIs(t, client.Set(ctx, "key-1", []byte("value")), quota.ErrExceeded)
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	createSchemaTableText = "create table if not exists otelcol_storage_schema (version integer not null)"
+	getSchemaVersionText  = "select version from otelcol_storage_schema"
+	addSchemaVersionText  = "insert into otelcol_storage_schema (version) values ($1)"
+	setSchemaVersionText  = "update otelcol_storage_schema set version=$1"
+
+	// the legacy tables had no time, so their keys are considered written when they're imported
+	importLegacyTableText = `insert into otelcol_storage (collector_id, client, key, value, updated_at)
+		select $1, $2, key, value, $3 from %s where true
+		on conflict (collector_id, client, key) do nothing`
+	dropLegacyTableText = "drop table %s"
+)
+
+// migrate upgrades the schema of the database to the latest version of the dialect
+func migrate(ctx context.Context, db *sql.DB, d *dialect) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if d.lockQuery != "" {
+		if _, err = tx.ExecContext(ctx, d.lockQuery); err != nil {
+			return err
+		}
+	}
+	if _, err = tx.ExecContext(ctx, createSchemaTableText); err != nil {
+		return err
+	}
+
+	var version int
+	switch err = tx.QueryRowContext(ctx, getSchemaVersionText).Scan(&version); {
+	case err == sql.ErrNoRows:
+		if _, err = tx.ExecContext(ctx, addSchemaVersionText, 0); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	}
+
+	if version > len(d.migrations) {
+		return fmt.Errorf("database schema version %d is newer than the latest supported version %d", version, len(d.migrations))
+	}
+	if version == len(d.migrations) {
+		return nil
+	}
+	for _, migration := range d.migrations[version:] {
+		if _, err = tx.ExecContext(ctx, migration); err != nil {
+			return fmt.Errorf("failed to upgrade the database schema from version %d: %w", version, err)
+		}
+	}
+	if _, err = tx.ExecContext(ctx, setSchemaVersionText, len(d.migrations)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// importLegacyTable moves the entries of the table the client used before the schema was versioned,
+// if any, to the entries of the client
+func importLegacyTable(ctx context.Context, db *sql.DB, d *dialect, collectorID string, client string) error {
+	table := d.legacyTableName(client)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var count int
+	if err = tx.QueryRowContext(ctx, d.tableExistsQuery, table).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		return nil
+	}
+
+	quoted := `"` + strings.ReplaceAll(table, `"`, `""`) + `"`
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf(importLegacyTableText, quoted), collectorID, client, time.Now().UnixNano()); err != nil {
+		return fmt.Errorf("failed to import the entries of table %s: %w", table, err)
+	}
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf(dropLegacyTableText, quoted)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Skip tests on Windows temporarily, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/11451
+//go:build !windows
+// +build !windows
+
+package dbstorage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	d := dialects[DriverSQLite]
+	db := newTestDB(t)
+
+	var version int
+	require.NoError(t, db.QueryRow(getSchemaVersionText).Scan(&version))
+	assert.Equal(t, len(d.migrations), version)
+
+	// migrating again is a no-op
+	require.NoError(t, migrate(ctx, db, d))
+	var versions int
+	require.NoError(t, db.QueryRow("select count(*) from otelcol_storage_schema").Scan(&versions))
+	assert.Equal(t, 1, versions)
+
+	// schemas created by later versions are not downgraded
+	_, err := db.Exec(setSchemaVersionText, len(d.migrations)+1)
+	require.NoError(t, err)
+	assert.Error(t, migrate(ctx, db, d))
+}
+
+func TestImportLegacyTable(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	_, err := db.Exec("create table receiver_nop_legacy (key text primary key, value blob)")
+	require.NoError(t, err)
+	_, err = db.Exec("insert into receiver_nop_legacy(key, value) values('checkpoint', 'offset')")
+	require.NoError(t, err)
+
+	client, err := newClient(ctx, db, dialects[DriverSQLite], "", "receiver_nop_legacy")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+
+	value, err := client.Get(ctx, "checkpoint")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("offset"), value)
+
+	var tables int
+	require.NoError(t, db.QueryRow(dialects[DriverSQLite].tableExistsQuery, "receiver_nop_legacy").Scan(&tables))
+	assert.Zero(t, tables)
+}
+
+func TestCollectorNamespaces(t *testing.T) {
+	ctx := context.Background()
+	dataSource := fmt.Sprintf("file:%s/foo.db?_pragma=busy_timeout(10000)", t.TempDir())
+
+	clients := map[string]storage.Client{}
+	for _, collectorID := range []string{"collector-1", "collector-2"} {
+		f := NewFactory()
+		cfg := f.CreateDefaultConfig().(*Config)
+		cfg.DriverName = DriverSQLite
+		cfg.DataSource = dataSource
+		cfg.CollectorID = collectorID
+
+		ext, err := f.CreateExtension(ctx, componenttest.NewNopExtensionCreateSettings(), cfg)
+		require.NoError(t, err)
+		require.NoError(t, ext.Start(ctx, componenttest.NewNopHost()))
+		t.Cleanup(func() {
+			require.NoError(t, ext.Shutdown(ctx))
+		})
+
+		client, err := ext.(storage.Extension).GetClient(ctx, component.KindReceiver, newTestEntity("shared"), "")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, client.Close(ctx))
+		})
+		require.NoError(t, client.Set(ctx, "key", []byte(collectorID)))
+		clients[collectorID] = client
+	}
+
+	for collectorID, client := range clients {
+		value, err := client.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte(collectorID), value)
+	}
+}
+
+func TestBatchIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx, newTestDB(t), dialects[DriverSQLite], "", "receiver_nop_batch")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+
+	get := storage.GetOperation("key")
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("key", []byte("updated")), get, storage.DeleteOperation("other")))
+	assert.Equal(t, []byte("updated"), get.Value)
+
+	// none of the operations are applied when one of them fails
+	invalid := storage.GetOperation("key")
+	invalid.Type = -1
+	assert.Error(t, client.Batch(ctx, storage.DeleteOperation("key"), invalid))
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("updated"), value)
+}
+
+func TestUnsupportedDriver(t *testing.T) {
+	ext, err := newDBStorage(nil, &Config{DriverName: "mysql", DataSource: "foo"})
+	require.NoError(t, err)
+	assert.Error(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+}
@@ -26,10 +26,19 @@ import (
 	"go.etcd.io/bbolt"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/internal/quota"
 )
 
 var defaultBucket = []byte(`default`)
 
+// metadataBucket holds information about the storage itself, like whether its values are encrypted
+var metadataBucket = []byte(`metadata`)
+
+var encryptedKey = []byte(`encrypted`)
+
+var errEncryptedStorage = errors.New("the storage is encrypted, but no encryption keys are configured")
+
 const (
 	elapsedKey       = "elapsed"
 	directoryKey     = "directory"
@@ -46,6 +55,14 @@ type fileStorageClient struct {
 	openTimeout     time.Duration
 	cancel          context.CancelFunc
 	closed          bool
+	encryptor       *valueEncryptor
+
+	// usageMutex serializes the writes of clients with limits, keeping usage consistent with the database
+	usageMutex  sync.Mutex
+	limits      *quota.Config
+	recorder    *quota.Recorder
+	usage       quota.Usage
+	stopSweeper context.CancelFunc
 }
 
 func bboltOptions(timeout time.Duration) *bbolt.Options {
@@ -57,7 +74,7 @@ func bboltOptions(timeout time.Duration) *bbolt.Options {
 	}
 }
 
-func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig) (*fileStorageClient, error) {
+func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, encryptor *valueEncryptor) (*fileStorageClient, error) {
 	options := bboltOptions(timeout)
 	db, err := bbolt.Open(filePath, 0600, options)
 	if err != nil {
@@ -73,7 +90,12 @@ func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compa
 		return nil, err
 	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
+	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout, encryptor: encryptor}
+	if err := db.Update(client.initEncryption); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
 	if compactionCfg.OnRebound {
 		client.startCompactionLoop(context.Background())
 	}
@@ -104,12 +126,17 @@ func (c *fileStorageClient) Delete(ctx context.Context, key string) error {
 
 // Batch executes the specified operations in order. Get operation results are updated in place
 func (c *fileStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	// delta is the change of the usage caused by the operations, only tracked for clients with limits
+	var delta, evicted quota.Usage
 	batch := func(tx *bbolt.Tx) error {
+		delta, evicted = quota.Usage{}, quota.Usage{}
 		bucket := tx.Bucket(defaultBucket)
 		if bucket == nil {
 			return errors.New("storage not initialized")
 		}
 
+		var written map[string]struct{}
+		now := time.Now()
 		var err error
 		for _, op := range ops {
 			switch op.Type {
@@ -120,12 +147,42 @@ func (c *fileStorageClient) Batch(ctx context.Context, ops ...storage.Operation)
 					// to be able to return the value
 					op.Value = make([]byte, len(value))
 					copy(op.Value, value)
+					if c.encryptor != nil {
+						op.Value, err = c.encryptor.decrypt(op.Key, op.Value)
+					}
 				} else {
 					op.Value = nil
 				}
 			case storage.Set:
-				err = bucket.Put([]byte(op.Key), op.Value)
+				value := op.Value
+				if c.encryptor != nil {
+					if value, err = c.encryptor.encrypt(op.Key, value); err != nil {
+						return err
+					}
+				}
+				if c.limits != nil {
+					delta = delta.Add(quota.Usage{Bytes: quota.EntrySize(op.Key, value)}).Sub(entryUsage(bucket, op.Key))
+					delta.Keys++
+					if c.limits.TracksWrites() {
+						if err = trackWrite(tx, []byte(op.Key), now); err != nil {
+							return err
+						}
+						if written == nil {
+							written = map[string]struct{}{}
+						}
+						written[op.Key] = struct{}{}
+					}
+				}
+				err = bucket.Put([]byte(op.Key), value)
 			case storage.Delete:
+				if c.limits != nil {
+					delta = delta.Sub(entryUsage(bucket, op.Key))
+					if c.limits.TracksWrites() {
+						if err = untrackWrite(tx, []byte(op.Key)); err != nil {
+							return err
+						}
+					}
+				}
 				err = bucket.Delete([]byte(op.Key))
 			default:
 				return errors.New("wrong operation type")
@@ -136,12 +193,46 @@ func (c *fileStorageClient) Batch(ctx context.Context, ops ...storage.Operation)
 			}
 		}
 
-		return nil
+		// writes are only limited when they increase the usage, so that the usage can always be reduced
+		usage := c.usage.Add(delta)
+		if c.limits == nil || (delta.Bytes <= 0 && delta.Keys <= 0) || !c.limits.Exceeds(usage) {
+			return nil
+		}
+		if !c.limits.Evicts() {
+			return quota.ErrExceeded
+		}
+		evicted, err = c.evictOldest(tx, usage, written)
+		return err
 	}
 
 	c.compactionMutex.RLock()
 	defer c.compactionMutex.RUnlock()
-	return c.db.Update(batch)
+	if c.limits == nil {
+		return c.db.Update(batch)
+	}
+
+	c.usageMutex.Lock()
+	defer c.usageMutex.Unlock()
+	if err := c.db.Update(batch); err != nil {
+		if errors.Is(err, quota.ErrExceeded) {
+			c.recorder.RecordRejected()
+		}
+		return err
+	}
+
+	c.usage = c.usage.Add(delta).Sub(evicted)
+	c.recorder.RecordUsage(c.usage)
+	c.recorder.RecordEvicted(quota.EvictionReasonLimits, evicted.Keys)
+	return nil
+}
+
+// entryUsage returns the usage of the key currently stored in the bucket, if any
+func entryUsage(bucket *bbolt.Bucket, key string) quota.Usage {
+	value := bucket.Get([]byte(key))
+	if value == nil {
+		return quota.Usage{}
+	}
+	return quota.Usage{Bytes: quota.EntrySize(key, value), Keys: 1}
 }
 
 // Close will close the database
@@ -152,6 +243,9 @@ func (c *fileStorageClient) Close(_ context.Context) error {
 	if c.cancel != nil {
 		c.cancel()
 	}
+	if c.stopSweeper != nil {
+		c.stopSweeper()
+	}
 	c.closed = true
 	return c.db.Close()
 }
@@ -204,6 +298,14 @@ func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Dur
 
 	compactionStart := time.Now()
 
+	if c.encryptor != nil {
+		// compaction copies the values as they are, so the ones encrypted with rotated keys are re-encrypted first
+		if err = c.rotateEncryptionKeys(maxTransactionSize); err != nil {
+			compactedDb.Close()
+			return fmt.Errorf("failed to re-encrypt values: %w", err)
+		}
+	}
+
 	if err = bbolt.Compact(compactedDb, c.db, maxTransactionSize); err != nil {
 		return err
 	}
@@ -248,6 +350,97 @@ func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Dur
 	return nil
 }
 
+// initEncryption makes sure all the values are encrypted when encryption is enabled, encrypting the ones stored
+// before it was enabled, and that encrypted storages aren't opened without keys
+func (c *fileStorageClient) initEncryption(tx *bbolt.Tx) error {
+	metadata, err := tx.CreateBucketIfNotExists(metadataBucket)
+	if err != nil {
+		return err
+	}
+
+	encrypted := metadata.Get(encryptedKey) != nil
+	if c.encryptor == nil {
+		if encrypted {
+			return errEncryptedStorage
+		}
+		return nil
+	}
+	if encrypted {
+		return nil
+	}
+
+	bucket := tx.Bucket(defaultBucket)
+	var keys [][]byte
+	if err = bucket.ForEach(func(k, _ []byte) error {
+		keys = append(keys, append([]byte(nil), k...))
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(keys) > 0 {
+		c.logger.Info("encrypting existing values", zap.String(directoryKey, c.db.Path()), zap.Int("count", len(keys)))
+	}
+	for _, k := range keys {
+		value, err := c.encryptor.encrypt(string(k), bucket.Get(k))
+		if err != nil {
+			return err
+		}
+		if err = bucket.Put(k, value); err != nil {
+			return err
+		}
+	}
+
+	return metadata.Put(encryptedKey, []byte{1})
+}
+
+// rotateEncryptionKeys re-encrypts the values that weren't encrypted with the primary key,
+// in transactions of up to maxTransactionSize values
+func (c *fileStorageClient) rotateEncryptionKeys(maxTransactionSize int64) error {
+	var keys [][]byte
+	err := c.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(defaultBucket).ForEach(func(k, v []byte) error {
+			if c.encryptor.needsRotation(v) {
+				keys = append(keys, append([]byte(nil), k...))
+			}
+			return nil
+		})
+	})
+	if err != nil || len(keys) == 0 {
+		return err
+	}
+
+	c.logger.Debug("re-encrypting values", zap.String(directoryKey, c.db.Path()), zap.Int("count", len(keys)))
+	for len(keys) > 0 {
+		batchSize := len(keys)
+		if maxTransactionSize > 0 && int64(batchSize) > maxTransactionSize {
+			batchSize = int(maxTransactionSize)
+		}
+
+		err = c.db.Update(func(tx *bbolt.Tx) error {
+			bucket := tx.Bucket(defaultBucket)
+			for _, k := range keys[:batchSize] {
+				value, err := c.encryptor.decrypt(string(k), bucket.Get(k))
+				if err != nil {
+					return err
+				}
+				if value, err = c.encryptor.encrypt(string(k), value); err != nil {
+					return err
+				}
+				if err = bucket.Put(k, value); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		keys = keys[batchSize:]
+	}
+	return nil
+}
+
 // startCompactionLoop provides asynchronous compaction function
 func (c *fileStorageClient) startCompactionLoop(ctx context.Context) {
 	ctx, c.cancel = context.WithCancel(ctx)
@@ -32,7 +32,7 @@ import (
 func TestClientOperations(t *testing.T) {
 	dbFile := filepath.Join(t.TempDir(), "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{})
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		require.NoError(t, client.Close(context.TODO()))
@@ -70,7 +70,7 @@ func TestClientBatchOperations(t *testing.T) {
 	tempDir := t.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{})
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		require.NoError(t, client.Close(context.TODO()))
@@ -191,7 +191,7 @@ func TestNewClientTransactionErrors(t *testing.T) {
 			tempDir := t.TempDir()
 			dbFile := filepath.Join(tempDir, "my_db")
 
-			client, err := newClient(zap.NewNop(), dbFile, timeout, &CompactionConfig{})
+			client, err := newClient(zap.NewNop(), dbFile, timeout, &CompactionConfig{}, nil)
 			require.NoError(t, err)
 			t.Cleanup(func() {
 				require.NoError(t, client.Close(context.TODO()))
@@ -215,7 +215,7 @@ func TestNewClientErrorsOnInvalidBucket(t *testing.T) {
 	tempDir := t.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{})
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
 	require.Error(t, err)
 	require.Nil(t, client)
 
@@ -234,7 +234,7 @@ func TestClientReboundCompaction(t *testing.T) {
 		CheckInterval:              checkInterval,
 		ReboundNeededThresholdMiB:  1,
 		ReboundTriggerThresholdMiB: 4,
-	})
+	}, nil)
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		require.NoError(t, client.Close(context.TODO()))
@@ -299,7 +299,7 @@ func TestClientConcurrentCompaction(t *testing.T) {
 		CheckInterval:              stepInterval * 2,
 		ReboundNeededThresholdMiB:  1,
 		ReboundTriggerThresholdMiB: 5,
-	})
+	}, nil)
 	require.NoError(t, err)
 
 	t.Cleanup(func() {
@@ -355,11 +355,126 @@ func TestClientConcurrentCompaction(t *testing.T) {
 	}
 }
 
+// rawValue reads the value as stored in the database file
+func rawValue(t *testing.T, client *fileStorageClient, key string) []byte {
+	var value []byte
+	require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+		value = append([]byte(nil), tx.Bucket(defaultBucket).Get([]byte(key))...)
+		return nil
+	}))
+	return value
+}
+
+func TestClientEncryption(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	encryptor := newTestEncryptor(t, newTestKeyFile(t, 32))
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, encryptor)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	testValue := []byte("4111 1111 1111 1111")
+	require.NoError(t, client.Batch(ctx,
+		storage.SetOperation("testKey", testValue),
+		storage.SetOperation("emptyKey", []byte{}),
+	))
+
+	raw := rawValue(t, client, "testKey")
+	require.NotEmpty(t, raw)
+	require.NotContains(t, string(raw), string(testValue))
+
+	getOp := storage.GetOperation("testKey")
+	emptyOp := storage.GetOperation("emptyKey")
+	missingOp := storage.GetOperation("missingKey")
+	require.NoError(t, client.Batch(ctx, getOp, emptyOp, missingOp))
+	require.Equal(t, testValue, getOp.Value)
+	require.Equal(t, []byte{}, emptyOp.Value)
+	require.Nil(t, missingOp.Value)
+	require.NoError(t, client.Close(ctx))
+
+	// the values can't be read without the key
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
+	require.ErrorIs(t, err, errEncryptedStorage)
+	require.Nil(t, client)
+
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newTestEncryptor(t, newTestKeyFile(t, 32)))
+	require.NoError(t, err)
+	_, err = client.Get(ctx, "testKey")
+	require.ErrorIs(t, err, errUnknownEncryptionKey)
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestClientEncryptsExistingValues(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	ctx := context.Background()
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "testKey", []byte("testValue")))
+	require.NoError(t, client.Close(ctx))
+
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newTestEncryptor(t, newTestKeyFile(t, 32)))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.TODO()))
+	})
+
+	require.NotContains(t, string(rawValue(t, client, "testKey")), "testValue")
+	value, err := client.Get(ctx, "testKey")
+	require.NoError(t, err)
+	require.Equal(t, []byte("testValue"), value)
+}
+
+func TestClientCompactionRotatesEncryptionKeys(t *testing.T) {
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+	ctx := context.Background()
+	oldKey := newTestKeyFile(t, 32)
+	newKey := newTestKeyFile(t, 32)
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newTestEncryptor(t, oldKey))
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("key-%d", i), []byte(fmt.Sprintf("value-%d", i))))
+	}
+	require.NoError(t, client.Close(ctx))
+
+	rotated := newTestEncryptor(t, newKey, oldKey)
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, rotated)
+	require.NoError(t, err)
+
+	// new values use the new key, while the old ones can still be read
+	require.NoError(t, client.Set(ctx, "key-new", []byte("value-new")))
+	require.False(t, rotated.needsRotation(rawValue(t, client, "key-new")))
+	require.True(t, rotated.needsRotation(rawValue(t, client, "key-0")))
+	value, err := client.Get(ctx, "key-0")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value-0"), value)
+
+	// compaction re-encrypts the old values, using several transactions
+	require.NoError(t, client.Compact(tempDir, time.Second, 3))
+	require.NoError(t, client.Close(ctx))
+
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newTestEncryptor(t, newKey))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.TODO()))
+	})
+	for i := 0; i < 10; i++ {
+		value, err = client.Get(ctx, fmt.Sprintf("key-%d", i))
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("value-%d", i)), value)
+	}
+	value, err = client.Get(ctx, "key-new")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value-new"), value)
+}
+
 func BenchmarkClientGet(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{})
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -379,7 +494,7 @@ func BenchmarkClientGet100(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{})
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -402,7 +517,27 @@ func BenchmarkClientSet(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{})
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
+	require.NoError(b, err)
+	b.Cleanup(func() {
+		require.NoError(b, client.Close(context.TODO()))
+	})
+
+	ctx := context.Background()
+	testKey := "testKey"
+	testValue := []byte("testValue")
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		require.NoError(b, client.Set(ctx, testKey, testValue))
+	}
+}
+
+func BenchmarkClientSetEncrypted(b *testing.B) {
+	tempDir := b.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newTestEncryptor(b, newTestKeyFile(b, 32)))
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -422,7 +557,7 @@ func BenchmarkClientSet100(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{})
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -444,7 +579,7 @@ func BenchmarkClientDelete(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{})
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -470,7 +605,7 @@ func BenchmarkClientSetLargeDB(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{})
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -507,7 +642,7 @@ func BenchmarkClientInitLargeDB(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{})
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -526,7 +661,7 @@ func BenchmarkClientInitLargeDB(b *testing.B) {
 	var tempClient *fileStorageClient
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
-		tempClient, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{})
+		tempClient, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
 		require.NoError(b, err)
 		b.StopTimer()
 		err = tempClient.Close(ctx)
@@ -544,7 +679,7 @@ func BenchmarkClientCompactLargeDBFile(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{})
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -571,7 +706,7 @@ func BenchmarkClientCompactLargeDBFile(b *testing.B) {
 		testDbFile := filepath.Join(tempDir, fmt.Sprintf("my_db%d", n))
 		err = os.Link(dbFile, testDbFile)
 		require.NoError(b, err)
-		client, err = newClient(zap.NewNop(), testDbFile, time.Second, &CompactionConfig{})
+		client, err = newClient(zap.NewNop(), testDbFile, time.Second, &CompactionConfig{}, nil)
 		require.NoError(b, err)
 		b.StartTimer()
 		require.NoError(b, client.Compact(tempDir, time.Second, 65536))
@@ -588,7 +723,7 @@ func BenchmarkClientCompactDb(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{})
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -615,7 +750,7 @@ func BenchmarkClientCompactDb(b *testing.B) {
 		testDbFile := filepath.Join(tempDir, fmt.Sprintf("my_db%d", n))
 		err = os.Link(dbFile, testDbFile)
 		require.NoError(b, err)
-		client, err = newClient(zap.NewNop(), testDbFile, time.Second, &CompactionConfig{})
+		client, err = newClient(zap.NewNop(), testDbFile, time.Second, &CompactionConfig{}, nil)
 		require.NoError(b, err)
 		b.StartTimer()
 		require.NoError(b, client.Compact(tempDir, time.Second, 65536))
@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/internal/quota"
 )
 
 // Config defines configuration for file storage extension.
@@ -32,6 +34,30 @@ type Config struct {
 	Timeout   time.Duration `mapstructure:"timeout,omitempty"`
 
 	Compaction *CompactionConfig `mapstructure:"compaction,omitempty"`
+
+	// Encryption enables the encryption of the stored values with AES-GCM
+	Encryption *EncryptionConfig `mapstructure:"encryption,omitempty"`
+
+	// Limits bounds the size and number of keys stored by each client, and the time keys are kept
+	Limits *LimitsConfig `mapstructure:"limits,omitempty"`
+}
+
+// LimitsConfig specifies the limits applied to each client
+type LimitsConfig = quota.Config
+
+// EncryptionConfig specifies the keys used to encrypt the stored values
+type EncryptionConfig struct {
+	// Keys lists the base64 encoded AES keys (16, 24 or 32 bytes long). The first key encrypts new values,
+	// while the other ones are only used to decrypt values stored before the keys were rotated
+	Keys []KeyConfig `mapstructure:"keys"`
+}
+
+// KeyConfig specifies where an encryption key is read from. Exactly one source must be set
+type KeyConfig struct {
+	// File is the path of a file holding the key
+	File string `mapstructure:"file,omitempty"`
+	// Env is the name of an environment variable holding the key
+	Env string `mapstructure:"env,omitempty"`
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -91,5 +117,22 @@ func (cfg *Config) Validate() error {
 		return errors.New("compaction check interval must be positive when rebound compaction is set")
 	}
 
+	if cfg.Limits != nil {
+		if err := cfg.Limits.Validate(); err != nil {
+			return fmt.Errorf("invalid limits: %w", err)
+		}
+	}
+
+	if cfg.Encryption != nil {
+		if len(cfg.Encryption.Keys) == 0 {
+			return errors.New("at least one key must be set when encryption is enabled")
+		}
+		for i, key := range cfg.Encryption.Keys {
+			if (key.File == "") == (key.Env == "") {
+				return fmt.Errorf("encryption key #%d must set either file or env", i)
+			}
+		}
+	}
+
 	return nil
 }
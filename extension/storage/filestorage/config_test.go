@@ -25,6 +25,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/internal/quota"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -59,6 +61,35 @@ func TestLoadConfig(t *testing.T) {
 				Timeout: 2 * time.Second,
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "encryption"),
+			expected: func() config.Extension {
+				ret := NewFactory().CreateDefaultConfig()
+				ret.(*Config).Directory = "."
+				ret.(*Config).Encryption = &EncryptionConfig{
+					Keys: []KeyConfig{
+						{File: "/etc/otelcol/keys/current"},
+						{Env: "OTELCOL_STORAGE_PREVIOUS_KEY"},
+					},
+				}
+				return ret
+			}(),
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "limits"),
+			expected: func() config.Extension {
+				ret := NewFactory().CreateDefaultConfig()
+				ret.(*Config).Directory = "."
+				ret.(*Config).Limits = &LimitsConfig{
+					MaxBytes:      104857600,
+					MaxKeys:       10000,
+					Policy:        quota.PolicyEvictOldest,
+					TTL:           24 * time.Hour,
+					SweepInterval: 5 * time.Minute,
+				}
+				return ret
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -103,3 +134,38 @@ func TestHandleProvidingFilePathAsDirWithAnError(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, file.Name()+" is not a directory")
 }
+
+func TestEncryptionValidation(t *testing.T) {
+	for name, encryption := range map[string]*EncryptionConfig{
+		"no-keys":      {},
+		"no-source":    {Keys: []KeyConfig{{}}},
+		"both-sources": {Keys: []KeyConfig{{File: "/etc/otelcol/key", Env: "OTELCOL_STORAGE_KEY"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := NewFactory()
+			cfg := f.CreateDefaultConfig().(*Config)
+			cfg.Directory = "."
+			cfg.Encryption = encryption
+
+			assert.Error(t, cfg.Validate())
+		})
+	}
+}
+
+func TestLimitsConfigInvalid(t *testing.T) {
+	for name, limits := range map[string]*LimitsConfig{
+		"negative-max-bytes": {MaxBytes: -1},
+		"negative-max-keys":  {MaxKeys: -1},
+		"unknown-policy":     {MaxKeys: 10, Policy: "drop"},
+		"negative-ttl":       {TTL: -time.Second},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := NewFactory()
+			cfg := f.CreateDefaultConfig().(*Config)
+			cfg.Directory = "."
+			cfg.Limits = limits
+
+			assert.Error(t, cfg.Validate())
+		})
+	}
+}
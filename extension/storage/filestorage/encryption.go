@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// encryptedValueVersion is the first byte of every encrypted value, identifying its format:
+	// version (1 byte) | key fingerprint (8 bytes) | nonce (12 bytes) | AES-GCM sealed value
+	encryptedValueVersion = byte(1)
+	fingerprintSize       = 8
+)
+
+var (
+	errUnknownEncryptionKey = errors.New("the value was encrypted with a key that isn't configured")
+	errMalformedValue       = errors.New("the encrypted value is malformed")
+)
+
+// encryptionKey is an AES key along with its fingerprint, stored with each value to find the key to decrypt it.
+type encryptionKey struct {
+	fingerprint [fingerprintSize]byte
+	aead        cipher.AEAD
+}
+
+// valueEncryptor encrypts values with the primary key and decrypts values encrypted with any of the configured keys.
+type valueEncryptor struct {
+	primary *encryptionKey
+	keys    map[[fingerprintSize]byte]*encryptionKey
+}
+
+func newValueEncryptor(cfg *EncryptionConfig) (*valueEncryptor, error) {
+	e := &valueEncryptor{keys: map[[fingerprintSize]byte]*encryptionKey{}}
+	for i, keyCfg := range cfg.Keys {
+		raw, err := keyCfg.load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load encryption key #%d: %w", i, err)
+		}
+
+		block, err := aes.NewCipher(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key #%d: %w", i, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key #%d: %w", i, err)
+		}
+
+		key := &encryptionKey{aead: aead}
+		sum := sha256.Sum256(raw)
+		copy(key.fingerprint[:], sum[:fingerprintSize])
+		if _, ok := e.keys[key.fingerprint]; ok {
+			return nil, fmt.Errorf("encryption key #%d is configured more than once", i)
+		}
+
+		e.keys[key.fingerprint] = key
+		if e.primary == nil {
+			e.primary = key
+		}
+	}
+	return e, nil
+}
+
+// load reads the key from the configured source. Keys are base64 encoded.
+func (k KeyConfig) load() ([]byte, error) {
+	var encoded []byte
+	if k.File != "" {
+		content, err := os.ReadFile(filepath.Clean(k.File))
+		if err != nil {
+			return nil, err
+		}
+		encoded = content
+	} else {
+		value, ok := os.LookupEnv(k.Env)
+		if !ok {
+			return nil, fmt.Errorf("environment variable %q is not set", k.Env)
+		}
+		encoded = []byte(value)
+	}
+
+	encoded = bytes.TrimSpace(encoded)
+	raw := make([]byte, base64.StdEncoding.DecodedLen(len(encoded)))
+	n, err := base64.StdEncoding.Decode(raw, encoded)
+	if err != nil {
+		return nil, fmt.Errorf("the key must be base64 encoded: %w", err)
+	}
+	return raw[:n], nil
+}
+
+// encrypt seals the value with the primary key. The storage key is used as additional data,
+// so that encrypted values can't be swapped between keys.
+func (e *valueEncryptor) encrypt(key string, value []byte) ([]byte, error) {
+	nonceSize := e.primary.aead.NonceSize()
+	out := make([]byte, 1+fingerprintSize+nonceSize, 1+fingerprintSize+nonceSize+len(value)+e.primary.aead.Overhead())
+	out[0] = encryptedValueVersion
+	copy(out[1:], e.primary.fingerprint[:])
+	nonce := out[1+fingerprintSize:]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return e.primary.aead.Seal(out, nonce, value, []byte(key)), nil
+}
+
+// decrypt opens a value sealed by encrypt with any of the configured keys.
+func (e *valueEncryptor) decrypt(key string, value []byte) ([]byte, error) {
+	encKey, err := e.keyFor(value)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := encKey.aead.NonceSize()
+	if len(value) < 1+fingerprintSize+nonceSize {
+		return nil, errMalformedValue
+	}
+	nonce := value[1+fingerprintSize : 1+fingerprintSize+nonceSize]
+	// opening into a non-nil slice keeps empty values distinguishable from missing ones
+	plain, err := encKey.aead.Open([]byte{}, nonce, value[1+fingerprintSize+nonceSize:], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value for key %q: %w", key, err)
+	}
+	return plain, nil
+}
+
+// needsRotation returns whether the value isn't encrypted with the primary key.
+func (e *valueEncryptor) needsRotation(value []byte) bool {
+	return len(value) < 1+fingerprintSize || !bytes.Equal(value[1:1+fingerprintSize], e.primary.fingerprint[:])
+}
+
+func (e *valueEncryptor) keyFor(value []byte) (*encryptionKey, error) {
+	if len(value) < 1+fingerprintSize || value[0] != encryptedValueVersion {
+		return nil, errMalformedValue
+	}
+	var fingerprint [fingerprintSize]byte
+	copy(fingerprint[:], value[1:1+fingerprintSize])
+	key, ok := e.keys[fingerprint]
+	if !ok {
+		return nil, errUnknownEncryptionKey
+	}
+	return key, nil
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestKeyFile writes a random base64 encoded key of the given size to a file, returning its path
+func newTestKeyFile(t testing.TB, size int) string {
+	raw := make([]byte, size)
+	_, err := rand.Read(raw)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(raw)+"\n"), 0600))
+	return path
+}
+
+func newTestEncryptor(t testing.TB, keyFiles ...string) *valueEncryptor {
+	cfg := &EncryptionConfig{}
+	for _, file := range keyFiles {
+		cfg.Keys = append(cfg.Keys, KeyConfig{File: file})
+	}
+	encryptor, err := newValueEncryptor(cfg)
+	require.NoError(t, err)
+	return encryptor
+}
+
+func TestValueEncryptorRoundTrip(t *testing.T) {
+	for _, size := range []int{16, 24, 32} {
+		encryptor := newTestEncryptor(t, newTestKeyFile(t, size))
+
+		encrypted, err := encryptor.encrypt("key", []byte("value"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(encrypted), "value")
+		assert.False(t, encryptor.needsRotation(encrypted))
+
+		decrypted, err := encryptor.decrypt("key", encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value"), decrypted)
+
+		// the value can't be read under another key
+		_, err = encryptor.decrypt("other-key", encrypted)
+		assert.Error(t, err)
+	}
+}
+
+func TestValueEncryptorEmptyValue(t *testing.T) {
+	encryptor := newTestEncryptor(t, newTestKeyFile(t, 32))
+
+	encrypted, err := encryptor.encrypt("key", []byte{})
+	require.NoError(t, err)
+
+	decrypted, err := encryptor.decrypt("key", encrypted)
+	require.NoError(t, err)
+	assert.Empty(t, decrypted)
+}
+
+func TestValueEncryptorKeyRotation(t *testing.T) {
+	oldKey := newTestKeyFile(t, 32)
+	newKey := newTestKeyFile(t, 32)
+
+	oldEncryptor := newTestEncryptor(t, oldKey)
+	encrypted, err := oldEncryptor.encrypt("key", []byte("value"))
+	require.NoError(t, err)
+
+	rotated := newTestEncryptor(t, newKey, oldKey)
+	assert.True(t, rotated.needsRotation(encrypted))
+	decrypted, err := rotated.decrypt("key", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), decrypted)
+
+	// once the old key is removed, its values can't be read anymore
+	_, err = newTestEncryptor(t, newKey).decrypt("key", encrypted)
+	assert.ErrorIs(t, err, errUnknownEncryptionKey)
+}
+
+func TestValueEncryptorMalformedValues(t *testing.T) {
+	encryptor := newTestEncryptor(t, newTestKeyFile(t, 32))
+	encrypted, err := encryptor.encrypt("key", []byte("value"))
+	require.NoError(t, err)
+
+	for name, value := range map[string][]byte{
+		"empty":     {},
+		"plaintext": []byte("value"),
+		"truncated": encrypted[:12],
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := encryptor.decrypt("key", value)
+			assert.Error(t, err)
+		})
+	}
+
+	tampered := append([]byte(nil), encrypted...)
+	tampered[len(tampered)-1] ^= 0xff
+	_, err = encryptor.decrypt("key", tampered)
+	assert.Error(t, err)
+}
+
+func TestNewValueEncryptorErrors(t *testing.T) {
+	invalidKey := filepath.Join(t.TempDir(), "invalid")
+	require.NoError(t, os.WriteFile(invalidKey, []byte("not base64!"), 0600))
+	key := newTestKeyFile(t, 32)
+
+	for name, cfg := range map[string]*EncryptionConfig{
+		"missing-file":  {Keys: []KeyConfig{{File: filepath.Join(t.TempDir(), "missing")}}},
+		"missing-env":   {Keys: []KeyConfig{{Env: "FILESTORAGE_TEST_MISSING_KEY"}}},
+		"invalid-key":   {Keys: []KeyConfig{{File: invalidKey}}},
+		"invalid-size":  {Keys: []KeyConfig{{File: newTestKeyFile(t, 20)}}},
+		"duplicate-key": {Keys: []KeyConfig{{File: key}, {File: key}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			encryptor, err := newValueEncryptor(cfg)
+			assert.Error(t, err)
+			assert.Nil(t, encryptor)
+		})
+	}
+}
+
+func TestKeyFromEnv(t *testing.T) {
+	keyFile := newTestKeyFile(t, 32)
+	key, err := os.ReadFile(keyFile)
+	require.NoError(t, err)
+	t.Setenv("FILESTORAGE_TEST_KEY", string(key))
+
+	fromEnv, err := newValueEncryptor(&EncryptionConfig{Keys: []KeyConfig{{Env: "FILESTORAGE_TEST_KEY"}}})
+	require.NoError(t, err)
+
+	encrypted, err := fromEnv.encrypt("key", []byte("value"))
+	require.NoError(t, err)
+
+	decrypted, err := newTestEncryptor(t, keyFile).decrypt("key", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), decrypted)
+}
@@ -23,20 +23,32 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/internal/quota"
 )
 
 type localFileStorage struct {
-	cfg    *Config
-	logger *zap.Logger
+	cfg       *Config
+	logger    *zap.Logger
+	encryptor *valueEncryptor
 }
 
 // Ensure this storage extension implements the appropriate interface
 var _ storage.Extension = (*localFileStorage)(nil)
 
 func newLocalFileStorage(logger *zap.Logger, config *Config) (component.Extension, error) {
+	var encryptor *valueEncryptor
+	if config.Encryption != nil {
+		var err error
+		if encryptor, err = newValueEncryptor(config.Encryption); err != nil {
+			return nil, err
+		}
+	}
+
 	return &localFileStorage{
-		cfg:    config,
-		logger: logger,
+		cfg:       config,
+		logger:    logger,
+		encryptor: encryptor,
 	}, nil
 }
 
@@ -62,12 +74,17 @@ func (lfs *localFileStorage) GetClient(ctx context.Context, kind component.Kind,
 	}
 	// TODO sanitize rawName
 	absoluteName := filepath.Join(lfs.cfg.Directory, rawName)
-	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction)
+	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, lfs.encryptor)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err = client.initLimits(lfs.cfg.Limits, quota.NewRecorder(lfs.cfg.ID().String(), rawName)); err != nil {
+		_ = client.Close(ctx)
+		return nil, err
+	}
+
 	// return if compaction is not required
 	if lfs.cfg.Compaction.OnStart {
 		compactionErr := client.Compact(lfs.cfg.Compaction.Directory, lfs.cfg.Timeout, lfs.cfg.Compaction.MaxTransactionSize)
@@ -398,3 +398,43 @@ func TestCompactionRemoveTemp(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 0, len(files))
 }
+
+func TestEncryptedClient(t *testing.T) {
+	ctx := context.Background()
+
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.Encryption = &EncryptionConfig{Keys: []KeyConfig{{File: newTestKeyFile(t, 32)}}}
+
+	extension, err := f.CreateExtension(ctx, componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.NoError(t, err)
+	se, ok := extension.(storage.Extension)
+	require.True(t, ok)
+
+	client, err := se.GetClient(ctx, component.KindExporter, newTestEntity("my_component"), "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	data, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), data)
+
+	raw, err := os.ReadFile(filepath.Join(cfg.Directory, "exporter_nop_my_component"))
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), "value")
+}
+
+func TestEncryptionKeyErrors(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.Encryption = &EncryptionConfig{Keys: []KeyConfig{{File: filepath.Join(t.TempDir(), "missing")}}}
+
+	extension, err := f.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.Error(t, err)
+	require.Nil(t, extension)
+}
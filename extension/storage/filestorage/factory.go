@@ -18,8 +18,11 @@ import (
 	"context"
 	"time"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/internal/quota"
 )
 
 // The value of extension "type" in configuration.
@@ -36,6 +39,8 @@ const (
 
 // NewFactory creates a factory for HostObserver extension.
 func NewFactory() component.ExtensionFactory {
+	_ = view.Register(quota.MetricViews()...)
+
 	return component.NewExtensionFactory(
 		typeStr,
 		createDefaultConfig,
@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/internal/quota"
+)
+
+var (
+	// writtenAtBucket holds the time each key was last written
+	writtenAtBucket = []byte(`written_at`)
+	// writeOrderBucket indexes the keys by the time they were last written, with keys made of the
+	// big endian timestamp followed by the key, so that iterating it returns the oldest keys first
+	writeOrderBucket = []byte(`write_order`)
+)
+
+const timestampSize = 8
+
+// initLimits computes the current usage of the client and prepares the buckets tracking the writes,
+// when they are needed to expire keys or evict the oldest ones. Without limits, it only drops what
+// was tracked by previous runs, as it won't be kept up to date.
+func (c *fileStorageClient) initLimits(limits *quota.Config, recorder *quota.Recorder) error {
+	if limits == nil {
+		return c.db.Update(dropWriteTracking)
+	}
+
+	c.usageMutex.Lock()
+	defer c.usageMutex.Unlock()
+
+	var usage quota.Usage
+	err := c.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		if err := bucket.ForEach(func(k, v []byte) error {
+			usage = usage.Add(quota.Usage{Bytes: quota.EntrySize(string(k), v), Keys: 1})
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if !limits.TracksWrites() {
+			return dropWriteTracking(tx)
+		}
+
+		for _, name := range [][]byte{writtenAtBucket, writeOrderBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+
+		// keys stored before writes were tracked are considered written now
+		var untracked [][]byte
+		writtenAt := tx.Bucket(writtenAtBucket)
+		if err := bucket.ForEach(func(k, _ []byte) error {
+			if writtenAt.Get(k) == nil {
+				untracked = append(untracked, append([]byte(nil), k...))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, k := range untracked {
+			if err := trackWrite(tx, k, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.limits = limits
+	c.recorder = recorder
+	c.usage = usage
+	c.recorder.RecordUsage(usage)
+
+	if limits.TTL > 0 {
+		c.startSweeper(context.Background())
+	}
+	return nil
+}
+
+func dropWriteTracking(tx *bbolt.Tx) error {
+	for _, name := range [][]byte{writtenAtBucket, writeOrderBucket} {
+		if err := tx.DeleteBucket(name); err != nil && err != bbolt.ErrBucketNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// trackWrite records the time the key was written, replacing the previous one
+func trackWrite(tx *bbolt.Tx, key []byte, now time.Time) error {
+	if err := untrackWrite(tx, key); err != nil {
+		return err
+	}
+
+	timestamp := make([]byte, timestampSize)
+	binary.BigEndian.PutUint64(timestamp, uint64(now.UnixNano()))
+	if err := tx.Bucket(writtenAtBucket).Put(key, timestamp); err != nil {
+		return err
+	}
+	return tx.Bucket(writeOrderBucket).Put(append(timestamp, key...), nil)
+}
+
+// untrackWrite removes the time the key was written
+func untrackWrite(tx *bbolt.Tx, key []byte) error {
+	writtenAt := tx.Bucket(writtenAtBucket)
+	timestamp := writtenAt.Get(key)
+	if timestamp == nil {
+		return nil
+	}
+	orderKey := append(append(make([]byte, 0, timestampSize+len(key)), timestamp...), key...)
+	if err := tx.Bucket(writeOrderBucket).Delete(orderKey); err != nil {
+		return err
+	}
+	return writtenAt.Delete(key)
+}
+
+// evictOldest deletes the least recently written keys, other than the ones in keep, until the usage is within
+// the limits. It returns the usage freed by the deleted keys, along with the number of keys deleted.
+func (c *fileStorageClient) evictOldest(tx *bbolt.Tx, usage quota.Usage, keep map[string]struct{}) (quota.Usage, error) {
+	bucket := tx.Bucket(defaultBucket)
+	var freed quota.Usage
+	var evicted [][]byte
+
+	cursor := tx.Bucket(writeOrderBucket).Cursor()
+	for k, _ := cursor.First(); k != nil && c.limits.Exceeds(usage.Sub(freed)); k, _ = cursor.Next() {
+		key := k[timestampSize:]
+		if _, ok := keep[string(key)]; ok {
+			continue
+		}
+		freed = freed.Add(quota.Usage{Bytes: quota.EntrySize(string(key), bucket.Get(key)), Keys: 1})
+		evicted = append(evicted, append([]byte(nil), key...))
+	}
+
+	if c.limits.Exceeds(usage.Sub(freed)) {
+		return quota.Usage{}, quota.ErrExceeded
+	}
+	return freed, deleteKeys(tx, evicted)
+}
+
+func deleteKeys(tx *bbolt.Tx, keys [][]byte) error {
+	bucket := tx.Bucket(defaultBucket)
+	for _, key := range keys {
+		if err := untrackWrite(tx, key); err != nil {
+			return err
+		}
+		if err := bucket.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteExpired deletes the keys that weren't written for longer than the TTL
+func (c *fileStorageClient) deleteExpired(now time.Time) error {
+	c.compactionMutex.RLock()
+	defer c.compactionMutex.RUnlock()
+	if c.closed {
+		return nil
+	}
+
+	c.usageMutex.Lock()
+	defer c.usageMutex.Unlock()
+
+	cutoff := make([]byte, timestampSize)
+	binary.BigEndian.PutUint64(cutoff, uint64(now.Add(-c.limits.TTL).UnixNano()))
+
+	var freed quota.Usage
+	err := c.db.Update(func(tx *bbolt.Tx) error {
+		freed = quota.Usage{}
+		bucket := tx.Bucket(defaultBucket)
+
+		var expired [][]byte
+		cursor := tx.Bucket(writeOrderBucket).Cursor()
+		for k, _ := cursor.First(); k != nil && bytes.Compare(k[:timestampSize], cutoff) < 0; k, _ = cursor.Next() {
+			key := append([]byte(nil), k[timestampSize:]...)
+			freed = freed.Add(quota.Usage{Bytes: quota.EntrySize(string(key), bucket.Get(key)), Keys: 1})
+			expired = append(expired, key)
+		}
+		return deleteKeys(tx, expired)
+	})
+	if err != nil {
+		return err
+	}
+
+	if freed.Keys > 0 {
+		c.usage = c.usage.Sub(freed)
+		c.recorder.RecordUsage(c.usage)
+		c.recorder.RecordEvicted(quota.EvictionReasonTTL, freed.Keys)
+	}
+	return nil
+}
+
+func (c *fileStorageClient) startSweeper(ctx context.Context) {
+	ctx, c.stopSweeper = context.WithCancel(ctx)
+
+	go func() {
+		ticker := time.NewTicker(c.limits.Interval())
+		defer ticker.Stop()
+
+		for {
+			select {
+			case now := <-ticker.C:
+				if err := c.deleteExpired(now); err != nil {
+					c.logger.Error("failed to delete expired keys", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
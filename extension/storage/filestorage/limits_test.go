@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/internal/quota"
+)
+
+func newLimitedClient(t *testing.T, dbFile string, limits *quota.Config) *fileStorageClient {
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, client.initLimits(limits, quota.NewRecorder("file_storage", t.Name())))
+	return client
+}
+
+func bucketExists(t *testing.T, client *fileStorageClient, name []byte) bool {
+	exists := false
+	require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+		exists = tx.Bucket(name) != nil
+		return nil
+	}))
+	return exists
+}
+
+func TestClientLimitsReject(t *testing.T) {
+	ctx := context.Background()
+	client := newLimitedClient(t, filepath.Join(t.TempDir(), "my_db"), &quota.Config{MaxKeys: 2, MaxBytes: 20})
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+
+	require.NoError(t, client.Set(ctx, "a", []byte("1234")))
+	require.NoError(t, client.Set(ctx, "b", []byte("1234")))
+	assert.Equal(t, quota.Usage{Bytes: 10, Keys: 2}, client.usage)
+
+	// too many keys
+	assert.ErrorIs(t, client.Set(ctx, "c", []byte("1234")), quota.ErrExceeded)
+	value, err := client.Get(ctx, "c")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	// too many bytes
+	assert.ErrorIs(t, client.Set(ctx, "a", make([]byte, 20)), quota.ErrExceeded)
+
+	// the whole batch is rejected
+	err = client.Batch(ctx, storage.SetOperation("a", []byte("5678")), storage.SetOperation("d", []byte("1234")))
+	assert.ErrorIs(t, err, quota.ErrExceeded)
+	value, err = client.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1234"), value)
+	assert.Equal(t, quota.Usage{Bytes: 10, Keys: 2}, client.usage)
+
+	// overwriting keys within the limits, and making room, is allowed
+	require.NoError(t, client.Set(ctx, "a", []byte("123456789")))
+	require.NoError(t, client.Delete(ctx, "b"))
+	require.NoError(t, client.Set(ctx, "c", []byte("1234")))
+	assert.Equal(t, quota.Usage{Bytes: 15, Keys: 2}, client.usage)
+}
+
+func TestClientLimitsEvictOldest(t *testing.T) {
+	ctx := context.Background()
+	client := newLimitedClient(t, filepath.Join(t.TempDir(), "my_db"), &quota.Config{MaxKeys: 3, Policy: quota.PolicyEvictOldest})
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("key-%d", i), []byte("value")))
+	}
+	// writing a key again makes it the most recent one
+	require.NoError(t, client.Set(ctx, "key-0", []byte("value")))
+
+	require.NoError(t, client.Set(ctx, "key-3", []byte("value")))
+	require.NoError(t, client.Set(ctx, "key-4", []byte("value")))
+
+	for key, expected := range map[string][]byte{
+		"key-0": []byte("value"),
+		"key-1": nil,
+		"key-2": nil,
+		"key-3": []byte("value"),
+		"key-4": []byte("value"),
+	} {
+		value, err := client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, value, key)
+	}
+	assert.Equal(t, quota.Usage{Bytes: 30, Keys: 3}, client.usage)
+
+	// batches that don't fit even after evicting all the other keys are rejected
+	err := client.Batch(ctx,
+		storage.SetOperation("key-5", []byte("value")),
+		storage.SetOperation("key-6", []byte("value")),
+		storage.SetOperation("key-7", []byte("value")),
+		storage.SetOperation("key-8", []byte("value")),
+	)
+	assert.ErrorIs(t, err, quota.ErrExceeded)
+	value, err := client.Get(ctx, "key-4")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestClientLimitsTTL(t *testing.T) {
+	ctx := context.Background()
+	client := newLimitedClient(t, filepath.Join(t.TempDir(), "my_db"), &quota.Config{TTL: time.Hour})
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+
+	require.NoError(t, client.Set(ctx, "old", []byte("value")))
+	require.NoError(t, client.Set(ctx, "deleted", []byte("value")))
+	require.NoError(t, client.Delete(ctx, "deleted"))
+
+	// nothing expired yet
+	require.NoError(t, client.deleteExpired(time.Now()))
+	assert.Equal(t, quota.Usage{Bytes: 8, Keys: 1}, client.usage)
+
+	require.NoError(t, client.deleteExpired(time.Now().Add(2*time.Hour)))
+	value, err := client.Get(ctx, "old")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	assert.Equal(t, quota.Usage{}, client.usage)
+}
+
+func TestClientLimitsSweeper(t *testing.T) {
+	ctx := context.Background()
+	client := newLimitedClient(t, filepath.Join(t.TempDir(), "my_db"), &quota.Config{
+		TTL:           10 * time.Millisecond,
+		SweepInterval: 10 * time.Millisecond,
+	})
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	require.Eventually(t, func() bool {
+		value, err := client.Get(ctx, "key")
+		require.NoError(t, err)
+		return value == nil
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestClientLimitsExistingKeys(t *testing.T) {
+	ctx := context.Background()
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key-0", []byte("value")))
+	require.NoError(t, client.Set(ctx, "key-1", []byte("value")))
+	require.NoError(t, client.Close(ctx))
+
+	// the existing keys are accounted for, and expire as if they were written when the limits were enabled
+	client = newLimitedClient(t, dbFile, &quota.Config{TTL: time.Hour})
+	assert.Equal(t, quota.Usage{Bytes: 20, Keys: 2}, client.usage)
+	assert.True(t, bucketExists(t, client, writeOrderBucket))
+	require.NoError(t, client.deleteExpired(time.Now()))
+	assert.Equal(t, quota.Usage{Bytes: 20, Keys: 2}, client.usage)
+	require.NoError(t, client.deleteExpired(time.Now().Add(2*time.Hour)))
+	assert.Equal(t, quota.Usage{}, client.usage)
+	require.NoError(t, client.Set(ctx, "key-2", []byte("value")))
+	require.NoError(t, client.Close(ctx))
+
+	// tracking the writes is dropped when it's not needed anymore
+	client = newLimitedClient(t, dbFile, &quota.Config{MaxKeys: 10})
+	assert.Equal(t, quota.Usage{Bytes: 10, Keys: 1}, client.usage)
+	assert.False(t, bucketExists(t, client, writtenAtBucket))
+	assert.False(t, bucketExists(t, client, writeOrderBucket))
+	require.NoError(t, client.Close(ctx))
+
+	// including when the limits are disabled
+	client = newLimitedClient(t, dbFile, &quota.Config{TTL: time.Hour})
+	assert.True(t, bucketExists(t, client, writtenAtBucket))
+	require.NoError(t, client.Close(ctx))
+	client = newLimitedClient(t, dbFile, nil)
+	assert.Nil(t, client.limits)
+	assert.False(t, bucketExists(t, client, writtenAtBucket))
+	assert.False(t, bucketExists(t, client, writeOrderBucket))
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestClientLimitsWithEncryption(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(zap.NewNop(), filepath.Join(t.TempDir(), "my_db"), time.Second, &CompactionConfig{}, newTestEncryptor(t, newTestKeyFile(t, 32)))
+	require.NoError(t, err)
+	require.NoError(t, client.initLimits(&quota.Config{MaxBytes: 100}, quota.NewRecorder("file_storage", t.Name())))
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+
+	// the limits apply to the encrypted values, as stored on disk
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	assert.Greater(t, client.usage.Bytes, quota.EntrySize("key", []byte("value")))
+	assert.ErrorIs(t, client.Set(ctx, "key", make([]byte, 80)), quota.ErrExceeded)
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package quota bounds what storage clients store, and reports their usage.
+package quota // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/internal/quota"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Policy specifies what happens to writes that would exceed the limits.
+type Policy string
+
+const (
+	// PolicyReject fails the writes that would exceed the limits.
+	PolicyReject Policy = "reject"
+	// PolicyEvictOldest deletes the least recently written keys to make room for new writes.
+	PolicyEvictOldest Policy = "evict_oldest"
+)
+
+const defaultSweepInterval = time.Minute
+
+// ErrExceeded is returned by writes rejected because of the client limits.
+var ErrExceeded = errors.New("storage client limits exceeded")
+
+// Config specifies the limits applied to each storage client.
+type Config struct {
+	// MaxBytes is the maximum size of the keys and values stored by a client. Zero means no limit.
+	MaxBytes int64 `mapstructure:"max_bytes,omitempty"`
+	// MaxKeys is the maximum number of keys stored by a client. Zero means no limit.
+	MaxKeys int64 `mapstructure:"max_keys,omitempty"`
+	// Policy is applied to writes that would exceed MaxBytes or MaxKeys. The default is PolicyReject.
+	Policy Policy `mapstructure:"policy,omitempty"`
+	// TTL is the time after which keys that weren't written again are deleted. Zero disables expiration.
+	TTL time.Duration `mapstructure:"ttl,omitempty"`
+	// SweepInterval is the frequency of the checks for expired keys. The default is one minute.
+	SweepInterval time.Duration `mapstructure:"sweep_interval,omitempty"`
+}
+
+// Validate checks the limits are consistent.
+func (cfg *Config) Validate() error {
+	if cfg.MaxBytes < 0 {
+		return errors.New("max_bytes cannot be negative")
+	}
+	if cfg.MaxKeys < 0 {
+		return errors.New("max_keys cannot be negative")
+	}
+	switch cfg.Policy {
+	case "", PolicyReject, PolicyEvictOldest:
+	default:
+		return fmt.Errorf("unknown policy %q, expected %q or %q", cfg.Policy, PolicyReject, PolicyEvictOldest)
+	}
+	if cfg.TTL < 0 {
+		return errors.New("ttl cannot be negative")
+	}
+	if cfg.SweepInterval < 0 {
+		return errors.New("sweep_interval cannot be negative")
+	}
+	return nil
+}
+
+// TracksWrites returns whether the time each key was last written needs to be tracked,
+// to expire keys or evict the oldest ones.
+func (cfg *Config) TracksWrites() bool {
+	return cfg != nil && (cfg.TTL > 0 || (cfg.Policy == PolicyEvictOldest && cfg.limited()))
+}
+
+// Evicts returns whether the oldest keys are evicted when the limits are exceeded.
+func (cfg *Config) Evicts() bool {
+	return cfg != nil && cfg.Policy == PolicyEvictOldest
+}
+
+// Interval returns how often expired keys are deleted.
+func (cfg *Config) Interval() time.Duration {
+	if cfg.SweepInterval > 0 {
+		return cfg.SweepInterval
+	}
+	return defaultSweepInterval
+}
+
+func (cfg *Config) limited() bool {
+	return cfg.MaxBytes > 0 || cfg.MaxKeys > 0
+}
+
+// Usage is the size and number of keys stored by a client.
+type Usage struct {
+	Bytes int64
+	Keys  int64
+}
+
+// Add returns the sum of both usages.
+func (u Usage) Add(other Usage) Usage {
+	return Usage{Bytes: u.Bytes + other.Bytes, Keys: u.Keys + other.Keys}
+}
+
+// Sub returns the difference between both usages.
+func (u Usage) Sub(other Usage) Usage {
+	return Usage{Bytes: u.Bytes - other.Bytes, Keys: u.Keys - other.Keys}
+}
+
+// Exceeds returns whether the usage is above the limits. A nil config has no limits.
+func (cfg *Config) Exceeds(u Usage) bool {
+	if cfg == nil {
+		return false
+	}
+	return (cfg.MaxBytes > 0 && u.Bytes > cfg.MaxBytes) || (cfg.MaxKeys > 0 && u.Keys > cfg.MaxKeys)
+}
+
+// EntrySize returns how much a key and its value count towards the MaxBytes limit.
+func EntrySize(key string, value []byte) int64 {
+	return int64(len(key) + len(value))
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, (&Config{}).Validate())
+	assert.NoError(t, (&Config{MaxBytes: 1024, MaxKeys: 10, Policy: PolicyEvictOldest, TTL: time.Hour}).Validate())
+	assert.Error(t, (&Config{MaxBytes: -1}).Validate())
+	assert.Error(t, (&Config{MaxKeys: -1}).Validate())
+	assert.Error(t, (&Config{Policy: "drop"}).Validate())
+	assert.Error(t, (&Config{TTL: -time.Hour}).Validate())
+	assert.Error(t, (&Config{SweepInterval: -time.Hour}).Validate())
+}
+
+func TestConfigTracksWrites(t *testing.T) {
+	var cfg *Config
+	assert.False(t, cfg.TracksWrites())
+	assert.False(t, (&Config{MaxKeys: 10}).TracksWrites())
+	assert.False(t, (&Config{Policy: PolicyEvictOldest}).TracksWrites())
+	assert.True(t, (&Config{MaxKeys: 10, Policy: PolicyEvictOldest}).TracksWrites())
+	assert.True(t, (&Config{TTL: time.Hour}).TracksWrites())
+}
+
+func TestConfigExceeds(t *testing.T) {
+	var cfg *Config
+	assert.False(t, cfg.Exceeds(Usage{Bytes: 1 << 40, Keys: 1 << 40}))
+
+	cfg = &Config{MaxBytes: 100, MaxKeys: 2}
+	assert.False(t, cfg.Exceeds(Usage{Bytes: 100, Keys: 2}))
+	assert.True(t, cfg.Exceeds(Usage{Bytes: 101, Keys: 2}))
+	assert.True(t, cfg.Exceeds(Usage{Bytes: 100, Keys: 3}))
+
+	assert.False(t, (&Config{MaxKeys: 2}).Exceeds(Usage{Bytes: 1 << 40, Keys: 2}))
+}
+
+func TestUsage(t *testing.T) {
+	u := Usage{Bytes: 10, Keys: 1}.Add(Usage{Bytes: EntrySize("key", []byte("value")), Keys: 1})
+	assert.Equal(t, Usage{Bytes: 18, Keys: 2}, u)
+	assert.Equal(t, Usage{Bytes: 10, Keys: 1}, u.Sub(Usage{Bytes: 8, Keys: 1}))
+}
+
+func TestConfigInterval(t *testing.T) {
+	assert.Equal(t, time.Minute, (&Config{}).Interval())
+	assert.Equal(t, time.Second, (&Config{SweepInterval: time.Second}).Interval())
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/internal/quota"
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+const (
+	// EvictionReasonTTL is the reason for keys deleted because they expired.
+	EvictionReasonTTL = "ttl"
+	// EvictionReasonLimits is the reason for keys deleted to make room for new writes.
+	EvictionReasonLimits = "limits"
+)
+
+var (
+	tagKeyExtension, _ = tag.NewKey("extension")
+	tagKeyClient, _    = tag.NewKey("client")
+	tagKeyReason, _    = tag.NewKey("reason")
+
+	mUsageBytes    = stats.Int64("storage_client_bytes", "Size of the keys and values stored by the client", stats.UnitBytes)
+	mUsageKeys     = stats.Int64("storage_client_keys", "Number of keys stored by the client", stats.UnitDimensionless)
+	mEvictedKeys   = stats.Int64("storage_client_evicted_keys", "Keys deleted because they expired or to make room for new writes", stats.UnitDimensionless)
+	mRejectedWrite = stats.Int64("storage_client_rejected_writes", "Writes rejected because they would exceed the client limits", stats.UnitDimensionless)
+)
+
+// MetricViews returns the views reporting the usage of the storage clients.
+func MetricViews() []*view.View {
+	clientTags := []tag.Key{tagKeyExtension, tagKeyClient}
+	return []*view.View{
+		{
+			Name:        mUsageBytes.Name(),
+			Measure:     mUsageBytes,
+			Description: mUsageBytes.Description(),
+			TagKeys:     clientTags,
+			Aggregation: view.LastValue(),
+		},
+		{
+			Name:        mUsageKeys.Name(),
+			Measure:     mUsageKeys,
+			Description: mUsageKeys.Description(),
+			TagKeys:     clientTags,
+			Aggregation: view.LastValue(),
+		},
+		{
+			Name:        mEvictedKeys.Name(),
+			Measure:     mEvictedKeys,
+			Description: mEvictedKeys.Description(),
+			TagKeys:     append(clientTags, tagKeyReason),
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mRejectedWrite.Name(),
+			Measure:     mRejectedWrite,
+			Description: mRejectedWrite.Description(),
+			TagKeys:     clientTags,
+			Aggregation: view.Sum(),
+		},
+	}
+}
+
+// Recorder records the usage metrics of a single client.
+type Recorder struct {
+	ctx context.Context
+}
+
+// NewRecorder returns a recorder for the client with the given name, created by the given storage extension.
+func NewRecorder(extension string, client string) *Recorder {
+	ctx, _ := tag.New(context.Background(), tag.Upsert(tagKeyExtension, extension), tag.Upsert(tagKeyClient, client))
+	return &Recorder{ctx: ctx}
+}
+
+// RecordUsage records the current usage of the client.
+func (r *Recorder) RecordUsage(u Usage) {
+	stats.Record(r.ctx, mUsageBytes.M(u.Bytes), mUsageKeys.M(u.Keys))
+}
+
+// RecordEvicted records keys deleted for the given reason.
+func (r *Recorder) RecordEvicted(reason string, keys int64) {
+	if keys == 0 {
+		return
+	}
+	_ = stats.RecordWithTags(r.ctx, []tag.Mutator{tag.Upsert(tagKeyReason, reason)}, mEvictedKeys.M(keys))
+}
+
+// RecordRejected records a write rejected because of the client limits.
+func (r *Recorder) RecordRejected() {
+	stats.Record(r.ctx, mRejectedWrite.M(1))
+}
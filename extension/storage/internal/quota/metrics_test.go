@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+func TestRecorder(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	t.Cleanup(func() {
+		view.Unregister(views...)
+	})
+
+	r := NewRecorder("file_storage", "receiver_filelog_")
+	r.RecordUsage(Usage{Bytes: 100, Keys: 5})
+	r.RecordUsage(Usage{Bytes: 80, Keys: 4})
+	r.RecordEvicted(EvictionReasonTTL, 2)
+	r.RecordEvicted(EvictionReasonTTL, 0)
+	r.RecordEvicted(EvictionReasonLimits, 1)
+	r.RecordRejected()
+	r.RecordRejected()
+
+	clientTags := []tag.Tag{
+		{Key: tagKeyClient, Value: "receiver_filelog_"},
+		{Key: tagKeyExtension, Value: "file_storage"},
+	}
+
+	rows, err := view.RetrieveData("storage_client_bytes")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, clientTags, rows[0].Tags)
+	assert.Equal(t, float64(80), rows[0].Data.(*view.LastValueData).Value)
+
+	rows, err = view.RetrieveData("storage_client_keys")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, float64(4), rows[0].Data.(*view.LastValueData).Value)
+
+	rows, err = view.RetrieveData("storage_client_evicted_keys")
+	require.NoError(t, err)
+	evicted := map[string]float64{}
+	for _, row := range rows {
+		for _, tg := range row.Tags {
+			if tg.Key == tagKeyReason {
+				evicted[tg.Value] = row.Data.(*view.SumData).Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{EvictionReasonTTL: 2, EvictionReasonLimits: 1}, evicted)
+
+	rows, err = view.RetrieveData("storage_client_rejected_writes")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, float64(2), rows[0].Data.(*view.SumData).Value)
+}
@@ -52,8 +52,11 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetter v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder v0.59.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension v0.59.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/consulobserver v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/ecstaskobserver v0.59.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/hostobserver v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/oidcauthextension v0.59.0
@@ -65,11 +68,14 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/attributesprocessor v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor v0.59.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatorateprocessor v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbyattrsprocessor v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor v0.59.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor v0.59.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/processor/logstometricsprocessor v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricsgenerationprocessor v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor v0.59.0
@@ -104,6 +110,8 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudspannerreceiver v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver v0.59.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver v0.59.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httplogreceiver v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/iisreceiver v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/influxdbreceiver v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/jaegerreceiver v0.59.0
@@ -111,6 +119,7 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/journaldreceiver v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8seventsreceiver v0.59.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver v0.59.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kubeletstatsreceiver v0.59.0
@@ -728,12 +737,18 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/http
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling => ./extension/jaegerremotesampling
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension => ./extension/mtlsauthextension
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension => ./extension/oauth2clientauthextension
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer => ./extension/observer
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/consulobserver => ./extension/observer/consulobserver
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/ecstaskobserver => ./extension/observer/ecstaskobserver
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver => ./extension/observer/fileobserver
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/hostobserver => ./extension/observer/hostobserver
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver => ./extension/observer/k8sobserver
@@ -776,6 +791,8 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/attr
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor => ./processor/cumulativetodeltaprocessor/
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor => ./processor/deltatocumulativeprocessor/
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatorateprocessor => ./processor/deltatorateprocessor/
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor => ./processor/filterprocessor
@@ -784,8 +801,12 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/grou
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor => ./processor/groupbytraceprocessor
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor => ./processor/intervalprocessor/
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor => ./processor/k8sattributesprocessor/
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/logstometricsprocessor => ./processor/logstometricsprocessor/
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricsgenerationprocessor => ./processor/metricsgenerationprocessor/
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor => ./processor/metricstransformprocessor/
@@ -854,6 +875,10 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googl
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver => ./receiver/hostmetricsreceiver
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver => ./receiver/httpcheckreceiver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httplogreceiver => ./receiver/httplogreceiver
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/influxdbreceiver => ./receiver/influxdbreceiver
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/iisreceiver => ./receiver/iisreceiver
@@ -868,6 +893,8 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8scl
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8seventsreceiver => ./receiver/k8seventsreceiver
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver => ./receiver/k8sobjectsreceiver
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/fluentforwardreceiver => ./receiver/fluentforwardreceiver
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver => ./receiver/kafkametricsreceiver
@@ -41,7 +41,9 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/consulobserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/ecstaskobserver"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/hostobserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/sigv4authextension"
@@ -170,6 +172,12 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension: "mtlsauth",
+			getConfigFn: func() config.Extension {
+				return extFactories["mtlsauth"].CreateDefaultConfig()
+			},
+		},
 		{
 			extension: "oauth2client",
 			getConfigFn: func() config.Extension {
@@ -188,7 +196,7 @@ func TestDefaultExtensions(t *testing.T) {
 			extension: "db_storage",
 			getConfigFn: func() config.Extension {
 				cfg := extFactories["db_storage"].CreateDefaultConfig().(*dbstorage.Config)
-				cfg.DriverName = "sqlite3"
+				cfg.DriverName = dbstorage.DriverSQLite
 				cfg.DataSource = filepath.Join(t.TempDir(), "foo.db")
 				return cfg
 			},
@@ -201,6 +209,21 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension: "consul_observer",
+			getConfigFn: func() config.Extension {
+				cfg := extFactories["consul_observer"].CreateDefaultConfig().(*consulobserver.Config)
+				return cfg
+			},
+		},
+		{
+			extension: "file_observer",
+			getConfigFn: func() config.Extension {
+				cfg := extFactories["file_observer"].CreateDefaultConfig().(*fileobserver.Config)
+				cfg.Files = []string{"/etc/otelcol/targets/*.yaml"}
+				return cfg
+			},
+		},
 		{
 			extension: "host_observer",
 			getConfigFn: func() config.Extension {
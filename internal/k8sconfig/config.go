@@ -22,6 +22,7 @@ import (
 
 	quotaclientset "github.com/openshift/client-go/quota/clientset/versioned"
 	k8sruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/dynamic"
 	k8s "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -163,3 +164,23 @@ func MakeOpenShiftQuotaClient(apiConf APIConfig) (quotaclientset.Interface, erro
 
 	return client, nil
 }
+
+// MakeDynamicClient can take configuration if needed for other types of auth
+// and return a dynamic client for arbitrary API resources
+func MakeDynamicClient(apiConf APIConfig) (dynamic.Interface, error) {
+	if err := apiConf.Validate(); err != nil {
+		return nil, err
+	}
+
+	authConf, err := createRestConfig(apiConf)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := dynamic.NewForConfig(authConf)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
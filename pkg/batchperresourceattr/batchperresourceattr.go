@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -46,16 +47,16 @@ func (bt *batchTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) erro
 	lenRss := rss.Len()
 	// If zero or one resource spans just call next.
 	if lenRss <= 1 {
+		if lenRss == 1 {
+			ctx = newAttrContext(ctx, bt.attrKey, resourceAttrValue(rss.At(0).Resource(), bt.attrKey))
+		}
 		return bt.next.ConsumeTraces(ctx, td)
 	}
 
 	tracesByAttr := make(map[string]ptrace.Traces)
 	for i := 0; i < lenRss; i++ {
 		rs := rss.At(i)
-		var attrVal string
-		if attributeValue, ok := rs.Resource().Attributes().Get(bt.attrKey); ok {
-			attrVal = attributeValue.StringVal()
-		}
+		attrVal := resourceAttrValue(rs.Resource(), bt.attrKey)
 
 		tracesForAttr, ok := tracesByAttr[attrVal]
 		if !ok {
@@ -68,8 +69,8 @@ func (bt *batchTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) erro
 	}
 
 	var errs error
-	for _, td := range tracesByAttr {
-		errs = multierr.Append(errs, bt.next.ConsumeTraces(ctx, td))
+	for attrVal, td := range tracesByAttr {
+		errs = multierr.Append(errs, bt.next.ConsumeTraces(newAttrContext(ctx, bt.attrKey, attrVal), td))
 	}
 	return errs
 }
@@ -96,16 +97,16 @@ func (bt *batchMetrics) ConsumeMetrics(ctx context.Context, td pmetric.Metrics)
 	lenRms := rms.Len()
 	// If zero or one resource spans just call next.
 	if lenRms <= 1 {
+		if lenRms == 1 {
+			ctx = newAttrContext(ctx, bt.attrKey, resourceAttrValue(rms.At(0).Resource(), bt.attrKey))
+		}
 		return bt.next.ConsumeMetrics(ctx, td)
 	}
 
 	metricsByAttr := make(map[string]pmetric.Metrics)
 	for i := 0; i < lenRms; i++ {
 		rm := rms.At(i)
-		var attrVal string
-		if attributeValue, ok := rm.Resource().Attributes().Get(bt.attrKey); ok {
-			attrVal = attributeValue.StringVal()
-		}
+		attrVal := resourceAttrValue(rm.Resource(), bt.attrKey)
 
 		metricsForAttr, ok := metricsByAttr[attrVal]
 		if !ok {
@@ -118,8 +119,8 @@ func (bt *batchMetrics) ConsumeMetrics(ctx context.Context, td pmetric.Metrics)
 	}
 
 	var errs error
-	for _, td := range metricsByAttr {
-		errs = multierr.Append(errs, bt.next.ConsumeMetrics(ctx, td))
+	for attrVal, td := range metricsByAttr {
+		errs = multierr.Append(errs, bt.next.ConsumeMetrics(newAttrContext(ctx, bt.attrKey, attrVal), td))
 	}
 	return errs
 }
@@ -146,16 +147,16 @@ func (bt *batchLogs) ConsumeLogs(ctx context.Context, td plog.Logs) error {
 	lenRls := rls.Len()
 	// If zero or one resource spans just call next.
 	if lenRls <= 1 {
+		if lenRls == 1 {
+			ctx = newAttrContext(ctx, bt.attrKey, resourceAttrValue(rls.At(0).Resource(), bt.attrKey))
+		}
 		return bt.next.ConsumeLogs(ctx, td)
 	}
 
 	logsByAttr := make(map[string]plog.Logs)
 	for i := 0; i < lenRls; i++ {
 		rl := rls.At(i)
-		var attrVal string
-		if attributeValue, ok := rl.Resource().Attributes().Get(bt.attrKey); ok {
-			attrVal = attributeValue.StringVal()
-		}
+		attrVal := resourceAttrValue(rl.Resource(), bt.attrKey)
 
 		logsForAttr, ok := logsByAttr[attrVal]
 		if !ok {
@@ -168,8 +169,15 @@ func (bt *batchLogs) ConsumeLogs(ctx context.Context, td plog.Logs) error {
 	}
 
 	var errs error
-	for _, td := range logsByAttr {
-		errs = multierr.Append(errs, bt.next.ConsumeLogs(ctx, td))
+	for attrVal, td := range logsByAttr {
+		errs = multierr.Append(errs, bt.next.ConsumeLogs(newAttrContext(ctx, bt.attrKey, attrVal), td))
 	}
 	return errs
 }
+
+func resourceAttrValue(res pcommon.Resource, attrKey string) string {
+	if attributeValue, ok := res.Attributes().Get(attrKey); ok {
+		return attributeValue.StringVal()
+	}
+	return ""
+}
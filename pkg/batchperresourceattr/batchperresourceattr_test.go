@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -225,6 +226,81 @@ func TestSplitLogsIntoDifferentBatches(t *testing.T) {
 	assert.Equal(t, newLogs(expected.ResourceLogs().At(3), expected.ResourceLogs().At(7)), outBatches[4])
 }
 
+func TestSplitTracesContext(t *testing.T) {
+	inBatch := ptrace.NewTraces()
+	fillResourceSpans(inBatch.ResourceSpans().AppendEmpty(), "attr_key", "1")
+	fillResourceSpans(inBatch.ResourceSpans().AppendEmpty(), "attr_key", "2")
+	fillResourceSpans(inBatch.ResourceSpans().AppendEmpty(), "diff_attr_key", "1")
+
+	var values []string
+	next, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		attrVal, ok := FromContext(ctx, "attr_key")
+		values = append(values, strconv.FormatBool(ok)+":"+attrVal)
+		return nil
+	})
+	require.NoError(t, err)
+	bpr := NewBatchPerResourceTraces("attr_key", next)
+	assert.NoError(t, bpr.ConsumeTraces(context.Background(), inBatch))
+	assert.ElementsMatch(t, []string{"true:1", "true:2", "false:"}, values)
+
+	// a single resource is consumed as is, along with its attribute value
+	values = nil
+	inBatch = ptrace.NewTraces()
+	fillResourceSpans(inBatch.ResourceSpans().AppendEmpty(), "attr_key", "1")
+	assert.NoError(t, bpr.ConsumeTraces(context.Background(), inBatch))
+	assert.Equal(t, []string{"true:1"}, values)
+}
+
+func TestSplitMetricsContext(t *testing.T) {
+	inBatch := pmetric.NewMetrics()
+	fillResourceMetrics(inBatch.ResourceMetrics().AppendEmpty(), "attr_key", "1")
+	fillResourceMetrics(inBatch.ResourceMetrics().AppendEmpty(), "attr_key", "2")
+	fillResourceMetrics(inBatch.ResourceMetrics().AppendEmpty(), "diff_attr_key", "1")
+
+	var values []string
+	next, err := consumer.NewMetrics(func(ctx context.Context, td pmetric.Metrics) error {
+		attrVal, ok := FromContext(ctx, "attr_key")
+		values = append(values, strconv.FormatBool(ok)+":"+attrVal)
+		return nil
+	})
+	require.NoError(t, err)
+	bpr := NewBatchPerResourceMetrics("attr_key", next)
+	assert.NoError(t, bpr.ConsumeMetrics(context.Background(), inBatch))
+	assert.ElementsMatch(t, []string{"true:1", "true:2", "false:"}, values)
+
+	// a single resource is consumed as is, along with its attribute value
+	values = nil
+	inBatch = pmetric.NewMetrics()
+	fillResourceMetrics(inBatch.ResourceMetrics().AppendEmpty(), "attr_key", "1")
+	assert.NoError(t, bpr.ConsumeMetrics(context.Background(), inBatch))
+	assert.Equal(t, []string{"true:1"}, values)
+}
+
+func TestSplitLogsContext(t *testing.T) {
+	inBatch := plog.NewLogs()
+	fillResourceLogs(inBatch.ResourceLogs().AppendEmpty(), "attr_key", "1")
+	fillResourceLogs(inBatch.ResourceLogs().AppendEmpty(), "attr_key", "2")
+	fillResourceLogs(inBatch.ResourceLogs().AppendEmpty(), "diff_attr_key", "1")
+
+	var values []string
+	next, err := consumer.NewLogs(func(ctx context.Context, td plog.Logs) error {
+		attrVal, ok := FromContext(ctx, "attr_key")
+		values = append(values, strconv.FormatBool(ok)+":"+attrVal)
+		return nil
+	})
+	require.NoError(t, err)
+	bpr := NewBatchPerResourceLogs("attr_key", next)
+	assert.NoError(t, bpr.ConsumeLogs(context.Background(), inBatch))
+	assert.ElementsMatch(t, []string{"true:1", "true:2", "false:"}, values)
+
+	// a single resource is consumed as is, along with its attribute value
+	values = nil
+	inBatch = plog.NewLogs()
+	fillResourceLogs(inBatch.ResourceLogs().AppendEmpty(), "attr_key", "1")
+	assert.NoError(t, bpr.ConsumeLogs(context.Background(), inBatch))
+	assert.Equal(t, []string{"true:1"}, values)
+}
+
 func newTraces(rss ...ptrace.ResourceSpans) ptrace.Traces {
 	td := ptrace.NewTraces()
 	for _, rs := range rss {
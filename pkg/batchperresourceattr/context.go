@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchperresourceattr // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchperresourceattr"
+
+import "context"
+
+type ctxKey struct {
+	attrKey string
+}
+
+// NewContext returns a context carrying the value of the resource attribute
+// shared by all the resources of the batch consumed with it.
+func NewContext(ctx context.Context, attrKey string, attrVal string) context.Context {
+	return context.WithValue(ctx, ctxKey{attrKey: attrKey}, attrVal)
+}
+
+// FromContext returns the value of the resource attribute shared by all the resources
+// of the batch consumed with the context, if the batch was split per value of the attribute.
+func FromContext(ctx context.Context, attrKey string) (string, bool) {
+	attrVal, ok := ctx.Value(ctxKey{attrKey: attrKey}).(string)
+	return attrVal, ok
+}
+
+// newAttrContext returns a context carrying the attribute value, unless the
+// resources of the batch don't have the attribute.
+func newAttrContext(ctx context.Context, attrKey string, attrVal string) context.Context {
+	if attrVal == "" {
+		return ctx
+	}
+	return NewContext(ctx, attrKey, attrVal)
+}
@@ -17,12 +17,14 @@ package adapter // import "github.com/open-telemetry/opentelemetry-collector-con
 import (
 	"context"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
 )
 
@@ -36,6 +38,9 @@ type LogReceiverType interface {
 
 // NewFactory creates a factory for a Stanza-based receiver
 func NewFactory(logReceiverType LogReceiverType, sl component.StabilityLevel) component.ReceiverFactory {
+	// Register the views of the per-operator metrics recorded by stanza pipelines
+	_ = view.Register(helper.MetricViews()...)
+
 	return component.NewReceiverFactory(
 		logReceiverType.Type(),
 		logReceiverType.CreateDefaultConfig,
@@ -62,6 +67,11 @@ func createLogsReceiver(logReceiverType LogReceiverType) component.CreateLogsRec
 		}
 
 		operators := append([]operator.Config{*inputCfg}, operatorCfgs...)
+		for _, op := range operators {
+			if receiverAware, ok := op.Builder.(operator.ReceiverAware); ok {
+				receiverAware.SetReceiverID(cfg.ID().String())
+			}
+		}
 
 		emitterOpts := []LogEmitterOption{
 			LogEmitterWithLogger(params.Logger.Sugar()),
@@ -102,6 +112,7 @@ func createLogsReceiver(logReceiverType LogReceiverType) component.CreateLogsRec
 			emitter:   emitter,
 			consumer:  nextConsumer,
 			logger:    params.Logger,
+			telemetry: params.TelemetrySettings,
 			converter: converter,
 			obsrecv:   obsrecv,
 			storageID: baseCfg.StorageID,
@@ -27,6 +27,7 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
 )
 
@@ -40,6 +41,7 @@ type receiver struct {
 	consumer  consumer.Logs
 	converter *Converter
 	logger    *zap.Logger
+	telemetry component.TelemetrySettings
 	obsrecv   *obsreport.Receiver
 
 	storageID     *config.ComponentID
@@ -59,6 +61,12 @@ func (r *receiver) Start(ctx context.Context, host component.Host) error {
 		return fmt.Errorf("storage client: %w", err)
 	}
 
+	for _, op := range r.pipe.Operators() {
+		if hostAware, ok := op.(operator.HostAware); ok {
+			hostAware.SetHost(host, r.telemetry)
+		}
+	}
+
 	if err := r.pipe.Start(r.storageClient); err != nil {
 		return fmt.Errorf("start stanza: %w", err)
 	}
@@ -35,6 +35,13 @@ type Builder interface {
 	SetID(string)
 }
 
+// ReceiverAware is an optional interface implemented by builders whose operators
+// identify the collector receiver running them in their metrics. The receiver ID
+// is provided before the operator is built.
+type ReceiverAware interface {
+	SetReceiverID(string)
+}
+
 // UnmarshalJSON will unmarshal a config from JSON.
 func (c *Config) UnmarshalJSON(bytes []byte) error {
 	var typeUnmarshaller struct {
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helper // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	receiverKey     = tag.MustNewKey("receiver")
+	operatorIDKey   = tag.MustNewKey("operator_id")
+	operatorTypeKey = tag.MustNewKey("operator_type")
+	reasonKey       = tag.MustNewKey("reason")
+
+	mEntriesProcessed  = stats.Int64("stanza_operator_entries_processed", "Number of entries processed by an operator", stats.UnitDimensionless)
+	mEntriesErrored    = stats.Int64("stanza_operator_entries_errored", "Number of entries an operator failed to process", stats.UnitDimensionless)
+	mEntriesDropped    = stats.Int64("stanza_operator_entries_dropped", "Number of entries dropped by an operator", stats.UnitDimensionless)
+	mProcessingLatency = stats.Float64("stanza_operator_processing_latency", "Time an operator spent processing an entry", stats.UnitMilliseconds)
+)
+
+const (
+	// DropReasonError is the reason recorded for entries dropped after an error.
+	DropReasonError = "error"
+
+	// DropReasonFiltered is the reason recorded for entries dropped intentionally, e.g. by a filter.
+	DropReasonFiltered = "filtered"
+)
+
+// MetricViews returns the views of the metrics recorded by stanza operators.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{receiverKey, operatorIDKey, operatorTypeKey}
+	return []*view.View{
+		{
+			Name:        mEntriesProcessed.Name(),
+			Measure:     mEntriesProcessed,
+			Description: mEntriesProcessed.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mEntriesErrored.Name(),
+			Measure:     mEntriesErrored,
+			Description: mEntriesErrored.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mEntriesDropped.Name(),
+			Measure:     mEntriesDropped,
+			Description: mEntriesDropped.Description(),
+			TagKeys:     append(tagKeys, reasonKey),
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mProcessingLatency.Name(),
+			Measure:     mProcessingLatency,
+			Description: mProcessingLatency.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Distribution(0, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 50, 100),
+		},
+	}
+}
+
+// newMetricTags returns the tags identifying an operator in recorded metrics.
+// The receiver is unknown when the operator isn't run by a collector receiver.
+func newMetricTags(receiverID, operatorID, operatorType string) []tag.Mutator {
+	mutators := []tag.Mutator{
+		tag.Upsert(operatorIDKey, operatorID),
+		tag.Upsert(operatorTypeKey, operatorType),
+	}
+	if receiverID != "" {
+		mutators = append(mutators, tag.Upsert(receiverKey, receiverID))
+	}
+	// Appending further tags must not share the backing array between records.
+	return mutators[:len(mutators):len(mutators)]
+}
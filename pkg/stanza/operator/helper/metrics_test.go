@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helper
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestTransformerMetrics(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	cfg := NewTransformerConfig("metrics-test-id", "metrics-test-type")
+	cfg.OnError = DropOnError
+	cfg.SetReceiverID("filelog/metrics")
+	transformer, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	transformer.OutputOperators = []operator.Operator{testutil.NewFakeOutput(t)}
+
+	for i := 0; i < 5; i++ {
+		e := entry.New()
+		e.Body = i
+		_ = transformer.ProcessWith(context.Background(), e, func(e *entry.Entry) error {
+			if e.Body.(int)%2 == 0 {
+				return fmt.Errorf("Failure")
+			}
+			return nil
+		})
+	}
+	transformer.RecordDropped(context.Background(), DropReasonFiltered)
+
+	expectedTags := []tag.Tag{
+		{Key: operatorIDKey, Value: "metrics-test-id"},
+		{Key: operatorTypeKey, Value: "metrics-test-type"},
+		{Key: receiverKey, Value: "filelog/metrics"},
+	}
+
+	require.Equal(t, float64(5), sumValue(t, mEntriesProcessed.Name(), expectedTags))
+	require.Equal(t, float64(3), sumValue(t, mEntriesErrored.Name(), expectedTags))
+	require.Equal(t, float64(3), sumValue(t, mEntriesDropped.Name(), append(expectedTags, tag.Tag{Key: reasonKey, Value: DropReasonError})))
+	require.Equal(t, float64(1), sumValue(t, mEntriesDropped.Name(), append(expectedTags, tag.Tag{Key: reasonKey, Value: DropReasonFiltered})))
+
+	rows, err := view.RetrieveData(mProcessingLatency.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.ElementsMatch(t, expectedTags, rows[0].Tags)
+	require.Equal(t, int64(5), rows[0].Data.(*view.DistributionData).Count)
+}
+
+func TestTransformerMetricsPerReceiver(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	for i, receiverID := range []string{"", "filelog/a", "filelog/b"} {
+		cfg := NewTransformerConfig("json_parser", "json_parser")
+		cfg.SetReceiverID(receiverID)
+		transformer, err := cfg.Build(testutil.Logger(t))
+		require.NoError(t, err)
+		transformer.OutputOperators = []operator.Operator{testutil.NewFakeOutput(t)}
+
+		for j := 0; j <= i; j++ {
+			_ = transformer.ProcessWith(context.Background(), entry.New(), func(e *entry.Entry) error { return nil })
+		}
+	}
+
+	operatorTags := []tag.Tag{
+		{Key: operatorIDKey, Value: "json_parser"},
+		{Key: operatorTypeKey, Value: "json_parser"},
+	}
+	require.Equal(t, float64(1), sumValue(t, mEntriesProcessed.Name(), operatorTags))
+	require.Equal(t, float64(2), sumValue(t, mEntriesProcessed.Name(), append(operatorTags, tag.Tag{Key: receiverKey, Value: "filelog/a"})))
+	require.Equal(t, float64(3), sumValue(t, mEntriesProcessed.Name(), append(operatorTags, tag.Tag{Key: receiverKey, Value: "filelog/b"})))
+}
+
+func sumValue(t *testing.T, viewName string, tags []tag.Tag) float64 {
+	rows, err := view.RetrieveData(viewName)
+	require.NoError(t, err)
+	for _, row := range rows {
+		if tagsEqual(row.Tags, tags) {
+			return row.Data.(*view.SumData).Value
+		}
+	}
+	require.FailNow(t, "no data recorded", "view %s with tags %v", viewName, tags)
+	return 0
+}
+
+// tagsEqual compares the tags regardless of their order, as the views sort them by key.
+func tagsEqual(a, b []tag.Tag) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	values := make(map[tag.Key]string, len(a))
+	for _, t := range a {
+		values[t.Key] = t.Value
+	}
+	for _, t := range b {
+		if v, ok := values[t.Key]; !ok || v != t.Value {
+			return false
+		}
+	}
+	return true
+}
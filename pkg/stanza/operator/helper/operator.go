@@ -33,8 +33,12 @@ func NewBasicConfig(operatorID, operatorType string) BasicConfig {
 type BasicConfig struct {
 	OperatorID   string `mapstructure:"id"   json:"id"   yaml:"id"`
 	OperatorType string `mapstructure:"type" json:"type" yaml:"type"`
+
+	receiverID string
 }
 
+var _ operator.ReceiverAware = (*BasicConfig)(nil)
+
 // ID will return the operator id.
 func (c BasicConfig) ID() string {
 	if c.OperatorID == "" {
@@ -48,6 +52,11 @@ func (c *BasicConfig) SetID(id string) {
 	c.OperatorID = id
 }
 
+// SetReceiverID will set the id of the receiver running the operator.
+func (c *BasicConfig) SetReceiverID(id string) {
+	c.receiverID = id
+}
+
 // Type will return the operator type.
 func (c BasicConfig) Type() string {
 	return c.OperatorType
@@ -17,6 +17,7 @@ package helper // import "github.com/open-telemetry/opentelemetry-collector-cont
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -111,26 +112,33 @@ func (p *ParserOperator) ProcessWith(ctx context.Context, entry *entry.Entry, pa
 }
 
 func (p *ParserOperator) ProcessWithCallback(ctx context.Context, entry *entry.Entry, parse ParseFunction, cb func(*entry.Entry) error) error {
+	start := time.Now()
+
 	// Short circuit if the "if" condition does not match
 	skip, err := p.Skip(ctx, entry)
 	if err != nil {
+		p.RecordProcessed(ctx, start)
 		return p.HandleEntryError(ctx, entry, err)
 	}
 	if skip {
+		p.RecordProcessed(ctx, start)
 		p.Write(ctx, entry)
 		return nil
 	}
 
 	if err = p.ParseWith(ctx, entry, parse); err != nil {
+		p.RecordProcessed(ctx, start)
 		return err
 	}
 	if cb != nil {
 		err = cb(entry)
 		if err != nil {
+			p.RecordProcessed(ctx, start)
 			return err
 		}
 	}
 
+	p.RecordProcessed(ctx, start)
 	p.Write(ctx, entry)
 	return nil
 }
@@ -17,13 +17,17 @@ package helper // import "github.com/open-telemetry/opentelemetry-collector-cont
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/antonmedv/expr"
 	"github.com/antonmedv/expr/vm"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/errors"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 )
 
 // NewTransformerConfig creates a new transformer config with default values
@@ -37,8 +41,9 @@ func NewTransformerConfig(operatorID, operatorType string) TransformerConfig {
 // TransformerConfig provides a basic implementation of a transformer config.
 type TransformerConfig struct {
 	WriterConfig `mapstructure:",squash"  yaml:",inline"`
-	OnError      string `mapstructure:"on_error" json:"on_error" yaml:"on_error"`
-	IfExpr       string `mapstructure:"if"       json:"if"       yaml:"if"`
+	OnError      string `mapstructure:"on_error"    json:"on_error"              yaml:"on_error"`
+	IfExpr       string `mapstructure:"if"          json:"if"                    yaml:"if"`
+	DeadLetter   string `mapstructure:"dead_letter" json:"dead_letter,omitempty" yaml:"dead_letter,omitempty"`
 }
 
 // Build will build a transformer operator.
@@ -58,9 +63,19 @@ func (c TransformerConfig) Build(logger *zap.SugaredLogger) (TransformerOperator
 		)
 	}
 
+	if c.DeadLetter != "" && c.DeadLetter == c.ID() {
+		return TransformerOperator{}, errors.NewError(
+			"operator config has an invalid `dead_letter` field.",
+			"ensure that the `dead_letter` field refers to a different operator.",
+			"dead_letter", c.DeadLetter,
+		)
+	}
+
 	transformerOperator := TransformerOperator{
 		WriterOperator: writerOperator,
 		OnError:        c.OnError,
+		DeadLetterID:   c.DeadLetter,
+		metricTags:     newMetricTags(c.receiverID, c.ID(), c.Type()),
 	}
 
 	if c.IfExpr != "" {
@@ -77,8 +92,12 @@ func (c TransformerConfig) Build(logger *zap.SugaredLogger) (TransformerOperator
 // TransformerOperator provides a basic implementation of a transformer operator.
 type TransformerOperator struct {
 	WriterOperator
-	OnError string
-	IfExpr  *vm.Program
+	OnError            string
+	IfExpr             *vm.Program
+	DeadLetterID       string
+	DeadLetterOperator operator.Operator
+
+	metricTags []tag.Mutator
 }
 
 // CanProcess will always return true for a transformer operator.
@@ -86,28 +105,94 @@ func (t *TransformerOperator) CanProcess() bool {
 	return true
 }
 
+// SetOutputs will set the outputs of the operator, including its dead letter output.
+func (t *TransformerOperator) SetOutputs(operators []operator.Operator) error {
+	if err := t.WriterOperator.SetOutputs(operators); err != nil {
+		return err
+	}
+
+	if t.DeadLetterID == "" {
+		return nil
+	}
+
+	deadLetter, ok := t.findOperator(operators, t.DeadLetterID)
+	if !ok {
+		return fmt.Errorf("dead letter operator '%s' does not exist", t.DeadLetterID)
+	}
+
+	if !deadLetter.CanProcess() {
+		return fmt.Errorf("dead letter operator '%s' can not process entries", t.DeadLetterID)
+	}
+
+	t.DeadLetterOperator = deadLetter
+	return nil
+}
+
+// DeadLetter returns the operator that receives entries which failed processing.
+func (t *TransformerOperator) DeadLetter() operator.Operator {
+	return t.DeadLetterOperator
+}
+
 // ProcessWith will process an entry with a transform function.
 func (t *TransformerOperator) ProcessWith(ctx context.Context, entry *entry.Entry, transform TransformFunction) error {
+	start := time.Now()
+
 	// Short circuit if the "if" condition does not match
 	skip, err := t.Skip(ctx, entry)
 	if err != nil {
+		t.RecordProcessed(ctx, start)
 		return t.HandleEntryError(ctx, entry, err)
 	}
 	if skip {
+		t.RecordProcessed(ctx, start)
 		t.Write(ctx, entry)
 		return nil
 	}
 
-	if err := transform(entry); err != nil {
+	err = transform(entry)
+	t.RecordProcessed(ctx, start)
+	if err != nil {
 		return t.HandleEntryError(ctx, entry, err)
 	}
 	t.Write(ctx, entry)
 	return nil
 }
 
+// RecordProcessed records that an entry was processed, along with the time
+// spent processing it since start.
+func (t *TransformerOperator) RecordProcessed(ctx context.Context, start time.Time) {
+	_ = stats.RecordWithTags(ctx, t.metricTags,
+		mEntriesProcessed.M(1),
+		mProcessingLatency.M(float64(time.Since(start))/float64(time.Millisecond)),
+	)
+}
+
+// RecordDropped records that an entry was dropped for the given reason.
+func (t *TransformerOperator) RecordDropped(ctx context.Context, reason string) {
+	_ = stats.RecordWithTags(ctx, append(t.metricTags, tag.Upsert(reasonKey, reason)), mEntriesDropped.M(1))
+}
+
 // HandleEntryError will handle an entry error using the on_error strategy.
+// If a dead letter operator is configured, the entry is also sent to it
+// with the error attached as attributes.
 func (t *TransformerOperator) HandleEntryError(ctx context.Context, entry *entry.Entry, err error) error {
 	t.Errorw("Failed to process entry", zap.Any("error", err), zap.Any("action", t.OnError), zap.Any("entry", entry))
+
+	_ = stats.RecordWithTags(ctx, t.metricTags, mEntriesErrored.M(1))
+	if t.OnError != SendOnError && t.DeadLetterOperator == nil {
+		t.RecordDropped(ctx, DropReasonError)
+	}
+
+	if t.DeadLetterOperator != nil {
+		deadLetter := entry
+		if t.OnError == SendOnError {
+			deadLetter = entry.Copy()
+		}
+		deadLetter.AddAttribute(DeadLetterErrorAttribute, err.Error())
+		deadLetter.AddAttribute(DeadLetterOperatorAttribute, t.ID())
+		_ = t.DeadLetterOperator.Process(ctx, deadLetter)
+	}
+
 	if t.OnError == SendOnError {
 		t.Write(ctx, entry)
 	}
@@ -138,3 +223,11 @@ const SendOnError = "send"
 
 // DropOnError specifies an on_error mode for dropping entries after an error.
 const DropOnError = "drop"
+
+// DeadLetterErrorAttribute is the attribute holding the processing error
+// of an entry sent to a dead letter operator.
+const DeadLetterErrorAttribute = "error.message"
+
+// DeadLetterOperatorAttribute is the attribute holding the ID of the operator
+// that failed to process an entry sent to a dead letter operator.
+const DeadLetterOperatorAttribute = "error.operator_id"
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -232,3 +233,116 @@ func TestTransformerIf(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestTransformerDeadLetterInvalid(t *testing.T) {
+	cfg := NewTransformerConfig("test", "test")
+	cfg.DeadLetter = "test"
+	_, err := cfg.Build(testutil.Logger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "operator config has an invalid `dead_letter` field.")
+}
+
+func TestTransformerSetOutputsDeadLetter(t *testing.T) {
+	output := testutil.NewMockOperator("test-output")
+	deadLetter := testutil.NewMockOperator("test-dead-letter")
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := NewTransformerConfig("test", "test")
+		cfg.OutputIDs = []string{"test-output"}
+		cfg.DeadLetter = "test-dead-letter"
+		transformer, err := cfg.Build(testutil.Logger(t))
+		require.NoError(t, err)
+
+		require.NoError(t, transformer.SetOutputs([]operator.Operator{output, deadLetter}))
+		require.Equal(t, []operator.Operator{output}, transformer.Outputs())
+		require.Equal(t, deadLetter, transformer.DeadLetter())
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		cfg := NewTransformerConfig("test", "test")
+		cfg.OutputIDs = []string{"test-output"}
+		cfg.DeadLetter = "missing"
+		transformer, err := cfg.Build(testutil.Logger(t))
+		require.NoError(t, err)
+
+		err = transformer.SetOutputs([]operator.Operator{output, deadLetter})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "dead letter operator 'missing' does not exist")
+	})
+
+	t.Run("CannotProcess", func(t *testing.T) {
+		input := &testutil.Operator{}
+		input.On("ID").Return("test-input")
+		input.On("CanProcess").Return(false)
+
+		cfg := NewTransformerConfig("test", "test")
+		cfg.DeadLetter = "test-input"
+		transformer, err := cfg.Build(testutil.Logger(t))
+		require.NoError(t, err)
+
+		err = transformer.SetOutputs([]operator.Operator{input})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "can not process entries")
+	})
+}
+
+func TestTransformerDeadLetterOnError(t *testing.T) {
+	cases := []struct {
+		name         string
+		onError      string
+		expectOutput bool
+	}{
+		{
+			name:         "Send",
+			onError:      SendOnError,
+			expectOutput: true,
+		},
+		{
+			name:    "Drop",
+			onError: DropOnError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewTransformerConfig("test-id", "test-type")
+			cfg.OnError = tc.onError
+			cfg.DeadLetter = "test-dead-letter"
+			transformer, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			output := testutil.NewFakeOutput(t)
+			deadLetter := testutil.NewFakeOutput(t)
+			transformer.OutputOperators = []operator.Operator{output}
+			transformer.DeadLetterOperator = deadLetter
+
+			testEntry := entry.New()
+			testEntry.Body = "test"
+			err = transformer.ProcessWith(context.Background(), testEntry, func(e *entry.Entry) error {
+				return fmt.Errorf("Failure")
+			})
+			require.Error(t, err)
+
+			select {
+			case e := <-deadLetter.Received:
+				require.Equal(t, "test", e.Body)
+				require.Equal(t, "Failure", e.Attributes[DeadLetterErrorAttribute])
+				require.Equal(t, "test-id", e.Attributes[DeadLetterOperatorAttribute])
+			case <-time.After(time.Second):
+				require.FailNow(t, "Timed out waiting for dead letter entry")
+			}
+
+			if tc.expectOutput {
+				select {
+				case e := <-output.Received:
+					require.Equal(t, "test", e.Body)
+					require.Nil(t, e.Attributes)
+				case <-time.After(time.Second):
+					require.FailNow(t, "Timed out waiting for entry")
+				}
+			} else {
+				output.ExpectNoEntry(t, 100*time.Millisecond)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configtls"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper/operatortest"
+)
+
+func TestConfig(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "all",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Endpoint = "0.0.0.0:8080"
+					cfg.Path = "/logs"
+					cfg.MaxLogSize = helper.ByteSize(128 * 1000)
+					cfg.AddAttributes = true
+					cfg.Encoding = helper.EncodingConfig{Encoding: "utf-16le"}
+					cfg.MaxRequestBodySize = 1048576
+					cfg.IncludeMetadata = true
+					return cfg
+				}(),
+			},
+			{
+				Name: "tls",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Endpoint = "0.0.0.0:8443"
+					cfg.TLSSetting = &configtls.TLSServerSetting{
+						TLSSetting: configtls.TLSSetting{
+							CertFile: "/tmp/cert.pem",
+							KeyFile:  "/tmp/key.pem",
+						},
+					}
+					return cfg
+				}(),
+			},
+			{
+				Name: "auth",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Endpoint = "0.0.0.0:8080"
+					cfg.Auth = &configauth.Authentication{
+						AuthenticatorID: config.NewComponentID("basicauth"),
+					}
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
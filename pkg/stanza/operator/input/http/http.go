@@ -0,0 +1,352 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/http"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "http_input"
+
+	// minMaxLogSize is the minimal size which can be used for buffering
+	// a single line of HTTP input
+	minMaxLogSize = 64 * 1024
+
+	// DefaultMaxLogSize is the max buffer sized used
+	// if MaxLogSize is not set
+	DefaultMaxLogSize = 1024 * 1024
+
+	// DefaultMaxRequestBodySize is the max size of a request body
+	// if MaxRequestBodySize is not set
+	DefaultMaxRequestBodySize = 20 * 1024 * 1024
+
+	// DefaultPath is the URL path logs are accepted on if Path is not set
+	DefaultPath = "/"
+)
+
+var errRequestBodyTooLarge = errors.New("request body too large")
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new HTTP input config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new HTTP input config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		InputConfig: helper.NewInputConfig(operatorID, operatorType),
+		BaseConfig: BaseConfig{
+			Path:     DefaultPath,
+			Encoding: helper.NewEncodingConfig(),
+		},
+	}
+}
+
+// Config is the configuration of an http input operator.
+type Config struct {
+	helper.InputConfig `mapstructure:",squash" yaml:",inline"`
+	BaseConfig         `mapstructure:",squash" yaml:",inline"`
+}
+
+// BaseConfig is the detailed configuration of an http input operator.
+type BaseConfig struct {
+	confighttp.HTTPServerSettings `mapstructure:",squash"`
+
+	Path          string                `mapstructure:"path,omitempty"           json:"path,omitempty"           yaml:"path,omitempty"`
+	MaxLogSize    helper.ByteSize       `mapstructure:"max_log_size,omitempty"   json:"max_log_size,omitempty"   yaml:"max_log_size,omitempty"`
+	AddAttributes bool                  `mapstructure:"add_attributes,omitempty" json:"add_attributes,omitempty" yaml:"add_attributes,omitempty"`
+	Encoding      helper.EncodingConfig `mapstructure:",squash,omitempty"        json:",inline,omitempty"        yaml:",inline,omitempty"`
+}
+
+// Build will build an http input operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	inputOperator, err := c.InputConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// If MaxLogSize not set, set sane default
+	if c.MaxLogSize == 0 {
+		c.MaxLogSize = DefaultMaxLogSize
+	}
+
+	if c.MaxLogSize < minMaxLogSize {
+		return nil, fmt.Errorf("invalid value for parameter 'max_log_size', must be equal to or greater than %d bytes", minMaxLogSize)
+	}
+
+	// The request body size is limited by the handler rather than the server,
+	// to reply with the appropriate status code.
+	serverSettings := c.HTTPServerSettings
+	maxRequestBodySize := serverSettings.MaxRequestBodySize
+	if maxRequestBodySize == 0 {
+		maxRequestBodySize = DefaultMaxRequestBodySize
+	}
+	serverSettings.MaxRequestBodySize = 0
+
+	if c.Endpoint == "" {
+		return nil, fmt.Errorf("missing required parameter 'endpoint'")
+	}
+
+	if _, err = net.ResolveTCPAddr("tcp", c.Endpoint); err != nil {
+		return nil, fmt.Errorf("failed to resolve endpoint: %w", err)
+	}
+
+	if c.Path == "" {
+		c.Path = DefaultPath
+	}
+
+	if !strings.HasPrefix(c.Path, "/") {
+		return nil, fmt.Errorf("invalid value for parameter 'path', must start with '/'")
+	}
+
+	encoding, err := c.Encoding.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Input{
+		InputOperator:      inputOperator,
+		serverSettings:     serverSettings,
+		path:               c.Path,
+		maxLogSize:         int(c.MaxLogSize),
+		maxRequestBodySize: maxRequestBodySize,
+		addAttributes:      c.AddAttributes,
+		encoding:           encoding,
+	}, nil
+}
+
+// Input is an operator that receives log entries over http.
+type Input struct {
+	helper.InputOperator
+	serverSettings     confighttp.HTTPServerSettings
+	path               string
+	maxLogSize         int
+	maxRequestBodySize int64
+	addAttributes      bool
+	encoding           helper.Encoding
+
+	host      component.Host
+	telemetry component.TelemetrySettings
+
+	listener net.Listener
+	server   *http.Server
+	wg       sync.WaitGroup
+}
+
+var _ operator.HostAware = (*Input)(nil)
+
+// SetHost provides the collector host used to look up authenticator extensions.
+func (t *Input) SetHost(host component.Host, settings component.TelemetrySettings) {
+	t.host = host
+	t.telemetry = settings
+}
+
+// Start will start listening for log entries over http.
+func (t *Input) Start(_ operator.Persister) error {
+	if t.serverSettings.Auth != nil && t.host == nil {
+		return fmt.Errorf("authentication requires the operator to be run by a collector receiver")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(t.path, t.handleRequest)
+
+	server, err := t.serverSettings.ToServer(t.host, t.telemetry, mux)
+	if err != nil {
+		return fmt.Errorf("failed to create http server: %w", err)
+	}
+
+	listener, err := t.serverSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to listen on interface: %w", err)
+	}
+
+	t.server = server
+	t.listener = listener
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		if err := t.server.Serve(t.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			t.Errorw("Server failed", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// handleRequest converts the body of a request into entries. JSON requests
+// may contain a single value or an array of values, each becoming an entry.
+// Any other content is treated as newline delimited text.
+func (t *Input) handleRequest(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req.Body = &limitedBody{
+		ReadCloser: http.MaxBytesReader(w, req.Body, t.maxRequestBodySize),
+		limit:      t.maxRequestBodySize,
+	}
+
+	var bodies []interface{}
+	var err error
+	if isJSON(req.Header.Get("Content-Type")) {
+		bodies, err = t.readJSON(req)
+	} else {
+		bodies, err = t.readText(req)
+	}
+	switch {
+	case errors.Is(err, errRequestBodyTooLarge):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for _, body := range bodies {
+		e, err := t.NewEntry(body)
+		if err != nil {
+			t.Errorw("Failed to create entry", zap.Error(err))
+			continue
+		}
+
+		if t.addAttributes {
+			t.addRequestAttributes(e, req)
+		}
+
+		t.Write(req.Context(), e)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (t *Input) readJSON(req *http.Request) ([]interface{}, error) {
+	var value interface{}
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("decode json body: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("decode json body: unexpected data after top-level value")
+	}
+
+	if values, ok := value.([]interface{}); ok {
+		return values, nil
+	}
+	return []interface{}{value}, nil
+}
+
+func (t *Input) readText(req *http.Request) ([]interface{}, error) {
+	var bodies []interface{}
+
+	scanner := bufio.NewScanner(req.Body)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), t.maxLogSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		decoded, err := t.encoding.Decode(line)
+		if err != nil {
+			return nil, fmt.Errorf("decode body: %w", err)
+		}
+		bodies = append(bodies, string(decoded))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+	return bodies, nil
+}
+
+func (t *Input) addRequestAttributes(e *entry.Entry, req *http.Request) {
+	e.AddAttribute("net.transport", "IP.TCP")
+	if host, port, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+		e.AddAttribute("net.peer.ip", host)
+		e.AddAttribute("net.peer.port", port)
+	}
+	if addr, ok := req.Context().Value(http.LocalAddrContextKey).(*net.TCPAddr); ok {
+		e.AddAttribute("net.host.ip", addr.IP.String())
+		e.AddAttribute("net.host.port", strconv.FormatInt(int64(addr.Port), 10))
+	}
+	e.AddAttribute("http.method", req.Method)
+	e.AddAttribute("http.target", req.URL.RequestURI())
+	if userAgent := req.UserAgent(); userAgent != "" {
+		e.AddAttribute("http.user_agent", userAgent)
+	}
+}
+
+// limitedBody reports the errors of the http.MaxBytesReader it wraps as
+// errRequestBodyTooLarge once the limit has been reached.
+type limitedBody struct {
+	io.ReadCloser
+	limit int64
+	read  int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	if err != nil && !errors.Is(err, io.EOF) && b.read >= b.limit {
+		err = errRequestBodyTooLarge
+	}
+	return n, err
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// Stop will stop listening for log entries over http.
+func (t *Input) Stop() error {
+	if t.server != nil {
+		if err := t.server.Shutdown(context.Background()); err != nil {
+			t.Errorf("failed to shutdown http server: %s", err)
+		}
+	}
+
+	t.wg.Wait()
+	return nil
+}
@@ -0,0 +1,342 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestInput(t *testing.T, cfg *Config) (*Input, *testutil.FakeOutput) {
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	httpInput := op.(*Input)
+	httpInput.OutputOperators = []operator.Operator{fake}
+	return httpInput, fake
+}
+
+func startTestInput(t *testing.T, httpInput *Input) string {
+	require.NoError(t, httpInput.Start(testutil.NewMockPersister("test")))
+	t.Cleanup(func() {
+		require.NoError(t, httpInput.Stop(), "expected to stop http input operator without error")
+	})
+	return "http://" + httpInput.listener.Addr().String()
+}
+
+func post(t *testing.T, url, contentType, body string) *http.Response {
+	resp, err := http.Post(url, contentType, strings.NewReader(body)) // #nosec G107 - test url
+	require.NoError(t, err)
+	_, err = io.Copy(io.Discard, resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp
+}
+
+func TestBuild(t *testing.T) {
+	cases := []struct {
+		name      string
+		modify    func(*Config)
+		expectErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:      "missing_endpoint",
+			modify:    func(cfg *Config) { cfg.Endpoint = "" },
+			expectErr: "missing required parameter 'endpoint'",
+		},
+		{
+			name:      "invalid_endpoint",
+			modify:    func(cfg *Config) { cfg.Endpoint = "localhost" },
+			expectErr: "failed to resolve endpoint",
+		},
+		{
+			name:      "invalid_path",
+			modify:    func(cfg *Config) { cfg.Path = "logs" },
+			expectErr: "must start with '/'",
+		},
+		{
+			name:      "max_log_size_too_small",
+			modify:    func(cfg *Config) { cfg.MaxLogSize = 1024 },
+			expectErr: "invalid value for parameter 'max_log_size'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test_id")
+			cfg.Endpoint = "localhost:0"
+			tc.modify(cfg)
+
+			_, err := cfg.Build(testutil.Logger(t))
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestInput(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    []interface{}
+	}{
+		{
+			name:        "text_single",
+			contentType: "text/plain",
+			body:        "message1",
+			expected:    []interface{}{"message1"},
+		},
+		{
+			name:        "text_multiple",
+			contentType: "text/plain",
+			body:        "message1\nmessage2\r\n\nmessage3\n",
+			expected:    []interface{}{"message1", "message2", "message3"},
+		},
+		{
+			name:        "no_content_type",
+			contentType: "",
+			body:        "message1\nmessage2",
+			expected:    []interface{}{"message1", "message2"},
+		},
+		{
+			name:        "json_object",
+			contentType: "application/json",
+			body:        `{"message":"hello","level":"info"}`,
+			expected: []interface{}{
+				map[string]interface{}{"message": "hello", "level": "info"},
+			},
+		},
+		{
+			name:        "json_array",
+			contentType: "application/json; charset=utf-8",
+			body:        `[{"message":"hello"},"plain",{"message":"world"}]`,
+			expected: []interface{}{
+				map[string]interface{}{"message": "hello"},
+				"plain",
+				map[string]interface{}{"message": "world"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test_id")
+			cfg.Endpoint = "localhost:0"
+			httpInput, fake := newTestInput(t, cfg)
+			url := startTestInput(t, httpInput)
+
+			resp := post(t, url, tc.contentType, tc.body)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			for _, expected := range tc.expected {
+				fake.ExpectBody(t, expected)
+			}
+			fake.ExpectNoEntry(t, 100*time.Millisecond)
+		})
+	}
+}
+
+func TestInputPath(t *testing.T) {
+	cfg := NewConfigWithID("test_id")
+	cfg.Endpoint = "localhost:0"
+	cfg.Path = "/logs"
+	httpInput, fake := newTestInput(t, cfg)
+	url := startTestInput(t, httpInput)
+
+	resp := post(t, url+"/other", "text/plain", "message1")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = post(t, url+"/logs", "text/plain", "message2")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	fake.ExpectBody(t, "message2")
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestInputRejects(t *testing.T) {
+	cfg := NewConfigWithID("test_id")
+	cfg.Endpoint = "localhost:0"
+	httpInput, fake := newTestInput(t, cfg)
+	url := startTestInput(t, httpInput)
+
+	resp, err := http.Get(url) // #nosec G107 - test url
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	resp = post(t, url, "application/json", `[{"message":"hello"}`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = post(t, url, "application/json", `{"message":"hello"} {"message":"world"}`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = post(t, url, "text/plain", strings.Repeat("a", DefaultMaxLogSize+1))
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestInputRequestBodyTooLarge(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{
+			name:        "json",
+			contentType: "application/json",
+			body:        `["` + strings.Repeat("a", 1024) + `"]`,
+		},
+		{
+			name:        "json_default",
+			contentType: "application/json",
+			body:        `"` + strings.Repeat("a", DefaultMaxRequestBodySize) + `"`,
+		},
+		{
+			name:        "text",
+			contentType: "text/plain",
+			body:        strings.Repeat("a\n", 1024),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test_id")
+			cfg.Endpoint = "localhost:0"
+			if tc.name != "json_default" {
+				cfg.MaxRequestBodySize = 1024
+			}
+			httpInput, fake := newTestInput(t, cfg)
+			url := startTestInput(t, httpInput)
+
+			resp := post(t, url, tc.contentType, tc.body)
+			require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+			fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+			resp = post(t, url, "text/plain", "message")
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			fake.ExpectBody(t, "message")
+		})
+	}
+}
+
+func TestInputAttributes(t *testing.T) {
+	cfg := NewConfigWithID("test_id")
+	cfg.Endpoint = "127.0.0.1:0"
+	cfg.Path = "/logs"
+	cfg.AddAttributes = true
+	httpInput, fake := newTestInput(t, cfg)
+	url := startTestInput(t, httpInput)
+
+	req, err := http.NewRequest(http.MethodPost, url+"/logs?source=test", strings.NewReader("message1"))
+	require.NoError(t, err)
+	req.Header.Set("User-Agent", "test-agent")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, "message1", e.Body)
+		require.Equal(t, "IP.TCP", e.Attributes["net.transport"])
+		require.Equal(t, "127.0.0.1", e.Attributes["net.peer.ip"])
+		require.NotEmpty(t, e.Attributes["net.peer.port"])
+		require.Equal(t, "127.0.0.1", e.Attributes["net.host.ip"])
+		require.Equal(t, httpInput.listener.Addr().String(), "127.0.0.1:"+e.Attributes["net.host.port"].(string))
+		require.Equal(t, "POST", e.Attributes["http.method"])
+		require.Equal(t, "/logs?source=test", e.Attributes["http.target"])
+		require.Equal(t, "test-agent", e.Attributes["http.user_agent"])
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry")
+	}
+}
+
+type authHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h authHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func TestInputAuth(t *testing.T) {
+	authID := config.NewComponentID("testauth")
+
+	cfg := NewConfigWithID("test_id")
+	cfg.Endpoint = "localhost:0"
+	cfg.Auth = &configauth.Authentication{AuthenticatorID: authID}
+
+	t.Run("no_host", func(t *testing.T) {
+		httpInput, _ := newTestInput(t, cfg)
+		require.ErrorContains(t, httpInput.Start(testutil.NewMockPersister("test")), "authentication requires")
+	})
+
+	t.Run("authenticated", func(t *testing.T) {
+		httpInput, fake := newTestInput(t, cfg)
+		httpInput.SetHost(authHost{
+			Host: componenttest.NewNopHost(),
+			extensions: map[config.ComponentID]component.Extension{
+				authID: configauth.NewServerAuthenticator(
+					configauth.WithAuthenticate(func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+						for _, v := range headers["Authorization"] {
+							if v == "secret" {
+								return ctx, nil
+							}
+						}
+						return ctx, errors.New("unauthorized")
+					}),
+				),
+			},
+		}, componenttest.NewNopTelemetrySettings())
+		url := startTestInput(t, httpInput)
+
+		resp := post(t, url, "text/plain", "rejected")
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+		req, err := http.NewRequest(http.MethodPost, url, strings.NewReader("accepted"))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "secret")
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		fake.ExpectBody(t, "accepted")
+	})
+}
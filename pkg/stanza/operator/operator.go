@@ -17,6 +17,7 @@ package operator // import "github.com/open-telemetry/opentelemetry-collector-co
 import (
 	"context"
 
+	"go.opentelemetry.io/collector/component"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
@@ -52,3 +53,17 @@ type Operator interface {
 	// Logger returns the operator's logger
 	Logger() *zap.SugaredLogger
 }
+
+// HostAware is an optional interface implemented by operators that need access
+// to the collector host, e.g. to look up authenticator extensions. The host is
+// provided before the operator is started.
+type HostAware interface {
+	SetHost(host component.Host, settings component.TelemetrySettings)
+}
+
+// DeadLetterOutput is an optional interface implemented by operators that can
+// route entries which failed processing to a dedicated operator.
+type DeadLetterOutput interface {
+	// DeadLetter returns the connected dead letter operator, or nil.
+	DeadLetter() Operator
+}
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cef // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/cef"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "cef_parser"
+
+	cefPrefix   = "CEF:"
+	headerCount = 7
+)
+
+// headerKeys are the keys of the CEF header fields, in order.
+var headerKeys = [headerCount]string{
+	"version",
+	"device_vendor",
+	"device_product",
+	"device_version",
+	"signature_id",
+	"name",
+	"severity",
+}
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new CEF parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new CEF parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig: helper.NewParserConfig(operatorID, operatorType),
+	}
+}
+
+// Config is the configuration of a CEF parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash" yaml:",inline"`
+}
+
+// Build will build a CEF parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	if c.ParserConfig.Config == nil {
+		severityConfig, err := defaultSeverityConfig(c.ParseTo)
+		if err != nil {
+			return nil, err
+		}
+		c.ParserConfig.Config = severityConfig
+	}
+
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Parser{
+		ParserOperator: parserOperator,
+	}, nil
+}
+
+// defaultSeverityConfig maps the CEF severity header, which is either an integer
+// from 0 to 10 or one of Low, Medium, High and Very-High, to a log severity.
+func defaultSeverityConfig(parseTo entry.Field) (*helper.SeverityConfig, error) {
+	parseFrom, err := entry.NewField(parseTo.String() + ".severity")
+	if err != nil {
+		return nil, fmt.Errorf("severity field: %w", err)
+	}
+
+	severityConfig := helper.NewSeverityConfig()
+	severityConfig.ParseFrom = &parseFrom
+	severityConfig.Mapping = map[interface{}]interface{}{
+		"info":  []interface{}{map[interface{}]interface{}{"min": 0, "max": 3}, "low"},
+		"warn":  []interface{}{map[interface{}]interface{}{"min": 4, "max": 6}, "medium"},
+		"error": []interface{}{map[interface{}]interface{}{"min": 7, "max": 8}, "high"},
+		"fatal": []interface{}{map[interface{}]interface{}{"min": 9, "max": 10}, "very-high"},
+	}
+	return &severityConfig, nil
+}
+
+// Parser is an operator that parses ArcSight Common Event Format messages.
+type Parser struct {
+	helper.ParserOperator
+}
+
+// Process will parse an entry as a CEF message.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ParserOperator.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a value as a CEF message.
+func (p *Parser) parse(value interface{}) (interface{}, error) {
+	switch m := value.(type) {
+	case string:
+		return parseCEF(m)
+	default:
+		return nil, fmt.Errorf("type %T cannot be parsed as CEF", value)
+	}
+}
+
+// parseCEF parses a CEF message of the form
+// CEF:Version|Device Vendor|Device Product|Device Version|Signature ID|Name|Severity|Extension
+// Any syslog header preceding the CEF prefix is ignored.
+func parseCEF(input string) (map[string]interface{}, error) {
+	start := strings.Index(input, cefPrefix)
+	if start < 0 {
+		return nil, fmt.Errorf("missing '%s' prefix", cefPrefix)
+	}
+	input = input[start+len(cefPrefix):]
+
+	parsed := make(map[string]interface{}, headerCount+1)
+
+	var field strings.Builder
+	fieldIndex := 0
+	escaped := false
+	pos := 0
+	for ; pos < len(input) && fieldIndex < headerCount; pos++ {
+		c := input[pos]
+		switch {
+		case escaped:
+			// Only pipes and backslashes are escaped in the header
+			if c != '|' && c != '\\' {
+				field.WriteByte('\\')
+			}
+			field.WriteByte(c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '|':
+			parsed[headerKeys[fieldIndex]] = field.String()
+			field.Reset()
+			fieldIndex++
+		default:
+			field.WriteByte(c)
+		}
+	}
+
+	if fieldIndex < headerCount {
+		return nil, fmt.Errorf("expected %d header fields, got %d", headerCount, fieldIndex)
+	}
+
+	parsed["extensions"] = parseExtension(input[pos:])
+	return parsed, nil
+}
+
+// parseExtension parses the space separated key=value pairs of the CEF extension.
+// Values may contain spaces as well as the escaped characters \=, \\, \n and \r.
+func parseExtension(extension string) map[string]interface{} {
+	extensions := make(map[string]interface{})
+
+	// Find the position of each key by looking for unescaped equal signs
+	// preceded by a valid key.
+	type keyPos struct {
+		key        string
+		start, end int
+	}
+	var keys []keyPos
+	for i := 0; i < len(extension); i++ {
+		switch extension[i] {
+		case '\\':
+			i++
+		case '=':
+			keyStart := i
+			for keyStart > 0 && isKeyChar(extension[keyStart-1]) {
+				keyStart--
+			}
+			if keyStart == i || (keyStart > 0 && extension[keyStart-1] != ' ') {
+				continue
+			}
+			keys = append(keys, keyPos{key: extension[keyStart:i], start: keyStart, end: i + 1})
+		}
+	}
+
+	for i, k := range keys {
+		valueEnd := len(extension)
+		if i+1 < len(keys) {
+			valueEnd = keys[i+1].start
+		}
+		extensions[k.key] = unescapeValue(strings.TrimRight(extension[k.end:valueEnd], " "))
+	}
+	return extensions
+}
+
+func isKeyChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+		c == '_' || c == '.' || c == '-' || c == '[' || c == ']'
+}
+
+var extensionReplacer = strings.NewReplacer(`\=`, `=`, `\\`, `\`, `\n`, "\n", `\r`, "\r")
+
+func unescapeValue(value string) string {
+	return extensionReplacer.Replace(value)
+}
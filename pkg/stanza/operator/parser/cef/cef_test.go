@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cef
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestCEFImplementations(t *testing.T) {
+	require.Implements(t, (*operator.Operator)(nil), new(Parser))
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "type []int cannot be parsed as CEF")
+}
+
+func TestParseCEF(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected map[string]interface{}
+		errorMsg string
+	}{
+		{
+			name:  "simple",
+			input: `CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 spt=1232`,
+			expected: map[string]interface{}{
+				"version":        "0",
+				"device_vendor":  "Security",
+				"device_product": "threatmanager",
+				"device_version": "1.0",
+				"signature_id":   "100",
+				"name":           "worm successfully stopped",
+				"severity":       "10",
+				"extensions": map[string]interface{}{
+					"src": "10.0.0.1",
+					"dst": "2.1.2.2",
+					"spt": "1232",
+				},
+			},
+		},
+		{
+			name:  "escaped_header",
+			input: `CEF:0|security|threatmanager|1.0|100|detected a \| in message|10|src=10.0.0.1`,
+			expected: map[string]interface{}{
+				"version":        "0",
+				"device_vendor":  "security",
+				"device_product": "threatmanager",
+				"device_version": "1.0",
+				"signature_id":   "100",
+				"name":           "detected a | in message",
+				"severity":       "10",
+				"extensions": map[string]interface{}{
+					"src": "10.0.0.1",
+				},
+			},
+		},
+		{
+			name:  "escaped_extension",
+			input: `CEF:0|security|threatmanager|1.0|100|detected|Low|msg=a \= b with spaces\nand lines request=http://x?a=b cs1Label=C:\\Windows`,
+			expected: map[string]interface{}{
+				"version":        "0",
+				"device_vendor":  "security",
+				"device_product": "threatmanager",
+				"device_version": "1.0",
+				"signature_id":   "100",
+				"name":           "detected",
+				"severity":       "Low",
+				"extensions": map[string]interface{}{
+					"msg":      "a = b with spaces\nand lines",
+					"request":  "http://x?a=b",
+					"cs1Label": `C:\Windows`,
+				},
+			},
+		},
+		{
+			name:  "syslog_header",
+			input: `Sep 19 08:26:10 host CEF:0|Vendor|Product|1.0|1|Name|5|`,
+			expected: map[string]interface{}{
+				"version":        "0",
+				"device_vendor":  "Vendor",
+				"device_product": "Product",
+				"device_version": "1.0",
+				"signature_id":   "1",
+				"name":           "Name",
+				"severity":       "5",
+				"extensions":     map[string]interface{}{},
+			},
+		},
+		{
+			name:     "missing_prefix",
+			input:    `0|Vendor|Product|1.0|1|Name|5|`,
+			errorMsg: "missing 'CEF:' prefix",
+		},
+		{
+			name:     "missing_header_fields",
+			input:    `CEF:0|Vendor|Product|1.0`,
+			errorMsg: "expected 7 header fields, got 3",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := parseCEF(tc.input)
+			if tc.errorMsg != "" {
+				require.EqualError(t, err, tc.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, parsed)
+		})
+	}
+}
+
+func TestParserSeverity(t *testing.T) {
+	cases := []struct {
+		severity string
+		expected entry.Severity
+	}{
+		{"0", entry.Info},
+		{"5", entry.Warn},
+		{"High", entry.Error},
+		{"10", entry.Fatal},
+		{"Very-High", entry.Fatal},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.severity, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			e := entry.New()
+			e.Body = "CEF:0|Vendor|Product|1.0|1|Name|" + tc.severity + "|src=10.0.0.1"
+			require.NoError(t, op.Process(context.Background(), e))
+
+			select {
+			case out := <-fake.Received:
+				require.Equal(t, tc.expected, out.Severity)
+				require.Equal(t, tc.severity, out.SeverityText)
+				require.Equal(t, "10.0.0.1", out.Attributes["extensions"].(map[string]interface{})["src"])
+			default:
+				require.FailNow(t, "expected entry")
+			}
+		})
+	}
+}
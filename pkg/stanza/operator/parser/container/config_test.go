@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper/operatortest"
+)
+
+func TestConfig(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "format",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Format = "crio"
+					return cfg
+				}(),
+			},
+			{
+				Name: "without_metadata",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.AddMetadataFromFilePath = false
+					return cfg
+				}(),
+			},
+			{
+				Name: "parse_to_attributes",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ParseTo = entry.NewAttributeField("log")
+					return cfg
+				}(),
+			},
+			{
+				Name: "flush",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ForceFlushTimeout = 10 * time.Second
+					cfg.MaxLogSize = helper.ByteSize(64 * 1024)
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
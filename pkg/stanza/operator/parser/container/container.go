@@ -0,0 +1,450 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/container"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/errors"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "container"
+
+	// Supported values of the format parameter. An empty format means the format
+	// is detected from each individual line.
+	dockerFormat     = "docker"
+	crioFormat       = "crio"
+	containerdFormat = "containerd"
+
+	streamAttribute  = "log.iostream"
+	logTagAttribute  = "logtag"
+	filePathAttrName = "log.file.path"
+
+	podNameResource        = "k8s.pod.name"
+	podUIDResource         = "k8s.pod.uid"
+	namespaceResource      = "k8s.namespace.name"
+	containerNameResource  = "k8s.container.name"
+	restartCountResource   = "k8s.container.restart_count"
+	defaultSourceIdentifer = "DefaultSourceIdentifier"
+)
+
+// criRegexp matches the CRI log format used by both CRI-O and containerd:
+// <RFC3339Nano timestamp> <stream> <P|F> <message>
+var criRegexp = regexp.MustCompile(`^(?P<time>[^ ]+) (?P<stream>stdout|stderr) (?P<logtag>[^ ]*) ?(?P<log>.*)$`)
+
+// podPathRegexp matches the kubelet log file layout:
+// /var/log/pods/<namespace>_<pod_name>_<pod_uid>/<container_name>/<restart_count>.log
+var podPathRegexp = regexp.MustCompile(`^.*/(?P<namespace>[^_/]+)_(?P<pod_name>[^_/]+)_(?P<uid>[a-f0-9-]+)/(?P<container_name>[^/]+)/(?P<restart_count>\d+)\.log$`)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new container parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new container parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	parserConfig := helper.NewParserConfig(operatorID, operatorType)
+	parserConfig.ParseTo = entry.NewBodyField()
+	return &Config{
+		ParserConfig:            parserConfig,
+		AddMetadataFromFilePath: true,
+		SourceIdentifier:        entry.NewAttributeField(filePathAttrName),
+		ForceFlushTimeout:       5 * time.Second,
+		MaxLogSize:              helper.ByteSize(1024 * 1024),
+	}
+}
+
+// Config is the configuration of a container parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash" yaml:",inline"`
+
+	Format                  string          `mapstructure:"format"                      json:"format"                      yaml:"format"`
+	AddMetadataFromFilePath bool            `mapstructure:"add_metadata_from_filepath" json:"add_metadata_from_filepath" yaml:"add_metadata_from_filepath"`
+	SourceIdentifier        entry.Field     `mapstructure:"source_identifier"          json:"source_identifier"          yaml:"source_identifier"`
+	ForceFlushTimeout       time.Duration   `mapstructure:"force_flush_period"         json:"force_flush_period"         yaml:"force_flush_period"`
+	MaxLogSize              helper.ByteSize `mapstructure:"max_log_size"               json:"max_log_size"               yaml:"max_log_size"`
+}
+
+// Build will build a container parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.Format {
+	case "", dockerFormat, crioFormat, containerdFormat:
+	default:
+		return nil, fmt.Errorf("invalid format '%s', must be one of '%s', '%s' or '%s'",
+			c.Format, dockerFormat, crioFormat, containerdFormat)
+	}
+
+	if c.ForceFlushTimeout <= 0 {
+		return nil, fmt.Errorf("force_flush_period must be positive")
+	}
+
+	return &Parser{
+		ParserOperator:          parserOperator,
+		format:                  c.Format,
+		addMetadataFromFilePath: c.AddMetadataFromFilePath,
+		sourceIdentifier:        c.SourceIdentifier,
+		forceFlushTimeout:       c.ForceFlushTimeout,
+		maxLogSize:              int(c.MaxLogSize),
+		json:                    jsoniter.ConfigFastest,
+		partials:                make(map[partialKey]*partialLog),
+		chClose:                 make(chan struct{}),
+	}, nil
+}
+
+// Parser is an operator that parses container runtime log lines and
+// reassembles lines which were split by the runtime.
+type Parser struct {
+	helper.ParserOperator
+	format                  string
+	addMetadataFromFilePath bool
+	sourceIdentifier        entry.Field
+	forceFlushTimeout       time.Duration
+	maxLogSize              int
+	json                    jsoniter.API
+	chClose                 chan struct{}
+	stopOnce                sync.Once
+	wg                      sync.WaitGroup
+
+	mu       sync.Mutex
+	partials map[partialKey]*partialLog
+}
+
+// partialKey identifies the stream of a source whose lines are reassembled,
+// as the runtimes interleave the lines of stdout and stderr in the same file.
+type partialKey struct {
+	source string
+	stream string
+}
+
+// containerLog is a single line written by a container runtime.
+type containerLog struct {
+	timestamp time.Time
+	stream    string
+	logTag    string
+	message   string
+	partial   bool
+}
+
+// partialLog holds the pieces of a log line that has not been completed yet.
+type partialLog struct {
+	base     *entry.Entry
+	log      containerLog
+	message  strings.Builder
+	lastSeen time.Time
+}
+
+// Start will start the loop flushing stale partial lines.
+func (p *Parser) Start(_ operator.Persister) error {
+	p.wg.Add(1)
+	go p.flushLoop()
+	return nil
+}
+
+// Stop will flush any partial lines and stop the flush loop.
+func (p *Parser) Stop() error {
+	p.stopOnce.Do(func() {
+		close(p.chClose)
+	})
+	p.wg.Wait()
+
+	p.mu.Lock()
+	partials := make([]*partialLog, 0, len(p.partials))
+	for key, partial := range p.partials {
+		delete(p.partials, key)
+		partials = append(partials, partial)
+	}
+	p.mu.Unlock()
+
+	for _, partial := range partials {
+		if err := p.emitPartial(context.Background(), partial); err != nil {
+			p.Errorf("there was an error flushing partial container logs %s", err)
+		}
+	}
+	return nil
+}
+
+// takeStalePartials removes and returns the partial lines not extended within the flush timeout.
+func (p *Parser) takeStalePartials(now time.Time) []*partialLog {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var stale []*partialLog
+	for key, partial := range p.partials {
+		if now.Sub(partial.lastSeen) >= p.forceFlushTimeout {
+			delete(p.partials, key)
+			stale = append(stale, partial)
+		}
+	}
+	return stale
+}
+
+func (p *Parser) flushLoop() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.forceFlushTimeout)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for _, partial := range p.takeStalePartials(time.Now()) {
+				if err := p.emitPartial(context.Background(), partial); err != nil {
+					p.Errorf("there was an error flushing partial container logs %s", err)
+				}
+			}
+		case <-p.chClose:
+			return
+		}
+	}
+}
+
+// Process will parse an entry as a container log line.
+func (p *Parser) Process(ctx context.Context, e *entry.Entry) error {
+	start := time.Now()
+
+	skip, err := p.Skip(ctx, e)
+	if err != nil {
+		p.RecordProcessed(ctx, start)
+		return p.HandleEntryError(ctx, e, err)
+	}
+	if skip {
+		p.RecordProcessed(ctx, start)
+		p.Write(ctx, e)
+		return nil
+	}
+
+	value, ok := e.Get(p.ParseFrom)
+	if !ok {
+		p.RecordProcessed(ctx, start)
+		err := errors.NewError(
+			"Entry is missing the expected parse_from field.",
+			"Ensure that all incoming entries contain the parse_from field.",
+			"parse_from", p.ParseFrom.String(),
+		)
+		return p.HandleEntryError(ctx, e, err)
+	}
+
+	log, err := p.parse(value)
+	p.RecordProcessed(ctx, start)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+
+	source := defaultSourceIdentifer
+	var s string
+	if err := e.Read(p.sourceIdentifier, &s); err == nil && s != "" {
+		source = s
+	}
+
+	key := partialKey{source: source, stream: log.stream}
+	complete, buffered := p.bufferPartial(key, e, log)
+	switch {
+	case complete != nil:
+		return p.emitPartial(ctx, complete)
+	case !buffered:
+		return p.emit(ctx, e, log, log.message)
+	default:
+		return nil
+	}
+}
+
+// bufferPartial adds the log line to the partial line of its source stream. It returns
+// the reassembled line once complete, which is no longer buffered, and whether the
+// line was buffered at all: a complete line following no partial one is not.
+func (p *Parser) bufferPartial(key partialKey, e *entry.Entry, log containerLog) (*partialLog, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	partial, buffered := p.partials[key]
+	if !buffered && !log.partial {
+		return nil, false
+	}
+
+	if !buffered {
+		partial = &partialLog{base: e, log: log}
+		p.partials[key] = partial
+	}
+	partial.message.WriteString(log.message)
+	partial.lastSeen = time.Now()
+
+	if !log.partial || (p.maxLogSize > 0 && partial.message.Len() >= p.maxLogSize) {
+		delete(p.partials, key)
+		return partial, true
+	}
+	return nil, true
+}
+
+// emitPartial emits a reassembled log line, which must no longer be buffered.
+func (p *Parser) emitPartial(ctx context.Context, partial *partialLog) error {
+	return p.emit(ctx, partial.base, partial.log, partial.message.String())
+}
+
+// emit sets the fields extracted from the container log line on the entry and writes it.
+func (p *Parser) emit(ctx context.Context, e *entry.Entry, log containerLog, message string) error {
+	e.Timestamp = log.timestamp
+	if err := e.Set(entry.NewAttributeField(streamAttribute), log.stream); err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+	if log.logTag != "" {
+		// The reassembled line is always complete.
+		if err := e.Set(entry.NewAttributeField(logTagAttribute), "F"); err != nil {
+			return p.HandleEntryError(ctx, e, err)
+		}
+	}
+
+	if p.addMetadataFromFilePath {
+		if err := p.setPodMetadata(e); err != nil {
+			return p.HandleEntryError(ctx, e, err)
+		}
+	}
+
+	if err := p.ParseWith(ctx, e, func(interface{}) (interface{}, error) { return message, nil }); err != nil {
+		return err
+	}
+
+	p.Write(ctx, e)
+	return nil
+}
+
+// parse will parse a value as a container log line of the configured or detected format.
+func (p *Parser) parse(value interface{}) (containerLog, error) {
+	raw, ok := value.(string)
+	if !ok {
+		return containerLog{}, fmt.Errorf("type '%T' cannot be parsed as container log", value)
+	}
+
+	format := p.format
+	if format == "" {
+		format = detectFormat(raw)
+	}
+
+	switch format {
+	case dockerFormat:
+		return p.parseDocker(raw)
+	default:
+		return parseCRI(raw)
+	}
+}
+
+// detectFormat detects the container runtime format of a raw log line. CRI-O and
+// containerd write the same format, so both are reported as containerd.
+func detectFormat(raw string) string {
+	if strings.HasPrefix(raw, "{") {
+		return dockerFormat
+	}
+	return containerdFormat
+}
+
+// dockerLog is a line written by the docker json-file logging driver.
+type dockerLog struct {
+	Log    string `json:"log"`
+	Stream string `json:"stream"`
+	Time   string `json:"time"`
+}
+
+func (p *Parser) parseDocker(raw string) (containerLog, error) {
+	var parsed dockerLog
+	if err := p.json.UnmarshalFromString(raw, &parsed); err != nil {
+		return containerLog{}, fmt.Errorf("parse docker log: %w", err)
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, parsed.Time)
+	if err != nil {
+		return containerLog{}, fmt.Errorf("parse docker log timestamp: %w", err)
+	}
+
+	// The json-file driver splits lines longer than 16KiB. Only the last part
+	// of a line ends with a newline.
+	message := strings.TrimSuffix(parsed.Log, "\n")
+	return containerLog{
+		timestamp: timestamp.UTC(),
+		stream:    parsed.Stream,
+		message:   message,
+		partial:   len(message) == len(parsed.Log),
+	}, nil
+}
+
+func parseCRI(raw string) (containerLog, error) {
+	matches := criRegexp.FindStringSubmatch(raw)
+	if matches == nil {
+		return containerLog{}, fmt.Errorf("line does not match the CRI log format")
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, matches[criRegexp.SubexpIndex("time")])
+	if err != nil {
+		return containerLog{}, fmt.Errorf("parse CRI log timestamp: %w", err)
+	}
+
+	// The log tag is a colon separated list of tags, the first one of which is either
+	// P (partial) or F (full).
+	logTag := matches[criRegexp.SubexpIndex("logtag")]
+	return containerLog{
+		timestamp: timestamp.UTC(),
+		stream:    matches[criRegexp.SubexpIndex("stream")],
+		logTag:    logTag,
+		message:   matches[criRegexp.SubexpIndex("log")],
+		partial:   strings.HasPrefix(logTag, "P"),
+	}, nil
+}
+
+// setPodMetadata sets the Kubernetes resource attributes derived from the log file path.
+func (p *Parser) setPodMetadata(e *entry.Entry) error {
+	var path string
+	if err := e.Read(entry.NewAttributeField(filePathAttrName), &path); err != nil {
+		return fmt.Errorf("entry does not contain the %s attribute required to add metadata from file path", filePathAttrName)
+	}
+
+	matches := podPathRegexp.FindStringSubmatch(path)
+	if matches == nil {
+		return fmt.Errorf("file path '%s' does not match the /var/log/pods layout", path)
+	}
+
+	restartCount, err := strconv.Atoi(matches[podPathRegexp.SubexpIndex("restart_count")])
+	if err != nil {
+		return fmt.Errorf("parse restart count: %w", err)
+	}
+
+	for field, value := range map[string]interface{}{
+		namespaceResource:     matches[podPathRegexp.SubexpIndex("namespace")],
+		podNameResource:       matches[podPathRegexp.SubexpIndex("pod_name")],
+		podUIDResource:        matches[podPathRegexp.SubexpIndex("uid")],
+		containerNameResource: matches[podPathRegexp.SubexpIndex("container_name")],
+		restartCountResource:  restartCount,
+	} {
+		if err := e.Set(entry.NewResourceField(field), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const testFilePath = "/var/log/pods/some-ns_some-pod_49cc7c1fd3702c40b2686ea7486091d6/some-container/1.log"
+
+func newTestParser(t *testing.T, configure func(*Config)) (*Parser, *testutil.FakeOutput) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	if configure != nil {
+		configure(cfg)
+	}
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(testutil.NewMockPersister("test")))
+	t.Cleanup(func() { require.NoError(t, op.Stop()) })
+	return op.(*Parser), fake
+}
+
+func newTestEntry(body string) *entry.Entry {
+	e := entry.New()
+	e.Body = body
+	e.Attributes = map[string]interface{}{
+		"log.file.path": testFilePath,
+	}
+	return e
+}
+
+func expectedResource() map[string]interface{} {
+	return map[string]interface{}{
+		"k8s.namespace.name":          "some-ns",
+		"k8s.pod.name":                "some-pod",
+		"k8s.pod.uid":                 "49cc7c1fd3702c40b2686ea7486091d6",
+		"k8s.container.name":          "some-container",
+		"k8s.container.restart_count": 1,
+	}
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.Format = "invalid"
+	_, err := cfg.Build(testutil.Logger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid format 'invalid'")
+}
+
+func TestParser(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     string
+		expect    func(*entry.Entry)
+	}{
+		{
+			"docker",
+			nil,
+			`{"log":"INFO: log line here\n","stream":"stdout","time":"2029-03-30T08:31:20.545192187Z"}`,
+			func(e *entry.Entry) {
+				e.Body = "INFO: log line here"
+				e.Timestamp = time.Date(2029, time.March, 30, 8, 31, 20, 545192187, time.UTC)
+				e.Attributes["log.iostream"] = "stdout"
+			},
+		},
+		{
+			"containerd",
+			nil,
+			"2023-06-22T10:27:25.813799277Z stderr F INFO: log line here",
+			func(e *entry.Entry) {
+				e.Body = "INFO: log line here"
+				e.Timestamp = time.Date(2023, time.June, 22, 10, 27, 25, 813799277, time.UTC)
+				e.Attributes["log.iostream"] = "stderr"
+				e.Attributes["logtag"] = "F"
+			},
+		},
+		{
+			"crio",
+			func(cfg *Config) { cfg.Format = "crio" },
+			"2024-04-13T07:59:37.505201169-05:00 stdout F INFO: log line here",
+			func(e *entry.Entry) {
+				e.Body = "INFO: log line here"
+				e.Timestamp = time.Date(2024, time.April, 13, 12, 59, 37, 505201169, time.UTC)
+				e.Attributes["log.iostream"] = "stdout"
+				e.Attributes["logtag"] = "F"
+			},
+		},
+		{
+			"parse_to_attribute",
+			func(cfg *Config) { cfg.ParseTo = entry.NewAttributeField("log") },
+			"2023-06-22T10:27:25.813799277Z stdout F INFO: log line here",
+			func(e *entry.Entry) {
+				e.Body = "2023-06-22T10:27:25.813799277Z stdout F INFO: log line here"
+				e.Timestamp = time.Date(2023, time.June, 22, 10, 27, 25, 813799277, time.UTC)
+				e.Attributes["log.iostream"] = "stdout"
+				e.Attributes["logtag"] = "F"
+				e.Attributes["log"] = "INFO: log line here"
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser, fake := newTestParser(t, tc.configure)
+
+			input := newTestEntry(tc.input)
+			expected := newTestEntry(tc.input)
+			expected.ObservedTimestamp = input.ObservedTimestamp
+			expected.Resource = expectedResource()
+			tc.expect(expected)
+
+			require.NoError(t, parser.Process(context.Background(), input))
+			fake.ExpectEntry(t, expected)
+		})
+	}
+}
+
+func TestParserRecombineCRI(t *testing.T) {
+	parser, fake := newTestParser(t, nil)
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2023-06-22T10:27:25.813799277Z stdout P part one, ")))
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2023-06-22T10:27:25.813799278Z stdout P part two, ")))
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2023-06-22T10:27:25.813799279Z stdout F part three")))
+	fake.ExpectBody(t, "part one, part two, part three")
+}
+
+func TestParserRecombineDocker(t *testing.T) {
+	parser, fake := newTestParser(t, nil)
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry(`{"log":"part one, ","stream":"stdout","time":"2029-03-30T08:31:20.545192187Z"}`)))
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry(`{"log":"part two\n","stream":"stdout","time":"2029-03-30T08:31:20.545192188Z"}`)))
+	fake.ExpectBody(t, "part one, part two")
+}
+
+func TestParserRecombineCRIInterleavedStreams(t *testing.T) {
+	parser, fake := newTestParser(t, nil)
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2023-06-22T10:27:25.813799277Z stdout P out one, ")))
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2023-06-22T10:27:25.813799278Z stderr P err one, ")))
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2023-06-22T10:27:25.813799279Z stdout F out two")))
+	fake.ExpectBody(t, "out one, out two")
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2023-06-22T10:27:25.813799280Z stderr F err two")))
+	fake.ExpectBody(t, "err one, err two")
+}
+
+func TestParserStopTwice(t *testing.T) {
+	parser, fake := newTestParser(t, nil)
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2023-06-22T10:27:25.813799277Z stdout P never completed")))
+	require.NoError(t, parser.Stop())
+	fake.ExpectBody(t, "never completed")
+	require.NoError(t, parser.Stop())
+}
+
+func TestParserBlockedOutput(t *testing.T) {
+	parser, fake := newTestParser(t, nil)
+
+	// Fill the output so that writing the next line blocks.
+	for i := 0; i < cap(fake.Received); i++ {
+		require.NoError(t, parser.Process(context.Background(), newTestEntry("2023-06-22T10:27:25.813799277Z stdout F line")))
+	}
+	blocked := make(chan struct{})
+	go func() {
+		defer close(blocked)
+		assert.NoError(t, parser.Process(context.Background(), newTestEntry("2023-06-22T10:27:25.813799278Z stdout F blocked")))
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	// The partial lines of other sources are still buffered.
+	processed := make(chan struct{})
+	go func() {
+		defer close(processed)
+		e := newTestEntry("2023-06-22T10:27:25.813799279Z stdout P partial")
+		e.Attributes["log.file.path"] = strings.Replace(testFilePath, "1.log", "2.log", 1)
+		assert.NoError(t, parser.Process(context.Background(), e))
+	}()
+	var wasBlocked bool
+	select {
+	case <-processed:
+	case <-time.After(time.Second):
+		wasBlocked = true
+	}
+
+	for i := 0; i < cap(fake.Received); i++ {
+		<-fake.Received
+	}
+	<-blocked
+	fake.ExpectBody(t, "blocked")
+	assert.False(t, wasBlocked, "processing a partial line was blocked by the output")
+}
+
+func TestParserForceFlushPartial(t *testing.T) {
+	parser, fake := newTestParser(t, func(cfg *Config) { cfg.ForceFlushTimeout = 100 * time.Millisecond })
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2023-06-22T10:27:25.813799277Z stdout P never completed")))
+	fake.ExpectBody(t, "never completed")
+}
+
+func TestParserMaxLogSize(t *testing.T) {
+	parser, fake := newTestParser(t, func(cfg *Config) { cfg.MaxLogSize = 10 })
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2023-06-22T10:27:25.813799277Z stdout P 0123456789")))
+	fake.ExpectBody(t, "0123456789")
+}
+
+func TestParserInvalidLine(t *testing.T) {
+	parser, _ := newTestParser(t, nil)
+	err := parser.Process(context.Background(), newTestEntry("not a container log"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not match the CRI log format")
+}
+
+func TestParserInvalidFilePath(t *testing.T) {
+	parser, _ := newTestParser(t, nil)
+	input := newTestEntry("2023-06-22T10:27:25.813799277Z stdout F message")
+	input.Attributes["log.file.path"] = "/var/log/syslog"
+	err := parser.Process(context.Background(), input)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not match the /var/log/pods layout")
+}
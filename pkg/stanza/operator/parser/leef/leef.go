@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leef // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/leef"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "leef_parser"
+
+	leefPrefix       = "LEEF:"
+	defaultDelimiter = "\t"
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new LEEF parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new LEEF parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig: helper.NewParserConfig(operatorID, operatorType),
+	}
+}
+
+// Config is the configuration of a LEEF parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash" yaml:",inline"`
+}
+
+// Build will build a LEEF parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &Parser{
+		ParserOperator: parserOperator,
+	}
+
+	// The sev attribute is optional, so unlike a configured severity parser,
+	// the default one is skipped for the events without it.
+	if c.ParserConfig.Config == nil {
+		severityConfig, err := defaultSeverityConfig(c.ParseTo)
+		if err != nil {
+			return nil, err
+		}
+		severityParser, err := severityConfig.Build(logger)
+		if err != nil {
+			return nil, err
+		}
+		parser.defaultSeverityParser = &severityParser
+	}
+	return parser, nil
+}
+
+// defaultSeverityConfig maps the LEEF sev attribute, an integer from 1 to 10,
+// to a log severity.
+func defaultSeverityConfig(parseTo entry.Field) (*helper.SeverityConfig, error) {
+	parseFrom, err := entry.NewField(parseTo.String() + ".event_attributes.sev")
+	if err != nil {
+		return nil, fmt.Errorf("severity field: %w", err)
+	}
+
+	severityConfig := helper.NewSeverityConfig()
+	severityConfig.ParseFrom = &parseFrom
+	severityConfig.Mapping = map[interface{}]interface{}{
+		"info":  map[interface{}]interface{}{"min": 0, "max": 3},
+		"warn":  map[interface{}]interface{}{"min": 4, "max": 6},
+		"error": map[interface{}]interface{}{"min": 7, "max": 8},
+		"fatal": map[interface{}]interface{}{"min": 9, "max": 10},
+	}
+	return &severityConfig, nil
+}
+
+// Parser is an operator that parses IBM Log Event Extended Format messages.
+type Parser struct {
+	helper.ParserOperator
+	defaultSeverityParser *helper.SeverityParser
+}
+
+// Process will parse an entry as a LEEF message.
+func (p *Parser) Process(ctx context.Context, e *entry.Entry) error {
+	if p.defaultSeverityParser == nil {
+		return p.ParserOperator.ProcessWith(ctx, e, p.parse)
+	}
+	return p.ParserOperator.ProcessWithCallback(ctx, e, p.parse, func(e *entry.Entry) error {
+		if _, ok := e.Get(p.defaultSeverityParser.ParseFrom); !ok {
+			return nil
+		}
+		if err := p.defaultSeverityParser.Parse(e); err != nil {
+			return p.HandleEntryError(ctx, e, fmt.Errorf("severity parser: %w", err))
+		}
+		return nil
+	})
+}
+
+// parse will parse a value as a LEEF message.
+func (p *Parser) parse(value interface{}) (interface{}, error) {
+	switch m := value.(type) {
+	case string:
+		return parseLEEF(m)
+	default:
+		return nil, fmt.Errorf("type %T cannot be parsed as LEEF", value)
+	}
+}
+
+// parseLEEF parses a LEEF 1.0 or 2.0 message of the form
+// LEEF:Version|Vendor|Product|Version|EventID|Attributes
+// LEEF:Version|Vendor|Product|Version|EventID|Delimiter|Attributes
+// The delimiter field is only allowed in LEEF 2.0. When missing, attributes are
+// separated by tabs. Any syslog header preceding the LEEF prefix is ignored.
+func parseLEEF(input string) (map[string]interface{}, error) {
+	start := strings.Index(input, leefPrefix)
+	if start < 0 {
+		return nil, fmt.Errorf("missing '%s' prefix", leefPrefix)
+	}
+	input = input[start+len(leefPrefix):]
+
+	fields := strings.SplitN(input, "|", 6)
+	if len(fields) < 6 {
+		return nil, fmt.Errorf("expected 5 header fields, got %d", len(fields)-1)
+	}
+
+	parsed := map[string]interface{}{
+		"version":        fields[0],
+		"device_vendor":  fields[1],
+		"device_product": fields[2],
+		"device_version": fields[3],
+		"event_id":       fields[4],
+	}
+
+	attributes := fields[5]
+	delimiter := defaultDelimiter
+	if strings.HasPrefix(fields[0], "2") {
+		// The delimiter field is optional, tell it apart from the first attribute.
+		if i := strings.IndexByte(attributes, '|'); i >= 0 && !strings.Contains(attributes[:i], "=") {
+			var err error
+			if delimiter, err = parseDelimiter(attributes[:i]); err != nil {
+				return nil, err
+			}
+			attributes = attributes[i+1:]
+		}
+	}
+
+	parsed["event_attributes"] = parseAttributes(attributes, delimiter)
+	return parsed, nil
+}
+
+// parseDelimiter parses the LEEF 2.0 delimiter field, which is either a single
+// character or its hex code, such as x09 or 0x09.
+func parseDelimiter(field string) (string, error) {
+	switch {
+	case field == "":
+		return defaultDelimiter, nil
+	case len(field) == 1:
+		return field, nil
+	}
+
+	hex := strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(field), "0"), "x")
+	code, err := strconv.ParseUint(hex, 16, 16)
+	if err != nil {
+		return "", fmt.Errorf("invalid delimiter '%s'", field)
+	}
+	return string(rune(code)), nil
+}
+
+// parseAttributes parses the key=value pairs of the LEEF event attributes.
+// Values may contain the escaped characters \=, \| and \\, as well as an
+// escaped delimiter.
+func parseAttributes(attributes, delimiter string) map[string]interface{} {
+	parsed := make(map[string]interface{})
+	for _, pair := range splitUnescaped(attributes, delimiter) {
+		i := indexUnescaped(pair, "=")
+		if i <= 0 {
+			continue
+		}
+		parsed[strings.TrimSpace(pair[:i])] = unescapeValue(pair[i+1:], delimiter)
+	}
+	return parsed
+}
+
+// indexUnescaped returns the index of the first occurrence of sep in s which
+// isn't preceded by a backslash escape, or -1.
+func indexUnescaped(s, sep string) int {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			i++
+		case strings.HasPrefix(s[i:], sep):
+			return i
+		}
+	}
+	return -1
+}
+
+// splitUnescaped splits s around the occurrences of sep which aren't escaped.
+func splitUnescaped(s, sep string) []string {
+	var parts []string
+	for {
+		i := indexUnescaped(s, sep)
+		if i < 0 {
+			return append(parts, s)
+		}
+		parts = append(parts, s[:i])
+		s = s[i+len(sep):]
+	}
+}
+
+func unescapeValue(value, delimiter string) string {
+	var unescaped strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			unescaped.WriteByte(value[i])
+			continue
+		}
+		next := value[i+1:]
+		switch {
+		case next[0] == '=' || next[0] == '|' || next[0] == '\\':
+			unescaped.WriteByte(next[0])
+			i++
+		case strings.HasPrefix(next, delimiter):
+			unescaped.WriteString(delimiter)
+			i += len(delimiter)
+		default:
+			unescaped.WriteByte('\\')
+		}
+	}
+	return unescaped.String()
+}
@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leef
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestLEEFImplementations(t *testing.T) {
+	require.Implements(t, (*operator.Operator)(nil), new(Parser))
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "type []int cannot be parsed as LEEF")
+}
+
+func TestParseLEEF(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected map[string]interface{}
+		errorMsg string
+	}{
+		{
+			name:  "leef_1",
+			input: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tdst=172.50.123.1\tsev=5\tmsg=an event with = sign",
+			expected: map[string]interface{}{
+				"version":        "1.0",
+				"device_vendor":  "Microsoft",
+				"device_product": "MSExchange",
+				"device_version": "4.0 SP1",
+				"event_id":       "15345",
+				"event_attributes": map[string]interface{}{
+					"src": "192.0.2.0",
+					"dst": "172.50.123.1",
+					"sev": "5",
+					"msg": "an event with = sign",
+				},
+			},
+		},
+		{
+			name:  "leef_2_character_delimiter",
+			input: "LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=5",
+			expected: map[string]interface{}{
+				"version":        "2.0",
+				"device_vendor":  "Lancope",
+				"device_product": "StealthWatch",
+				"device_version": "1.0",
+				"event_id":       "41",
+				"event_attributes": map[string]interface{}{
+					"src": "10.0.1.8",
+					"dst": "10.0.0.5",
+					"sev": "5",
+				},
+			},
+		},
+		{
+			name:  "leef_2_hex_delimiter",
+			input: "LEEF:2.0|Lancope|StealthWatch|1.0|41|0x7C|src=10.0.1.8|dst=10.0.0.5",
+			expected: map[string]interface{}{
+				"version":        "2.0",
+				"device_vendor":  "Lancope",
+				"device_product": "StealthWatch",
+				"device_version": "1.0",
+				"event_id":       "41",
+				"event_attributes": map[string]interface{}{
+					"src": "10.0.1.8",
+					"dst": "10.0.0.5",
+				},
+			},
+		},
+		{
+			name:  "leef_2_without_delimiter",
+			input: "Jan 18 11:07:53 host LEEF:2.0|Lancope|StealthWatch|1.0|41|src=10.0.1.8\tdst=10.0.0.5",
+			expected: map[string]interface{}{
+				"version":        "2.0",
+				"device_vendor":  "Lancope",
+				"device_product": "StealthWatch",
+				"device_version": "1.0",
+				"event_id":       "41",
+				"event_attributes": map[string]interface{}{
+					"src": "10.0.1.8",
+					"dst": "10.0.0.5",
+				},
+			},
+		},
+		{
+			name:  "escaped_values",
+			input: `LEEF:1.0|Vendor|Product|1.0|1|msg=a\=b c\|d e\\f g\h` + "\tusrName=domain\\\\user",
+			expected: map[string]interface{}{
+				"version":        "1.0",
+				"device_vendor":  "Vendor",
+				"device_product": "Product",
+				"device_version": "1.0",
+				"event_id":       "1",
+				"event_attributes": map[string]interface{}{
+					"msg":     `a=b c|d e\f g\h`,
+					"usrName": `domain\user`,
+				},
+			},
+		},
+		{
+			name:  "escaped_delimiter",
+			input: `LEEF:2.0|Vendor|Product|1.0|1|^|msg=a\^b^src=10.0.1.8`,
+			expected: map[string]interface{}{
+				"version":        "2.0",
+				"device_vendor":  "Vendor",
+				"device_product": "Product",
+				"device_version": "1.0",
+				"event_id":       "1",
+				"event_attributes": map[string]interface{}{
+					"msg": "a^b",
+					"src": "10.0.1.8",
+				},
+			},
+		},
+		{
+			name:  "escaped_equal_sign_in_key",
+			input: `LEEF:1.0|Vendor|Product|1.0|1|k\=ey=value`,
+			expected: map[string]interface{}{
+				"version":        "1.0",
+				"device_vendor":  "Vendor",
+				"device_product": "Product",
+				"device_version": "1.0",
+				"event_id":       "1",
+				"event_attributes": map[string]interface{}{
+					`k\=ey`: "value",
+				},
+			},
+		},
+		{
+			name:     "missing_prefix",
+			input:    "1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0",
+			errorMsg: "missing 'LEEF:' prefix",
+		},
+		{
+			name:     "missing_header_fields",
+			input:    "LEEF:1.0|Microsoft|MSExchange",
+			errorMsg: "expected 5 header fields, got 2",
+		},
+		{
+			name:     "invalid_delimiter",
+			input:    "LEEF:2.0|Lancope|StealthWatch|1.0|41|xZZ|src=10.0.1.8",
+			errorMsg: "invalid delimiter 'xZZ'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := parseLEEF(tc.input)
+			if tc.errorMsg != "" {
+				require.EqualError(t, err, tc.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, parsed)
+		})
+	}
+}
+
+func TestParserSeverity(t *testing.T) {
+	cases := []struct {
+		name         string
+		attributes   string
+		expected     entry.Severity
+		expectedText string
+	}{
+		{"low", "sev=1\tsrc=10.0.0.1", entry.Info, "1"},
+		{"medium", "sev=5\tsrc=10.0.0.1", entry.Warn, "5"},
+		{"high", "sev=8\tsrc=10.0.0.1", entry.Error, "8"},
+		{"critical", "sev=10\tsrc=10.0.0.1", entry.Fatal, "10"},
+		{"unknown", "sev=urgent\tsrc=10.0.0.1", entry.Default, "urgent"},
+		{"missing", "src=10.0.0.1", entry.Default, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			e := entry.New()
+			e.Body = "LEEF:1.0|Vendor|Product|1.0|1|" + tc.attributes
+			require.NoError(t, op.Process(context.Background(), e))
+
+			select {
+			case out := <-fake.Received:
+				require.Equal(t, tc.expected, out.Severity)
+				require.Equal(t, tc.expectedText, out.SeverityText)
+				require.Equal(t, "10.0.0.1", out.Attributes["event_attributes"].(map[string]interface{})["src"])
+			default:
+				require.FailNow(t, "expected entry")
+			}
+		})
+	}
+}
+
+func TestParserConfiguredSeverity(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	parseFrom := entry.NewAttributeField("event_attributes", "level")
+	severityConfig := helper.NewSeverityConfig()
+	severityConfig.ParseFrom = &parseFrom
+	cfg.Config = &severityConfig
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+	e := entry.New()
+	e.Body = "LEEF:1.0|Vendor|Product|1.0|1|sev=10\tlevel=debug"
+	require.NoError(t, op.Process(context.Background(), e))
+
+	select {
+	case out := <-fake.Received:
+		require.Equal(t, entry.Debug, out.Severity)
+	default:
+		require.FailNow(t, "expected entry")
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xml // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/xml"
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "xml_parser"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new XML parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new XML parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig:    helper.NewParserConfig(operatorID, operatorType),
+		AttributePrefix: "@",
+		TextKey:         "#text",
+	}
+}
+
+// Config is the configuration of an XML parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash" yaml:",inline"`
+
+	AttributePrefix string `mapstructure:"attribute_prefix" json:"attribute_prefix" yaml:"attribute_prefix"`
+	TextKey         string `mapstructure:"text_key"         json:"text_key"         yaml:"text_key"`
+}
+
+// Build will build an XML parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.TextKey == "" {
+		return nil, errors.New("text_key is a required parameter")
+	}
+
+	return &Parser{
+		ParserOperator:  parserOperator,
+		attributePrefix: c.AttributePrefix,
+		textKey:         c.TextKey,
+	}, nil
+}
+
+// Parser is an operator that parses XML.
+type Parser struct {
+	helper.ParserOperator
+	attributePrefix string
+	textKey         string
+}
+
+// Process will parse an entry as XML.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ParserOperator.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a value as XML.
+func (p *Parser) parse(value interface{}) (interface{}, error) {
+	switch m := value.(type) {
+	case string:
+		return p.parseXML(m)
+	default:
+		return nil, fmt.Errorf("type %T cannot be parsed as XML", value)
+	}
+}
+
+// element is an XML element being decoded.
+type element struct {
+	name     string
+	fields   map[string]interface{}
+	text     strings.Builder
+	hasChild bool
+}
+
+// parseXML converts an XML document into nested maps keyed by the name of the root element.
+// Attributes are stored with the attribute prefix, repeated elements are stored as lists, and
+// elements without attributes or children are stored as their text.
+func (p *Parser) parseXML(input string) (map[string]interface{}, error) {
+	decoder := xml.NewDecoder(strings.NewReader(input))
+
+	var stack []*element
+	var root map[string]interface{}
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse XML: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if root != nil {
+				return nil, errors.New("parse XML: multiple root elements")
+			}
+			e := &element{name: t.Name.Local, fields: make(map[string]interface{})}
+			for _, attr := range t.Attr {
+				if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+					continue
+				}
+				e.fields[p.attributePrefix+attr.Name.Local] = attr.Value
+			}
+			if len(stack) > 0 {
+				stack[len(stack)-1].hasChild = true
+			}
+			stack = append(stack, e)
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		case xml.EndElement:
+			e := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			value := p.elementValue(e)
+			if len(stack) == 0 {
+				root = map[string]interface{}{e.name: value}
+				continue
+			}
+			addField(stack[len(stack)-1].fields, e.name, value)
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("parse XML: no root element")
+	}
+	return root, nil
+}
+
+// elementValue returns the text of an element without attributes or children,
+// and the map of its fields otherwise.
+func (p *Parser) elementValue(e *element) interface{} {
+	text := strings.TrimSpace(e.text.String())
+	if len(e.fields) == 0 && !e.hasChild {
+		return text
+	}
+	if text != "" {
+		e.fields[p.textKey] = text
+	}
+	return e.fields
+}
+
+// addField adds a value to the fields of an element, turning the field into a list
+// when the element is repeated.
+func addField(fields map[string]interface{}, key string, value interface{}) {
+	existing, ok := fields[key]
+	if !ok {
+		fields[key] = value
+		return
+	}
+	if list, ok := existing.([]interface{}); ok {
+		fields[key] = append(list, value)
+		return
+	}
+	fields[key] = []interface{}{existing, value}
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestXMLImplementations(t *testing.T) {
+	require.Implements(t, (*operator.Operator)(nil), new(Parser))
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.TextKey = ""
+	_, err := config.Build(testutil.Logger(t))
+	require.EqualError(t, err, "text_key is a required parameter")
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "type []int cannot be parsed as XML")
+}
+
+func TestParser(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected map[string]interface{}
+		errorMsg string
+	}{
+		{
+			name:     "text",
+			input:    `<message>hello</message>`,
+			expected: map[string]interface{}{"message": "hello"},
+		},
+		{
+			name: "nested",
+			input: `<?xml version="1.0"?>
+<Event xmlns="http://schemas.microsoft.com/win/2004/08/events/event">
+  <System>
+    <EventID Qualifiers="16384">7036</EventID>
+    <Level>4</Level>
+  </System>
+  <EventData>
+    <Data Name="param1">Windows Update</Data>
+    <Data Name="param2">running</Data>
+  </EventData>
+</Event>`,
+			expected: map[string]interface{}{
+				"Event": map[string]interface{}{
+					"System": map[string]interface{}{
+						"EventID": map[string]interface{}{
+							"@Qualifiers": "16384",
+							"#text":       "7036",
+						},
+						"Level": "4",
+					},
+					"EventData": map[string]interface{}{
+						"Data": []interface{}{
+							map[string]interface{}{"@Name": "param1", "#text": "Windows Update"},
+							map[string]interface{}{"@Name": "param2", "#text": "running"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "empty_elements",
+			input: `<event><user/><host name="a"/></event>`,
+			expected: map[string]interface{}{
+				"event": map[string]interface{}{
+					"user": "",
+					"host": map[string]interface{}{"@name": "a"},
+				},
+			},
+		},
+		{
+			name:     "invalid",
+			input:    `<event><user></event>`,
+			errorMsg: "parse XML: XML syntax error on line 1: element <user> closed by </event>",
+		},
+		{
+			name:     "multiple_roots",
+			input:    `<a/><b/>`,
+			errorMsg: "parse XML: multiple root elements",
+		},
+		{
+			name:     "no_root",
+			input:    `just text`,
+			errorMsg: "parse XML: no root element",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := newTestParser(t)
+			parsed, err := parser.parse(tc.input)
+			if tc.errorMsg != "" {
+				require.EqualError(t, err, tc.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, parsed)
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/antonmedv/expr"
 	"github.com/antonmedv/expr/vm"
@@ -92,22 +93,29 @@ type Transformer struct {
 
 // Process will drop incoming entries that match the filter expression
 func (f *Transformer) Process(ctx context.Context, entry *entry.Entry) error {
+	start := time.Now()
+
 	env := helper.GetExprEnv(entry)
 	defer helper.PutExprEnv(env)
 
 	matches, err := vm.Run(f.expression, env)
 	if err != nil {
+		f.RecordProcessed(ctx, start)
+		f.RecordDropped(ctx, helper.DropReasonError)
 		f.Errorf("Running expressing returned an error", zap.Error(err))
 		return nil
 	}
 
 	filtered, ok := matches.(bool)
 	if !ok {
+		f.RecordProcessed(ctx, start)
+		f.RecordDropped(ctx, helper.DropReasonError)
 		f.Errorf("Expression did not compile as a boolean")
 		return nil
 	}
 
 	if !filtered {
+		f.RecordProcessed(ctx, start)
 		f.Write(ctx, entry)
 		return nil
 	}
@@ -117,9 +125,12 @@ func (f *Transformer) Process(ctx context.Context, entry *entry.Entry) error {
 		return err
 	}
 
+	f.RecordProcessed(ctx, start)
 	if i.Cmp(f.dropCutoff) >= 0 {
 		f.Write(ctx, entry)
+		return nil
 	}
 
+	f.RecordDropped(ctx, helper.DropReasonFiltered)
 	return nil
 }
@@ -311,3 +311,47 @@ func TestPipelineOperators(t *testing.T) {
 	operators := pipeline.Operators()
 	require.Equal(t, []operator.Operator{mockOperator1, mockOperator2, mockOperator3}, operators)
 }
+
+type deadLetterOperator struct {
+	*testutil.Operator
+	deadLetter operator.Operator
+}
+
+func (o deadLetterOperator) DeadLetter() operator.Operator {
+	return o.deadLetter
+}
+
+func TestPipelineDeadLetter(t *testing.T) {
+	t.Run("Connected", func(t *testing.T) {
+		mockOperator1 := testutil.NewMockOperator("operator1")
+		mockOperator2 := testutil.NewMockOperator("operator2")
+		mockOperator1.On("Outputs").Return(nil)
+		mockOperator1.On("SetOutputs", mock.Anything).Return(nil)
+		mockOperator2.On("Outputs").Return(nil)
+		mockOperator2.On("SetOutputs", mock.Anything).Return(nil)
+
+		operator1 := deadLetterOperator{Operator: mockOperator1, deadLetter: mockOperator2}
+		pipeline, err := NewDirectedPipeline([]operator.Operator{operator1, mockOperator2})
+		require.NoError(t, err)
+
+		node1 := createOperatorNode(operator1)
+		node2 := createOperatorNode(mockOperator2)
+		require.True(t, pipeline.Graph.HasEdgeFromTo(node1.ID(), node2.ID()))
+		require.Equal(t, []operator.Operator{operator1, mockOperator2}, pipeline.Operators())
+	})
+
+	t.Run("Cyclical", func(t *testing.T) {
+		mockOperator1 := testutil.NewMockOperator("operator1")
+		mockOperator2 := testutil.NewMockOperator("operator2")
+		mockOperator1.On("Outputs").Return(nil)
+		mockOperator1.On("SetOutputs", mock.Anything).Return(nil)
+		mockOperator2.On("SetOutputs", mock.Anything).Return(nil)
+
+		operator1 := deadLetterOperator{Operator: mockOperator1, deadLetter: mockOperator2}
+		mockOperator2.On("Outputs").Return([]operator.Operator{operator1})
+
+		_, err := NewDirectedPipeline([]operator.Operator{operator1, mockOperator2})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "circular dependency")
+	})
+}
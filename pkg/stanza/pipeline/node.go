@@ -48,16 +48,21 @@ func (b OperatorNode) OutputIDs() map[string]int64 {
 }
 
 // createOperatorNode will create an operator node.
-func createOperatorNode(operator operator.Operator) OperatorNode {
-	id := createNodeID(operator.ID())
+func createOperatorNode(op operator.Operator) OperatorNode {
+	id := createNodeID(op.ID())
 	outputIDs := make(map[string]int64)
-	if operator.CanOutput() {
-		for _, output := range operator.Outputs() {
+	if op.CanOutput() {
+		for _, output := range op.Outputs() {
 			outputIDs[output.ID()] = createNodeID(output.ID())
 		}
 	}
+	if deadLetterOutput, ok := op.(operator.DeadLetterOutput); ok {
+		if deadLetter := deadLetterOutput.DeadLetter(); deadLetter != nil {
+			outputIDs[deadLetter.ID()] = createNodeID(deadLetter.ID())
+		}
+	}
 	return OperatorNode{
-		operator:  operator,
+		operator:  op,
 		outputIDs: outputIDs,
 		id:        id,
 	}
@@ -802,3 +802,38 @@ func testParseEnum(val *EnumSymbol) (*Enum, error) {
 	}
 	return nil, fmt.Errorf("enum symbol not provided")
 }
+
+func Test_ParseConditions(t *testing.T) {
+	evaluators, err := ParseConditions(
+		[]string{
+			`name == "foo"`,
+			`name == "foo" or name == "bar"`,
+			`true and name != "foo"`,
+		},
+		DefaultFunctionsForTests(),
+		testParsePath,
+		testParseEnum,
+	)
+	assert.NoError(t, err)
+	assert.Len(t, evaluators, 3)
+
+	ctx := tqltest.TestTransformContext{Item: "bar"}
+	assert.False(t, evaluators[0](ctx))
+	assert.True(t, evaluators[1](ctx))
+	assert.True(t, evaluators[2](ctx))
+}
+
+func Test_ParseConditions_failure(t *testing.T) {
+	tests := []string{
+		`set(name, "foo")`,
+		`name ==`,
+		`unknown == "foo"`,
+		`name == SYMBOL_NOT_FOUND`,
+	}
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			_, err := ParseConditions([]string{tt}, DefaultFunctionsForTests(), testParsePath, testParseEnum)
+			assert.Error(t, err)
+		})
+	}
+}
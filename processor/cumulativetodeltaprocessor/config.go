@@ -30,6 +30,14 @@ type Config struct {
 	// MaxStaleness is the total time a state entry will live past the time it was last seen. Set to 0 to retain state indefinitely.
 	MaxStaleness time.Duration `mapstructure:"max_staleness"`
 
+	// StorageID is the ID of the storage extension the tracked state is saved to,
+	// so that it survives restarts. If not set, state is only kept in memory.
+	StorageID *config.ComponentID `mapstructure:"storage"`
+
+	// SnapshotInterval is how often the tracked state is saved to storage, in
+	// addition to when the processor shuts down. Set to 0 to only save on shutdown.
+	SnapshotInterval time.Duration `mapstructure:"snapshot_interval"`
+
 	// Include specifies a filter on the metrics that should be converted.
 	// Exclude specifies a filter on the metrics that should not be converted.
 	// If neither `include` nor `exclude` are set, all metrics will be converted.
@@ -57,5 +65,8 @@ func (config *Config) Validate() error {
 		(len(config.Exclude.MatchType) > 0 && len(config.Exclude.Metrics) == 0) {
 		return fmt.Errorf("metrics must be supplied if match_type is set")
 	}
+	if config.SnapshotInterval < 0 {
+		return fmt.Errorf("snapshot_interval must not be negative")
+	}
 	return nil
 }
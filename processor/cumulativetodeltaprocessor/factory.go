@@ -67,5 +67,6 @@ func createMetricsProcessor(
 		nextConsumer,
 		metricsProcessor.processMetrics,
 		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(metricsProcessor.start),
 		processorhelper.WithShutdown(metricsProcessor.shutdown))
 }
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// snapshotVersion is increased whenever the encoding of the snapshot changes
+// in a way that older snapshots cannot be restored.
+const snapshotVersion = 1
+
+type snapshot struct {
+	Version int             `json:"version"`
+	States  []snapshotState `json:"states"`
+}
+
+type snapshotState struct {
+	Key       string     `json:"key"`
+	PrevPoint ValuePoint `json:"prev_point"`
+}
+
+// MarshalStates encodes the previous point of every tracked series.
+func (t *MetricTracker) MarshalStates() ([]byte, error) {
+	snap := snapshot{Version: snapshotVersion}
+	t.states.Range(func(key, value interface{}) bool {
+		s := value.(*State)
+		s.Lock()
+		snap.States = append(snap.States, snapshotState{
+			Key:       key.(string),
+			PrevPoint: s.PrevPoint,
+		})
+		s.Unlock()
+		return true
+	})
+	return json.Marshal(snap)
+}
+
+// UnmarshalStates restores the series encoded by MarshalStates. Series which
+// would already have been removed by the sweeper are skipped, as are series
+// which are tracked already. It returns the number of restored series.
+func (t *MetricTracker) UnmarshalStates(data []byte) (int, error) {
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return 0, err
+	}
+	if snap.Version != snapshotVersion {
+		return 0, fmt.Errorf("unsupported snapshot version %d", snap.Version)
+	}
+
+	var staleBefore pcommon.Timestamp
+	if t.maxStaleness > 0 {
+		staleBefore = pcommon.NewTimestampFromTime(time.Now().Add(-t.maxStaleness))
+	}
+
+	restored := 0
+	for _, s := range snap.States {
+		if s.PrevPoint.ObservedTimestamp < staleBefore {
+			continue
+		}
+		if _, loaded := t.states.LoadOrStore(s.Key, &State{PrevPoint: s.PrevPoint}); !loaded {
+			restored++
+		}
+	}
+	return restored, nil
+}
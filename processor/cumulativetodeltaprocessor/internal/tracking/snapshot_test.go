@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+func TestMetricTracker_MarshalStates(t *testing.T) {
+	now := pcommon.NewTimestampFromTime(time.Now())
+	id := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricDataType:         pmetric.MetricDataTypeSum,
+		MetricIsMonotonic:      true,
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+		Attributes:             pcommon.NewMap(),
+	}
+	expID := id
+	expID.MetricDataType = pmetric.MetricDataTypeExponentialHistogram
+
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+	m.Convert(MetricPoint{Identity: id, Value: ValuePoint{ObservedTimestamp: now, IntValue: 100}})
+	m.Convert(MetricPoint{Identity: expID, Value: ValuePoint{
+		ObservedTimestamp: now,
+		ExponentialHistogram: &ExponentialHistogramValue{
+			Count:    3,
+			Scale:    1,
+			Positive: ExponentialBuckets{Offset: 1, BucketCounts: []uint64{1, 2}},
+		},
+	}})
+
+	data, err := m.MarshalStates()
+	require.NoError(t, err)
+
+	restored := NewMetricTracker(context.Background(), zap.NewNop(), time.Minute)
+	n, err := restored.UnmarshalStates(data)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	// The first points after the restore are converted using the restored state.
+	out, valid := restored.Convert(MetricPoint{Identity: id, Value: ValuePoint{ObservedTimestamp: now + 10, IntValue: 150}})
+	require.True(t, valid)
+	assert.Equal(t, now, out.StartTimestamp)
+	assert.Equal(t, int64(50), out.IntValue)
+
+	out, valid = restored.Convert(MetricPoint{Identity: expID, Value: ValuePoint{
+		ObservedTimestamp: now + 10,
+		ExponentialHistogram: &ExponentialHistogramValue{
+			Count:    4,
+			Scale:    1,
+			Positive: ExponentialBuckets{Offset: 1, BucketCounts: []uint64{1, 3}},
+		},
+	}})
+	require.True(t, valid)
+	assert.Equal(t, uint64(1), out.ExponentialHistogram.Count)
+	assert.Equal(t, []uint64{0, 1}, out.ExponentialHistogram.Positive.BucketCounts)
+}
+
+func TestMetricTracker_UnmarshalStates(t *testing.T) {
+	now := time.Now()
+	fresh := pcommon.NewTimestampFromTime(now)
+	stale := pcommon.NewTimestampFromTime(now.Add(-time.Hour))
+
+	tests := []struct {
+		name         string
+		maxStaleness time.Duration
+		existing     []string
+		data         string
+		wantKeys     []string
+		wantErr      bool
+	}{
+		{
+			name:         "skips stale series",
+			maxStaleness: time.Minute,
+			data:         `{"version":1,"states":[{"key":"fresh","prev_point":{"ObservedTimestamp":` + strconv.FormatUint(uint64(fresh), 10) + `}},{"key":"stale","prev_point":{"ObservedTimestamp":` + strconv.FormatUint(uint64(stale), 10) + `}}]}`,
+			wantKeys:     []string{"fresh"},
+		},
+		{
+			name:     "keeps all series without max staleness",
+			data:     `{"version":1,"states":[{"key":"fresh","prev_point":{"ObservedTimestamp":` + strconv.FormatUint(uint64(fresh), 10) + `}},{"key":"stale","prev_point":{"ObservedTimestamp":` + strconv.FormatUint(uint64(stale), 10) + `}}]}`,
+			wantKeys: []string{"fresh", "stale"},
+		},
+		{
+			name:     "keeps tracked series",
+			existing: []string{"fresh"},
+			data:     `{"version":1,"states":[{"key":"fresh","prev_point":{"ObservedTimestamp":1}}]}`,
+			wantKeys: []string{"fresh"},
+		},
+		{
+			name:    "unsupported version",
+			data:    `{"version":2,"states":[]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid data",
+			data:    `{"version":`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &MetricTracker{logger: zap.NewNop(), maxStaleness: tt.maxStaleness}
+			for _, key := range tt.existing {
+				m.states.Store(key, &State{PrevPoint: ValuePoint{ObservedTimestamp: fresh}})
+			}
+
+			_, err := m.UnmarshalStates([]byte(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var keys []string
+			m.states.Range(func(key, value interface{}) bool {
+				keys = append(keys, key.(string))
+				if key == "fresh" {
+					assert.Equal(t, fresh, value.(*State).PrevPoint.ObservedTimestamp)
+				}
+				return true
+			})
+			assert.ElementsMatch(t, tt.wantKeys, keys)
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
 )
 
@@ -41,9 +42,10 @@ type State struct {
 }
 
 type DeltaValue struct {
-	StartTimestamp pcommon.Timestamp
-	FloatValue     float64
-	IntValue       int64
+	StartTimestamp       pcommon.Timestamp
+	FloatValue           float64
+	IntValue             int64
+	ExponentialHistogram *ExponentialHistogramValue
 }
 
 func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration) *MetricTracker {
@@ -92,9 +94,10 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 	if !ok {
 		if metricID.MetricIsMonotonic {
 			out = DeltaValue{
-				StartTimestamp: metricPoint.ObservedTimestamp,
-				FloatValue:     metricPoint.FloatValue,
-				IntValue:       metricPoint.IntValue,
+				StartTimestamp:       metricPoint.ObservedTimestamp,
+				FloatValue:           metricPoint.FloatValue,
+				IntValue:             metricPoint.IntValue,
+				ExponentialHistogram: metricPoint.ExponentialHistogram,
 			}
 			valid = true
 		}
@@ -108,7 +111,10 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 
 	out.StartTimestamp = state.PrevPoint.ObservedTimestamp
 
-	if metricID.IsFloatVal() {
+	switch {
+	case metricID.MetricDataType == pmetric.MetricDataTypeExponentialHistogram:
+		out.ExponentialHistogram = metricPoint.ExponentialHistogram.delta(state.PrevPoint.ExponentialHistogram)
+	case metricID.IsFloatVal():
 		value := metricPoint.FloatValue
 		prevValue := state.PrevPoint.FloatValue
 		delta := value - prevValue
@@ -119,7 +125,7 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 		}
 
 		out.FloatValue = delta
-	} else {
+	default:
 		value := metricPoint.IntValue
 		prevValue := state.PrevPoint.IntValue
 		delta := value - prevValue
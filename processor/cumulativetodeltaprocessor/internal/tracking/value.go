@@ -17,7 +17,83 @@ package tracking // import "github.com/open-telemetry/opentelemetry-collector-co
 import "go.opentelemetry.io/collector/pdata/pcommon"
 
 type ValuePoint struct {
-	ObservedTimestamp pcommon.Timestamp
-	FloatValue        float64
-	IntValue          int64
+	ObservedTimestamp    pcommon.Timestamp
+	FloatValue           float64
+	IntValue             int64
+	ExponentialHistogram *ExponentialHistogramValue `json:",omitempty"`
+}
+
+// ExponentialBuckets are the buckets of one range of an exponential histogram.
+type ExponentialBuckets struct {
+	Offset       int32
+	BucketCounts []uint64
+}
+
+// ExponentialHistogramValue is the value of an exponential histogram point.
+type ExponentialHistogramValue struct {
+	Count     uint64
+	Sum       float64
+	ZeroCount uint64
+	Scale     int32
+	Positive  ExponentialBuckets
+	Negative  ExponentialBuckets
+}
+
+// delta returns the difference between the value and the previous value of
+// the same series. The value itself is returned if the series was reset.
+func (v *ExponentialHistogramValue) delta(prev *ExponentialHistogramValue) *ExponentialHistogramValue {
+	// Cumulative histograms can only reduce their scale, counts can only grow.
+	if prev == nil || v.Scale > prev.Scale || v.Count < prev.Count || v.ZeroCount < prev.ZeroCount {
+		return v
+	}
+
+	positive, ok := v.Positive.sub(prev.Positive.downscale(prev.Scale - v.Scale))
+	if !ok {
+		return v
+	}
+	negative, ok := v.Negative.sub(prev.Negative.downscale(prev.Scale - v.Scale))
+	if !ok {
+		return v
+	}
+
+	return &ExponentialHistogramValue{
+		Count:     v.Count - prev.Count,
+		Sum:       v.Sum - prev.Sum,
+		ZeroCount: v.ZeroCount - prev.ZeroCount,
+		Scale:     v.Scale,
+		Positive:  positive,
+		Negative:  negative,
+	}
+}
+
+// downscale returns the buckets merged to represent a scale reduced by the given amount.
+func (b ExponentialBuckets) downscale(by int32) ExponentialBuckets {
+	if by <= 0 || len(b.BucketCounts) == 0 {
+		return b
+	}
+
+	offset := b.Offset >> by
+	last := (b.Offset + int32(len(b.BucketCounts)) - 1) >> by
+	counts := make([]uint64, last-offset+1)
+	for i, count := range b.BucketCounts {
+		counts[((b.Offset+int32(i))>>by)-offset] += count
+	}
+	return ExponentialBuckets{Offset: offset, BucketCounts: counts}
+}
+
+// sub subtracts the previous buckets of the same scale. It returns false if
+// any previous bucket count is larger than the current one.
+func (b ExponentialBuckets) sub(prev ExponentialBuckets) (ExponentialBuckets, bool) {
+	counts := append([]uint64(nil), b.BucketCounts...)
+	for i, count := range prev.BucketCounts {
+		if count == 0 {
+			continue
+		}
+		index := int(prev.Offset - b.Offset + int32(i))
+		if index < 0 || index >= len(counts) || counts[index] < count {
+			return ExponentialBuckets{}, false
+		}
+		counts[index] -= count
+	}
+	return ExponentialBuckets{Offset: b.Offset, BucketCounts: counts}, true
 }
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialHistogramValue_delta(t *testing.T) {
+	prev := &ExponentialHistogramValue{
+		Count:     6,
+		Sum:       10,
+		ZeroCount: 1,
+		Scale:     1,
+		Positive:  ExponentialBuckets{Offset: 2, BucketCounts: []uint64{1, 2, 1}},
+		Negative:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1}},
+	}
+
+	tests := []struct {
+		name  string
+		value *ExponentialHistogramValue
+		want  *ExponentialHistogramValue
+	}{
+		{
+			name: "same scale",
+			value: &ExponentialHistogramValue{
+				Count:     10,
+				Sum:       15,
+				ZeroCount: 2,
+				Scale:     1,
+				Positive:  ExponentialBuckets{Offset: 1, BucketCounts: []uint64{1, 2, 3, 1}},
+				Negative:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1}},
+			},
+			want: &ExponentialHistogramValue{
+				Count:     4,
+				Sum:       5,
+				ZeroCount: 1,
+				Scale:     1,
+				Positive:  ExponentialBuckets{Offset: 1, BucketCounts: []uint64{1, 1, 1, 0}},
+				Negative:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{0}},
+			},
+		},
+		{
+			name: "reduced scale",
+			value: &ExponentialHistogramValue{
+				Count:     8,
+				Sum:       12,
+				ZeroCount: 1,
+				Scale:     0,
+				Positive:  ExponentialBuckets{Offset: 1, BucketCounts: []uint64{4, 2}},
+				Negative:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1}},
+			},
+			want: &ExponentialHistogramValue{
+				Count:     2,
+				Sum:       2,
+				ZeroCount: 0,
+				Scale:     0,
+				Positive:  ExponentialBuckets{Offset: 1, BucketCounts: []uint64{1, 1}},
+				Negative:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{0}},
+			},
+		},
+		{
+			name: "lower count is a reset",
+			value: &ExponentialHistogramValue{
+				Count:    2,
+				Scale:    1,
+				Positive: ExponentialBuckets{Offset: 2, BucketCounts: []uint64{2}},
+			},
+		},
+		{
+			name: "increased scale is a reset",
+			value: &ExponentialHistogramValue{
+				Count:     12,
+				ZeroCount: 1,
+				Scale:     2,
+				Positive:  ExponentialBuckets{Offset: 4, BucketCounts: []uint64{11}},
+			},
+		},
+		{
+			name: "lower bucket count is a reset",
+			value: &ExponentialHistogramValue{
+				Count:     6,
+				ZeroCount: 1,
+				Scale:     1,
+				Positive:  ExponentialBuckets{Offset: 2, BucketCounts: []uint64{2, 1, 1}},
+				Negative:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1}},
+			},
+		},
+		{
+			name: "missing bucket is a reset",
+			value: &ExponentialHistogramValue{
+				Count:     6,
+				ZeroCount: 1,
+				Scale:     1,
+				Positive:  ExponentialBuckets{Offset: 2, BucketCounts: []uint64{1, 2, 1}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+			if want == nil {
+				want = tt.value
+			}
+			assert.Equal(t, want, tt.value.delta(prev))
+		})
+	}
+
+	t.Run("no previous value", func(t *testing.T) {
+		value := &ExponentialHistogramValue{Count: 1}
+		assert.Equal(t, value, value.delta(nil))
+	})
+}
+
+func TestExponentialBuckets_downscale(t *testing.T) {
+	b := ExponentialBuckets{Offset: -3, BucketCounts: []uint64{1, 2, 3, 4}}
+	assert.Equal(t, b, b.downscale(0))
+	assert.Equal(t, ExponentialBuckets{Offset: -2, BucketCounts: []uint64{1, 5, 4}}, b.downscale(1))
+	assert.Equal(t, ExponentialBuckets{Offset: -1, BucketCounts: []uint64{6, 4}}, b.downscale(2))
+}
@@ -18,10 +18,16 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sync"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/service/featuregate"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
@@ -41,21 +47,32 @@ func init() {
 }
 
 type cumulativeToDeltaProcessor struct {
+	id                      config.ComponentID
 	includeFS               filterset.FilterSet
 	excludeFS               filterset.FilterSet
 	logger                  *zap.Logger
 	deltaCalculator         *tracking.MetricTracker
+	ctx                     context.Context
 	cancelFunc              context.CancelFunc
 	histogramSupportEnabled bool
+
+	storageID        *config.ComponentID
+	snapshotInterval time.Duration
+	storageClient    storage.Client
+	wg               sync.WaitGroup
 }
 
 func newCumulativeToDeltaProcessor(config *Config, logger *zap.Logger) *cumulativeToDeltaProcessor {
 	ctx, cancel := context.WithCancel(context.Background())
 	p := &cumulativeToDeltaProcessor{
+		id:                      config.ID(),
 		logger:                  logger,
 		deltaCalculator:         tracking.NewMetricTracker(ctx, logger, config.MaxStaleness),
+		ctx:                     ctx,
 		cancelFunc:              cancel,
 		histogramSupportEnabled: featuregate.GetRegistry().IsEnabled(enableHistogramSupportGateID),
+		storageID:               config.StorageID,
+		snapshotInterval:        config.SnapshotInterval,
 	}
 	if len(config.Include.Metrics) > 0 {
 		p.includeFS, _ = filterset.CreateFilterSet(config.Include.Metrics, &config.Include.Config)
@@ -138,6 +155,30 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 					}
 
 					ctdp.convertHistogramDataPoints(ms.DataPoints(), &histogramIdentities)
+					removeHistogramMinMax(ms.DataPoints())
+
+					ms.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricDataTypeExponentialHistogram:
+					if !ctdp.histogramSupportEnabled {
+						return false
+					}
+
+					ms := m.ExponentialHistogram()
+					if ms.AggregationTemporality() != pmetric.MetricAggregationTemporalityCumulative {
+						return false
+					}
+
+					baseIdentity := tracking.MetricIdentity{
+						Resource:               rm.Resource(),
+						InstrumentationLibrary: ilm.Scope(),
+						MetricDataType:         m.DataType(),
+						MetricName:             m.Name(),
+						MetricUnit:             m.Unit(),
+						MetricIsMonotonic:      true,
+					}
+					ctdp.convertExponentialHistogramDataPoints(ms.DataPoints(), baseIdentity)
+					removeExponentialHistogramMinMax(ms.DataPoints())
 
 					ms.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
 					return ms.DataPoints().Len() == 0
@@ -165,11 +206,41 @@ func makeBucketIdentities(baseIdentity tracking.MetricIdentity, dp pmetric.Histo
 	return bucketIdentities
 }
 
-func (ctdp *cumulativeToDeltaProcessor) shutdown(context.Context) error {
-	ctdp.cancelFunc()
+func (ctdp *cumulativeToDeltaProcessor) start(ctx context.Context, host component.Host) error {
+	if ctdp.storageID == nil {
+		return nil
+	}
+
+	client, err := getStorageClient(ctx, host, *ctdp.storageID, ctdp.id)
+	if err != nil {
+		return err
+	}
+	ctdp.storageClient = client
+
+	if err = ctdp.loadState(ctx); err != nil {
+		return err
+	}
+
+	if ctdp.snapshotInterval > 0 {
+		ctdp.wg.Add(1)
+		go ctdp.snapshotter(ctdp.ctx, ctdp.snapshotInterval)
+	}
 	return nil
 }
 
+func (ctdp *cumulativeToDeltaProcessor) shutdown(ctx context.Context) error {
+	ctdp.cancelFunc()
+	ctdp.wg.Wait()
+
+	if ctdp.storageClient == nil {
+		return nil
+	}
+	return multierr.Combine(
+		ctdp.saveState(ctx),
+		ctdp.storageClient.Close(ctx),
+	)
+}
+
 func (ctdp *cumulativeToDeltaProcessor) shouldConvertMetric(metricName string) bool {
 	return (ctdp.includeFS == nil || ctdp.includeFS.Matches(metricName)) &&
 		(ctdp.excludeFS == nil || !ctdp.excludeFS.Matches(metricName))
@@ -282,3 +353,121 @@ func (ctdp *cumulativeToDeltaProcessor) convertHistogramDataPoints(in interface{
 		})
 	}
 }
+
+func (ctdp *cumulativeToDeltaProcessor) convertExponentialHistogramDataPoints(dps pmetric.ExponentialHistogramDataPointSlice, baseIdentity tracking.MetricIdentity) {
+	dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+		id := baseIdentity
+		id.StartTimestamp = dp.StartTimestamp()
+		id.Attributes = dp.Attributes()
+
+		hasSum := dp.HasSum() && !math.IsNaN(dp.Sum())
+		value := &tracking.ExponentialHistogramValue{
+			Count:     dp.Count(),
+			ZeroCount: dp.ZeroCount(),
+			Scale:     dp.Scale(),
+			Positive: tracking.ExponentialBuckets{
+				Offset:       dp.Positive().Offset(),
+				BucketCounts: dp.Positive().BucketCounts().AsRaw(),
+			},
+			Negative: tracking.ExponentialBuckets{
+				Offset:       dp.Negative().Offset(),
+				BucketCounts: dp.Negative().BucketCounts().AsRaw(),
+			},
+		}
+		if hasSum {
+			value.Sum = dp.Sum()
+		}
+
+		trackingPoint := tracking.MetricPoint{
+			Identity: id,
+			Value: tracking.ValuePoint{
+				ObservedTimestamp:    dp.Timestamp(),
+				ExponentialHistogram: value,
+			},
+		}
+		delta, valid := ctdp.deltaCalculator.Convert(trackingPoint)
+		if !valid {
+			return true
+		}
+
+		dp.SetStartTimestamp(delta.StartTimestamp)
+		dp.SetCount(delta.ExponentialHistogram.Count)
+		if hasSum {
+			dp.SetSum(delta.ExponentialHistogram.Sum)
+		}
+		dp.SetZeroCount(delta.ExponentialHistogram.ZeroCount)
+		dp.SetScale(delta.ExponentialHistogram.Scale)
+		dp.Positive().SetOffset(delta.ExponentialHistogram.Positive.Offset)
+		dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice(delta.ExponentialHistogram.Positive.BucketCounts))
+		dp.Negative().SetOffset(delta.ExponentialHistogram.Negative.Offset)
+		dp.Negative().SetBucketCounts(pcommon.NewImmutableUInt64Slice(delta.ExponentialHistogram.Negative.BucketCounts))
+		return false
+	})
+}
+
+// removeHistogramMinMax removes the min and max of the converted data points, which are
+// those of the cumulative points and can't be derived for the delta points. The points
+// are copied without them, as pdata doesn't allow clearing optional fields.
+func removeHistogramMinMax(dps pmetric.HistogramDataPointSlice) {
+	found := false
+	for i := 0; i < dps.Len() && !found; i++ {
+		found = dps.At(i).HasMin() || dps.At(i).HasMax()
+	}
+	if !found {
+		return
+	}
+
+	cleared := pmetric.NewHistogramDataPointSlice()
+	cleared.EnsureCapacity(dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		dest := cleared.AppendEmpty()
+		dp.Attributes().CopyTo(dest.Attributes())
+		dest.SetStartTimestamp(dp.StartTimestamp())
+		dest.SetTimestamp(dp.Timestamp())
+		dest.SetCount(dp.Count())
+		if dp.HasSum() {
+			dest.SetSum(dp.Sum())
+		}
+		dest.SetBucketCounts(dp.BucketCounts())
+		dest.SetExplicitBounds(dp.ExplicitBounds())
+		dp.Exemplars().CopyTo(dest.Exemplars())
+		dest.SetFlagsImmutable(dp.FlagsImmutable())
+	}
+	dps.RemoveIf(func(pmetric.HistogramDataPoint) bool { return true })
+	cleared.MoveAndAppendTo(dps)
+}
+
+// removeExponentialHistogramMinMax removes the min and max of the converted data points,
+// like removeHistogramMinMax.
+func removeExponentialHistogramMinMax(dps pmetric.ExponentialHistogramDataPointSlice) {
+	found := false
+	for i := 0; i < dps.Len() && !found; i++ {
+		found = dps.At(i).HasMin() || dps.At(i).HasMax()
+	}
+	if !found {
+		return
+	}
+
+	cleared := pmetric.NewExponentialHistogramDataPointSlice()
+	cleared.EnsureCapacity(dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		dest := cleared.AppendEmpty()
+		dp.Attributes().CopyTo(dest.Attributes())
+		dest.SetStartTimestamp(dp.StartTimestamp())
+		dest.SetTimestamp(dp.Timestamp())
+		dest.SetCount(dp.Count())
+		if dp.HasSum() {
+			dest.SetSum(dp.Sum())
+		}
+		dest.SetScale(dp.Scale())
+		dest.SetZeroCount(dp.ZeroCount())
+		dp.Positive().CopyTo(dest.Positive())
+		dp.Negative().CopyTo(dest.Negative())
+		dp.Exemplars().CopyTo(dest.Exemplars())
+		dest.SetFlagsImmutable(dp.FlagsImmutable())
+	}
+	dps.RemoveIf(func(pmetric.ExponentialHistogramDataPoint) bool { return true })
+	cleared.MoveAndAppendTo(dps)
+}
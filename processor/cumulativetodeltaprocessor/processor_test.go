@@ -48,6 +48,14 @@ type testHistogramMetric struct {
 	isCumulative  []bool
 }
 
+type testExponentialHistogramMetric struct {
+	metricNames   []string
+	metricScales  [][]int32
+	metricOffsets [][]int32
+	metricBuckets [][][]uint64
+	isCumulative  []bool
+}
+
 type cumulativeToDeltaTest struct {
 	name                    string
 	include                 MatchMetrics
@@ -270,6 +278,59 @@ var (
 			}),
 			histogramSupportEnabled: false,
 		},
+		{
+			name: "cumulative_to_delta_exponential_histogram_one_positive",
+			include: MatchMetrics{
+				Metrics: []string{"metric_1"},
+				Config: filterset.Config{
+					MatchType:    "strict",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+				metricNames:   []string{"metric_1", "metric_2"},
+				metricScales:  [][]int32{{1, 1, 0}, {1}},
+				metricOffsets: [][]int32{{0, 0, 0}, {0}},
+				metricBuckets: [][][]uint64{
+					{{1, 2}, {2, 3}, {6, 1}},
+					{{4, 4}},
+				},
+				isCumulative: []bool{true, true},
+			}),
+			outMetrics: generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+				metricNames:   []string{"metric_1", "metric_2"},
+				metricScales:  [][]int32{{1, 1, 0}, {1}},
+				metricOffsets: [][]int32{{0, 0, 0}, {0}},
+				metricBuckets: [][][]uint64{
+					{{1, 2}, {1, 1}, {1, 1}},
+					{{4, 4}},
+				},
+				isCumulative: []bool{false, true},
+			}),
+			histogramSupportEnabled: true,
+		},
+		{
+			name: "cumulative_to_delta_exponential_histogram_ignored_without_feature",
+			inMetrics: generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+				metricNames:   []string{"metric_1"},
+				metricScales:  [][]int32{{1, 1}},
+				metricOffsets: [][]int32{{0, 0}},
+				metricBuckets: [][][]uint64{
+					{{1, 2}, {2, 3}},
+				},
+				isCumulative: []bool{true},
+			}),
+			outMetrics: generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+				metricNames:   []string{"metric_1"},
+				metricScales:  [][]int32{{1, 1}},
+				metricOffsets: [][]int32{{0, 0}},
+				metricBuckets: [][][]uint64{
+					{{1, 2}, {2, 3}},
+				},
+				isCumulative: []bool{true},
+			}),
+			histogramSupportEnabled: false,
+		},
 	}
 )
 
@@ -364,6 +425,21 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 						require.Equal(t, eDataPoints.At(j).BucketCounts().AsRaw(), aDataPoints.At(j).BucketCounts().AsRaw())
 					}
 				}
+
+				if eM.DataType() == pmetric.MetricDataTypeExponentialHistogram {
+					eDataPoints := eM.ExponentialHistogram().DataPoints()
+					aDataPoints := aM.ExponentialHistogram().DataPoints()
+
+					require.Equal(t, eDataPoints.Len(), aDataPoints.Len())
+					require.Equal(t, eM.ExponentialHistogram().AggregationTemporality(), aM.ExponentialHistogram().AggregationTemporality())
+
+					for j := 0; j < eDataPoints.Len(); j++ {
+						require.Equal(t, eDataPoints.At(j).Count(), aDataPoints.At(j).Count())
+						require.Equal(t, eDataPoints.At(j).Scale(), aDataPoints.At(j).Scale())
+						require.Equal(t, eDataPoints.At(j).Positive().Offset(), aDataPoints.At(j).Positive().Offset())
+						require.Equal(t, eDataPoints.At(j).Positive().BucketCounts().AsRaw(), aDataPoints.At(j).Positive().BucketCounts().AsRaw())
+					}
+				}
 			}
 
 			require.NoError(t, mgp.Shutdown(ctx))
@@ -371,6 +447,83 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 	}
 }
 
+func TestCumulativeToDeltaProcessorHistogramMinMax(t *testing.T) {
+	registry := featuregate.GetRegistry()
+	require.NoError(t, registry.Apply(map[string]bool{enableHistogramSupportGateID: true}))
+	defer func() {
+		require.NoError(t, registry.Apply(map[string]bool{enableHistogramSupportGateID: false}))
+	}()
+
+	next := new(consumertest.MetricsSink)
+	cfg := createDefaultConfig().(*Config)
+	mgp, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, mgp.Start(context.Background(), nil))
+	defer func() { require.NoError(t, mgp.Shutdown(context.Background())) }()
+
+	in := generateTestHistogramMetrics(testHistogramMetric{
+		metricNames:   []string{"histogram"},
+		metricCounts:  [][]uint64{{10, 20, 30}},
+		metricSums:    [][]float64{{100, 200, 300}},
+		metricBuckets: [][][]uint64{{{5, 5}, {10, 10}, {15, 15}}},
+		isCumulative:  []bool{true},
+	})
+	expHistograms := generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+		metricNames:   []string{"exponential_histogram"},
+		metricScales:  [][]int32{{1, 1, 1}},
+		metricOffsets: [][]int32{{0, 0, 0}},
+		metricBuckets: [][][]uint64{{{1, 2}, {2, 3}, {6, 1}}},
+		isCumulative:  []bool{true},
+	})
+	expHistograms.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().MoveAndAppendTo(
+		in.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics())
+
+	metrics := in.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		if m.DataType() == pmetric.MetricDataTypeHistogram {
+			for j := 0; j < m.Histogram().DataPoints().Len(); j++ {
+				m.Histogram().DataPoints().At(j).SetMin(1)
+				m.Histogram().DataPoints().At(j).SetMax(float64(10 * (j + 1)))
+			}
+		} else {
+			for j := 0; j < m.ExponentialHistogram().DataPoints().Len(); j++ {
+				m.ExponentialHistogram().DataPoints().At(j).SetMin(1)
+				m.ExponentialHistogram().DataPoints().At(j).SetMax(float64(10 * (j + 1)))
+			}
+		}
+	}
+
+	require.NoError(t, mgp.ConsumeMetrics(context.Background(), in))
+	require.Len(t, next.AllMetrics(), 1)
+
+	metrics = next.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	histogram := metrics.At(0).Histogram()
+	assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, histogram.AggregationTemporality())
+	require.Equal(t, 3, histogram.DataPoints().Len())
+	for j := 0; j < histogram.DataPoints().Len(); j++ {
+		dp := histogram.DataPoints().At(j)
+		assert.Equal(t, uint64(10), dp.Count())
+		assert.Equal(t, float64(100), dp.Sum())
+		assert.Equal(t, []uint64{5, 5}, dp.BucketCounts().AsRaw())
+		assert.False(t, dp.HasMin())
+		assert.False(t, dp.HasMax())
+	}
+
+	expHistogram := metrics.At(1).ExponentialHistogram()
+	assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, expHistogram.AggregationTemporality())
+	require.Equal(t, 3, expHistogram.DataPoints().Len())
+	for j := 0; j < expHistogram.DataPoints().Len(); j++ {
+		dp := expHistogram.DataPoints().At(j)
+		assert.Equal(t, int32(1), dp.Scale())
+		assert.False(t, dp.HasMin())
+		assert.False(t, dp.HasMax())
+	}
+	assert.Equal(t, []uint64{1, 1}, expHistogram.DataPoints().At(1).Positive().BucketCounts().AsRaw())
+}
+
 func generateTestSumMetrics(tm testSumMetric) pmetric.Metrics {
 	md := pmetric.NewMetrics()
 	now := time.Now()
@@ -436,6 +589,43 @@ func generateTestHistogramMetrics(tm testHistogramMetric) pmetric.Metrics {
 	return md
 }
 
+func generateTestExponentialHistogramMetrics(tm testExponentialHistogramMetric) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	now := time.Now()
+
+	rm := md.ResourceMetrics().AppendEmpty()
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	for i, name := range tm.metricNames {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		m.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+
+		hist := m.ExponentialHistogram()
+
+		if tm.isCumulative[i] {
+			hist.SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		} else {
+			hist.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+		}
+
+		for index, buckets := range tm.metricBuckets[i] {
+			dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(now.Add(10 * time.Second)))
+
+			var count uint64
+			for _, c := range buckets {
+				count += c
+			}
+			dp.SetCount(count)
+			dp.SetScale(tm.metricScales[i][index])
+			dp.Positive().SetOffset(tm.metricOffsets[i][index])
+			dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice(buckets))
+		}
+	}
+
+	return md
+}
+
 func BenchmarkConsumeMetrics(b *testing.B) {
 	c := consumertest.NewNop()
 	params := component.ProcessorCreateSettings{
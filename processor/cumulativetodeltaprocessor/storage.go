@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+// stateKey is the storage key the tracker state is saved under.
+const stateKey = "tracker_state"
+
+func getStorageClient(ctx context.Context, host component.Host, storageID config.ComponentID, componentID config.ComponentID) (storage.Client, error) {
+	extension, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindProcessor, componentID, "")
+}
+
+// loadState restores the tracker state saved by a previous run. A state that
+// cannot be decoded is discarded, as series are recreated as points arrive.
+func (ctdp *cumulativeToDeltaProcessor) loadState(ctx context.Context) error {
+	data, err := ctdp.storageClient.Get(ctx, stateKey)
+	if err != nil {
+		return fmt.Errorf("failed to load tracker state: %w", err)
+	}
+	if data == nil {
+		return nil
+	}
+
+	restored, err := ctdp.deltaCalculator.UnmarshalStates(data)
+	if err != nil {
+		ctdp.logger.Warn("Discarding tracker state which cannot be restored", zap.Error(err))
+		return nil
+	}
+	ctdp.logger.Debug("Restored tracker state", zap.Int("series", restored))
+	return nil
+}
+
+func (ctdp *cumulativeToDeltaProcessor) saveState(ctx context.Context) error {
+	data, err := ctdp.deltaCalculator.MarshalStates()
+	if err != nil {
+		return fmt.Errorf("failed to encode tracker state: %w", err)
+	}
+	if err = ctdp.storageClient.Set(ctx, stateKey, data); err != nil {
+		return fmt.Errorf("failed to save tracker state: %w", err)
+	}
+	return nil
+}
+
+func (ctdp *cumulativeToDeltaProcessor) snapshotter(ctx context.Context, interval time.Duration) {
+	defer ctdp.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := ctdp.saveState(ctx); err != nil {
+				ctdp.logger.Warn("Failed to snapshot tracker state", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newStorageTestMetrics(ts time.Time, value int64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("metric_1")
+	m.SetDataType(pmetric.MetricDataTypeSum)
+	m.Sum().SetIsMonotonic(true)
+	m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	dp := m.Sum().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	dp.SetIntVal(value)
+	return md
+}
+
+func TestStorageRestoresState(t *testing.T) {
+	ctx := context.Background()
+	storageExt := storagetest.NewFileBackedStorageExtension("test", t.TempDir())
+	host := storagetest.NewStorageHost().WithExtension(storageExt.ID(), storageExt)
+
+	cfg := createDefaultConfig().(*Config)
+	storageID := storageExt.ID()
+	cfg.StorageID = &storageID
+
+	now := time.Now()
+	next := new(consumertest.MetricsSink)
+	p, err := createMetricsProcessor(ctx, componenttest.NewNopProcessorCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(ctx, host))
+	require.NoError(t, p.ConsumeMetrics(ctx, newStorageTestMetrics(now, 100)))
+	require.NoError(t, p.Shutdown(ctx))
+
+	// Cycle the processor
+	next.Reset()
+	p, err = createMetricsProcessor(ctx, componenttest.NewNopProcessorCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(ctx, host))
+	require.NoError(t, p.ConsumeMetrics(ctx, newStorageTestMetrics(now.Add(time.Second), 150)))
+	require.NoError(t, p.Shutdown(ctx))
+
+	// The first point after the restart is converted using the saved state.
+	got := next.AllMetrics()
+	require.Len(t, got, 1)
+	dp := got[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(50), dp.IntVal())
+	assert.Equal(t, pcommon.NewTimestampFromTime(now), dp.StartTimestamp())
+}
+
+func TestStorageSnapshotInterval(t *testing.T) {
+	ctx := context.Background()
+	storageExt := storagetest.NewInMemoryStorageExtension("test")
+	host := storagetest.NewStorageHost().WithExtension(storageExt.ID(), storageExt)
+
+	cfg := createDefaultConfig().(*Config)
+	storageID := storageExt.ID()
+	cfg.StorageID = &storageID
+	cfg.SnapshotInterval = time.Millisecond
+
+	ctdp := newCumulativeToDeltaProcessor(cfg, componenttest.NewNopTelemetrySettings().Logger)
+	require.NoError(t, ctdp.start(ctx, host))
+	_, err := ctdp.processMetrics(ctx, newStorageTestMetrics(time.Now(), 100))
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		data, err := ctdp.storageClient.Get(ctx, stateKey)
+		return err == nil && data != nil
+	}, 5*time.Second, time.Millisecond)
+
+	require.NoError(t, ctdp.shutdown(ctx))
+}
+
+func TestStorageInvalidState(t *testing.T) {
+	ctx := context.Background()
+	storageExt := storagetest.NewInMemoryStorageExtension("test")
+	host := storagetest.NewStorageHost().WithExtension(storageExt.ID(), storageExt)
+
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, config.NewComponentID(typeStr), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, stateKey, []byte("invalid")))
+
+	cfg := createDefaultConfig().(*Config)
+	storageID := storageExt.ID()
+	cfg.StorageID = &storageID
+
+	// An invalid state is discarded rather than failing the processor.
+	ctdp := newCumulativeToDeltaProcessor(cfg, componenttest.NewNopTelemetrySettings().Logger)
+	require.NoError(t, ctdp.start(ctx, host))
+	require.NoError(t, ctdp.shutdown(ctx))
+}
+
+func TestStorageMissingExtension(t *testing.T) {
+	tests := []struct {
+		name      string
+		host      *storagetest.StorageHost
+		storageID config.ComponentID
+		wantErr   string
+	}{
+		{
+			name:      "not found",
+			host:      storagetest.NewStorageHost(),
+			storageID: storagetest.NewStorageID("missing"),
+			wantErr:   "storage extension 'test_storage/missing' not found",
+		},
+		{
+			name:      "not a storage extension",
+			host:      storagetest.NewStorageHost().WithNonStorageExtension("other"),
+			storageID: storagetest.NewNonStorageID("other"),
+			wantErr:   "non-storage extension 'non_storage/other' found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.StorageID = &tt.storageID
+
+			ctdp := newCumulativeToDeltaProcessor(cfg, componenttest.NewNopTelemetrySettings().Logger)
+			assert.EqualError(t, ctdp.start(context.Background(), tt.host), tt.wantErr)
+			require.NoError(t, ctdp.shutdown(context.Background()))
+		})
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/tracking"
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+// Allocate a minimum of 64 bytes to the builder initially
+const initialBytes = 64
+
+var identityBufferPool = sync.Pool{
+	New: func() interface{} {
+		return bytes.NewBuffer(make([]byte, initialBytes))
+	},
+}
+
+// State is the accumulated state of a stream.
+type State struct {
+	Identity       MetricIdentity
+	StartTimestamp pcommon.Timestamp
+	LastTimestamp  pcommon.Timestamp
+	LastSeen       time.Time
+
+	Number               NumberValue
+	Histogram            *HistogramValue
+	ExponentialHistogram *ExponentialHistogramValue
+}
+
+// reset starts a new cumulative series at the given point.
+func (s *State) reset(start, timestamp pcommon.Timestamp) {
+	if start == 0 {
+		start = timestamp
+	}
+	s.StartTimestamp = start
+	s.LastTimestamp = timestamp
+	s.Number = NumberValue{}
+	s.Histogram = nil
+	s.ExponentialHistogram = nil
+}
+
+// NewMetricTracker creates a tracker which removes streams not seen for
+// maxStaleness, and tracks at most maxStreams streams. Zero disables either limit.
+func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration, maxStreams int) *MetricTracker {
+	t := &MetricTracker{
+		logger:       logger,
+		maxStaleness: maxStaleness,
+		maxStreams:   maxStreams,
+		states:       map[string]*State{},
+		now:          time.Now,
+	}
+	if maxStaleness > 0 {
+		go t.sweeper(ctx, t.removeStale)
+	}
+	return t
+}
+
+// MetricTracker accumulates delta points into cumulative values per stream.
+type MetricTracker struct {
+	logger       *zap.Logger
+	maxStaleness time.Duration
+	maxStreams   int
+
+	mu     sync.Mutex
+	states map[string]*State
+	now    func() time.Time
+}
+
+// ConvertNumber accumulates the delta point of a sum and replaces its value and
+// start timestamp with the cumulative ones. It returns false if the point must be dropped.
+func (t *MetricTracker) ConvertNumber(id MetricIdentity, dp pmetric.NumberDataPoint) bool {
+	// NaN is used to signal "stale" metrics and cannot be accumulated.
+	if id.IsFloatVal() && math.IsNaN(dp.DoubleVal()) {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, continued := t.stateFor(id, dp.StartTimestamp(), dp.Timestamp())
+	if state == nil {
+		return false
+	}
+
+	if continued {
+		state.Number.FloatValue += dp.DoubleVal()
+		state.Number.IntValue += dp.IntVal()
+	} else {
+		state.Number = NumberValue{FloatValue: dp.DoubleVal(), IntValue: dp.IntVal()}
+	}
+
+	dp.SetStartTimestamp(state.StartTimestamp)
+	if id.IsFloatVal() {
+		dp.SetDoubleVal(state.Number.FloatValue)
+	} else {
+		dp.SetIntVal(state.Number.IntValue)
+	}
+	return true
+}
+
+// ConvertHistogram accumulates the delta point and sets the cumulative point on
+// dest, which must be a new point. It returns false if the point must be dropped.
+func (t *MetricTracker) ConvertHistogram(id MetricIdentity, dp pmetric.HistogramDataPoint, dest pmetric.HistogramDataPoint) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, continued := t.stateFor(id, dp.StartTimestamp(), dp.Timestamp())
+	if state == nil {
+		return false
+	}
+
+	switch {
+	case !continued || state.Histogram == nil:
+		state.Histogram = NewHistogramValue(dp)
+	case !state.Histogram.Compatible(dp):
+		// The buckets changed, the previous counts cannot be carried over.
+		state.reset(dp.StartTimestamp(), dp.Timestamp())
+		state.Histogram = NewHistogramValue(dp)
+	default:
+		state.Histogram.Add(dp)
+	}
+
+	dp.Attributes().CopyTo(dest.Attributes())
+	dest.SetStartTimestamp(state.StartTimestamp)
+	dest.SetTimestamp(dp.Timestamp())
+	dest.SetFlagsImmutable(dp.FlagsImmutable())
+	dp.Exemplars().CopyTo(dest.Exemplars())
+	state.Histogram.CopyTo(dest)
+	return true
+}
+
+// ConvertExponentialHistogram accumulates the delta point and sets the cumulative
+// point on dest, which must be a new point. It returns false if the point must be dropped.
+func (t *MetricTracker) ConvertExponentialHistogram(id MetricIdentity, dp pmetric.ExponentialHistogramDataPoint, dest pmetric.ExponentialHistogramDataPoint) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, continued := t.stateFor(id, dp.StartTimestamp(), dp.Timestamp())
+	if state == nil {
+		return false
+	}
+
+	if !continued || state.ExponentialHistogram == nil {
+		state.ExponentialHistogram = NewExponentialHistogramValue(dp)
+	} else {
+		state.ExponentialHistogram.Add(dp)
+	}
+
+	dp.Attributes().CopyTo(dest.Attributes())
+	dest.SetStartTimestamp(state.StartTimestamp)
+	dest.SetTimestamp(dp.Timestamp())
+	dest.SetFlagsImmutable(dp.FlagsImmutable())
+	dp.Exemplars().CopyTo(dest.Exemplars())
+	state.ExponentialHistogram.CopyTo(dest)
+	return true
+}
+
+// stateFor returns the state of the stream a point belongs to, and whether the
+// point continues the accumulated series. A nil state is returned for points
+// that must be dropped. It must be called while holding the lock.
+func (t *MetricTracker) stateFor(id MetricIdentity, start, timestamp pcommon.Timestamp) (*State, bool) {
+	if !id.IsSupportedMetricType() {
+		return nil, false
+	}
+
+	b := identityBufferPool.Get().(*bytes.Buffer)
+	b.Reset()
+	id.Write(b)
+	hashableID := b.String()
+	identityBufferPool.Put(b)
+
+	state, ok := t.states[hashableID]
+	if !ok {
+		if t.maxStreams > 0 && len(t.states) >= t.maxStreams {
+			t.logger.Debug("dropping point of new stream, max_streams reached", zap.String("metric", id.MetricName))
+			return nil, false
+		}
+		state = &State{Identity: id}
+		state.reset(start, timestamp)
+		state.LastSeen = t.now()
+		t.states[hashableID] = state
+		return state, false
+	}
+
+	// Points at or before the last accumulated point are either duplicates
+	// or arrived out of order. Accumulating them would double count.
+	if timestamp <= state.LastTimestamp {
+		t.logger.Debug("dropping out of order point", zap.String("metric", id.MetricName))
+		return nil, false
+	}
+
+	state.LastSeen = t.now()
+
+	// A point starting after the last accumulated point means that points
+	// were lost in between. Restart the series instead of reporting a wrong total.
+	if start > state.LastTimestamp {
+		t.logger.Debug("gap detected, starting new cumulative series", zap.String("metric", id.MetricName))
+		state.reset(start, timestamp)
+		return state, false
+	}
+
+	state.LastTimestamp = timestamp
+	return state, true
+}
+
+func (t *MetricTracker) removeStale(staleBefore time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, state := range t.states {
+		if state.LastSeen.Before(staleBefore) {
+			t.logger.Debug("removing stale state key", zap.String("key", key))
+			delete(t.states, key)
+		}
+	}
+}
+
+func (t *MetricTracker) sweeper(ctx context.Context, remove func(time.Time)) {
+	ticker := time.NewTicker(t.maxStaleness)
+	for {
+		select {
+		case currentTime := <-ticker.C:
+			remove(currentTime.Add(-t.maxStaleness))
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		}
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+func newSumIdentity(name string) MetricIdentity {
+	return MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricDataType:         pmetric.MetricDataTypeSum,
+		MetricIsMonotonic:      true,
+		MetricName:             name,
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+		Attributes:             pcommon.NewMap(),
+	}
+}
+
+func newIntPoint(start, ts pcommon.Timestamp, value int64) pmetric.NumberDataPoint {
+	dp := pmetric.NewNumberDataPoint()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(value)
+	return dp
+}
+
+func TestMetricTracker_ConvertNumber(t *testing.T) {
+	id := newSumIdentity("m")
+
+	tests := []struct {
+		name      string
+		start     pcommon.Timestamp
+		timestamp pcommon.Timestamp
+		value     int64
+		wantOK    bool
+		wantStart pcommon.Timestamp
+		wantValue int64
+	}{
+		{
+			name:      "first point starts series",
+			start:     10,
+			timestamp: 20,
+			value:     5,
+			wantOK:    true,
+			wantStart: 10,
+			wantValue: 5,
+		},
+		{
+			name:      "contiguous point accumulates",
+			start:     20,
+			timestamp: 30,
+			value:     3,
+			wantOK:    true,
+			wantStart: 10,
+			wantValue: 8,
+		},
+		{
+			name:      "duplicate point dropped",
+			start:     20,
+			timestamp: 30,
+			value:     3,
+			wantOK:    false,
+		},
+		{
+			name:      "out of order point dropped",
+			start:     15,
+			timestamp: 25,
+			value:     1,
+			wantOK:    false,
+		},
+		{
+			name:      "overlapping point accumulates",
+			start:     25,
+			timestamp: 40,
+			value:     2,
+			wantOK:    true,
+			wantStart: 10,
+			wantValue: 10,
+		},
+		{
+			name:      "gap restarts series",
+			start:     50,
+			timestamp: 60,
+			value:     4,
+			wantOK:    true,
+			wantStart: 50,
+			wantValue: 4,
+		},
+		{
+			name:      "point without start accumulates",
+			start:     0,
+			timestamp: 70,
+			value:     1,
+			wantOK:    true,
+			wantStart: 50,
+			wantValue: 5,
+		},
+	}
+
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dp := newIntPoint(tt.start, tt.timestamp, tt.value)
+			ok := m.ConvertNumber(id, dp)
+			require.Equal(t, tt.wantOK, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.wantStart, dp.StartTimestamp())
+			assert.Equal(t, tt.timestamp, dp.Timestamp())
+			assert.Equal(t, tt.wantValue, dp.IntVal())
+		})
+	}
+}
+
+func TestMetricTracker_ConvertNumberFloat(t *testing.T) {
+	id := newSumIdentity("m")
+	id.MetricValueType = pmetric.NumberDataPointValueTypeDouble
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+
+	// Points without a start timestamp start the series at their own timestamp.
+	for i, value := range []float64{1.5, 2.5, 3} {
+		dp := pmetric.NewNumberDataPoint()
+		dp.SetTimestamp(pcommon.Timestamp((i + 1) * 10))
+		dp.SetDoubleVal(value)
+		require.True(t, m.ConvertNumber(id, dp))
+		assert.Equal(t, pcommon.Timestamp(10), dp.StartTimestamp())
+		if i == 2 {
+			assert.Equal(t, 7.0, dp.DoubleVal())
+		}
+	}
+}
+
+func TestMetricTracker_MaxStreams(t *testing.T) {
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 2)
+
+	assert.True(t, m.ConvertNumber(newSumIdentity("a"), newIntPoint(0, 10, 1)))
+	assert.True(t, m.ConvertNumber(newSumIdentity("b"), newIntPoint(0, 10, 1)))
+	assert.False(t, m.ConvertNumber(newSumIdentity("c"), newIntPoint(0, 10, 1)))
+
+	// Known streams are still converted.
+	dp := newIntPoint(10, 20, 1)
+	assert.True(t, m.ConvertNumber(newSumIdentity("a"), dp))
+	assert.Equal(t, int64(2), dp.IntVal())
+}
+
+func TestMetricTracker_UnsupportedType(t *testing.T) {
+	id := newSumIdentity("m")
+	id.MetricDataType = pmetric.MetricDataTypeGauge
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+	assert.False(t, m.ConvertNumber(id, newIntPoint(0, 10, 1)))
+}
+
+func TestMetricTracker_RemoveStale(t *testing.T) {
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+	now := time.Unix(1000, 0)
+	m.now = func() time.Time { return now }
+
+	require.True(t, m.ConvertNumber(newSumIdentity("a"), newIntPoint(0, 10, 1)))
+	now = now.Add(time.Minute)
+	require.True(t, m.ConvertNumber(newSumIdentity("b"), newIntPoint(0, 10, 1)))
+
+	m.removeStale(now.Add(-time.Second))
+	assert.Len(t, m.states, 1)
+
+	// The expired stream starts a new series.
+	dp := newIntPoint(10, 20, 1)
+	require.True(t, m.ConvertNumber(newSumIdentity("a"), dp))
+	assert.Equal(t, int64(1), dp.IntVal())
+	assert.Equal(t, pcommon.Timestamp(10), dp.StartTimestamp())
+}
+
+func TestMetricTracker_Sweeper(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &MetricTracker{maxStaleness: time.Millisecond}
+
+	sweeps := make(chan time.Time, 1)
+	done := make(chan struct{})
+	go func() {
+		m.sweeper(ctx, func(staleBefore time.Time) {
+			select {
+			case sweeps <- staleBefore:
+			default:
+			}
+		})
+		close(done)
+	}()
+
+	staleBefore := <-sweeps
+	assert.WithinDuration(t, time.Now().Add(-time.Millisecond), staleBefore, time.Second)
+
+	cancel()
+	<-done
+}
+
+func TestMetricTracker_ConvertHistogram(t *testing.T) {
+	id := newSumIdentity("h")
+	id.MetricDataType = pmetric.MetricDataTypeHistogram
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+
+	newPoint := func(start, ts pcommon.Timestamp, bounds []float64, counts []uint64, min, max float64) pmetric.HistogramDataPoint {
+		dp := pmetric.NewHistogramDataPoint()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(ts)
+		var count uint64
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		dp.SetSum(float64(count))
+		dp.SetMin(min)
+		dp.SetMax(max)
+		dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice(bounds))
+		dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(counts))
+		return dp
+	}
+
+	dest := pmetric.NewHistogramDataPoint()
+	require.True(t, m.ConvertHistogram(id, newPoint(10, 20, []float64{1, 2}, []uint64{1, 2, 3}, 0.5, 3), dest))
+	assert.Equal(t, uint64(6), dest.Count())
+
+	dest = pmetric.NewHistogramDataPoint()
+	require.True(t, m.ConvertHistogram(id, newPoint(20, 30, []float64{1, 2}, []uint64{1, 0, 1}, 0.1, 2.5), dest))
+	assert.Equal(t, pcommon.Timestamp(10), dest.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(30), dest.Timestamp())
+	assert.Equal(t, uint64(8), dest.Count())
+	assert.Equal(t, 8.0, dest.Sum())
+	assert.Equal(t, 0.1, dest.Min())
+	assert.Equal(t, 3.0, dest.Max())
+	assert.Equal(t, []float64{1, 2}, dest.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{2, 2, 4}, dest.BucketCounts().AsRaw())
+
+	// Changed buckets restart the series.
+	dest = pmetric.NewHistogramDataPoint()
+	require.True(t, m.ConvertHistogram(id, newPoint(30, 40, []float64{5}, []uint64{1, 1}, 1, 6), dest))
+	assert.Equal(t, pcommon.Timestamp(30), dest.StartTimestamp())
+	assert.Equal(t, uint64(2), dest.Count())
+	assert.Equal(t, []float64{5}, dest.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{1, 1}, dest.BucketCounts().AsRaw())
+
+	// Out of order points are dropped.
+	assert.False(t, m.ConvertHistogram(id, newPoint(30, 40, []float64{5}, []uint64{1, 1}, 1, 6), pmetric.NewHistogramDataPoint()))
+}
+
+func TestMetricTracker_ConvertExponentialHistogram(t *testing.T) {
+	id := newSumIdentity("e")
+	id.MetricDataType = pmetric.MetricDataTypeExponentialHistogram
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetStartTimestamp(10)
+	dp.SetTimestamp(20)
+	dp.SetCount(4)
+	dp.SetZeroCount(1)
+	dp.SetScale(1)
+	dp.Positive().SetOffset(2)
+	dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{1, 2}))
+	dest := pmetric.NewExponentialHistogramDataPoint()
+	require.True(t, m.ConvertExponentialHistogram(id, dp, dest))
+	assert.Equal(t, int32(1), dest.Scale())
+
+	dp = pmetric.NewExponentialHistogramDataPoint()
+	dp.SetStartTimestamp(20)
+	dp.SetTimestamp(30)
+	dp.SetCount(2)
+	dp.SetScale(0)
+	dp.Positive().SetOffset(1)
+	dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{2}))
+	dest = pmetric.NewExponentialHistogramDataPoint()
+	require.True(t, m.ConvertExponentialHistogram(id, dp, dest))
+
+	assert.Equal(t, pcommon.Timestamp(10), dest.StartTimestamp())
+	assert.Equal(t, uint64(6), dest.Count())
+	assert.Equal(t, uint64(1), dest.ZeroCount())
+	assert.Equal(t, int32(0), dest.Scale())
+	assert.Equal(t, int32(1), dest.Positive().Offset())
+	assert.Equal(t, []uint64{5}, dest.Positive().BucketCounts().AsRaw())
+}
@@ -0,0 +1,293 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/tracking"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// maxExponentialBuckets is the maximum number of buckets kept for each range
+// of an exponential histogram. Accumulated histograms are downscaled until
+// their buckets fit, matching the default maximum size of the SDKs.
+const maxExponentialBuckets = 160
+
+// NumberValue is the accumulated value of a sum stream.
+type NumberValue struct {
+	FloatValue float64
+	IntValue   int64
+}
+
+// HistogramValue is the accumulated value of an explicit bucket histogram stream.
+type HistogramValue struct {
+	Count          uint64
+	Sum            float64
+	HasSum         bool
+	Min            float64
+	HasMin         bool
+	Max            float64
+	HasMax         bool
+	ExplicitBounds []float64
+	BucketCounts   []uint64
+}
+
+// NewHistogramValue creates an accumulated value starting at the given point.
+func NewHistogramValue(dp pmetric.HistogramDataPoint) *HistogramValue {
+	return &HistogramValue{
+		Count:          dp.Count(),
+		Sum:            dp.Sum(),
+		HasSum:         dp.HasSum(),
+		Min:            dp.Min(),
+		HasMin:         dp.HasMin(),
+		Max:            dp.Max(),
+		HasMax:         dp.HasMax(),
+		ExplicitBounds: dp.ExplicitBounds().AsRaw(),
+		BucketCounts:   dp.BucketCounts().AsRaw(),
+	}
+}
+
+// Compatible reports whether the point has the same buckets as the accumulated value.
+func (v *HistogramValue) Compatible(dp pmetric.HistogramDataPoint) bool {
+	if dp.BucketCounts().Len() != len(v.BucketCounts) || dp.ExplicitBounds().Len() != len(v.ExplicitBounds) {
+		return false
+	}
+	for i, bound := range v.ExplicitBounds {
+		if dp.ExplicitBounds().At(i) != bound {
+			return false
+		}
+	}
+	return true
+}
+
+// Add accumulates the point. The point must be compatible with the value.
+func (v *HistogramValue) Add(dp pmetric.HistogramDataPoint) {
+	v.Count += dp.Count()
+	v.Sum += dp.Sum()
+	v.HasSum = v.HasSum && dp.HasSum()
+	v.Min, v.HasMin = accumulateMin(v.Min, v.HasMin, dp.Min(), dp.HasMin())
+	v.Max, v.HasMax = accumulateMax(v.Max, v.HasMax, dp.Max(), dp.HasMax())
+	for i := range v.BucketCounts {
+		v.BucketCounts[i] += dp.BucketCounts().At(i)
+	}
+}
+
+// CopyTo sets the accumulated value on the point, which must be a new point
+// as optional fields are only ever set.
+func (v *HistogramValue) CopyTo(dp pmetric.HistogramDataPoint) {
+	dp.SetCount(v.Count)
+	if v.HasSum {
+		dp.SetSum(v.Sum)
+	}
+	if v.HasMin {
+		dp.SetMin(v.Min)
+	}
+	if v.HasMax {
+		dp.SetMax(v.Max)
+	}
+	dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice(append([]float64(nil), v.ExplicitBounds...)))
+	dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(append([]uint64(nil), v.BucketCounts...)))
+}
+
+// ExponentialBuckets are the buckets of one range of an exponential histogram.
+type ExponentialBuckets struct {
+	Offset       int32
+	BucketCounts []uint64
+}
+
+func newExponentialBuckets(b pmetric.Buckets) ExponentialBuckets {
+	return ExponentialBuckets{
+		Offset:       b.Offset(),
+		BucketCounts: b.BucketCounts().AsRaw(),
+	}
+}
+
+// downscale merges buckets so that they represent a scale reduced by the given amount.
+func (b *ExponentialBuckets) downscale(by int32) {
+	if by <= 0 || len(b.BucketCounts) == 0 {
+		return
+	}
+
+	offset := b.Offset >> by
+	last := (b.Offset + int32(len(b.BucketCounts)) - 1) >> by
+	counts := make([]uint64, last-offset+1)
+	for i, count := range b.BucketCounts {
+		counts[((b.Offset+int32(i))>>by)-offset] += count
+	}
+
+	b.Offset = offset
+	b.BucketCounts = counts
+}
+
+// merge adds the counts of buckets with the same scale.
+func (b *ExponentialBuckets) merge(o ExponentialBuckets) {
+	if len(o.BucketCounts) == 0 {
+		return
+	}
+	if len(b.BucketCounts) == 0 {
+		b.Offset = o.Offset
+		b.BucketCounts = append([]uint64(nil), o.BucketCounts...)
+		return
+	}
+
+	offset := minInt32(b.Offset, o.Offset)
+	last := maxInt32(b.Offset+int32(len(b.BucketCounts)), o.Offset+int32(len(o.BucketCounts))) - 1
+	counts := make([]uint64, last-offset+1)
+	for i, count := range b.BucketCounts {
+		counts[b.Offset+int32(i)-offset] += count
+	}
+	for i, count := range o.BucketCounts {
+		counts[o.Offset+int32(i)-offset] += count
+	}
+
+	b.Offset = offset
+	b.BucketCounts = counts
+}
+
+// scaleReductionToFit returns by how much the scale must be reduced for the
+// buckets to fit in maxExponentialBuckets.
+func (b *ExponentialBuckets) scaleReductionToFit() int32 {
+	if len(b.BucketCounts) == 0 {
+		return 0
+	}
+	var by int32
+	first := b.Offset
+	last := b.Offset + int32(len(b.BucketCounts)) - 1
+	for (last>>by)-(first>>by)+1 > maxExponentialBuckets {
+		by++
+	}
+	return by
+}
+
+func (b *ExponentialBuckets) copyTo(dest pmetric.Buckets) {
+	dest.SetOffset(b.Offset)
+	dest.SetBucketCounts(pcommon.NewImmutableUInt64Slice(append([]uint64(nil), b.BucketCounts...)))
+}
+
+// ExponentialHistogramValue is the accumulated value of an exponential histogram stream.
+type ExponentialHistogramValue struct {
+	Count     uint64
+	Sum       float64
+	HasSum    bool
+	Min       float64
+	HasMin    bool
+	Max       float64
+	HasMax    bool
+	Scale     int32
+	ZeroCount uint64
+	Positive  ExponentialBuckets
+	Negative  ExponentialBuckets
+}
+
+// NewExponentialHistogramValue creates an accumulated value starting at the given point.
+func NewExponentialHistogramValue(dp pmetric.ExponentialHistogramDataPoint) *ExponentialHistogramValue {
+	v := &ExponentialHistogramValue{
+		Count:     dp.Count(),
+		Sum:       dp.Sum(),
+		HasSum:    dp.HasSum(),
+		Min:       dp.Min(),
+		HasMin:    dp.HasMin(),
+		Max:       dp.Max(),
+		HasMax:    dp.HasMax(),
+		Scale:     dp.Scale(),
+		ZeroCount: dp.ZeroCount(),
+		Positive:  newExponentialBuckets(dp.Positive()),
+		Negative:  newExponentialBuckets(dp.Negative()),
+	}
+	v.fit()
+	return v
+}
+
+// Add accumulates the point. Points with a different scale are merged at the
+// lower of both scales.
+func (v *ExponentialHistogramValue) Add(dp pmetric.ExponentialHistogramDataPoint) {
+	v.Count += dp.Count()
+	v.Sum += dp.Sum()
+	v.HasSum = v.HasSum && dp.HasSum()
+	v.Min, v.HasMin = accumulateMin(v.Min, v.HasMin, dp.Min(), dp.HasMin())
+	v.Max, v.HasMax = accumulateMax(v.Max, v.HasMax, dp.Max(), dp.HasMax())
+	v.ZeroCount += dp.ZeroCount()
+
+	positive := newExponentialBuckets(dp.Positive())
+	negative := newExponentialBuckets(dp.Negative())
+
+	scale := minInt32(v.Scale, dp.Scale())
+	v.downscale(v.Scale - scale)
+	positive.downscale(dp.Scale() - scale)
+	negative.downscale(dp.Scale() - scale)
+
+	v.Positive.merge(positive)
+	v.Negative.merge(negative)
+	v.fit()
+}
+
+// CopyTo sets the accumulated value on the point, which must be a new point
+// as optional fields are only ever set.
+func (v *ExponentialHistogramValue) CopyTo(dp pmetric.ExponentialHistogramDataPoint) {
+	dp.SetCount(v.Count)
+	if v.HasSum {
+		dp.SetSum(v.Sum)
+	}
+	if v.HasMin {
+		dp.SetMin(v.Min)
+	}
+	if v.HasMax {
+		dp.SetMax(v.Max)
+	}
+	dp.SetScale(v.Scale)
+	dp.SetZeroCount(v.ZeroCount)
+	v.Positive.copyTo(dp.Positive())
+	v.Negative.copyTo(dp.Negative())
+}
+
+func (v *ExponentialHistogramValue) downscale(by int32) {
+	v.Scale -= by
+	v.Positive.downscale(by)
+	v.Negative.downscale(by)
+}
+
+// fit downscales the value until both bucket ranges fit in maxExponentialBuckets.
+func (v *ExponentialHistogramValue) fit() {
+	v.downscale(maxInt32(v.Positive.scaleReductionToFit(), v.Negative.scaleReductionToFit()))
+}
+
+func accumulateMin(current float64, hasCurrent bool, value float64, hasValue bool) (float64, bool) {
+	if !hasCurrent || !hasValue {
+		return 0, false
+	}
+	return math.Min(current, value), true
+}
+
+func accumulateMax(current float64, hasCurrent bool, value float64, hasValue bool) (float64, bool) {
+	if !hasCurrent || !hasValue {
+		return 0, false
+	}
+	return math.Max(current, value), true
+}
+
+func minInt32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt32(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
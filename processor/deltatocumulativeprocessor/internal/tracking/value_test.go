@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialBuckets_Downscale(t *testing.T) {
+	tests := []struct {
+		name       string
+		buckets    ExponentialBuckets
+		by         int32
+		wantOffset int32
+		wantCounts []uint64
+	}{
+		{
+			name:       "no change",
+			buckets:    ExponentialBuckets{Offset: 3, BucketCounts: []uint64{1, 2}},
+			by:         0,
+			wantOffset: 3,
+			wantCounts: []uint64{1, 2},
+		},
+		{
+			name:       "by one",
+			buckets:    ExponentialBuckets{Offset: 3, BucketCounts: []uint64{1, 2, 3, 4}},
+			by:         1,
+			wantOffset: 1,
+			wantCounts: []uint64{1, 5, 4},
+		},
+		{
+			name:       "negative offset",
+			buckets:    ExponentialBuckets{Offset: -3, BucketCounts: []uint64{1, 2, 3}},
+			by:         1,
+			wantOffset: -2,
+			wantCounts: []uint64{1, 5},
+		},
+		{
+			name:       "by two",
+			buckets:    ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1, 1, 1, 1, 1}},
+			by:         2,
+			wantOffset: 0,
+			wantCounts: []uint64{4, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.buckets.downscale(tt.by)
+			assert.Equal(t, tt.wantOffset, tt.buckets.Offset)
+			assert.Equal(t, tt.wantCounts, tt.buckets.BucketCounts)
+		})
+	}
+}
+
+func TestExponentialBuckets_Merge(t *testing.T) {
+	b := ExponentialBuckets{}
+	b.merge(ExponentialBuckets{Offset: 2, BucketCounts: []uint64{1, 1}})
+	assert.Equal(t, ExponentialBuckets{Offset: 2, BucketCounts: []uint64{1, 1}}, b)
+
+	b.merge(ExponentialBuckets{Offset: -1, BucketCounts: []uint64{2}})
+	assert.Equal(t, ExponentialBuckets{Offset: -1, BucketCounts: []uint64{2, 0, 0, 1, 1}}, b)
+
+	b.merge(ExponentialBuckets{Offset: 3, BucketCounts: []uint64{1, 1}})
+	assert.Equal(t, ExponentialBuckets{Offset: -1, BucketCounts: []uint64{2, 0, 0, 1, 2, 1}}, b)
+}
+
+func TestExponentialHistogramValue_Fit(t *testing.T) {
+	counts := make([]uint64, maxExponentialBuckets*2)
+	for i := range counts {
+		counts[i] = 1
+	}
+	v := &ExponentialHistogramValue{
+		Scale:    5,
+		Positive: ExponentialBuckets{Offset: 0, BucketCounts: counts},
+		Negative: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1}},
+	}
+	v.fit()
+
+	assert.Equal(t, int32(4), v.Scale)
+	assert.Len(t, v.Positive.BucketCounts, maxExponentialBuckets)
+	assert.Equal(t, []uint64{1}, v.Negative.BucketCounts)
+}
+
+func TestAccumulateMinMax(t *testing.T) {
+	min, ok := accumulateMin(1, true, 0.5, true)
+	assert.True(t, ok)
+	assert.Equal(t, 0.5, min)
+
+	_, ok = accumulateMin(1, true, 0, false)
+	assert.False(t, ok)
+
+	max, ok := accumulateMax(1, true, 0.5, true)
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, max)
+}
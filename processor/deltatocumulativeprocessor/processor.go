@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/tracking"
+)
+
+type deltaToCumulativeProcessor struct {
+	includeFS  filterset.FilterSet
+	excludeFS  filterset.FilterSet
+	logger     *zap.Logger
+	tracker    *tracking.MetricTracker
+	cancelFunc context.CancelFunc
+}
+
+func newDeltaToCumulativeProcessor(config *Config, logger *zap.Logger) *deltaToCumulativeProcessor {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &deltaToCumulativeProcessor{
+		logger:     logger,
+		tracker:    tracking.NewMetricTracker(ctx, logger, config.MaxStaleness, config.MaxStreams),
+		cancelFunc: cancel,
+	}
+	if len(config.Include.Metrics) > 0 {
+		p.includeFS, _ = filterset.CreateFilterSet(config.Include.Metrics, &config.Include.Config)
+	}
+	if len(config.Exclude.Metrics) > 0 {
+		p.excludeFS, _ = filterset.CreateFilterSet(config.Exclude.Metrics, &config.Exclude.Config)
+	}
+	return p
+}
+
+// processMetrics implements the ProcessMetricsFunc type.
+func (dtcp *deltaToCumulativeProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(ilm pmetric.ScopeMetrics) bool {
+			ilm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				if !dtcp.shouldConvertMetric(m.Name()) {
+					return false
+				}
+
+				baseIdentity := tracking.MetricIdentity{
+					Resource:               rm.Resource(),
+					InstrumentationLibrary: ilm.Scope(),
+					MetricDataType:         m.DataType(),
+					MetricName:             m.Name(),
+					MetricUnit:             m.Unit(),
+				}
+
+				switch m.DataType() {
+				case pmetric.MetricDataTypeSum:
+					ms := m.Sum()
+					if ms.AggregationTemporality() != pmetric.MetricAggregationTemporalityDelta {
+						return false
+					}
+
+					baseIdentity.MetricIsMonotonic = ms.IsMonotonic()
+					dtcp.convertDataPoints(ms.DataPoints(), baseIdentity)
+					ms.SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricDataTypeHistogram:
+					ms := m.Histogram()
+					if ms.AggregationTemporality() != pmetric.MetricAggregationTemporalityDelta {
+						return false
+					}
+
+					baseIdentity.MetricIsMonotonic = true
+					dtcp.convertHistogramDataPoints(ms.DataPoints(), baseIdentity)
+					ms.SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricDataTypeExponentialHistogram:
+					ms := m.ExponentialHistogram()
+					if ms.AggregationTemporality() != pmetric.MetricAggregationTemporalityDelta {
+						return false
+					}
+
+					baseIdentity.MetricIsMonotonic = true
+					dtcp.convertExponentialHistogramDataPoints(ms.DataPoints(), baseIdentity)
+					ms.SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+					return ms.DataPoints().Len() == 0
+				default:
+					return false
+				}
+			})
+			return ilm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return md, nil
+}
+
+func (dtcp *deltaToCumulativeProcessor) shutdown(context.Context) error {
+	dtcp.cancelFunc()
+	return nil
+}
+
+func (dtcp *deltaToCumulativeProcessor) shouldConvertMetric(metricName string) bool {
+	return (dtcp.includeFS == nil || dtcp.includeFS.Matches(metricName)) &&
+		(dtcp.excludeFS == nil || !dtcp.excludeFS.Matches(metricName))
+}
+
+func (dtcp *deltaToCumulativeProcessor) convertDataPoints(dps pmetric.NumberDataPointSlice, baseIdentity tracking.MetricIdentity) {
+	dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+		id := baseIdentity
+		id.Attributes = dp.Attributes()
+		id.MetricValueType = dp.ValueType()
+		return !dtcp.tracker.ConvertNumber(id, dp)
+	})
+}
+
+func (dtcp *deltaToCumulativeProcessor) convertHistogramDataPoints(dps pmetric.HistogramDataPointSlice, baseIdentity tracking.MetricIdentity) {
+	// Cumulative points are built from scratch, as optional fields such as
+	// min and max of a delta point cannot be unset.
+	converted := pmetric.NewHistogramDataPointSlice()
+	converted.EnsureCapacity(dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		id := baseIdentity
+		id.Attributes = dp.Attributes()
+
+		cumulative := pmetric.NewHistogramDataPoint()
+		if dtcp.tracker.ConvertHistogram(id, dp, cumulative) {
+			cumulative.MoveTo(converted.AppendEmpty())
+		}
+	}
+	converted.CopyTo(dps)
+}
+
+func (dtcp *deltaToCumulativeProcessor) convertExponentialHistogramDataPoints(dps pmetric.ExponentialHistogramDataPointSlice, baseIdentity tracking.MetricIdentity) {
+	converted := pmetric.NewExponentialHistogramDataPointSlice()
+	converted.EnsureCapacity(dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		id := baseIdentity
+		id.Attributes = dp.Attributes()
+
+		cumulative := pmetric.NewExponentialHistogramDataPoint()
+		if dtcp.tracker.ConvertExponentialHistogram(id, dp, cumulative) {
+			cumulative.MoveTo(converted.AppendEmpty())
+		}
+	}
+	converted.CopyTo(dps)
+}
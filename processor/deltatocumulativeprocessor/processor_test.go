@@ -0,0 +1,518 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+type testSumMetric struct {
+	metricNames  []string
+	metricValues [][]float64
+	isDelta      []bool
+}
+
+type testHistogramMetric struct {
+	metricNames   []string
+	metricCounts  [][]uint64
+	metricSums    [][]float64
+	metricBuckets [][][]uint64
+	isDelta       []bool
+}
+
+type testExponentialHistogramMetric struct {
+	metricNames   []string
+	metricScales  [][]int32
+	metricOffsets [][]int32
+	metricBuckets [][][]uint64
+	isDelta       []bool
+}
+
+type deltaToCumulativeTest struct {
+	name       string
+	include    MatchMetrics
+	exclude    MatchMetrics
+	maxStreams int
+	inMetrics  pmetric.Metrics
+	outMetrics pmetric.Metrics
+}
+
+var (
+	testStart = pcommon.NewTimestampFromTime(time.Unix(1000, 0))
+
+	testCases = []deltaToCumulativeTest{
+		{
+			name: "delta_to_cumulative_convert_nothing",
+			exclude: MatchMetrics{
+				Metrics: []string{".*"},
+				Config: filterset.Config{
+					MatchType:    "regexp",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100}, {4}},
+				isDelta:      []bool{true, true},
+			}),
+			outMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100}, {4}},
+				isDelta:      []bool{true, true},
+			}),
+		},
+		{
+			name: "delta_to_cumulative_one_positive",
+			include: MatchMetrics{
+				Metrics: []string{"metric_1"},
+				Config: filterset.Config{
+					MatchType:    "strict",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100, 100, 300}, {4}},
+				isDelta:      []bool{true, true},
+			}),
+			outMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100, 200, 500}, {4}},
+				isDelta:      []bool{false, true},
+			}),
+		},
+		{
+			name: "delta_to_cumulative_nan_value",
+			include: MatchMetrics{
+				Metrics: []string{"_1"},
+				Config: filterset.Config{
+					MatchType:    "regexp",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100, math.NaN(), 300}, {4}},
+				isDelta:      []bool{true, true},
+			}),
+			outMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames: []string{"metric_1", "metric_2"},
+				// The point after the dropped NaN starts a new series.
+				metricValues: [][]float64{{100, 300}, {4}},
+				isDelta:      []bool{false, true},
+			}),
+		},
+		{
+			name: "delta_to_cumulative_cumulative_untouched",
+			inMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100, 200}, {4}},
+				isDelta:      []bool{false, true},
+			}),
+			outMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100, 200}, {4}},
+				isDelta:      []bool{false, false},
+			}),
+		},
+		{
+			name: "delta_to_cumulative_exclude_precedence",
+			include: MatchMetrics{
+				Metrics: []string{".*"},
+				Config: filterset.Config{
+					MatchType:    "regexp",
+					RegexpConfig: nil,
+				},
+			},
+			exclude: MatchMetrics{
+				Metrics: []string{".*"},
+				Config: filterset.Config{
+					MatchType:    "regexp",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100}, {4}},
+				isDelta:      []bool{true, true},
+			}),
+			outMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100}, {4}},
+				isDelta:      []bool{true, true},
+			}),
+		},
+		{
+			name:       "delta_to_cumulative_max_streams",
+			maxStreams: 1,
+			inMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100, 100}, {4}},
+				isDelta:      []bool{true, true},
+			}),
+			outMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1"},
+				metricValues: [][]float64{{100, 200}},
+				isDelta:      []bool{false},
+			}),
+		},
+		{
+			name: "delta_to_cumulative_histogram_one_positive",
+			include: MatchMetrics{
+				Metrics: []string{"metric_1"},
+				Config: filterset.Config{
+					MatchType:    "strict",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricCounts: [][]uint64{{100, 100, 300}, {4}},
+				metricSums:   [][]float64{{100, 100, 300}, {4}},
+				metricBuckets: [][][]uint64{
+					{{50, 25, 25}, {50, 25, 25}, {150, 75, 75}},
+					{{4, 4, 4}},
+				},
+				isDelta: []bool{true, true},
+			}),
+			outMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricCounts: [][]uint64{{100, 200, 500}, {4}},
+				metricSums:   [][]float64{{100, 200, 500}, {4}},
+				metricBuckets: [][][]uint64{
+					{{50, 25, 25}, {100, 50, 50}, {250, 125, 125}},
+					{{4, 4, 4}},
+				},
+				isDelta: []bool{false, true},
+			}),
+		},
+		{
+			name: "delta_to_cumulative_histogram_no_sum",
+			inMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:  []string{"metric_1"},
+				metricCounts: [][]uint64{{100, 100}},
+				metricSums:   [][]float64{},
+				metricBuckets: [][][]uint64{
+					{{50, 25, 25}, {50, 25, 25}},
+				},
+				isDelta: []bool{true},
+			}),
+			outMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:  []string{"metric_1"},
+				metricCounts: [][]uint64{{100, 200}},
+				metricSums:   [][]float64{},
+				metricBuckets: [][][]uint64{
+					{{50, 25, 25}, {100, 50, 50}},
+				},
+				isDelta: []bool{false},
+			}),
+		},
+		{
+			name: "delta_to_cumulative_exponential_histogram",
+			inMetrics: generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+				metricNames:   []string{"metric_1"},
+				metricScales:  [][]int32{{1, 1, 0}},
+				metricOffsets: [][]int32{{0, 1, 0}},
+				metricBuckets: [][][]uint64{
+					{{1, 2}, {3}, {1, 1}},
+				},
+				isDelta: []bool{true},
+			}),
+			outMetrics: generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+				metricNames:   []string{"metric_1"},
+				metricScales:  [][]int32{{1, 1, 0}},
+				metricOffsets: [][]int32{{0, 0, 0}},
+				metricBuckets: [][][]uint64{
+					{{1, 2}, {1, 5}, {7, 1}},
+				},
+				isDelta: []bool{false},
+			}),
+		},
+	}
+)
+
+func TestDeltaToCumulativeProcessor(t *testing.T) {
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// next stores the results of the filter metric processor
+			next := new(consumertest.MetricsSink)
+			cfg := &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				MaxStreams:        test.maxStreams,
+				Include:           test.include,
+				Exclude:           test.exclude,
+			}
+			factory := NewFactory()
+			mgp, err := factory.CreateMetricsProcessor(
+				context.Background(),
+				componenttest.NewNopProcessorCreateSettings(),
+				cfg,
+				next,
+			)
+			assert.NotNil(t, mgp)
+			assert.Nil(t, err)
+
+			caps := mgp.Capabilities()
+			assert.True(t, caps.MutatesData)
+			ctx := context.Background()
+			require.NoError(t, mgp.Start(ctx, nil))
+
+			cErr := mgp.ConsumeMetrics(context.Background(), test.inMetrics)
+			assert.Nil(t, cErr)
+			got := next.AllMetrics()
+
+			require.Equal(t, 1, len(got))
+			require.Equal(t, test.outMetrics.ResourceMetrics().Len(), got[0].ResourceMetrics().Len())
+
+			expectedMetrics := test.outMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			actualMetrics := got[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+
+			require.Equal(t, expectedMetrics.Len(), actualMetrics.Len())
+
+			for i := 0; i < expectedMetrics.Len(); i++ {
+				eM := expectedMetrics.At(i)
+				aM := actualMetrics.At(i)
+
+				require.Equal(t, eM.Name(), aM.Name())
+
+				switch eM.DataType() {
+				case pmetric.MetricDataTypeSum:
+					eDataPoints := eM.Sum().DataPoints()
+					aDataPoints := aM.Sum().DataPoints()
+
+					require.Equal(t, eDataPoints.Len(), aDataPoints.Len())
+					require.Equal(t, eM.Sum().AggregationTemporality(), aM.Sum().AggregationTemporality())
+
+					for j := 0; j < eDataPoints.Len(); j++ {
+						require.Equal(t, eDataPoints.At(j).DoubleVal(), aDataPoints.At(j).DoubleVal())
+					}
+				case pmetric.MetricDataTypeHistogram:
+					eDataPoints := eM.Histogram().DataPoints()
+					aDataPoints := aM.Histogram().DataPoints()
+
+					require.Equal(t, eDataPoints.Len(), aDataPoints.Len())
+					require.Equal(t, eM.Histogram().AggregationTemporality(), aM.Histogram().AggregationTemporality())
+
+					for j := 0; j < eDataPoints.Len(); j++ {
+						require.Equal(t, eDataPoints.At(j).Count(), aDataPoints.At(j).Count())
+						require.Equal(t, eDataPoints.At(j).HasSum(), aDataPoints.At(j).HasSum())
+						require.Equal(t, eDataPoints.At(j).Sum(), aDataPoints.At(j).Sum())
+						require.Equal(t, eDataPoints.At(j).BucketCounts().AsRaw(), aDataPoints.At(j).BucketCounts().AsRaw())
+					}
+				case pmetric.MetricDataTypeExponentialHistogram:
+					eDataPoints := eM.ExponentialHistogram().DataPoints()
+					aDataPoints := aM.ExponentialHistogram().DataPoints()
+
+					require.Equal(t, eDataPoints.Len(), aDataPoints.Len())
+					require.Equal(t, eM.ExponentialHistogram().AggregationTemporality(), aM.ExponentialHistogram().AggregationTemporality())
+
+					for j := 0; j < eDataPoints.Len(); j++ {
+						require.Equal(t, eDataPoints.At(j).Count(), aDataPoints.At(j).Count())
+						require.Equal(t, eDataPoints.At(j).Scale(), aDataPoints.At(j).Scale())
+						require.Equal(t, eDataPoints.At(j).Positive().Offset(), aDataPoints.At(j).Positive().Offset())
+						require.Equal(t, eDataPoints.At(j).Positive().BucketCounts().AsRaw(), aDataPoints.At(j).Positive().BucketCounts().AsRaw())
+					}
+				}
+			}
+
+			require.NoError(t, mgp.Shutdown(ctx))
+		})
+	}
+}
+
+func TestDeltaToCumulativeProcessorStartTimestamp(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	p, err := createMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), createDefaultConfig(), next)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	in := generateTestSumMetrics(testSumMetric{
+		metricNames:  []string{"metric_1"},
+		metricValues: [][]float64{{1, 2, 3}},
+		isDelta:      []bool{true},
+	})
+	require.NoError(t, p.ConsumeMetrics(context.Background(), in))
+
+	// Every cumulative point starts at the start of the first delta point.
+	dps := next.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 3, dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		assert.Equal(t, testStart, dps.At(i).StartTimestamp())
+	}
+	assert.Equal(t, 6.0, dps.At(2).DoubleVal())
+
+	require.NoError(t, p.Shutdown(context.Background()))
+}
+
+// pointTimestamps returns the timestamps of the index-th contiguous delta point.
+func pointTimestamps(index int) (pcommon.Timestamp, pcommon.Timestamp) {
+	interval := pcommon.Timestamp(10 * time.Second)
+	return testStart + pcommon.Timestamp(index)*interval, testStart + pcommon.Timestamp(index+1)*interval
+}
+
+func temporality(isDelta bool) pmetric.MetricAggregationTemporality {
+	if isDelta {
+		return pmetric.MetricAggregationTemporalityDelta
+	}
+	return pmetric.MetricAggregationTemporalityCumulative
+}
+
+func generateTestSumMetrics(tm testSumMetric) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+
+	rm := md.ResourceMetrics().AppendEmpty()
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	for i, name := range tm.metricNames {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		m.SetDataType(pmetric.MetricDataTypeSum)
+
+		sum := m.Sum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(temporality(tm.isDelta[i]))
+
+		for index, value := range tm.metricValues[i] {
+			dp := m.Sum().DataPoints().AppendEmpty()
+			start, ts := pointTimestamps(index)
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(ts)
+			dp.SetDoubleVal(value)
+		}
+	}
+
+	return md
+}
+
+func generateTestHistogramMetrics(tm testHistogramMetric) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+
+	rm := md.ResourceMetrics().AppendEmpty()
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	for i, name := range tm.metricNames {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		m.SetDataType(pmetric.MetricDataTypeHistogram)
+
+		hist := m.Histogram()
+		hist.SetAggregationTemporality(temporality(tm.isDelta[i]))
+
+		for index, count := range tm.metricCounts[i] {
+			dp := m.Histogram().DataPoints().AppendEmpty()
+			start, ts := pointTimestamps(index)
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(ts)
+			dp.SetCount(count)
+
+			if len(tm.metricSums) > i {
+				dp.SetSum(tm.metricSums[i][index])
+			}
+			dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice([]float64{1, 10}))
+			dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(tm.metricBuckets[i][index]))
+		}
+	}
+
+	return md
+}
+
+func generateTestExponentialHistogramMetrics(tm testExponentialHistogramMetric) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+
+	rm := md.ResourceMetrics().AppendEmpty()
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	for i, name := range tm.metricNames {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		m.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+
+		hist := m.ExponentialHistogram()
+		hist.SetAggregationTemporality(temporality(tm.isDelta[i]))
+
+		for index, buckets := range tm.metricBuckets[i] {
+			dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+			start, ts := pointTimestamps(index)
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(ts)
+
+			var count uint64
+			for _, c := range buckets {
+				count += c
+			}
+			dp.SetCount(count)
+			dp.SetScale(tm.metricScales[i][index])
+			dp.Positive().SetOffset(tm.metricOffsets[i][index])
+			dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice(buckets))
+		}
+	}
+
+	return md
+}
+
+func BenchmarkConsumeMetrics(b *testing.B) {
+	c := consumertest.NewNop()
+	params := component.ProcessorCreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zap.NewNop(),
+		},
+		BuildInfo: component.BuildInfo{},
+	}
+	cfg := createDefaultConfig().(*Config)
+	p, err := createMetricsProcessor(context.Background(), params, cfg, c)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	metrics := pmetric.NewMetrics()
+	rms := metrics.ResourceMetrics().AppendEmpty()
+	r := rms.Resource()
+	r.Attributes().UpsertBool("resource", true)
+	ilms := rms.ScopeMetrics().AppendEmpty()
+	ilms.Scope().SetName("test")
+	ilms.Scope().SetVersion("0.1")
+	m := ilms.Metrics().AppendEmpty()
+	m.SetDataType(pmetric.MetricDataTypeSum)
+	m.Sum().SetIsMonotonic(true)
+	dp := m.Sum().DataPoints().AppendEmpty()
+	dp.Attributes().UpsertString("tag", "value")
+
+	var ts pcommon.Timestamp
+	reset := func() {
+		m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+		dp.SetStartTimestamp(ts)
+		ts += pcommon.Timestamp(time.Second)
+		dp.SetTimestamp(ts)
+		dp.SetDoubleVal(1.0)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reset()
+		assert.NoError(b, p.ConsumeMetrics(context.Background(), metrics))
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor"
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Interval is the time between two exports of the buffered metrics.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks whether the input configuration has all of the required fields for the processor.
+func (config *Config) Validate() error {
+	if config.Interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	return nil
+}
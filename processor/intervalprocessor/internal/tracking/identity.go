@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor/internal/tracking"
+
+import (
+	"bytes"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// MetricIdentity identifies a stream of data points, as the cumulativetodelta
+// processor does. It does not include the start timestamp, so that the points of
+// a stream are buffered together across resets and delta intervals.
+type MetricIdentity struct {
+	Resource               pcommon.Resource
+	InstrumentationLibrary pcommon.InstrumentationScope
+	MetricDataType         pmetric.MetricDataType
+	MetricIsMonotonic      bool
+	MetricName             string
+	MetricUnit             string
+	Attributes             pcommon.Map
+	MetricValueType        pmetric.NumberDataPointValueType
+}
+
+const A = int32('A')
+const SEP = byte(0x1E)
+const SEPSTR = string(SEP)
+
+// Write writes the key of the stream to b.
+func (mi *MetricIdentity) Write(b *bytes.Buffer) {
+	mi.writeMetric(b)
+	b.WriteByte(SEP)
+	b.WriteRune(A + int32(mi.MetricValueType))
+	writeAttributes(b, mi.Attributes)
+}
+
+// ResourceKey returns a key identifying the resource of the stream.
+func (mi *MetricIdentity) ResourceKey() string {
+	b := &bytes.Buffer{}
+	mi.writeResource(b)
+	return b.String()
+}
+
+// ScopeKey returns a key identifying the resource and instrumentation scope of the stream.
+func (mi *MetricIdentity) ScopeKey() string {
+	b := &bytes.Buffer{}
+	mi.writeScope(b)
+	return b.String()
+}
+
+// MetricKey returns a key identifying the metric of the stream, regardless of its attributes.
+func (mi *MetricIdentity) MetricKey() string {
+	b := &bytes.Buffer{}
+	mi.writeMetric(b)
+	return b.String()
+}
+
+func (mi *MetricIdentity) writeResource(b *bytes.Buffer) {
+	writeAttributes(b, mi.Resource.Attributes())
+}
+
+func (mi *MetricIdentity) writeScope(b *bytes.Buffer) {
+	mi.writeResource(b)
+	b.WriteByte(SEP)
+	b.WriteString(mi.InstrumentationLibrary.Name())
+	b.WriteByte(SEP)
+	b.WriteString(mi.InstrumentationLibrary.Version())
+}
+
+func (mi *MetricIdentity) writeMetric(b *bytes.Buffer) {
+	mi.writeScope(b)
+	b.WriteByte(SEP)
+	b.WriteRune(A + int32(mi.MetricDataType))
+	b.WriteByte(SEP)
+	if mi.MetricIsMonotonic {
+		b.WriteByte('Y')
+	} else {
+		b.WriteByte('N')
+	}
+
+	b.WriteByte(SEP)
+	b.WriteString(mi.MetricName)
+	b.WriteByte(SEP)
+	b.WriteString(mi.MetricUnit)
+}
+
+func writeAttributes(b *bytes.Buffer, attrs pcommon.Map) {
+	attrs.Sort().Range(func(k string, v pcommon.Value) bool {
+		b.WriteByte(SEP)
+		b.WriteString(k)
+		b.WriteByte(':')
+		b.WriteString(v.AsString())
+		return true
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newIdentity() MetricIdentity {
+	resource := pcommon.NewResource()
+	resource.Attributes().InsertBool("resource", true)
+
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("ilm_name")
+	il.SetVersion("ilm_version")
+
+	attributes := pcommon.NewMap()
+	attributes.InsertString("label", "value")
+
+	return MetricIdentity{
+		Resource:               resource,
+		InstrumentationLibrary: il,
+		MetricDataType:         pmetric.MetricDataTypeSum,
+		MetricIsMonotonic:      true,
+		MetricName:             "m_name",
+		MetricUnit:             "m_unit",
+		Attributes:             attributes,
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+	}
+}
+
+func TestMetricIdentity_Write(t *testing.T) {
+	mi := newIdentity()
+	b := &bytes.Buffer{}
+	mi.Write(b)
+
+	assert.Equal(t, SEPSTR+"resource:true"+SEPSTR+"ilm_name"+SEPSTR+"ilm_version"+SEPSTR+"C"+SEPSTR+"Y"+
+		SEPSTR+"m_name"+SEPSTR+"m_unit"+SEPSTR+"B"+SEPSTR+"label:value", b.String())
+}
+
+func TestMetricIdentity_Keys(t *testing.T) {
+	mi := newIdentity()
+	other := newIdentity()
+	other.Attributes.UpsertString("label", "other")
+	other.MetricValueType = pmetric.NumberDataPointValueTypeDouble
+
+	// Streams of the same metric only differ by their attributes and value type.
+	assert.Equal(t, mi.MetricKey(), other.MetricKey())
+	assert.NotEqual(t, streamKey(mi), streamKey(other))
+
+	other.MetricName = "other"
+	assert.NotEqual(t, mi.MetricKey(), other.MetricKey())
+	assert.Equal(t, mi.ScopeKey(), other.ScopeKey())
+
+	other.InstrumentationLibrary = pcommon.NewInstrumentationScope()
+	assert.NotEqual(t, mi.ScopeKey(), other.ScopeKey())
+	assert.Equal(t, mi.ResourceKey(), other.ResourceKey())
+
+	other.Resource = pcommon.NewResource()
+	assert.NotEqual(t, mi.ResourceKey(), other.ResourceKey())
+}
+
+func TestMetricIdentity_AttributeOrder(t *testing.T) {
+	mi := newIdentity()
+	mi.Attributes.InsertString("a", "1")
+	other := newIdentity()
+	other.Attributes = pcommon.NewMap()
+	other.Attributes.InsertString("a", "1")
+	other.Attributes.InsertString("label", "value")
+
+	assert.Equal(t, streamKey(mi), streamKey(other))
+}
+
+func streamKey(mi MetricIdentity) string {
+	b := &bytes.Buffer{}
+	mi.Write(b)
+	return b.String()
+}
@@ -0,0 +1,420 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor"
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor/internal/tracking"
+)
+
+type intervalProcessor struct {
+	logger       *zap.Logger
+	interval     time.Duration
+	nextConsumer consumer.Metrics
+
+	mu    sync.Mutex
+	state *state
+
+	cancelFunc context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+// state holds the metrics buffered during the current interval. The resources,
+// scopes and metrics are kept in md, while the points of every stream are kept
+// apart until the interval ends, so that they can be replaced by newer points.
+type state struct {
+	md        pmetric.Metrics
+	resources map[string]pmetric.ResourceMetrics
+	scopes    map[string]pmetric.ScopeMetrics
+	metrics   map[string]pmetric.Metric
+	streams   map[string]*stream
+	// keys are the keys of streams in order of arrival.
+	keys []string
+}
+
+// stream holds the buffered point of a stream. Only the point matching the
+// data type of the metric is set.
+type stream struct {
+	metric               pmetric.Metric
+	number               pmetric.NumberDataPoint
+	histogram            pmetric.HistogramDataPoint
+	exponentialHistogram pmetric.ExponentialHistogramDataPoint
+}
+
+func newState() *state {
+	return &state{
+		md:        pmetric.NewMetrics(),
+		resources: make(map[string]pmetric.ResourceMetrics),
+		scopes:    make(map[string]pmetric.ScopeMetrics),
+		metrics:   make(map[string]pmetric.Metric),
+		streams:   make(map[string]*stream),
+	}
+}
+
+func newIntervalProcessor(config *Config, logger *zap.Logger, nextConsumer consumer.Metrics) *intervalProcessor {
+	return &intervalProcessor{
+		logger:       logger,
+		interval:     config.Interval,
+		nextConsumer: nextConsumer,
+		state:        newState(),
+	}
+}
+
+func (p *intervalProcessor) start(context.Context, component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancelFunc = cancel
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := p.export(ctx); err != nil {
+					p.logger.Error("Failed to export buffered metrics", zap.Error(err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// shutdown stops the periodic export and exports the metrics buffered since the last interval.
+func (p *intervalProcessor) shutdown(ctx context.Context) error {
+	if p.cancelFunc == nil {
+		return nil
+	}
+	p.cancelFunc()
+	p.wg.Wait()
+	return p.export(ctx)
+}
+
+// export sends the buffered metrics to the next consumer and starts a new interval.
+func (p *intervalProcessor) export(ctx context.Context) error {
+	p.mu.Lock()
+	md := p.state.flush()
+	p.state = newState()
+	p.mu.Unlock()
+
+	if md.DataPointCount() == 0 {
+		return nil
+	}
+	return p.nextConsumer.ConsumeMetrics(ctx, md)
+}
+
+// processMetrics implements the ProcessMetricsFunc type. Buffered metrics are
+// removed from md, and the remaining ones are passed through.
+func (p *intervalProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(ilm pmetric.ScopeMetrics) bool {
+			ilm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				baseIdentity := tracking.MetricIdentity{
+					Resource:               rm.Resource(),
+					InstrumentationLibrary: ilm.Scope(),
+					MetricDataType:         m.DataType(),
+					MetricName:             m.Name(),
+					MetricUnit:             m.Unit(),
+				}
+
+				switch m.DataType() {
+				case pmetric.MetricDataTypeGauge:
+					p.bufferNumberDataPoints(m.Gauge().DataPoints(), baseIdentity, rm, ilm, m, false)
+					return true
+				case pmetric.MetricDataTypeSum:
+					ms := m.Sum()
+					baseIdentity.MetricIsMonotonic = ms.IsMonotonic()
+					merge := ms.AggregationTemporality() == pmetric.MetricAggregationTemporalityDelta
+					p.bufferNumberDataPoints(ms.DataPoints(), baseIdentity, rm, ilm, m, merge)
+					return true
+				case pmetric.MetricDataTypeHistogram:
+					ms := m.Histogram()
+					baseIdentity.MetricIsMonotonic = true
+					merge := ms.AggregationTemporality() == pmetric.MetricAggregationTemporalityDelta
+					p.bufferHistogramDataPoints(ms.DataPoints(), baseIdentity, rm, ilm, m, merge)
+					return true
+				case pmetric.MetricDataTypeExponentialHistogram:
+					ms := m.ExponentialHistogram()
+					if ms.AggregationTemporality() == pmetric.MetricAggregationTemporalityDelta {
+						// Merging delta exponential histograms of different scales is not supported.
+						return false
+					}
+					baseIdentity.MetricIsMonotonic = true
+					p.bufferExponentialHistogramDataPoints(ms.DataPoints(), baseIdentity, rm, ilm, m)
+					return true
+				default:
+					return false
+				}
+			})
+			return ilm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+
+	if md.ResourceMetrics().Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+func (p *intervalProcessor) bufferNumberDataPoints(dps pmetric.NumberDataPointSlice, baseIdentity tracking.MetricIdentity,
+	rm pmetric.ResourceMetrics, ilm pmetric.ScopeMetrics, m pmetric.Metric, merge bool) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		id := baseIdentity
+		id.Attributes = dp.Attributes()
+		id.MetricValueType = dp.ValueType()
+
+		s, ok := p.state.stream(id, rm, ilm, m)
+		switch {
+		case !ok || (!merge && dp.Timestamp() >= s.number.Timestamp()):
+			s.number = pmetric.NewNumberDataPoint()
+			dp.CopyTo(s.number)
+		case merge:
+			mergeNumberDataPoints(s.number, dp)
+		default:
+			p.logger.Debug("Dropping point older than the buffered point", zap.String("metric", m.Name()))
+		}
+	}
+}
+
+func (p *intervalProcessor) bufferHistogramDataPoints(dps pmetric.HistogramDataPointSlice, baseIdentity tracking.MetricIdentity,
+	rm pmetric.ResourceMetrics, ilm pmetric.ScopeMetrics, m pmetric.Metric, merge bool) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		id := baseIdentity
+		id.Attributes = dp.Attributes()
+
+		s, ok := p.state.stream(id, rm, ilm, m)
+		switch {
+		case ok && merge && !equalBounds(s.histogram.ExplicitBounds(), dp.ExplicitBounds()):
+			p.logger.Debug("Replacing buffered histogram point with different bounds", zap.String("metric", m.Name()))
+			fallthrough
+		case !ok || (!merge && dp.Timestamp() >= s.histogram.Timestamp()):
+			s.histogram = pmetric.NewHistogramDataPoint()
+			dp.CopyTo(s.histogram)
+		case merge:
+			s.histogram = mergeHistogramDataPoints(s.histogram, dp)
+		default:
+			p.logger.Debug("Dropping point older than the buffered point", zap.String("metric", m.Name()))
+		}
+	}
+}
+
+func (p *intervalProcessor) bufferExponentialHistogramDataPoints(dps pmetric.ExponentialHistogramDataPointSlice, baseIdentity tracking.MetricIdentity,
+	rm pmetric.ResourceMetrics, ilm pmetric.ScopeMetrics, m pmetric.Metric) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		id := baseIdentity
+		id.Attributes = dp.Attributes()
+
+		s, ok := p.state.stream(id, rm, ilm, m)
+		if ok && dp.Timestamp() < s.exponentialHistogram.Timestamp() {
+			p.logger.Debug("Dropping point older than the buffered point", zap.String("metric", m.Name()))
+			continue
+		}
+		// Points are copied to a new point, as optional fields such as min and max cannot be unset.
+		s.exponentialHistogram = pmetric.NewExponentialHistogramDataPoint()
+		dp.CopyTo(s.exponentialHistogram)
+	}
+}
+
+// stream returns the buffered stream identified by id, creating it along with
+// its resource, scope and metric if needed. It reports whether the stream
+// already had a buffered point.
+func (s *state) stream(id tracking.MetricIdentity, rm pmetric.ResourceMetrics, ilm pmetric.ScopeMetrics, m pmetric.Metric) (*stream, bool) {
+	b := &bytes.Buffer{}
+	id.Write(b)
+	key := b.String()
+	if st, ok := s.streams[key]; ok {
+		return st, true
+	}
+
+	st := &stream{metric: s.metric(id, rm, ilm, m)}
+	s.streams[key] = st
+	s.keys = append(s.keys, key)
+	return st, false
+}
+
+func (s *state) metric(id tracking.MetricIdentity, rm pmetric.ResourceMetrics, ilm pmetric.ScopeMetrics, m pmetric.Metric) pmetric.Metric {
+	metricKey := id.MetricKey()
+	if bm, ok := s.metrics[metricKey]; ok {
+		return bm
+	}
+
+	scopeKey := id.ScopeKey()
+	sm, ok := s.scopes[scopeKey]
+	if !ok {
+		resourceKey := id.ResourceKey()
+		brm, ok := s.resources[resourceKey]
+		if !ok {
+			brm = s.md.ResourceMetrics().AppendEmpty()
+			rm.Resource().CopyTo(brm.Resource())
+			brm.SetSchemaUrl(rm.SchemaUrl())
+			s.resources[resourceKey] = brm
+		}
+		sm = brm.ScopeMetrics().AppendEmpty()
+		ilm.Scope().CopyTo(sm.Scope())
+		sm.SetSchemaUrl(ilm.SchemaUrl())
+		s.scopes[scopeKey] = sm
+	}
+
+	bm := sm.Metrics().AppendEmpty()
+	bm.SetName(m.Name())
+	bm.SetDescription(m.Description())
+	bm.SetUnit(m.Unit())
+	bm.SetDataType(m.DataType())
+	switch m.DataType() {
+	case pmetric.MetricDataTypeSum:
+		bm.Sum().SetIsMonotonic(m.Sum().IsMonotonic())
+		bm.Sum().SetAggregationTemporality(m.Sum().AggregationTemporality())
+	case pmetric.MetricDataTypeHistogram:
+		bm.Histogram().SetAggregationTemporality(m.Histogram().AggregationTemporality())
+	case pmetric.MetricDataTypeExponentialHistogram:
+		bm.ExponentialHistogram().SetAggregationTemporality(m.ExponentialHistogram().AggregationTemporality())
+	}
+	s.metrics[metricKey] = bm
+	return bm
+}
+
+// flush moves the buffered points into their metrics, in order of arrival, and returns the metrics.
+func (s *state) flush() pmetric.Metrics {
+	for _, key := range s.keys {
+		st := s.streams[key]
+		switch st.metric.DataType() {
+		case pmetric.MetricDataTypeGauge:
+			st.number.MoveTo(st.metric.Gauge().DataPoints().AppendEmpty())
+		case pmetric.MetricDataTypeSum:
+			st.number.MoveTo(st.metric.Sum().DataPoints().AppendEmpty())
+		case pmetric.MetricDataTypeHistogram:
+			st.histogram.MoveTo(st.metric.Histogram().DataPoints().AppendEmpty())
+		case pmetric.MetricDataTypeExponentialHistogram:
+			st.exponentialHistogram.MoveTo(st.metric.ExponentialHistogram().DataPoints().AppendEmpty())
+		}
+	}
+	return s.md
+}
+
+// mergeNumberDataPoints adds the value of the delta point dp to the buffered point.
+// The merged point covers the time ranges of both points.
+func mergeNumberDataPoints(buffered, dp pmetric.NumberDataPoint) {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		buffered.SetIntVal(buffered.IntVal() + dp.IntVal())
+	case pmetric.NumberDataPointValueTypeDouble:
+		buffered.SetDoubleVal(buffered.DoubleVal() + dp.DoubleVal())
+	}
+	mergeTimestamps(buffered, dp)
+	for i := 0; i < dp.Exemplars().Len(); i++ {
+		dp.Exemplars().At(i).CopyTo(buffered.Exemplars().AppendEmpty())
+	}
+}
+
+type timestamped interface {
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+}
+
+func mergeTimestamps(buffered, dp timestamped) {
+	if dp.StartTimestamp() < buffered.StartTimestamp() {
+		buffered.SetStartTimestamp(dp.StartTimestamp())
+	}
+	if dp.Timestamp() > buffered.Timestamp() {
+		buffered.SetTimestamp(dp.Timestamp())
+	}
+}
+
+// mergeHistogramDataPoints returns the sum of two delta histogram points with the same bounds.
+// The merged point is built from scratch, as optional fields such as min and max cannot be unset.
+func mergeHistogramDataPoints(buffered, dp pmetric.HistogramDataPoint) pmetric.HistogramDataPoint {
+	merged := pmetric.NewHistogramDataPoint()
+	buffered.Attributes().CopyTo(merged.Attributes())
+	merged.SetStartTimestamp(buffered.StartTimestamp())
+	merged.SetTimestamp(buffered.Timestamp())
+	mergeTimestamps(merged, dp)
+	merged.SetFlagsImmutable(dp.FlagsImmutable())
+
+	merged.SetCount(buffered.Count() + dp.Count())
+	if buffered.HasSum() && dp.HasSum() {
+		merged.SetSum(buffered.Sum() + dp.Sum())
+	}
+	if buffered.HasMin() && dp.HasMin() {
+		merged.SetMin(minFloat(buffered.Min(), dp.Min()))
+	}
+	if buffered.HasMax() && dp.HasMax() {
+		merged.SetMax(maxFloat(buffered.Max(), dp.Max()))
+	}
+
+	merged.SetExplicitBounds(buffered.ExplicitBounds())
+	counts := buffered.BucketCounts().AsRaw()
+	dpCounts := dp.BucketCounts().AsRaw()
+	if len(counts) == len(dpCounts) {
+		for i := range counts {
+			counts[i] += dpCounts[i]
+		}
+	}
+	merged.SetBucketCounts(pcommon.NewImmutableUInt64Slice(counts))
+
+	buffered.Exemplars().CopyTo(merged.Exemplars())
+	for i := 0; i < dp.Exemplars().Len(); i++ {
+		dp.Exemplars().At(i).CopyTo(merged.Exemplars().AppendEmpty())
+	}
+	return merged
+}
+
+func equalBounds(a, b pcommon.ImmutableFloat64Slice) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for i := 0; i < a.Len(); i++ {
+		if a.At(i) != b.At(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,332 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+var testStart = time.Unix(1000, 0)
+
+func ts(sec int) pcommon.Timestamp {
+	return pcommon.NewTimestampFromTime(testStart.Add(time.Duration(sec) * time.Second))
+}
+
+// newMetric returns metrics holding a single metric of the given type, under a
+// resource and scope shared by all test metrics.
+func newMetric(name string, dataType pmetric.MetricDataType) (pmetric.Metrics, pmetric.Metric) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "test")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+	m := sm.Metrics().AppendEmpty()
+	m.SetName(name)
+	m.SetDataType(dataType)
+	return md, m
+}
+
+func newSum(name string, temporality pmetric.MetricAggregationTemporality, start, timestamp pcommon.Timestamp, values ...int64) pmetric.Metrics {
+	md, m := newMetric(name, pmetric.MetricDataTypeSum)
+	m.Sum().SetIsMonotonic(true)
+	m.Sum().SetAggregationTemporality(temporality)
+	for i, v := range values {
+		dp := m.Sum().DataPoints().AppendEmpty()
+		dp.Attributes().InsertInt("index", int64(i))
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(timestamp)
+		dp.SetIntVal(v)
+	}
+	return md
+}
+
+func newGauge(name string, timestamp pcommon.Timestamp, value float64) pmetric.Metrics {
+	md, m := newMetric(name, pmetric.MetricDataTypeGauge)
+	dp := m.Gauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(timestamp)
+	dp.SetDoubleVal(value)
+	return md
+}
+
+func newHistogram(temporality pmetric.MetricAggregationTemporality, start, timestamp pcommon.Timestamp, bounds []float64, counts []uint64, sum float64) (pmetric.Metrics, pmetric.HistogramDataPoint) {
+	md, m := newMetric("histogram", pmetric.MetricDataTypeHistogram)
+	m.Histogram().SetAggregationTemporality(temporality)
+	dp := m.Histogram().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(timestamp)
+	dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice(bounds))
+	dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(counts))
+	var count uint64
+	for _, c := range counts {
+		count += c
+	}
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	return md, dp
+}
+
+func newTestProcessor(sink *consumertest.MetricsSink) *intervalProcessor {
+	return newIntervalProcessor(&Config{Interval: time.Minute}, zap.NewNop(), sink)
+}
+
+func process(t *testing.T, p *intervalProcessor, md pmetric.Metrics) {
+	_, err := p.processMetrics(context.Background(), md)
+	require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+}
+
+// exported exports the buffered metrics and returns the single metric they hold.
+func exported(t *testing.T, p *intervalProcessor, sink *consumertest.MetricsSink) pmetric.Metric {
+	sink.Reset()
+	require.NoError(t, p.export(context.Background()))
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]interface{}{"service.name": "test"}, rm.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, rm.ScopeMetrics().Len())
+	sm := rm.ScopeMetrics().At(0)
+	assert.Equal(t, "scope", sm.Scope().Name())
+	require.Equal(t, 1, sm.Metrics().Len())
+	return sm.Metrics().At(0)
+}
+
+func TestGaugeKeepsLatestPoint(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newTestProcessor(sink)
+
+	process(t, p, newGauge("gauge", ts(10), 1))
+	process(t, p, newGauge("gauge", ts(20), 2))
+	// Out of order points are dropped.
+	process(t, p, newGauge("gauge", ts(15), 3))
+
+	m := exported(t, p, sink)
+	assert.Equal(t, "gauge", m.Name())
+	require.Equal(t, pmetric.MetricDataTypeGauge, m.DataType())
+	require.Equal(t, 1, m.Gauge().DataPoints().Len())
+	dp := m.Gauge().DataPoints().At(0)
+	assert.Equal(t, ts(20), dp.Timestamp())
+	assert.Equal(t, 2.0, dp.DoubleVal())
+
+	// The buffer is emptied once exported.
+	sink.Reset()
+	require.NoError(t, p.export(context.Background()))
+	assert.Empty(t, sink.AllMetrics())
+}
+
+func TestCumulativeSumKeepsLatestPoints(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newTestProcessor(sink)
+
+	process(t, p, newSum("sum", pmetric.MetricAggregationTemporalityCumulative, ts(0), ts(10), 1, 10))
+	process(t, p, newSum("sum", pmetric.MetricAggregationTemporalityCumulative, ts(0), ts(20), 2, 20))
+
+	m := exported(t, p, sink)
+	require.Equal(t, pmetric.MetricDataTypeSum, m.DataType())
+	assert.True(t, m.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+	dps := m.Sum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	for i, expected := range []int64{2, 20} {
+		dp := dps.At(i)
+		index, _ := dp.Attributes().Get("index")
+		assert.Equal(t, int64(i), index.IntVal())
+		assert.Equal(t, ts(0), dp.StartTimestamp())
+		assert.Equal(t, ts(20), dp.Timestamp())
+		assert.Equal(t, expected, dp.IntVal())
+	}
+}
+
+func TestDeltaSumMergesPoints(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newTestProcessor(sink)
+
+	process(t, p, newSum("sum", pmetric.MetricAggregationTemporalityDelta, ts(0), ts(10), 1))
+	process(t, p, newSum("sum", pmetric.MetricAggregationTemporalityDelta, ts(10), ts(20), 2))
+	process(t, p, newSum("sum", pmetric.MetricAggregationTemporalityDelta, ts(20), ts(30), 3))
+
+	m := exported(t, p, sink)
+	assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, m.Sum().AggregationTemporality())
+	require.Equal(t, 1, m.Sum().DataPoints().Len())
+	dp := m.Sum().DataPoints().At(0)
+	assert.Equal(t, ts(0), dp.StartTimestamp())
+	assert.Equal(t, ts(30), dp.Timestamp())
+	assert.Equal(t, int64(6), dp.IntVal())
+
+	// The next interval starts from scratch.
+	process(t, p, newSum("sum", pmetric.MetricAggregationTemporalityDelta, ts(30), ts(40), 4))
+	dp = exported(t, p, sink).Sum().DataPoints().At(0)
+	assert.Equal(t, ts(30), dp.StartTimestamp())
+	assert.Equal(t, int64(4), dp.IntVal())
+}
+
+func TestDeltaHistogramMergesPoints(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newTestProcessor(sink)
+
+	md, dp := newHistogram(pmetric.MetricAggregationTemporalityDelta, ts(0), ts(10), []float64{1, 10}, []uint64{1, 2, 3}, 30)
+	dp.SetMin(0.5)
+	dp.SetMax(20)
+	process(t, p, md)
+	md, dp = newHistogram(pmetric.MetricAggregationTemporalityDelta, ts(10), ts(20), []float64{1, 10}, []uint64{4, 0, 1}, 15)
+	dp.SetMin(0.1)
+	process(t, p, md)
+
+	m := exported(t, p, sink)
+	require.Equal(t, pmetric.MetricDataTypeHistogram, m.DataType())
+	require.Equal(t, 1, m.Histogram().DataPoints().Len())
+	merged := m.Histogram().DataPoints().At(0)
+	assert.Equal(t, ts(0), merged.StartTimestamp())
+	assert.Equal(t, ts(20), merged.Timestamp())
+	assert.Equal(t, uint64(11), merged.Count())
+	assert.Equal(t, 45.0, merged.Sum())
+	assert.Equal(t, []float64{1, 10}, merged.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{5, 2, 4}, merged.BucketCounts().AsRaw())
+	assert.True(t, merged.HasMin())
+	assert.Equal(t, 0.1, merged.Min())
+	// The max of the second point is unknown.
+	assert.False(t, merged.HasMax())
+}
+
+func TestDeltaHistogramDifferentBounds(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newTestProcessor(sink)
+
+	md, _ := newHistogram(pmetric.MetricAggregationTemporalityDelta, ts(0), ts(10), []float64{1, 10}, []uint64{1, 2, 3}, 30)
+	process(t, p, md)
+	md, _ = newHistogram(pmetric.MetricAggregationTemporalityDelta, ts(10), ts(20), []float64{5}, []uint64{4, 1}, 15)
+	process(t, p, md)
+
+	dp := exported(t, p, sink).Histogram().DataPoints().At(0)
+	assert.Equal(t, ts(10), dp.StartTimestamp())
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, []float64{5}, dp.ExplicitBounds().AsRaw())
+}
+
+func TestCumulativeHistogramKeepsLatestPoint(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newTestProcessor(sink)
+
+	md, dp := newHistogram(pmetric.MetricAggregationTemporalityCumulative, ts(0), ts(10), []float64{1, 10}, []uint64{1, 2, 3}, 30)
+	dp.SetMin(0.5)
+	process(t, p, md)
+	md, _ = newHistogram(pmetric.MetricAggregationTemporalityCumulative, ts(0), ts(20), []float64{1, 10}, []uint64{2, 2, 3}, 31)
+	process(t, p, md)
+
+	m := exported(t, p, sink)
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, m.Histogram().AggregationTemporality())
+	latest := m.Histogram().DataPoints().At(0)
+	assert.Equal(t, ts(20), latest.Timestamp())
+	assert.Equal(t, uint64(7), latest.Count())
+	assert.Equal(t, []uint64{2, 2, 3}, latest.BucketCounts().AsRaw())
+	assert.False(t, latest.HasMin())
+}
+
+func TestCumulativeExponentialHistogramKeepsLatestPoint(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newTestProcessor(sink)
+
+	for i, count := range []uint64{3, 5} {
+		md, m := newMetric("exponential", pmetric.MetricDataTypeExponentialHistogram)
+		m.ExponentialHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+		dp.SetTimestamp(ts(10 * (i + 1)))
+		dp.SetCount(count)
+		process(t, p, md)
+	}
+
+	m := exported(t, p, sink)
+	require.Equal(t, 1, m.ExponentialHistogram().DataPoints().Len())
+	assert.Equal(t, uint64(5), m.ExponentialHistogram().DataPoints().At(0).Count())
+}
+
+func TestUnsupportedMetricsArePassedThrough(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newTestProcessor(sink)
+
+	md := newSum("sum", pmetric.MetricAggregationTemporalityCumulative, ts(0), ts(10), 1)
+	sm := md.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("summary")
+	summary.SetDataType(pmetric.MetricDataTypeSummary)
+	summary.Summary().DataPoints().AppendEmpty().SetCount(1)
+	exponential := sm.Metrics().AppendEmpty()
+	exponential.SetName("exponential")
+	exponential.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+	exponential.ExponentialHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	exponential.ExponentialHistogram().DataPoints().AppendEmpty().SetCount(1)
+
+	out, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	metrics := out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "summary", metrics.At(0).Name())
+	assert.Equal(t, "exponential", metrics.At(1).Name())
+
+	assert.Equal(t, "sum", exported(t, p, sink).Name())
+}
+
+func TestMetricsAreGrouped(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newTestProcessor(sink)
+
+	process(t, p, newGauge("first", ts(10), 1))
+	process(t, p, newGauge("second", ts(10), 2))
+	process(t, p, newGauge("first", ts(20), 3))
+
+	require.NoError(t, p.export(context.Background()))
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	require.Equal(t, 1, md.ResourceMetrics().At(0).ScopeMetrics().Len())
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "first", metrics.At(0).Name())
+	assert.Equal(t, 3.0, metrics.At(0).Gauge().DataPoints().At(0).DoubleVal())
+	assert.Equal(t, "second", metrics.At(1).Name())
+	assert.Equal(t, 2.0, metrics.At(1).Gauge().DataPoints().At(0).DoubleVal())
+}
+
+func TestIntervalExport(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Interval = 10 * time.Millisecond
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), newGauge("gauge", ts(10), 1)))
+	assert.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	// Points buffered when shutting down are exported.
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), newGauge("gauge", ts(20), 2)))
+	require.NoError(t, mp.Shutdown(context.Background()))
+	require.Len(t, sink.AllMetrics(), 2)
+	assert.Equal(t, 2.0, sink.AllMetrics()[1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).DoubleVal())
+}
@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logstometricsprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logstometricsprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+const (
+	delta      = "AGGREGATION_TEMPORALITY_DELTA"
+	cumulative = "AGGREGATION_TEMPORALITY_CUMULATIVE"
+)
+
+// MetricType is the kind of aggregation of a metric.
+type MetricType string
+
+const (
+	// MetricTypeCount counts the matching log records.
+	MetricTypeCount MetricType = "count"
+	// MetricTypeSum sums the values of the matching log records.
+	MetricTypeSum MetricType = "sum"
+	// MetricTypeHistogram records the values of the matching log records in a histogram.
+	MetricTypeHistogram MetricType = "histogram"
+)
+
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MetricsExporter is the name of the metrics exporter to use to ship metrics.
+	MetricsExporter string `mapstructure:"metrics_exporter"`
+
+	// AggregationInterval is how often the aggregated metrics are sent to the exporter.
+	AggregationInterval time.Duration `mapstructure:"aggregation_interval"`
+
+	// AggregationTemporality is either AGGREGATION_TEMPORALITY_CUMULATIVE or AGGREGATION_TEMPORALITY_DELTA.
+	AggregationTemporality string `mapstructure:"aggregation_temporality"`
+
+	// ExpireAfterIntervals is the number of aggregation intervals after which the cumulative
+	// data points which weren't updated are dropped. A value of 0 keeps them forever.
+	ExpireAfterIntervals int `mapstructure:"expire_after_intervals"`
+
+	// Metrics defines the metrics aggregated from log records.
+	Metrics []MetricConfig `mapstructure:"metrics"`
+}
+
+// MetricConfig defines a metric aggregated from log records.
+type MetricConfig struct {
+	Name        string     `mapstructure:"name"`
+	Description string     `mapstructure:"description"`
+	Unit        string     `mapstructure:"unit"`
+	Type        MetricType `mapstructure:"type"`
+
+	// Conditions are TQL conditions evaluated against each log record, using the logs context.
+	// A log record is aggregated if any of the conditions is true. If no conditions are set,
+	// all log records are aggregated.
+	Conditions []string `mapstructure:"conditions"`
+
+	// Value selects the value aggregated by sum and histogram metrics.
+	Value *ValueConfig `mapstructure:"value"`
+
+	// Buckets are the explicit bucket boundaries of histogram metrics.
+	// See defaultHistogramBuckets in processor.go for the default value.
+	Buckets []float64 `mapstructure:"buckets"`
+
+	// Dimensions are the attributes of the data points. They are fetched from the
+	// attributes of the log record, falling back to the resource attributes.
+	Dimensions []Dimension `mapstructure:"dimensions"`
+}
+
+// ValueConfig selects a numeric value of a log record. Exactly one field must be set.
+type ValueConfig struct {
+	// Attribute is the name of a log record attribute.
+	Attribute string `mapstructure:"attribute"`
+	// BodyField is the name of a field of a log record with a map body.
+	BodyField string `mapstructure:"body_field"`
+}
+
+type Dimension struct {
+	Name    string  `mapstructure:"name"`
+	Default *string `mapstructure:"default"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if c.MetricsExporter == "" {
+		return errors.New("metrics_exporter must be set")
+	}
+	if c.AggregationInterval <= 0 {
+		return errors.New("aggregation_interval must be positive")
+	}
+	if c.AggregationTemporality != delta && c.AggregationTemporality != cumulative {
+		return fmt.Errorf("aggregation_temporality must be %s or %s", cumulative, delta)
+	}
+	if c.ExpireAfterIntervals < 0 {
+		return errors.New("expire_after_intervals must not be negative")
+	}
+	if len(c.Metrics) == 0 {
+		return errors.New("at least one metric must be configured")
+	}
+
+	var errs error
+	names := make(map[string]struct{}, len(c.Metrics))
+	for _, m := range c.Metrics {
+		if _, ok := names[m.Name]; ok {
+			errs = multierr.Append(errs, fmt.Errorf("duplicate metric name %q", m.Name))
+		}
+		names[m.Name] = struct{}{}
+		if err := m.validate(); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("metric %q: %w", m.Name, err))
+		}
+	}
+	return errs
+}
+
+func (m *MetricConfig) validate() error {
+	if m.Name == "" {
+		return errors.New("name must be set")
+	}
+
+	switch m.Type {
+	case MetricTypeCount:
+		if m.Value != nil {
+			return errors.New("value must not be set for count metrics")
+		}
+	case MetricTypeSum, MetricTypeHistogram:
+		if m.Value == nil || (m.Value.Attribute == "") == (m.Value.BodyField == "") {
+			return errors.New("exactly one of value.attribute or value.body_field must be set")
+		}
+	default:
+		return fmt.Errorf("unsupported type %q", m.Type)
+	}
+
+	if len(m.Buckets) > 0 {
+		if m.Type != MetricTypeHistogram {
+			return errors.New("buckets can only be set for histogram metrics")
+		}
+		if !sort.Float64sAreSorted(m.Buckets) {
+			return errors.New("buckets must be sorted in ascending order")
+		}
+	}
+
+	dimensions := make(map[string]struct{}, len(m.Dimensions))
+	for _, d := range m.Dimensions {
+		if _, ok := dimensions[d.Name]; ok {
+			return fmt.Errorf("duplicate dimension name %s", d.Name)
+		}
+		dimensions[d.Name] = struct{}{}
+	}
+
+	_, err := tql.ParseConditions(m.Conditions, functions(), tqllogs.ParsePath, tqllogs.ParseEnum)
+	return err
+}
+
+func (c Config) getAggregationTemporality() pmetric.MetricAggregationTemporality {
+	if c.AggregationTemporality == delta {
+		return pmetric.MetricAggregationTemporalityDelta
+	}
+	return pmetric.MetricAggregationTemporalityCumulative
+}
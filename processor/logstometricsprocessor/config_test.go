@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logstometricsprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	defaultMethod := "GET"
+	tests := []struct {
+		id           config.ComponentID
+		expected     config.Processor
+		errorMessage string
+	}{
+		{
+			id: config.NewComponentIDWithName(typeStr, ""),
+			expected: &Config{
+				ProcessorSettings:      config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				MetricsExporter:        "prometheus",
+				AggregationInterval:    30 * time.Second,
+				AggregationTemporality: delta,
+				ExpireAfterIntervals:   defaultExpireAfterIntervals,
+				Metrics: []MetricConfig{
+					{
+						Name:        "log.errors",
+						Description: "Number of error logs.",
+						Unit:        "1",
+						Type:        MetricTypeCount,
+						Conditions: []string{
+							"severity_number == SEVERITY_NUMBER_ERROR",
+							`severity_text == "ERROR"`,
+						},
+						Dimensions: []Dimension{
+							{Name: "service.name"},
+							{Name: "http.method", Default: &defaultMethod},
+						},
+					},
+					{
+						Name:  "http.response.size",
+						Unit:  "By",
+						Type:  MetricTypeSum,
+						Value: &ValueConfig{Attribute: "http.response_content_length"},
+					},
+					{
+						Name:       "http.duration",
+						Unit:       "ms",
+						Type:       MetricTypeHistogram,
+						Conditions: []string{`IsMatch(body, "^GET .*") == true`},
+						Value:      &ValueConfig{BodyField: "duration"},
+						Buckets:    []float64{10, 100, 1000},
+					},
+				},
+			},
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "missing_exporter"),
+			errorMessage: "metrics_exporter must be set",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "no_metrics"),
+			errorMessage: "at least one metric must be configured",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "invalid_temporality"),
+			errorMessage: "aggregation_temporality must be AGGREGATION_TEMPORALITY_CUMULATIVE or AGGREGATION_TEMPORALITY_DELTA",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "negative_expiration"),
+			errorMessage: "expire_after_intervals must not be negative",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "invalid_type"),
+			errorMessage: `metric "log.count": unsupported type "gauge"`,
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "missing_value"),
+			errorMessage: `metric "log.size": exactly one of value.attribute or value.body_field must be set`,
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "two_values"),
+			errorMessage: `metric "log.size": exactly one of value.attribute or value.body_field must be set`,
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "unsorted_buckets"),
+			errorMessage: `metric "log.size": buckets must be sorted in ascending order`,
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "duplicate_name"),
+			errorMessage: `duplicate metric name "log.count"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalProcessor(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, cfg.Validate(), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestLoadConfigInvalidCondition(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	cfg := NewFactory().CreateDefaultConfig()
+	sub, err := cm.Sub(config.NewComponentIDWithName(typeStr, "invalid_condition").String())
+	require.NoError(t, err)
+	require.NoError(t, config.UnmarshalProcessor(sub, cfg))
+
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `metric "log.count"`)
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logstometricsprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logstometricsprocessor"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlcommon"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "logstometrics"
+	// The stability level of the processor.
+	stability = component.StabilityLevelInDevelopment
+
+	defaultAggregationInterval  = time.Minute
+	defaultExpireAfterIntervals = 10
+)
+
+func NewFactory() component.ProcessorFactory {
+	return component.NewProcessorFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithLogsProcessor(createLogsProcessor, stability),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings:      config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		AggregationInterval:    defaultAggregationInterval,
+		AggregationTemporality: cumulative,
+		ExpireAfterIntervals:   defaultExpireAfterIntervals,
+	}
+}
+
+func createLogsProcessor(_ context.Context, params component.ProcessorCreateSettings, cfg config.Processor, nextConsumer consumer.Logs) (component.LogsProcessor, error) {
+	return newProcessor(params.Logger, cfg.(*Config), nextConsumer)
+}
+
+// functions are the TQL functions available to conditions.
+func functions() map[string]interface{} {
+	return map[string]interface{}{
+		"IsMatch": tqlcommon.IsMatch,
+		"TraceID": tqlotel.TraceID,
+		"SpanID":  tqlotel.SpanID,
+	}
+}
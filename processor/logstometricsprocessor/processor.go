@@ -0,0 +1,447 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logstometricsprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logstometricsprocessor"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+const (
+	scopeName          = "logstometricsprocessor"
+	metricKeySeparator = string(byte(0))
+
+	// The values of the dimensions are prefixed in the data point keys to tell
+	// the missing dimensions apart from the ones with an empty value.
+	presentDimensionMarker = "+"
+	missingDimensionMarker = "-"
+)
+
+var (
+	// defaultHistogramBuckets are the explicit bucket boundaries used by the OpenTelemetry SDKs.
+	defaultHistogramBuckets = []float64{
+		0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000,
+	}
+)
+
+// metric is a configured metric with its compiled conditions and its current aggregates.
+type metric struct {
+	config     MetricConfig
+	conditions []tql.BoolExpressionEvaluator
+	buckets    []float64
+
+	// dataPoints are keyed by the values of the dimensions.
+	dataPoints map[string]*dataPoint
+}
+
+type dataPoint struct {
+	attributes pcommon.Map
+	// startTime is the start of the interval the data point was created in.
+	startTime time.Time
+	// lastInterval is the last interval the data point was updated in.
+	lastInterval uint64
+
+	count        uint64
+	sum          float64
+	bucketCounts []uint64
+}
+
+type processorImp struct {
+	lock   sync.Mutex
+	logger *zap.Logger
+	config Config
+
+	metricsExporter component.MetricsExporter
+	nextConsumer    consumer.Logs
+
+	metrics []*metric
+
+	// The starting time of the data points.
+	startTime time.Time
+	// interval counts the aggregation intervals, which start at intervalStart.
+	interval      uint64
+	intervalStart time.Time
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newProcessor(logger *zap.Logger, cfg *Config, nextConsumer consumer.Logs) (*processorImp, error) {
+	logger.Info("Building logstometricsprocessor")
+
+	metrics := make([]*metric, 0, len(cfg.Metrics))
+	for _, mc := range cfg.Metrics {
+		conditions, err := tql.ParseConditions(mc.Conditions, functions(), tqllogs.ParsePath, tqllogs.ParseEnum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse conditions of metric %q: %w", mc.Name, err)
+		}
+		m := &metric{
+			config:     mc,
+			conditions: conditions,
+			dataPoints: make(map[string]*dataPoint),
+		}
+		if mc.Type == MetricTypeHistogram {
+			m.buckets = mc.Buckets
+			if len(m.buckets) == 0 {
+				m.buckets = defaultHistogramBuckets
+			}
+		}
+		metrics = append(metrics, m)
+	}
+
+	now := time.Now()
+	return &processorImp{
+		logger:        logger,
+		config:        *cfg,
+		nextConsumer:  nextConsumer,
+		metrics:       metrics,
+		startTime:     now,
+		intervalStart: now,
+		done:          make(chan struct{}),
+	}, nil
+}
+
+// Start implements the component.Component interface.
+func (p *processorImp) Start(ctx context.Context, host component.Host) error {
+	p.logger.Info("Starting logstometricsprocessor")
+	exporters := host.GetExporters()
+
+	var availableMetricsExporters []string
+
+	// The available list of exporters come from any configured metrics pipelines' exporters.
+	for k, exp := range exporters[config.MetricsDataType] {
+		metricsExp, ok := exp.(component.MetricsExporter)
+		if !ok {
+			return fmt.Errorf("the exporter %q isn't a metrics exporter", k.String())
+		}
+
+		availableMetricsExporters = append(availableMetricsExporters, k.String())
+
+		if k.String() == p.config.MetricsExporter {
+			p.metricsExporter = metricsExp
+			p.logger.Info("Found exporter", zap.String("logstometrics-exporter", p.config.MetricsExporter))
+			break
+		}
+	}
+	if p.metricsExporter == nil {
+		return fmt.Errorf("failed to find metrics exporter: '%s'; please configure metrics_exporter from one of: %+v",
+			p.config.MetricsExporter, availableMetricsExporters)
+	}
+
+	p.wg.Add(1)
+	go p.flushLoop()
+
+	p.logger.Info("Started logstometricsprocessor")
+	return nil
+}
+
+// Shutdown implements the component.Component interface. The metrics aggregated
+// since the last interval are exported before returning.
+func (p *processorImp) Shutdown(ctx context.Context) error {
+	p.logger.Info("Shutting down logstometricsprocessor")
+	if p.metricsExporter == nil {
+		// Never started.
+		return nil
+	}
+	close(p.done)
+	p.wg.Wait()
+	return p.exportMetrics(ctx)
+}
+
+// Capabilities implements the consumer interface.
+func (p *processorImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeLogs implements the consumer.Logs interface. The logs are aggregated
+// and then forwarded unchanged to the next consumer.
+func (p *processorImp) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	p.aggregateMetrics(ld)
+	return p.nextConsumer.ConsumeLogs(ctx, ld)
+}
+
+func (p *processorImp) flushLoop() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.config.AggregationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			if err := p.exportMetrics(context.Background()); err != nil {
+				p.logger.Error("Failed to export metrics", zap.Error(err))
+			}
+		}
+	}
+}
+
+// exportMetrics sends the aggregated metrics to the configured metrics exporter.
+func (p *processorImp) exportMetrics(ctx context.Context) error {
+	md := p.buildMetrics(time.Now())
+	if md.DataPointCount() == 0 {
+		return nil
+	}
+	return p.metricsExporter.ConsumeMetrics(ctx, md)
+}
+
+func (p *processorImp) aggregateMetrics(ld plog.Logs) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resource := rl.Resource()
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				tCtx := tqllogs.NewTransformContext(lr, sl.Scope(), resource)
+				for _, m := range p.metrics {
+					p.aggregate(m, tCtx, lr, resource)
+				}
+			}
+		}
+	}
+}
+
+func (p *processorImp) aggregate(m *metric, tCtx tql.TransformContext, lr plog.LogRecord, resource pcommon.Resource) {
+	if !matches(m.conditions, tCtx) {
+		return
+	}
+
+	var value float64
+	if m.config.Type != MetricTypeCount {
+		var ok bool
+		if value, ok = getValue(m.config.Value, lr); !ok {
+			p.logger.Debug("Skipping log record without a numeric value", zap.String("metric", m.config.Name))
+			return
+		}
+	}
+
+	key, attributes := buildDimensions(m.config.Dimensions, lr.Attributes(), resource.Attributes())
+	dp, ok := m.dataPoints[key]
+	if !ok {
+		dp = &dataPoint{attributes: attributes, startTime: p.intervalStart}
+		if m.config.Type == MetricTypeHistogram {
+			dp.bucketCounts = make([]uint64, len(m.buckets)+1)
+		}
+		m.dataPoints[key] = dp
+	}
+
+	dp.lastInterval = p.interval
+	dp.count++
+	dp.sum += value
+	if dp.bucketCounts != nil {
+		// Bucket boundaries are upper inclusive.
+		dp.bucketCounts[sort.SearchFloat64s(m.buckets, value)]++
+	}
+}
+
+// matches reports whether any of the conditions is true. A record always matches an empty list of conditions.
+func matches(conditions []tql.BoolExpressionEvaluator, tCtx tql.TransformContext) bool {
+	if len(conditions) == 0 {
+		return true
+	}
+	for _, condition := range conditions {
+		if condition(tCtx) {
+			return true
+		}
+	}
+	return false
+}
+
+// getValue returns the numeric value selected by cfg. String values are parsed as floats.
+func getValue(cfg *ValueConfig, lr plog.LogRecord) (float64, bool) {
+	var v pcommon.Value
+	var ok bool
+	switch {
+	case cfg.Attribute != "":
+		v, ok = lr.Attributes().Get(cfg.Attribute)
+	case lr.Body().Type() == pcommon.ValueTypeMap:
+		v, ok = lr.Body().MapVal().Get(cfg.BodyField)
+	}
+	if !ok {
+		return 0, false
+	}
+
+	switch v.Type() {
+	case pcommon.ValueTypeInt:
+		return float64(v.IntVal()), true
+	case pcommon.ValueTypeDouble:
+		return v.DoubleVal(), true
+	case pcommon.ValueTypeString:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v.StringVal()), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// buildDimensions returns the key identifying the data point and its attributes.
+// Dimensions are looked up in the log record attributes, then in the resource
+// attributes, and finally fall back to their default value if one is set.
+func buildDimensions(dimensions []Dimension, attrs, resourceAttrs pcommon.Map) (string, pcommon.Map) {
+	var b strings.Builder
+	m := pcommon.NewMap()
+	for _, d := range dimensions {
+		v, ok := attrs.Get(d.Name)
+		if !ok {
+			v, ok = resourceAttrs.Get(d.Name)
+		}
+		var s string
+		switch {
+		case ok:
+			s = v.AsString()
+		case d.Default != nil:
+			s = *d.Default
+		default:
+			// Dimension missing and no default: omit it from the attributes.
+			b.WriteString(metricKeySeparator)
+			b.WriteString(missingDimensionMarker)
+			continue
+		}
+		m.UpsertString(d.Name, s)
+		b.WriteString(metricKeySeparator)
+		b.WriteString(presentDimensionMarker)
+		b.WriteString(s)
+	}
+	return b.String(), m
+}
+
+// buildMetrics converts the aggregates into metrics. With delta temporality
+// the aggregates are reset and the next interval starts at now. With cumulative
+// temporality, the data points not updated for expire_after_intervals are dropped,
+// and start again at the interval they are next updated in.
+func (p *processorImp) buildMetrics(now time.Time) pmetric.Metrics {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	md := pmetric.NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+
+	start := pcommon.NewTimestampFromTime(p.startTime)
+	ts := pcommon.NewTimestampFromTime(now)
+	temporality := p.config.getAggregationTemporality()
+
+	for _, m := range p.metrics {
+		if temporality == pmetric.MetricAggregationTemporalityCumulative {
+			p.expireDataPoints(m)
+		}
+		if len(m.dataPoints) == 0 {
+			continue
+		}
+		mm := sm.Metrics().AppendEmpty()
+		mm.SetName(m.config.Name)
+		mm.SetDescription(m.config.Description)
+		mm.SetUnit(m.config.Unit)
+
+		// Sort the keys so that the data points are emitted in a stable order.
+		keys := make([]string, 0, len(m.dataPoints))
+		for k := range m.dataPoints {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		switch m.config.Type {
+		case MetricTypeCount, MetricTypeSum:
+			mm.SetDataType(pmetric.MetricDataTypeSum)
+			sum := mm.Sum()
+			sum.SetIsMonotonic(m.config.Type == MetricTypeCount)
+			sum.SetAggregationTemporality(temporality)
+			dps := sum.DataPoints()
+			dps.EnsureCapacity(len(keys))
+			for _, k := range keys {
+				src := m.dataPoints[k]
+				dp := dps.AppendEmpty()
+				dp.SetStartTimestamp(start)
+				if temporality == pmetric.MetricAggregationTemporalityCumulative {
+					dp.SetStartTimestamp(pcommon.NewTimestampFromTime(src.startTime))
+				}
+				dp.SetTimestamp(ts)
+				src.attributes.CopyTo(dp.Attributes())
+				if m.config.Type == MetricTypeCount {
+					dp.SetIntVal(int64(src.count))
+				} else {
+					dp.SetDoubleVal(src.sum)
+				}
+			}
+		case MetricTypeHistogram:
+			mm.SetDataType(pmetric.MetricDataTypeHistogram)
+			hist := mm.Histogram()
+			hist.SetAggregationTemporality(temporality)
+			dps := hist.DataPoints()
+			dps.EnsureCapacity(len(keys))
+			for _, k := range keys {
+				src := m.dataPoints[k]
+				dp := dps.AppendEmpty()
+				dp.SetStartTimestamp(start)
+				if temporality == pmetric.MetricAggregationTemporalityCumulative {
+					dp.SetStartTimestamp(pcommon.NewTimestampFromTime(src.startTime))
+				}
+				dp.SetTimestamp(ts)
+				src.attributes.CopyTo(dp.Attributes())
+				dp.SetCount(src.count)
+				dp.SetSum(src.sum)
+				dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice(m.buckets))
+				dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(append([]uint64(nil), src.bucketCounts...)))
+			}
+		}
+
+		if temporality == pmetric.MetricAggregationTemporalityDelta {
+			m.dataPoints = make(map[string]*dataPoint)
+		}
+	}
+
+	if temporality == pmetric.MetricAggregationTemporalityDelta {
+		p.startTime = now
+	}
+	p.interval++
+	p.intervalStart = now
+	return md
+}
+
+// expireDataPoints drops the data points of the metric which weren't updated for
+// expire_after_intervals. It must be called while holding the lock.
+func (p *processorImp) expireDataPoints(m *metric) {
+	if p.config.ExpireAfterIntervals <= 0 {
+		return
+	}
+	for key, dp := range m.dataPoints {
+		if p.interval-dp.lastInterval >= uint64(p.config.ExpireAfterIntervals) {
+			delete(m.dataPoints, key)
+		}
+	}
+}
@@ -0,0 +1,419 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logstometricsprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+// testExporter is a metrics exporter recording the metrics it receives.
+type testExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumertest.MetricsSink
+}
+
+// testHost exposes exporters to the processor.
+type testHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *testHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
+func newTestHost(exp component.Exporter) *testHost {
+	return &testHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.MetricsDataType: {config.NewComponentID("prometheus"): exp},
+		},
+	}
+}
+
+func newTestConfig(temporality string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+	cfg.AggregationInterval = time.Hour
+	cfg.AggregationTemporality = temporality
+	cfg.Metrics = []MetricConfig{
+		{
+			Name: "log.errors",
+			Type: MetricTypeCount,
+			Conditions: []string{
+				"severity_number == SEVERITY_NUMBER_ERROR",
+				`attributes["level"] == "error"`,
+			},
+			Dimensions: []Dimension{{Name: "service.name"}},
+		},
+		{
+			Name:  "response.size",
+			Type:  MetricTypeSum,
+			Value: &ValueConfig{Attribute: "size"},
+		},
+		{
+			Name:    "duration",
+			Type:    MetricTypeHistogram,
+			Value:   &ValueConfig{BodyField: "duration"},
+			Buckets: []float64{10, 100},
+		},
+	}
+	return cfg
+}
+
+func newTestLogs() plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().UpsertString("service.name", "checkout")
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+
+	lr := lrs.AppendEmpty()
+	lr.SetSeverityNumber(plog.SeverityNumberERROR)
+	lr.Attributes().UpsertInt("size", 100)
+
+	lr = lrs.AppendEmpty()
+	lr.Attributes().UpsertString("level", "error")
+	lr.Attributes().UpsertString("service.name", "cart")
+	lr.Attributes().UpsertString("size", "20.5")
+
+	lr = lrs.AppendEmpty()
+	lr.Body().SetEmptyMapVal().UpsertDouble("duration", 42)
+
+	lr = lrs.AppendEmpty()
+	lr.Body().SetEmptyMapVal().UpsertString("duration", "7")
+
+	lr = lrs.AppendEmpty()
+	lr.Body().SetStringVal("duration=1000")
+	lr.Attributes().UpsertString("size", "unknown")
+	return ld
+}
+
+func TestStartMissingExporter(t *testing.T) {
+	cfg := newTestConfig(cumulative)
+	cfg.MetricsExporter = "otlp"
+
+	p, err := newProcessor(zap.NewNop(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	err = p.Start(context.Background(), newTestHost(&testExporter{}))
+	assert.EqualError(t, err, "failed to find metrics exporter: 'otlp'; please configure metrics_exporter from one of: [prometheus]")
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestConsumeLogs(t *testing.T) {
+	next := new(consumertest.LogsSink)
+	p, err := newProcessor(zap.NewNop(), newTestConfig(cumulative), next)
+	require.NoError(t, err)
+
+	ld := newTestLogs()
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+
+	// Logs are forwarded unchanged.
+	require.Len(t, next.AllLogs(), 2)
+	assert.Equal(t, ld, next.AllLogs()[0])
+
+	now := p.startTime.Add(time.Minute)
+	md := p.buildMetrics(now)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	sm := md.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	assert.Equal(t, "logstometricsprocessor", sm.Scope().Name())
+	require.Equal(t, 3, sm.Metrics().Len())
+
+	count := sm.Metrics().At(0)
+	assert.Equal(t, "log.errors", count.Name())
+	require.Equal(t, pmetric.MetricDataTypeSum, count.DataType())
+	assert.True(t, count.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, count.Sum().AggregationTemporality())
+	dps := count.Sum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	expectedDimensions := []string{"cart", "checkout"}
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		v, ok := dp.Attributes().Get("service.name")
+		require.True(t, ok)
+		assert.Equal(t, expectedDimensions[i], v.StringVal())
+		assert.Equal(t, int64(2), dp.IntVal())
+		assert.Equal(t, pcommon.NewTimestampFromTime(p.startTime), dp.StartTimestamp())
+		assert.Equal(t, pcommon.NewTimestampFromTime(now), dp.Timestamp())
+	}
+
+	sum := sm.Metrics().At(1)
+	assert.Equal(t, "response.size", sum.Name())
+	require.Equal(t, pmetric.MetricDataTypeSum, sum.DataType())
+	assert.False(t, sum.Sum().IsMonotonic())
+	require.Equal(t, 1, sum.Sum().DataPoints().Len())
+	assert.Equal(t, 241.0, sum.Sum().DataPoints().At(0).DoubleVal())
+	assert.Equal(t, 0, sum.Sum().DataPoints().At(0).Attributes().Len())
+
+	hist := sm.Metrics().At(2)
+	assert.Equal(t, "duration", hist.Name())
+	require.Equal(t, pmetric.MetricDataTypeHistogram, hist.DataType())
+	require.Equal(t, 1, hist.Histogram().DataPoints().Len())
+	hdp := hist.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(4), hdp.Count())
+	assert.Equal(t, 98.0, hdp.Sum())
+	assert.Equal(t, []float64{10, 100}, hdp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{2, 2, 0}, hdp.BucketCounts().AsRaw())
+
+	// Cumulative aggregates keep growing.
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+	md = p.buildMetrics(now.Add(time.Minute))
+	dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(3), dp.IntVal())
+	assert.Equal(t, pcommon.NewTimestampFromTime(p.startTime), dp.StartTimestamp())
+}
+
+func TestDeltaTemporality(t *testing.T) {
+	p, err := newProcessor(zap.NewNop(), newTestConfig(delta), consumertest.NewNop())
+	require.NoError(t, err)
+	start := p.startTime
+
+	require.NoError(t, p.ConsumeLogs(context.Background(), newTestLogs()))
+	now := start.Add(time.Minute)
+	md := p.buildMetrics(now)
+	count := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, count.Sum().AggregationTemporality())
+	dp := count.Sum().DataPoints().At(0)
+	assert.Equal(t, int64(1), dp.IntVal())
+	assert.Equal(t, pcommon.NewTimestampFromTime(start), dp.StartTimestamp())
+
+	// Aggregates are reset after each interval.
+	assert.Equal(t, 0, p.buildMetrics(now.Add(time.Minute)).DataPointCount())
+
+	require.NoError(t, p.ConsumeLogs(context.Background(), newTestLogs()))
+	md = p.buildMetrics(now.Add(2 * time.Minute))
+	dp = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(1), dp.IntVal())
+	assert.Equal(t, pcommon.NewTimestampFromTime(now.Add(time.Minute)), dp.StartTimestamp())
+}
+
+func TestDimensionDefault(t *testing.T) {
+	defaultService := "unknown"
+	cfg := newTestConfig(cumulative)
+	cfg.Metrics = []MetricConfig{{
+		Name: "log.count",
+		Type: MetricTypeCount,
+		Dimensions: []Dimension{
+			{Name: "service.name", Default: &defaultService},
+			{Name: "missing"},
+		},
+	}}
+	p, err := newProcessor(zap.NewNop(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+
+	dp := p.buildMetrics(time.Now()).ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, map[string]interface{}{"service.name": "unknown"}, dp.Attributes().AsRaw())
+	assert.Equal(t, int64(1), dp.IntVal())
+}
+
+func TestDimensionMissingAndEmpty(t *testing.T) {
+	cfg := newTestConfig(cumulative)
+	cfg.Metrics = []MetricConfig{{
+		Name:       "log.count",
+		Type:       MetricTypeCount,
+		Dimensions: []Dimension{{Name: "user"}},
+	}}
+	p, err := newProcessor(zap.NewNop(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty()
+	lrs.AppendEmpty().Attributes().UpsertString("user", "")
+	lrs.AppendEmpty().Attributes().UpsertString("user", "")
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+
+	dps := p.buildMetrics(time.Now()).ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	counts := make(map[string]int64)
+	for i := 0; i < dps.Len(); i++ {
+		user, ok := dps.At(i).Attributes().Get("user")
+		if !ok {
+			counts["missing"] = dps.At(i).IntVal()
+			continue
+		}
+		counts["empty:"+user.StringVal()] = dps.At(i).IntVal()
+	}
+	assert.Equal(t, map[string]int64{"missing": 1, "empty:": 2}, counts)
+}
+
+func TestCumulativeExpiration(t *testing.T) {
+	cfg := newTestConfig(cumulative)
+	cfg.ExpireAfterIntervals = 2
+	cfg.Metrics = []MetricConfig{{
+		Name:       "log.count",
+		Type:       MetricTypeCount,
+		Dimensions: []Dimension{{Name: "user"}},
+	}}
+	p, err := newProcessor(zap.NewNop(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	consume := func(users ...string) {
+		ld := plog.NewLogs()
+		lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+		for _, user := range users {
+			lrs.AppendEmpty().Attributes().UpsertString("user", user)
+		}
+		require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+	}
+	dataPoints := func(now time.Time) map[string]pmetric.NumberDataPoint {
+		md := p.buildMetrics(now)
+		dps := make(map[string]pmetric.NumberDataPoint)
+		if md.DataPointCount() == 0 {
+			return dps
+		}
+		sum := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum()
+		for i := 0; i < sum.DataPoints().Len(); i++ {
+			user, _ := sum.DataPoints().At(i).Attributes().Get("user")
+			dps[user.StringVal()] = sum.DataPoints().At(i)
+		}
+		return dps
+	}
+	start := p.startTime
+
+	consume("alice", "bob")
+	assert.Len(t, dataPoints(start.Add(time.Minute)), 2)
+
+	// The data point of bob is kept until it isn't updated for two intervals.
+	consume("alice")
+	assert.Len(t, dataPoints(start.Add(2*time.Minute)), 2)
+	dps := dataPoints(start.Add(3 * time.Minute))
+	assert.Len(t, dps, 1)
+	assert.Contains(t, dps, "alice")
+	assert.Len(t, p.metrics[0].dataPoints, 1)
+
+	// It starts again from the interval it is next updated in.
+	consume("alice", "bob")
+	dps = dataPoints(start.Add(4 * time.Minute))
+	require.Contains(t, dps, "alice")
+	require.Contains(t, dps, "bob")
+	assert.Equal(t, int64(1), dps["bob"].IntVal())
+	assert.Equal(t, pcommon.NewTimestampFromTime(start.Add(3*time.Minute)), dps["bob"].StartTimestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(start), dps["alice"].StartTimestamp())
+	assert.Equal(t, int64(3), dps["alice"].IntVal())
+}
+
+func TestShutdownExportsMetrics(t *testing.T) {
+	exp := &testExporter{}
+	p, err := newProcessor(zap.NewNop(), newTestConfig(cumulative), consumertest.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), newTestHost(exp)))
+
+	require.NoError(t, p.ConsumeLogs(context.Background(), newTestLogs()))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	require.Len(t, exp.AllMetrics(), 1)
+	assert.Equal(t, 4, exp.AllMetrics()[0].DataPointCount())
+}
+
+func TestAggregationInterval(t *testing.T) {
+	exp := &testExporter{}
+	cfg := newTestConfig(cumulative)
+	cfg.AggregationInterval = 10 * time.Millisecond
+	p, err := newProcessor(zap.NewNop(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), newTestHost(exp)))
+	defer func() { require.NoError(t, p.Shutdown(context.Background())) }()
+
+	// Nothing is exported while no logs were received.
+	time.Sleep(30 * time.Millisecond)
+	assert.Empty(t, exp.AllMetrics())
+
+	require.NoError(t, p.ConsumeLogs(context.Background(), newTestLogs()))
+	assert.Eventually(t, func() bool {
+		return len(exp.AllMetrics()) > 0
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestGetValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      ValueConfig
+		record   func(lr plog.LogRecord)
+		expected float64
+		ok       bool
+	}{
+		{
+			name:     "int attribute",
+			cfg:      ValueConfig{Attribute: "v"},
+			record:   func(lr plog.LogRecord) { lr.Attributes().UpsertInt("v", 3) },
+			expected: 3,
+			ok:       true,
+		},
+		{
+			name:     "double attribute",
+			cfg:      ValueConfig{Attribute: "v"},
+			record:   func(lr plog.LogRecord) { lr.Attributes().UpsertDouble("v", 1.5) },
+			expected: 1.5,
+			ok:       true,
+		},
+		{
+			name:     "string attribute",
+			cfg:      ValueConfig{Attribute: "v"},
+			record:   func(lr plog.LogRecord) { lr.Attributes().UpsertString("v", " 2.5 ") },
+			expected: 2.5,
+			ok:       true,
+		},
+		{
+			name:   "non numeric attribute",
+			cfg:    ValueConfig{Attribute: "v"},
+			record: func(lr plog.LogRecord) { lr.Attributes().UpsertBool("v", true) },
+		},
+		{
+			name:   "missing attribute",
+			cfg:    ValueConfig{Attribute: "v"},
+			record: func(lr plog.LogRecord) {},
+		},
+		{
+			name:     "body field",
+			cfg:      ValueConfig{BodyField: "v"},
+			record:   func(lr plog.LogRecord) { lr.Body().SetEmptyMapVal().UpsertInt("v", 8) },
+			expected: 8,
+			ok:       true,
+		},
+		{
+			name:   "body not a map",
+			cfg:    ValueConfig{BodyField: "v"},
+			record: func(lr plog.LogRecord) { lr.Body().SetStringVal("v=8") },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr := plog.NewLogRecord()
+			tt.record(lr)
+			v, ok := getValue(&tt.cfg, lr)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, v)
+		})
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/metadata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchperresourceattr"
 )
 
 // extractor is responsible for extracting configured attributes from the processed data.
@@ -51,6 +53,16 @@ func (e extractor) extractAttrFromResource(r pcommon.Resource) string {
 	return routingAttribute.AsString()
 }
 
+// contextWithResourceAttr returns a context carrying the value of the resource attribute
+// shared by the resources routed together, e.g. for the authenticators of the exporters
+// to set request headers from it.
+func (e extractor) contextWithResourceAttr(ctx context.Context, value string) context.Context {
+	if value == "" {
+		return ctx
+	}
+	return batchperresourceattr.NewContext(ctx, e.fromAttr, value)
+}
+
 func (e extractor) extractFromContext(ctx context.Context) string {
 	// right now, we only support looking up attributes from requests that have
 	// gone through the gRPC server in that case, it will add the HTTP headers
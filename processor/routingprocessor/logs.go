@@ -110,13 +110,14 @@ func (p *logProcessor) route(ctx context.Context, l plog.Logs) error {
 		}
 	}
 
-	for _, g := range groups {
+	for attrValue, g := range groups {
+		groupCtx := p.extractor.contextWithResourceAttr(ctx, attrValue)
 		l := plog.NewLogs()
 		l.ResourceLogs().EnsureCapacity(g.resLogs.Len())
 		g.resLogs.MoveAndAppendTo(l.ResourceLogs())
 
 		for _, e := range g.exporters {
-			errs = multierr.Append(errs, e.ConsumeLogs(ctx, l))
+			errs = multierr.Append(errs, e.ConsumeLogs(groupCtx, l))
 		}
 	}
 	return errs
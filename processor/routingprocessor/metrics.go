@@ -110,13 +110,14 @@ func (p *metricsProcessor) route(ctx context.Context, tm pmetric.Metrics) error
 		}
 	}
 
-	for _, g := range groups {
+	for attrValue, g := range groups {
+		groupCtx := p.extractor.contextWithResourceAttr(ctx, attrValue)
 		m := pmetric.NewMetrics()
 		m.ResourceMetrics().EnsureCapacity(g.resMetrics.Len())
 		g.resMetrics.MoveAndAppendTo(m.ResourceMetrics())
 
 		for _, e := range g.exporters {
-			errs = multierr.Append(errs, e.ConsumeMetrics(ctx, m))
+			errs = multierr.Append(errs, e.ConsumeMetrics(groupCtx, m))
 		}
 	}
 	return errs
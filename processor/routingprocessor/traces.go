@@ -110,13 +110,14 @@ func (p *tracesProcessor) route(ctx context.Context, t ptrace.Traces) error {
 		}
 	}
 
-	for _, g := range groups {
+	for attrValue, g := range groups {
+		groupCtx := p.extractor.contextWithResourceAttr(ctx, attrValue)
 		t := ptrace.NewTraces()
 		t.ResourceSpans().EnsureCapacity(g.resSpans.Len())
 		g.resSpans.MoveAndAppendTo(t.ResourceSpans())
 
 		for _, e := range g.exporters {
-			errs = multierr.Append(errs, e.ConsumeTraces(ctx, t))
+			errs = multierr.Append(errs, e.ConsumeTraces(groupCtx, t))
 		}
 	}
 	return errs
@@ -29,6 +29,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/metadata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchperresourceattr"
 )
 
 func TestTraces_RegisterExportersForValidRoute(t *testing.T) {
@@ -334,6 +336,49 @@ func TestTraces_RoutingWorks_ResourceAttribute_DropsRoutingAttribute(t *testing.
 	assert.Equal(t, "acme", v.StringVal())
 }
 
+func TestTraces_ResourceAttributeRoutingValueInContext(t *testing.T) {
+	defaultExp := &mockTracesExporter{}
+
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		GetExportersFunc: func() map[config.DataType]map[config.ComponentID]component.Exporter {
+			return map[config.DataType]map[config.ComponentID]component.Exporter{
+				config.TracesDataType: {
+					config.NewComponentID("otlp"): defaultExp,
+				},
+			}
+		},
+	}
+
+	exp := newTracesProcessor(zap.NewNop(), &Config{
+		FromAttribute:                "X-Tenant",
+		AttributeSource:              resourceAttributeSource,
+		DropRoutingResourceAttribute: true,
+		DefaultExporters:             []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Value:     "acme",
+				Exporters: []string{"otlp"},
+			},
+		},
+	})
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	tr := ptrace.NewTraces()
+	for _, tenant := range []string{"acme", "globex", "acme", ""} {
+		rs := tr.ResourceSpans().AppendEmpty()
+		if tenant != "" {
+			rs.Resource().Attributes().InsertString("X-Tenant", tenant)
+		}
+	}
+	require.NoError(t, exp.ConsumeTraces(context.Background(), tr))
+
+	// each group of resources is exported with the routing attribute value in
+	// its context, even when the attribute is dropped from the resources
+	require.Len(t, defaultExp.AllTraces(), 3)
+	assert.ElementsMatch(t, []string{"acme", "globex", ""}, defaultExp.routingValues)
+}
+
 func TestTraceProcessorCapabilities(t *testing.T) {
 	// prepare
 	config := &Config{
@@ -355,4 +400,12 @@ func TestTraceProcessorCapabilities(t *testing.T) {
 type mockTracesExporter struct {
 	mockComponent
 	consumertest.TracesSink
+
+	routingValues []string
+}
+
+func (m *mockTracesExporter) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	value, _ := batchperresourceattr.FromContext(ctx, "X-Tenant")
+	m.routingValues = append(m.routingValues, value)
+	return m.TracesSink.ConsumeTraces(ctx, td)
 }
@@ -28,6 +28,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cpuscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/diskscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper"
@@ -94,6 +95,15 @@ func TestLoadConfig(t *testing.T) {
 				}
 				return cfg
 			})(),
+			cgroupscraper.TypeStr: (func() internal.Config {
+				cfg := (&cgroupscraper.Factory{}).CreateDefaultConfig()
+				cfg.(*cgroupscraper.Config).MaxDepth = 3
+				cfg.(*cgroupscraper.Config).Exclude = cgroupscraper.MatchConfig{
+					Paths:  []string{"^/user.slice/"},
+					Config: filterset.Config{MatchType: "regexp"},
+				}
+				return cfg
+			})(),
 		},
 	}
 
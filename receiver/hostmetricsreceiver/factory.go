@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cpuscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/diskscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper"
@@ -46,6 +47,7 @@ const (
 
 var (
 	scraperFactories = map[string]internal.ScraperFactory{
+		cgroupscraper.TypeStr:     &cgroupscraper.Factory{},
 		cpuscraper.TypeStr:        &cpuscraper.Factory{},
 		diskscraper.TypeStr:       &diskscraper.Factory{},
 		loadscraper.TypeStr:       &loadscraper.Factory{},
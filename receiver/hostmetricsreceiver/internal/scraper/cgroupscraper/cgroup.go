@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// containerIDPattern matches the container IDs in the cgroup names set by the
+// container runtimes, e.g. "docker-<id>.scope", "cri-containerd-<id>.scope" or "<id>".
+var containerIDPattern = regexp.MustCompile(`(?:^|[-:])([0-9a-f]{64})(?:\.scope)?$`)
+
+// systemdUnitPattern matches the names of the cgroups managed by systemd.
+var systemdUnitPattern = regexp.MustCompile(`^[^/]+\.(?:service|scope|slice|socket|mount|swap|timer)$`)
+
+// listCgroups returns the paths, relative to the root, of the cgroups at most
+// maxDepth levels below the root. The root cgroup is not returned.
+func listCgroups(root string, maxDepth int) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// cgroups are removed while walking the hierarchy.
+			if p != root && os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() || p == root {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		depth := strings.Count(rel, string(filepath.Separator)) + 1
+		if maxDepth > 0 && depth > maxDepth {
+			return filepath.SkipDir
+		}
+		paths = append(paths, "/"+filepath.ToSlash(rel))
+		return nil
+	})
+	return paths, err
+}
+
+// getCgroupIdentity returns the systemd unit and the container ID of the cgroup,
+// when they can be detected from its path.
+func getCgroupIdentity(cgroupPath string) (systemdUnit string, containerID string) {
+	name := path.Base(cgroupPath)
+	if systemdUnitPattern.MatchString(name) {
+		systemdUnit = name
+	}
+	if match := containerIDPattern.FindStringSubmatch(name); match != nil {
+		containerID = match[1]
+	}
+	return systemdUnit, containerID
+}
+
+// readFlatKeyed parses a file made of "<key> <value>" lines, like cpu.stat.
+func readFlatKeyed(file string) (map[string]uint64, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := map[string]uint64{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %q in %s: %w", fields[0], file, err)
+		}
+		values[fields[0]] = value
+	}
+	return values, scanner.Err()
+}
+
+// readSingleValue parses a file holding a single value, like memory.current.
+// It returns false when the value is "max", i.e. there is no limit.
+func readSingleValue(file string) (int64, bool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return 0, false, err
+	}
+
+	content := strings.TrimSpace(string(data))
+	if content == "max" {
+		return 0, false, nil
+	}
+	value, err := strconv.ParseInt(content, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	return value, true, nil
+}
+
+// ioStat holds the statistics of a block device from io.stat.
+type ioStat struct {
+	device string
+	rbytes int64
+	wbytes int64
+	rios   int64
+	wios   int64
+}
+
+// readIOStat parses an io.stat file, made of lines like
+// "8:0 rbytes=1 wbytes=2 rios=3 wios=4 dbytes=0 dios=0".
+func readIOStat(file string) ([]ioStat, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var stats []ioStat
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		stat := ioStat{device: fields[0]}
+		for _, field := range fields[1:] {
+			key, value, found := strings.Cut(field, "=")
+			if !found {
+				continue
+			}
+			var dst *int64
+			switch key {
+			case "rbytes":
+				dst = &stat.rbytes
+			case "wbytes":
+				dst = &stat.wbytes
+			case "rios":
+				dst = &stat.rios
+			case "wios":
+				dst = &stat.wios
+			default:
+				continue
+			}
+			if *dst, err = strconv.ParseInt(value, 10, 64); err != nil {
+				return nil, fmt.Errorf("failed to parse %q of device %s in %s: %w", key, stat.device, file, err)
+			}
+		}
+		stats = append(stats, stat)
+	}
+	return stats, scanner.Err()
+}
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+const (
+	pressureMetricsLen = 2
+	cpuMetricsLen      = 3
+	memoryMetricsLen   = 2
+	ioMetricsLen       = 2
+	cgroupMetricsLen   = pressureMetricsLen + cpuMetricsLen + memoryMetricsLen + ioMetricsLen
+)
+
+// pressureResources are the resources with pressure stall information, by file name.
+var pressureResources = []struct {
+	name     string
+	resource metadata.AttributeResource
+}{
+	{"cpu", metadata.AttributeResourceCpu},
+	{"memory", metadata.AttributeResourceMemory},
+	{"io", metadata.AttributeResourceIo},
+}
+
+// scraper for cgroup Metrics
+type scraper struct {
+	settings  component.ReceiverCreateSettings
+	config    *Config
+	mb        *metadata.MetricsBuilder
+	includeFS filterset.FilterSet
+	excludeFS filterset.FilterSet
+
+	// for mocking
+	bootTime func() (uint64, error)
+}
+
+// newCgroupScraper creates a cgroup Scraper
+func newCgroupScraper(settings component.ReceiverCreateSettings, cfg *Config) (*scraper, error) {
+	scraper := &scraper{settings: settings, config: cfg, bootTime: host.BootTime}
+
+	var err error
+	if len(cfg.Include.Paths) > 0 {
+		scraper.includeFS, err = filterset.CreateFilterSet(cfg.Include.Paths, &cfg.Include.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup include filters: %w", err)
+		}
+	}
+
+	if len(cfg.Exclude.Paths) > 0 {
+		scraper.excludeFS, err = filterset.CreateFilterSet(cfg.Exclude.Paths, &cfg.Exclude.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup exclude filters: %w", err)
+		}
+	}
+
+	return scraper, nil
+}
+
+func (s *scraper) start(context.Context, component.Host) error {
+	bootTime, err := s.bootTime()
+	if err != nil {
+		return err
+	}
+
+	s.mb = metadata.NewMetricsBuilder(s.config.Metrics, s.settings.BuildInfo, metadata.WithStartTime(pcommon.Timestamp(bootTime*1e9)))
+	return nil
+}
+
+func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
+	var errs scrapererror.ScrapeErrors
+
+	paths, err := listCgroups(s.config.CgroupRoot, s.config.MaxDepth)
+	if err != nil {
+		errs.AddPartial(cgroupMetricsLen, fmt.Errorf("error listing cgroups: %w", err))
+	}
+
+	for _, path := range paths {
+		if !s.matches(path) {
+			continue
+		}
+
+		now := pcommon.NewTimestampFromTime(time.Now())
+		dir := filepath.Join(s.config.CgroupRoot, filepath.FromSlash(path))
+		s.scrapeAndAppendCgroupMetrics(now, dir, path, &errs)
+
+		systemdUnit, containerID := getCgroupIdentity(path)
+		s.mb.EmitForResource(
+			metadata.WithCgroupPath(path),
+			metadata.WithSystemdUnit(systemdUnit),
+			metadata.WithContainerID(containerID),
+		)
+	}
+
+	// The host metrics are recorded last, to be emitted without any cgroup resource.
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for _, r := range pressureResources {
+		stats, err := readPressure(filepath.Join(s.config.PressureRoot, r.name))
+		if err != nil {
+			errs.AddPartial(pressureMetricsLen, fmt.Errorf("error reading %s pressure: %w", r.name, err))
+			continue
+		}
+		for _, stat := range stats {
+			s.mb.RecordSystemPressureStallTimeDataPoint(now, stat.total, r.resource, stat.stall)
+			for _, window := range pressureWindows {
+				if avg, ok := stat.averages[window.name]; ok {
+					s.mb.RecordSystemPressureStallRatioDataPoint(now, avg, r.resource, stat.stall, window.window)
+				}
+			}
+		}
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func (s *scraper) matches(path string) bool {
+	return (s.includeFS == nil || s.includeFS.Matches(path)) &&
+		(s.excludeFS == nil || !s.excludeFS.Matches(path))
+}
+
+// scrapeAndAppendCgroupMetrics records the metrics of a cgroup. The files of the
+// controllers which are not enabled for the cgroup do not exist and are skipped.
+func (s *scraper) scrapeAndAppendCgroupMetrics(now pcommon.Timestamp, dir string, path string, errs *scrapererror.ScrapeErrors) {
+	for _, r := range pressureResources {
+		stats, err := readPressure(filepath.Join(dir, r.name+".pressure"))
+		if err != nil {
+			addCgroupError(errs, pressureMetricsLen, path, err)
+			continue
+		}
+		for _, stat := range stats {
+			s.mb.RecordCgroupPressureStallTimeDataPoint(now, stat.total, r.resource, stat.stall)
+			for _, window := range pressureWindows {
+				if avg, ok := stat.averages[window.name]; ok {
+					s.mb.RecordCgroupPressureStallRatioDataPoint(now, avg, r.resource, stat.stall, window.window)
+				}
+			}
+		}
+	}
+
+	if cpuStat, err := readFlatKeyed(filepath.Join(dir, "cpu.stat")); err != nil {
+		addCgroupError(errs, cpuMetricsLen, path, err)
+	} else {
+		if usec, ok := cpuStat["user_usec"]; ok {
+			s.mb.RecordCgroupCPUTimeDataPoint(now, float64(usec)/1e6, metadata.AttributeStateUser)
+		}
+		if usec, ok := cpuStat["system_usec"]; ok {
+			s.mb.RecordCgroupCPUTimeDataPoint(now, float64(usec)/1e6, metadata.AttributeStateSystem)
+		}
+		// The throttling statistics are only reported when the cpu controller is enabled.
+		if usec, ok := cpuStat["throttled_usec"]; ok {
+			s.mb.RecordCgroupCPUThrottledTimeDataPoint(now, float64(usec)/1e6)
+		}
+		if periods, ok := cpuStat["nr_throttled"]; ok {
+			s.mb.RecordCgroupCPUThrottledPeriodsDataPoint(now, int64(periods))
+		}
+	}
+
+	if usage, _, err := readSingleValue(filepath.Join(dir, "memory.current")); err != nil {
+		addCgroupError(errs, 1, path, err)
+	} else {
+		s.mb.RecordCgroupMemoryUsageDataPoint(now, usage)
+	}
+	if limit, limited, err := readSingleValue(filepath.Join(dir, "memory.max")); err != nil {
+		addCgroupError(errs, 1, path, err)
+	} else if limited {
+		s.mb.RecordCgroupMemoryLimitDataPoint(now, limit)
+	}
+
+	if ioStats, err := readIOStat(filepath.Join(dir, "io.stat")); err != nil {
+		addCgroupError(errs, ioMetricsLen, path, err)
+	} else {
+		for _, stat := range ioStats {
+			s.mb.RecordCgroupIoBytesDataPoint(now, stat.rbytes, stat.device, metadata.AttributeDirectionRead)
+			s.mb.RecordCgroupIoBytesDataPoint(now, stat.wbytes, stat.device, metadata.AttributeDirectionWrite)
+			s.mb.RecordCgroupIoOperationsDataPoint(now, stat.rios, stat.device, metadata.AttributeDirectionRead)
+			s.mb.RecordCgroupIoOperationsDataPoint(now, stat.wios, stat.device, metadata.AttributeDirectionWrite)
+		}
+	}
+}
+
+// addCgroupError adds the error to errs, unless the file does not exist because
+// the controller is not enabled or the cgroup was removed.
+func addCgroupError(errs *scrapererror.ScrapeErrors, failed int, path string, err error) {
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	errs.AddPartial(failed, fmt.Errorf("error reading metrics of cgroup %q: %w", path, err))
+}
@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+const (
+	sshPath    = "/system.slice/ssh.service"
+	dockerID   = "3a4f1b2c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f701"
+	dockerPath = "/system.slice/docker-" + dockerID + ".scope"
+	podPath    = "/kubepods/burstable/pod0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0"
+	podID      = "b1c2d3e4f5a60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90"
+	bootTime   = 100
+)
+
+func newTestConfig() *Config {
+	cfg := (&Factory{}).CreateDefaultConfig().(*Config)
+	cfg.CgroupRoot = filepath.Join("testdata", "cgroup")
+	cfg.PressureRoot = filepath.Join("testdata", "pressure")
+	return cfg
+}
+
+func scrapeTestdata(t *testing.T, cfg *Config) (pmetric.Metrics, error) {
+	scraper, err := newCgroupScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, err)
+	scraper.bootTime = func() (uint64, error) { return bootTime, nil }
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	return scraper.scrape(context.Background())
+}
+
+// resourceMetricsByPath returns the metrics of every cgroup by path, and the host
+// metrics under the empty path.
+func resourceMetricsByPath(md pmetric.Metrics) map[string]pmetric.ResourceMetrics {
+	out := map[string]pmetric.ResourceMetrics{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		path, _ := rm.Resource().Attributes().Get("cgroup.path")
+		out[path.StringVal()] = rm
+	}
+	return out
+}
+
+func metricsByName(rm pmetric.ResourceMetrics) map[string]pmetric.Metric {
+	out := map[string]pmetric.Metric{}
+	metrics := rm.ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		out[metrics.At(i).Name()] = metrics.At(i)
+	}
+	return out
+}
+
+func TestScrape(t *testing.T) {
+	md, err := scrapeTestdata(t, newTestConfig())
+	require.NoError(t, err)
+
+	byPath := resourceMetricsByPath(md)
+	assert.Len(t, byPath, 8)
+	for _, path := range []string{"", "/system.slice", sshPath, dockerPath, "/kubepods", "/kubepods/burstable", podPath, podPath + "/" + podID} {
+		assert.Contains(t, byPath, path)
+	}
+
+	// Host pressure stall information.
+	host := metricsByName(byPath[""])
+	assert.Len(t, host, 2)
+	stallTime := host["system.pressure.stall.time"]
+	internal.AssertSumMetricStartTimeEquals(t, stallTime, pcommon.Timestamp(bootTime*1e9))
+	require.Equal(t, 6, stallTime.Sum().DataPoints().Len())
+	assert.Equal(t, 2.5, stallTime.Sum().DataPoints().At(0).DoubleVal())
+	internal.AssertSumMetricHasAttributeValue(t, stallTime, 0, "resource", pcommon.NewValueString("cpu"))
+	internal.AssertSumMetricHasAttributeValue(t, stallTime, 0, "stall", pcommon.NewValueString("some"))
+	assert.Equal(t, 9.0, stallTime.Sum().DataPoints().At(3).DoubleVal())
+	internal.AssertSumMetricHasAttributeValue(t, stallTime, 3, "resource", pcommon.NewValueString("memory"))
+	internal.AssertSumMetricHasAttributeValue(t, stallTime, 3, "stall", pcommon.NewValueString("full"))
+
+	stallRatio := host["system.pressure.stall.ratio"]
+	require.Equal(t, 18, stallRatio.Gauge().DataPoints().Len())
+	assert.Equal(t, 0.015, stallRatio.Gauge().DataPoints().At(0).DoubleVal())
+	internal.AssertGaugeMetricHasAttributeValue(t, stallRatio, 0, "window", pcommon.NewValueString("10s"))
+	assert.Equal(t, 0.0025, stallRatio.Gauge().DataPoints().At(2).DoubleVal())
+	internal.AssertGaugeMetricHasAttributeValue(t, stallRatio, 2, "window", pcommon.NewValueString("300s"))
+
+	// A systemd service with all the controllers enabled.
+	ssh := byPath[sshPath]
+	unit, ok := ssh.Resource().Attributes().Get("systemd.unit")
+	require.True(t, ok)
+	assert.Equal(t, "ssh.service", unit.StringVal())
+	containerID, ok := ssh.Resource().Attributes().Get("container.id")
+	require.True(t, ok)
+	assert.Equal(t, "", containerID.StringVal())
+
+	sshMetrics := metricsByName(ssh)
+	assert.Len(t, sshMetrics, 7)
+	assert.NotContains(t, sshMetrics, "cgroup.memory.limit")
+	assert.NotContains(t, sshMetrics, "cgroup.pressure.stall.ratio")
+
+	cpuTime := sshMetrics["cgroup.cpu.time"]
+	require.Equal(t, 2, cpuTime.Sum().DataPoints().Len())
+	assert.Equal(t, 1.0, cpuTime.Sum().DataPoints().At(0).DoubleVal())
+	internal.AssertSumMetricHasAttributeValue(t, cpuTime, 0, "state", pcommon.NewValueString("user"))
+	assert.Equal(t, 0.5, cpuTime.Sum().DataPoints().At(1).DoubleVal())
+	internal.AssertSumMetricHasAttributeValue(t, cpuTime, 1, "state", pcommon.NewValueString("system"))
+
+	assert.Equal(t, int64(4194304), sshMetrics["cgroup.memory.usage"].Sum().DataPoints().At(0).IntVal())
+
+	ioBytes := sshMetrics["cgroup.io.bytes"]
+	require.Equal(t, 4, ioBytes.Sum().DataPoints().Len())
+	assert.Equal(t, int64(8192), ioBytes.Sum().DataPoints().At(1).IntVal())
+	internal.AssertSumMetricHasAttributeValue(t, ioBytes, 1, "device", pcommon.NewValueString("8:0"))
+	internal.AssertSumMetricHasAttributeValue(t, ioBytes, 1, "direction", pcommon.NewValueString("write"))
+	assert.Equal(t, 4, sshMetrics["cgroup.io.operations"].Sum().DataPoints().Len())
+
+	cgroupStallTime := sshMetrics["cgroup.pressure.stall.time"]
+	require.Equal(t, 6, cgroupStallTime.Sum().DataPoints().Len())
+	assert.Equal(t, 4.0, cgroupStallTime.Sum().DataPoints().At(0).DoubleVal())
+
+	// A container with a memory limit and throttled CPU, without io controller.
+	docker := byPath[dockerPath]
+	unit, _ = docker.Resource().Attributes().Get("systemd.unit")
+	assert.Equal(t, "docker-"+dockerID+".scope", unit.StringVal())
+	containerID, _ = docker.Resource().Attributes().Get("container.id")
+	assert.Equal(t, dockerID, containerID.StringVal())
+
+	dockerMetrics := metricsByName(docker)
+	assert.Len(t, dockerMetrics, 5)
+	assert.Equal(t, 1.25, dockerMetrics["cgroup.cpu.throttled.time"].Sum().DataPoints().At(0).DoubleVal())
+	assert.Equal(t, int64(25), dockerMetrics["cgroup.cpu.throttled.periods"].Sum().DataPoints().At(0).IntVal())
+	assert.Equal(t, int64(536870912), dockerMetrics["cgroup.memory.limit"].Gauge().DataPoints().At(0).IntVal())
+
+	// A container created by the cgroupfs driver.
+	containerID, _ = byPath[podPath+"/"+podID].Resource().Attributes().Get("container.id")
+	assert.Equal(t, podID, containerID.StringVal())
+}
+
+func TestScrapeMaxDepth(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.MaxDepth = 2
+
+	md, err := scrapeTestdata(t, cfg)
+	require.NoError(t, err)
+
+	byPath := resourceMetricsByPath(md)
+	assert.Len(t, byPath, 6)
+	assert.NotContains(t, byPath, podPath)
+}
+
+func TestScrapeFilters(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Include = MatchConfig{
+		Config: filterset.Config{MatchType: filterset.Regexp},
+		Paths:  []string{"^/system.slice/"},
+	}
+	cfg.Exclude = MatchConfig{
+		Config: filterset.Config{MatchType: filterset.Strict},
+		Paths:  []string{sshPath},
+	}
+
+	md, err := scrapeTestdata(t, cfg)
+	require.NoError(t, err)
+
+	byPath := resourceMetricsByPath(md)
+	assert.Len(t, byPath, 2)
+	assert.Contains(t, byPath, dockerPath)
+	assert.Contains(t, byPath, "")
+}
+
+func TestScrapeErrors(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.PressureRoot = t.TempDir()
+
+	md, err := scrapeTestdata(t, cfg)
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	var partialErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, 3*pressureMetricsLen, partialErr.Failed)
+	// The metrics of the cgroups are still scraped.
+	assert.Len(t, resourceMetricsByPath(md), 7)
+
+	cfg = newTestConfig()
+	cfg.CgroupRoot = filepath.Join(t.TempDir(), "missing")
+	_, err = scrapeTestdata(t, cfg)
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, cgroupMetricsLen, partialErr.Failed)
+}
+
+func TestReadPressureErrors(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"unknown_line": "partial avg10=0.00 avg60=0.00 avg300=0.00 total=0\n",
+		"bad_field":    "some avg10\n",
+		"bad_total":    "some avg10=0.00 total=x\n",
+		"bad_average":  "some avg10=x total=0\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(dir, name)
+			require.NoError(t, os.WriteFile(file, []byte(content), 0600))
+			_, err := readPressure(file)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGetCgroupIdentity(t *testing.T) {
+	tests := []struct {
+		path        string
+		systemdUnit string
+		containerID string
+	}{
+		{path: "/system.slice", systemdUnit: "system.slice"},
+		{path: "/user.slice/user-1000.slice/session-2.scope", systemdUnit: "session-2.scope"},
+		{path: "/system.slice/containerd.service", systemdUnit: "containerd.service"},
+		{path: dockerPath, systemdUnit: "docker-" + dockerID + ".scope", containerID: dockerID},
+		{path: "/kubepods.slice/kubepods-pod1.slice/cri-containerd-" + podID + ".scope", systemdUnit: "cri-containerd-" + podID + ".scope", containerID: podID},
+		{path: "/docker/" + dockerID, containerID: dockerID},
+		{path: "/kubepods/burstable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			systemdUnit, containerID := getCgroupIdentity(tt.path)
+			assert.Equal(t, tt.systemdUnit, systemdUnit)
+			assert.Equal(t, tt.containerID, containerID)
+		})
+	}
+}
+
+func TestDisabledMetrics(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Metrics = metadata.DefaultMetricsSettings()
+	cfg.Metrics.CgroupPressureStallRatio.Enabled = true
+	cfg.Metrics.SystemPressureStallRatio.Enabled = false
+
+	md, err := scrapeTestdata(t, cfg)
+	require.NoError(t, err)
+
+	byPath := resourceMetricsByPath(md)
+	assert.NotContains(t, metricsByName(byPath[""]), "system.pressure.stall.ratio")
+	assert.Contains(t, metricsByName(byPath[sshPath]), "cgroup.pressure.stall.ratio")
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+// Config relating to cgroup Metric Scraper.
+type Config struct {
+	// Metrics allows to customize scraped metrics representation.
+	Metrics metadata.MetricsSettings `mapstructure:"metrics"`
+
+	// CgroupRoot is the mount point of the cgroup v2 unified hierarchy.
+	CgroupRoot string `mapstructure:"cgroup_root"`
+	// PressureRoot is the directory holding the pressure stall information of the host.
+	PressureRoot string `mapstructure:"pressure_root"`
+	// MaxDepth is the maximum depth below the root of the scraped cgroups. The
+	// default value of 0 scrapes all the cgroups.
+	MaxDepth int `mapstructure:"max_depth"`
+
+	// Include specifies a filter on the cgroup paths that should be included from the generated metrics.
+	// Exclude specifies a filter on the cgroup paths that should be excluded from the generated metrics.
+	// If neither `include` or `exclude` are set, metrics will be generated for all cgroups.
+	Include MatchConfig `mapstructure:"include"`
+	Exclude MatchConfig `mapstructure:"exclude"`
+}
+
+type MatchConfig struct {
+	filterset.Config `mapstructure:",squash"`
+
+	Paths []string `mapstructure:"paths"`
+}
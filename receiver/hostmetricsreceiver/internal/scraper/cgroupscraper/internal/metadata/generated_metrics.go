@@ -0,0 +1,1000 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.9.0"
+)
+
+// MetricSettings provides common settings for a particular metric.
+type MetricSettings struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// MetricsSettings provides settings for hostmetricsreceiver/cgroup metrics.
+type MetricsSettings struct {
+	CgroupCPUThrottledPeriods MetricSettings `mapstructure:"cgroup.cpu.throttled.periods"`
+	CgroupCPUThrottledTime    MetricSettings `mapstructure:"cgroup.cpu.throttled.time"`
+	CgroupCPUTime             MetricSettings `mapstructure:"cgroup.cpu.time"`
+	CgroupIoBytes             MetricSettings `mapstructure:"cgroup.io.bytes"`
+	CgroupIoOperations        MetricSettings `mapstructure:"cgroup.io.operations"`
+	CgroupMemoryLimit         MetricSettings `mapstructure:"cgroup.memory.limit"`
+	CgroupMemoryUsage         MetricSettings `mapstructure:"cgroup.memory.usage"`
+	CgroupPressureStallRatio  MetricSettings `mapstructure:"cgroup.pressure.stall.ratio"`
+	CgroupPressureStallTime   MetricSettings `mapstructure:"cgroup.pressure.stall.time"`
+	SystemPressureStallRatio  MetricSettings `mapstructure:"system.pressure.stall.ratio"`
+	SystemPressureStallTime   MetricSettings `mapstructure:"system.pressure.stall.time"`
+}
+
+func DefaultMetricsSettings() MetricsSettings {
+	return MetricsSettings{
+		CgroupCPUThrottledPeriods: MetricSettings{
+			Enabled: true,
+		},
+		CgroupCPUThrottledTime: MetricSettings{
+			Enabled: true,
+		},
+		CgroupCPUTime: MetricSettings{
+			Enabled: true,
+		},
+		CgroupIoBytes: MetricSettings{
+			Enabled: true,
+		},
+		CgroupIoOperations: MetricSettings{
+			Enabled: true,
+		},
+		CgroupMemoryLimit: MetricSettings{
+			Enabled: true,
+		},
+		CgroupMemoryUsage: MetricSettings{
+			Enabled: true,
+		},
+		CgroupPressureStallRatio: MetricSettings{
+			Enabled: false,
+		},
+		CgroupPressureStallTime: MetricSettings{
+			Enabled: true,
+		},
+		SystemPressureStallRatio: MetricSettings{
+			Enabled: true,
+		},
+		SystemPressureStallTime: MetricSettings{
+			Enabled: true,
+		},
+	}
+}
+
+// AttributeDirection specifies the a value direction attribute.
+type AttributeDirection int
+
+const (
+	_ AttributeDirection = iota
+	AttributeDirectionRead
+	AttributeDirectionWrite
+)
+
+// String returns the string representation of the AttributeDirection.
+func (av AttributeDirection) String() string {
+	switch av {
+	case AttributeDirectionRead:
+		return "read"
+	case AttributeDirectionWrite:
+		return "write"
+	}
+	return ""
+}
+
+// MapAttributeDirection is a helper map of string to AttributeDirection attribute value.
+var MapAttributeDirection = map[string]AttributeDirection{
+	"read":  AttributeDirectionRead,
+	"write": AttributeDirectionWrite,
+}
+
+// AttributeResource specifies the a value resource attribute.
+type AttributeResource int
+
+const (
+	_ AttributeResource = iota
+	AttributeResourceCpu
+	AttributeResourceMemory
+	AttributeResourceIo
+)
+
+// String returns the string representation of the AttributeResource.
+func (av AttributeResource) String() string {
+	switch av {
+	case AttributeResourceCpu:
+		return "cpu"
+	case AttributeResourceMemory:
+		return "memory"
+	case AttributeResourceIo:
+		return "io"
+	}
+	return ""
+}
+
+// MapAttributeResource is a helper map of string to AttributeResource attribute value.
+var MapAttributeResource = map[string]AttributeResource{
+	"cpu":    AttributeResourceCpu,
+	"memory": AttributeResourceMemory,
+	"io":     AttributeResourceIo,
+}
+
+// AttributeStall specifies the a value stall attribute.
+type AttributeStall int
+
+const (
+	_ AttributeStall = iota
+	AttributeStallSome
+	AttributeStallFull
+)
+
+// String returns the string representation of the AttributeStall.
+func (av AttributeStall) String() string {
+	switch av {
+	case AttributeStallSome:
+		return "some"
+	case AttributeStallFull:
+		return "full"
+	}
+	return ""
+}
+
+// MapAttributeStall is a helper map of string to AttributeStall attribute value.
+var MapAttributeStall = map[string]AttributeStall{
+	"some": AttributeStallSome,
+	"full": AttributeStallFull,
+}
+
+// AttributeState specifies the a value state attribute.
+type AttributeState int
+
+const (
+	_ AttributeState = iota
+	AttributeStateUser
+	AttributeStateSystem
+)
+
+// String returns the string representation of the AttributeState.
+func (av AttributeState) String() string {
+	switch av {
+	case AttributeStateUser:
+		return "user"
+	case AttributeStateSystem:
+		return "system"
+	}
+	return ""
+}
+
+// MapAttributeState is a helper map of string to AttributeState attribute value.
+var MapAttributeState = map[string]AttributeState{
+	"user":   AttributeStateUser,
+	"system": AttributeStateSystem,
+}
+
+// AttributeWindow specifies the a value window attribute.
+type AttributeWindow int
+
+const (
+	_ AttributeWindow = iota
+	AttributeWindow10s
+	AttributeWindow60s
+	AttributeWindow300s
+)
+
+// String returns the string representation of the AttributeWindow.
+func (av AttributeWindow) String() string {
+	switch av {
+	case AttributeWindow10s:
+		return "10s"
+	case AttributeWindow60s:
+		return "60s"
+	case AttributeWindow300s:
+		return "300s"
+	}
+	return ""
+}
+
+// MapAttributeWindow is a helper map of string to AttributeWindow attribute value.
+var MapAttributeWindow = map[string]AttributeWindow{
+	"10s":  AttributeWindow10s,
+	"60s":  AttributeWindow60s,
+	"300s": AttributeWindow300s,
+}
+
+type metricCgroupCPUThrottledPeriods struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cgroup.cpu.throttled.periods metric with initial data.
+func (m *metricCgroupCPUThrottledPeriods) init() {
+	m.data.SetName("cgroup.cpu.throttled.periods")
+	m.data.SetDescription("Number of enforcement periods in which the tasks of the cgroup were throttled.")
+	m.data.SetUnit("{periods}")
+	m.data.SetDataType(pmetric.MetricDataTypeSum)
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+}
+
+func (m *metricCgroupCPUThrottledPeriods) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCgroupCPUThrottledPeriods) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCgroupCPUThrottledPeriods) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCgroupCPUThrottledPeriods(settings MetricSettings) metricCgroupCPUThrottledPeriods {
+	m := metricCgroupCPUThrottledPeriods{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCgroupCPUThrottledTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cgroup.cpu.throttled.time metric with initial data.
+func (m *metricCgroupCPUThrottledTime) init() {
+	m.data.SetName("cgroup.cpu.throttled.time")
+	m.data.SetDescription("Total time the tasks of the cgroup were throttled by the CPU bandwidth limit.")
+	m.data.SetUnit("s")
+	m.data.SetDataType(pmetric.MetricDataTypeSum)
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+}
+
+func (m *metricCgroupCPUThrottledTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleVal(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCgroupCPUThrottledTime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCgroupCPUThrottledTime) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCgroupCPUThrottledTime(settings MetricSettings) metricCgroupCPUThrottledTime {
+	m := metricCgroupCPUThrottledTime{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCgroupCPUTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cgroup.cpu.time metric with initial data.
+func (m *metricCgroupCPUTime) init() {
+	m.data.SetName("cgroup.cpu.time")
+	m.data.SetDescription("Total CPU time consumed by the tasks of the cgroup.")
+	m.data.SetUnit("s")
+	m.data.SetDataType(pmetric.MetricDataTypeSum)
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCgroupCPUTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, stateAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleVal(val)
+	dp.Attributes().UpsertString("state", stateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCgroupCPUTime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCgroupCPUTime) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCgroupCPUTime(settings MetricSettings) metricCgroupCPUTime {
+	m := metricCgroupCPUTime{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCgroupIoBytes struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cgroup.io.bytes metric with initial data.
+func (m *metricCgroupIoBytes) init() {
+	m.data.SetName("cgroup.io.bytes")
+	m.data.SetDescription("Bytes transferred by the tasks of the cgroup to and from the block device.")
+	m.data.SetUnit("By")
+	m.data.SetDataType(pmetric.MetricDataTypeSum)
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCgroupIoBytes) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, deviceAttributeValue string, directionAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+	dp.Attributes().UpsertString("device", deviceAttributeValue)
+	dp.Attributes().UpsertString("direction", directionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCgroupIoBytes) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCgroupIoBytes) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCgroupIoBytes(settings MetricSettings) metricCgroupIoBytes {
+	m := metricCgroupIoBytes{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCgroupIoOperations struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cgroup.io.operations metric with initial data.
+func (m *metricCgroupIoOperations) init() {
+	m.data.SetName("cgroup.io.operations")
+	m.data.SetDescription("Operations issued by the tasks of the cgroup to the block device.")
+	m.data.SetUnit("{operations}")
+	m.data.SetDataType(pmetric.MetricDataTypeSum)
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCgroupIoOperations) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, deviceAttributeValue string, directionAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+	dp.Attributes().UpsertString("device", deviceAttributeValue)
+	dp.Attributes().UpsertString("direction", directionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCgroupIoOperations) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCgroupIoOperations) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCgroupIoOperations(settings MetricSettings) metricCgroupIoOperations {
+	m := metricCgroupIoOperations{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCgroupMemoryLimit struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cgroup.memory.limit metric with initial data.
+func (m *metricCgroupMemoryLimit) init() {
+	m.data.SetName("cgroup.memory.limit")
+	m.data.SetDescription("Memory usage hard limit of the cgroup. Not emitted when the cgroup has no limit.")
+	m.data.SetUnit("By")
+	m.data.SetDataType(pmetric.MetricDataTypeGauge)
+}
+
+func (m *metricCgroupMemoryLimit) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCgroupMemoryLimit) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCgroupMemoryLimit) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCgroupMemoryLimit(settings MetricSettings) metricCgroupMemoryLimit {
+	m := metricCgroupMemoryLimit{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCgroupMemoryUsage struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cgroup.memory.usage metric with initial data.
+func (m *metricCgroupMemoryUsage) init() {
+	m.data.SetName("cgroup.memory.usage")
+	m.data.SetDescription("Memory used by the tasks of the cgroup and their descendants.")
+	m.data.SetUnit("By")
+	m.data.SetDataType(pmetric.MetricDataTypeSum)
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+}
+
+func (m *metricCgroupMemoryUsage) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCgroupMemoryUsage) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCgroupMemoryUsage) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCgroupMemoryUsage(settings MetricSettings) metricCgroupMemoryUsage {
+	m := metricCgroupMemoryUsage{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCgroupPressureStallRatio struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cgroup.pressure.stall.ratio metric with initial data.
+func (m *metricCgroupPressureStallRatio) init() {
+	m.data.SetName("cgroup.pressure.stall.ratio")
+	m.data.SetDescription("Ratio of time the tasks of the cgroup were stalled on the resource, averaged over the window.")
+	m.data.SetUnit("1")
+	m.data.SetDataType(pmetric.MetricDataTypeGauge)
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCgroupPressureStallRatio) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, resourceAttributeValue string, stallAttributeValue string, windowAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleVal(val)
+	dp.Attributes().UpsertString("resource", resourceAttributeValue)
+	dp.Attributes().UpsertString("stall", stallAttributeValue)
+	dp.Attributes().UpsertString("window", windowAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCgroupPressureStallRatio) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCgroupPressureStallRatio) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCgroupPressureStallRatio(settings MetricSettings) metricCgroupPressureStallRatio {
+	m := metricCgroupPressureStallRatio{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCgroupPressureStallTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cgroup.pressure.stall.time metric with initial data.
+func (m *metricCgroupPressureStallTime) init() {
+	m.data.SetName("cgroup.pressure.stall.time")
+	m.data.SetDescription("Total time the tasks of the cgroup were stalled on the resource.")
+	m.data.SetUnit("s")
+	m.data.SetDataType(pmetric.MetricDataTypeSum)
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCgroupPressureStallTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, resourceAttributeValue string, stallAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleVal(val)
+	dp.Attributes().UpsertString("resource", resourceAttributeValue)
+	dp.Attributes().UpsertString("stall", stallAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCgroupPressureStallTime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCgroupPressureStallTime) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCgroupPressureStallTime(settings MetricSettings) metricCgroupPressureStallTime {
+	m := metricCgroupPressureStallTime{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemPressureStallRatio struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.pressure.stall.ratio metric with initial data.
+func (m *metricSystemPressureStallRatio) init() {
+	m.data.SetName("system.pressure.stall.ratio")
+	m.data.SetDescription("Ratio of time the tasks of the host were stalled on the resource, averaged over the window.")
+	m.data.SetUnit("1")
+	m.data.SetDataType(pmetric.MetricDataTypeGauge)
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemPressureStallRatio) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, resourceAttributeValue string, stallAttributeValue string, windowAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleVal(val)
+	dp.Attributes().UpsertString("resource", resourceAttributeValue)
+	dp.Attributes().UpsertString("stall", stallAttributeValue)
+	dp.Attributes().UpsertString("window", windowAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemPressureStallRatio) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemPressureStallRatio) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemPressureStallRatio(settings MetricSettings) metricSystemPressureStallRatio {
+	m := metricSystemPressureStallRatio{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemPressureStallTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.pressure.stall.time metric with initial data.
+func (m *metricSystemPressureStallTime) init() {
+	m.data.SetName("system.pressure.stall.time")
+	m.data.SetDescription("Total time the tasks of the host were stalled on the resource.")
+	m.data.SetUnit("s")
+	m.data.SetDataType(pmetric.MetricDataTypeSum)
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemPressureStallTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, resourceAttributeValue string, stallAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleVal(val)
+	dp.Attributes().UpsertString("resource", resourceAttributeValue)
+	dp.Attributes().UpsertString("stall", stallAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemPressureStallTime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemPressureStallTime) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemPressureStallTime(settings MetricSettings) metricSystemPressureStallTime {
+	m := metricSystemPressureStallTime{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+// MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
+// required to produce metric representation defined in metadata and user settings.
+type MetricsBuilder struct {
+	startTime                       pcommon.Timestamp   // start time that will be applied to all recorded data points.
+	metricsCapacity                 int                 // maximum observed number of metrics per resource.
+	resourceCapacity                int                 // maximum observed number of resource attributes.
+	metricsBuffer                   pmetric.Metrics     // accumulates metrics data before emitting.
+	buildInfo                       component.BuildInfo // contains version information
+	metricCgroupCPUThrottledPeriods metricCgroupCPUThrottledPeriods
+	metricCgroupCPUThrottledTime    metricCgroupCPUThrottledTime
+	metricCgroupCPUTime             metricCgroupCPUTime
+	metricCgroupIoBytes             metricCgroupIoBytes
+	metricCgroupIoOperations        metricCgroupIoOperations
+	metricCgroupMemoryLimit         metricCgroupMemoryLimit
+	metricCgroupMemoryUsage         metricCgroupMemoryUsage
+	metricCgroupPressureStallRatio  metricCgroupPressureStallRatio
+	metricCgroupPressureStallTime   metricCgroupPressureStallTime
+	metricSystemPressureStallRatio  metricSystemPressureStallRatio
+	metricSystemPressureStallTime   metricSystemPressureStallTime
+}
+
+// metricBuilderOption applies changes to default metrics builder.
+type metricBuilderOption func(*MetricsBuilder)
+
+// WithStartTime sets startTime on the metrics builder.
+func WithStartTime(startTime pcommon.Timestamp) metricBuilderOption {
+	return func(mb *MetricsBuilder) {
+		mb.startTime = startTime
+	}
+}
+
+func NewMetricsBuilder(settings MetricsSettings, buildInfo component.BuildInfo, options ...metricBuilderOption) *MetricsBuilder {
+	mb := &MetricsBuilder{
+		startTime:                       pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                   pmetric.NewMetrics(),
+		buildInfo:                       buildInfo,
+		metricCgroupCPUThrottledPeriods: newMetricCgroupCPUThrottledPeriods(settings.CgroupCPUThrottledPeriods),
+		metricCgroupCPUThrottledTime:    newMetricCgroupCPUThrottledTime(settings.CgroupCPUThrottledTime),
+		metricCgroupCPUTime:             newMetricCgroupCPUTime(settings.CgroupCPUTime),
+		metricCgroupIoBytes:             newMetricCgroupIoBytes(settings.CgroupIoBytes),
+		metricCgroupIoOperations:        newMetricCgroupIoOperations(settings.CgroupIoOperations),
+		metricCgroupMemoryLimit:         newMetricCgroupMemoryLimit(settings.CgroupMemoryLimit),
+		metricCgroupMemoryUsage:         newMetricCgroupMemoryUsage(settings.CgroupMemoryUsage),
+		metricCgroupPressureStallRatio:  newMetricCgroupPressureStallRatio(settings.CgroupPressureStallRatio),
+		metricCgroupPressureStallTime:   newMetricCgroupPressureStallTime(settings.CgroupPressureStallTime),
+		metricSystemPressureStallRatio:  newMetricSystemPressureStallRatio(settings.SystemPressureStallRatio),
+		metricSystemPressureStallTime:   newMetricSystemPressureStallTime(settings.SystemPressureStallTime),
+	}
+	for _, op := range options {
+		op(mb)
+	}
+	return mb
+}
+
+// updateCapacity updates max length of metrics and resource attributes that will be used for the slice capacity.
+func (mb *MetricsBuilder) updateCapacity(rm pmetric.ResourceMetrics) {
+	if mb.metricsCapacity < rm.ScopeMetrics().At(0).Metrics().Len() {
+		mb.metricsCapacity = rm.ScopeMetrics().At(0).Metrics().Len()
+	}
+	if mb.resourceCapacity < rm.Resource().Attributes().Len() {
+		mb.resourceCapacity = rm.Resource().Attributes().Len()
+	}
+}
+
+// ResourceMetricsOption applies changes to provided resource metrics.
+type ResourceMetricsOption func(pmetric.ResourceMetrics)
+
+// WithCgroupPath sets provided value as "cgroup.path" attribute for current resource.
+func WithCgroupPath(val string) ResourceMetricsOption {
+	return func(rm pmetric.ResourceMetrics) {
+		rm.Resource().Attributes().UpsertString("cgroup.path", val)
+	}
+}
+
+// WithContainerID sets provided value as "container.id" attribute for current resource.
+func WithContainerID(val string) ResourceMetricsOption {
+	return func(rm pmetric.ResourceMetrics) {
+		rm.Resource().Attributes().UpsertString("container.id", val)
+	}
+}
+
+// WithSystemdUnit sets provided value as "systemd.unit" attribute for current resource.
+func WithSystemdUnit(val string) ResourceMetricsOption {
+	return func(rm pmetric.ResourceMetrics) {
+		rm.Resource().Attributes().UpsertString("systemd.unit", val)
+	}
+}
+
+// WithStartTimeOverride overrides start time for all the resource metrics data points.
+// This option should be only used if different start time has to be set on metrics coming from different resources.
+func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
+	return func(rm pmetric.ResourceMetrics) {
+		var dps pmetric.NumberDataPointSlice
+		metrics := rm.ScopeMetrics().At(0).Metrics()
+		for i := 0; i < metrics.Len(); i++ {
+			switch metrics.At(i).DataType() {
+			case pmetric.MetricDataTypeGauge:
+				dps = metrics.At(i).Gauge().DataPoints()
+			case pmetric.MetricDataTypeSum:
+				dps = metrics.At(i).Sum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dps.At(j).SetStartTimestamp(start)
+			}
+		}
+	}
+}
+
+// EmitForResource saves all the generated metrics under a new resource and updates the internal state to be ready for
+// recording another set of data points as part of another resource. This function can be helpful when one scraper
+// needs to emit metrics from several resources. Otherwise calling this function is not required,
+// just `Emit` function can be called instead.
+// Resource attributes should be provided as ResourceMetricsOption arguments.
+func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
+	rm := pmetric.NewResourceMetrics()
+	rm.SetSchemaUrl(conventions.SchemaURL)
+	rm.Resource().Attributes().EnsureCapacity(mb.resourceCapacity)
+	ils := rm.ScopeMetrics().AppendEmpty()
+	ils.Scope().SetName("otelcol/hostmetricsreceiver/cgroup")
+	ils.Scope().SetVersion(mb.buildInfo.Version)
+	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricCgroupCPUThrottledPeriods.emit(ils.Metrics())
+	mb.metricCgroupCPUThrottledTime.emit(ils.Metrics())
+	mb.metricCgroupCPUTime.emit(ils.Metrics())
+	mb.metricCgroupIoBytes.emit(ils.Metrics())
+	mb.metricCgroupIoOperations.emit(ils.Metrics())
+	mb.metricCgroupMemoryLimit.emit(ils.Metrics())
+	mb.metricCgroupMemoryUsage.emit(ils.Metrics())
+	mb.metricCgroupPressureStallRatio.emit(ils.Metrics())
+	mb.metricCgroupPressureStallTime.emit(ils.Metrics())
+	mb.metricSystemPressureStallRatio.emit(ils.Metrics())
+	mb.metricSystemPressureStallTime.emit(ils.Metrics())
+	for _, op := range rmo {
+		op(rm)
+	}
+	if ils.Metrics().Len() > 0 {
+		mb.updateCapacity(rm)
+		rm.MoveTo(mb.metricsBuffer.ResourceMetrics().AppendEmpty())
+	}
+}
+
+// Emit returns all the metrics accumulated by the metrics builder and updates the internal state to be ready for
+// recording another set of metrics. This function will be responsible for applying all the transformations required to
+// produce metric representation defined in metadata and user settings, e.g. delta or cumulative.
+func (mb *MetricsBuilder) Emit(rmo ...ResourceMetricsOption) pmetric.Metrics {
+	mb.EmitForResource(rmo...)
+	metrics := pmetric.NewMetrics()
+	mb.metricsBuffer.MoveTo(metrics)
+	return metrics
+}
+
+// RecordCgroupCPUThrottledPeriodsDataPoint adds a data point to cgroup.cpu.throttled.periods metric.
+func (mb *MetricsBuilder) RecordCgroupCPUThrottledPeriodsDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricCgroupCPUThrottledPeriods.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordCgroupCPUThrottledTimeDataPoint adds a data point to cgroup.cpu.throttled.time metric.
+func (mb *MetricsBuilder) RecordCgroupCPUThrottledTimeDataPoint(ts pcommon.Timestamp, val float64) {
+	mb.metricCgroupCPUThrottledTime.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordCgroupCPUTimeDataPoint adds a data point to cgroup.cpu.time metric.
+func (mb *MetricsBuilder) RecordCgroupCPUTimeDataPoint(ts pcommon.Timestamp, val float64, stateAttributeValue AttributeState) {
+	mb.metricCgroupCPUTime.recordDataPoint(mb.startTime, ts, val, stateAttributeValue.String())
+}
+
+// RecordCgroupIoBytesDataPoint adds a data point to cgroup.io.bytes metric.
+func (mb *MetricsBuilder) RecordCgroupIoBytesDataPoint(ts pcommon.Timestamp, val int64, deviceAttributeValue string, directionAttributeValue AttributeDirection) {
+	mb.metricCgroupIoBytes.recordDataPoint(mb.startTime, ts, val, deviceAttributeValue, directionAttributeValue.String())
+}
+
+// RecordCgroupIoOperationsDataPoint adds a data point to cgroup.io.operations metric.
+func (mb *MetricsBuilder) RecordCgroupIoOperationsDataPoint(ts pcommon.Timestamp, val int64, deviceAttributeValue string, directionAttributeValue AttributeDirection) {
+	mb.metricCgroupIoOperations.recordDataPoint(mb.startTime, ts, val, deviceAttributeValue, directionAttributeValue.String())
+}
+
+// RecordCgroupMemoryLimitDataPoint adds a data point to cgroup.memory.limit metric.
+func (mb *MetricsBuilder) RecordCgroupMemoryLimitDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricCgroupMemoryLimit.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordCgroupMemoryUsageDataPoint adds a data point to cgroup.memory.usage metric.
+func (mb *MetricsBuilder) RecordCgroupMemoryUsageDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricCgroupMemoryUsage.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordCgroupPressureStallRatioDataPoint adds a data point to cgroup.pressure.stall.ratio metric.
+func (mb *MetricsBuilder) RecordCgroupPressureStallRatioDataPoint(ts pcommon.Timestamp, val float64, resourceAttributeValue AttributeResource, stallAttributeValue AttributeStall, windowAttributeValue AttributeWindow) {
+	mb.metricCgroupPressureStallRatio.recordDataPoint(mb.startTime, ts, val, resourceAttributeValue.String(), stallAttributeValue.String(), windowAttributeValue.String())
+}
+
+// RecordCgroupPressureStallTimeDataPoint adds a data point to cgroup.pressure.stall.time metric.
+func (mb *MetricsBuilder) RecordCgroupPressureStallTimeDataPoint(ts pcommon.Timestamp, val float64, resourceAttributeValue AttributeResource, stallAttributeValue AttributeStall) {
+	mb.metricCgroupPressureStallTime.recordDataPoint(mb.startTime, ts, val, resourceAttributeValue.String(), stallAttributeValue.String())
+}
+
+// RecordSystemPressureStallRatioDataPoint adds a data point to system.pressure.stall.ratio metric.
+func (mb *MetricsBuilder) RecordSystemPressureStallRatioDataPoint(ts pcommon.Timestamp, val float64, resourceAttributeValue AttributeResource, stallAttributeValue AttributeStall, windowAttributeValue AttributeWindow) {
+	mb.metricSystemPressureStallRatio.recordDataPoint(mb.startTime, ts, val, resourceAttributeValue.String(), stallAttributeValue.String(), windowAttributeValue.String())
+}
+
+// RecordSystemPressureStallTimeDataPoint adds a data point to system.pressure.stall.time metric.
+func (mb *MetricsBuilder) RecordSystemPressureStallTimeDataPoint(ts pcommon.Timestamp, val float64, resourceAttributeValue AttributeResource, stallAttributeValue AttributeStall) {
+	mb.metricSystemPressureStallTime.recordDataPoint(mb.startTime, ts, val, resourceAttributeValue.String(), stallAttributeValue.String())
+}
+
+// Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
+// and metrics builder should update its startTime and reset it's internal state accordingly.
+func (mb *MetricsBuilder) Reset(options ...metricBuilderOption) {
+	mb.startTime = pcommon.NewTimestampFromTime(time.Now())
+	for _, op := range options {
+		op(mb)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+// pressureStat is a line of a pressure stall information file, e.g.
+// "some avg10=0.12 avg60=0.05 avg300=0.01 total=123456".
+type pressureStat struct {
+	stall metadata.AttributeStall
+	// averages are the ratios of stalled time over the last 10, 60 and 300 seconds,
+	// by field name.
+	averages map[string]float64
+	// total is the total stalled time in seconds.
+	total float64
+}
+
+// pressureWindows are the windows of the averages, by field name.
+var pressureWindows = []struct {
+	name   string
+	window metadata.AttributeWindow
+}{
+	{"avg10", metadata.AttributeWindow10s},
+	{"avg60", metadata.AttributeWindow60s},
+	{"avg300", metadata.AttributeWindow300s},
+}
+
+// readPressure parses a pressure stall information file, as found under
+// /proc/pressure or in a cgroup directory.
+func readPressure(path string) ([]pressureStat, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var stats []pressureStat
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		stall, ok := metadata.MapAttributeStall[fields[0]]
+		if !ok {
+			return nil, fmt.Errorf("unexpected pressure line %q in %s", scanner.Text(), path)
+		}
+		stat := pressureStat{stall: stall, averages: map[string]float64{}}
+		for _, field := range fields[1:] {
+			key, value, found := strings.Cut(field, "=")
+			if !found {
+				return nil, fmt.Errorf("unexpected pressure field %q in %s", field, path)
+			}
+			if key == "total" {
+				total, err := strconv.ParseUint(value, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse pressure total in %s: %w", path, err)
+				}
+				stat.total = float64(total) / 1e6
+				continue
+			}
+			avg, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse pressure %s in %s: %w", key, path, err)
+			}
+			stat.averages[key] = avg / 100
+		}
+		stats = append(stats, stat)
+	}
+	return stats, scanner.Err()
+}
@@ -39,6 +39,23 @@ type Config struct {
 	// ScrapeProcessDelay is used to indicate the minimum amount of time a process must be running
 	// before metrics are scraped for it.  The default value is 0 seconds (0s)
 	ScrapeProcessDelay time.Duration `mapstructure:"scrape_process_delay"`
+
+	// Grouping aggregates the metrics of the processes into groups instead of
+	// reporting one resource per process. Disabled by default.
+	Grouping GroupingConfig `mapstructure:"grouping"`
+}
+
+// GroupingConfig configures how processes are aggregated into groups.
+type GroupingConfig struct {
+	// By selects the grouping key, either "executable_name" or "command_line".
+	// If empty, processes are not grouped.
+	By string `mapstructure:"by"`
+
+	// CommandLinePattern is the regular expression matched against the command line
+	// of the processes when grouping by "command_line". The group is named after the
+	// first capturing group of the pattern, or the whole match if it has none.
+	// Processes that don't match are reported individually.
+	CommandLinePattern string `mapstructure:"command_line_pattern"`
 }
 
 type MatchConfig struct {
@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/net"
 	"github.com/shirou/gopsutil/v3/process"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper/internal/metadata"
@@ -33,6 +34,7 @@ type processMetadata struct {
 	executable *executableMetadata
 	command    *commandMetadata
 	username   string
+	createTime int64
 	handle     processHandle
 }
 
@@ -47,6 +49,14 @@ type commandMetadata struct {
 	commandLineSlice []string
 }
 
+// commandLineString returns the full command line of the process as a single string.
+func (c *commandMetadata) commandLineString() string {
+	if c.commandLineSlice != nil {
+		return strings.Join(c.commandLineSlice, " ")
+	}
+	return c.commandLine
+}
+
 func (m *processMetadata) resourceOptions() []metadata.ResourceMetricsOption {
 	opts := make([]metadata.ResourceMetricsOption, 0, 6)
 	opts = append(opts,
@@ -92,6 +102,10 @@ type processHandle interface {
 	NumThreads() (int32, error)
 	CreateTime() (int64, error)
 	Parent() (*process.Process, error)
+	NumFDs() (int32, error)
+	NumCtxSwitches() (*process.NumCtxSwitchesStat, error)
+	PageFaults() (*process.PageFaultsStat, error)
+	Connections() ([]net.ConnectionStat, error)
 }
 
 type gopsProcessHandles struct {
@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/process"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper/internal/metadata"
+)
+
+const (
+	groupByExecutableName = "executable_name"
+	groupByCommandLine    = "command_line"
+)
+
+// processStats holds the values read for a process, or the sum of the values
+// of the processes of a group. A nil field means the value was not read.
+type processStats struct {
+	cpuTimes        *cpu.TimesStat
+	memory          *process.MemoryInfoStat
+	io              *process.IOCountersStat
+	threads         *int64
+	openFDs         *int64
+	contextSwitches *process.NumCtxSwitchesStat
+	pageFaults      *process.PageFaultsStat
+	connections     map[string]int64
+}
+
+// add sums the values of other into s.
+func (s *processStats) add(other *processStats) {
+	if other.cpuTimes != nil {
+		if s.cpuTimes == nil {
+			s.cpuTimes = &cpu.TimesStat{}
+		}
+		s.cpuTimes.User += other.cpuTimes.User
+		s.cpuTimes.System += other.cpuTimes.System
+		s.cpuTimes.Iowait += other.cpuTimes.Iowait
+	}
+	if other.memory != nil {
+		if s.memory == nil {
+			s.memory = &process.MemoryInfoStat{}
+		}
+		s.memory.RSS += other.memory.RSS
+		s.memory.VMS += other.memory.VMS
+	}
+	if other.io != nil {
+		if s.io == nil {
+			s.io = &process.IOCountersStat{}
+		}
+		s.io.ReadBytes += other.io.ReadBytes
+		s.io.WriteBytes += other.io.WriteBytes
+	}
+	s.threads = addInt(s.threads, other.threads)
+	s.openFDs = addInt(s.openFDs, other.openFDs)
+	if other.contextSwitches != nil {
+		if s.contextSwitches == nil {
+			s.contextSwitches = &process.NumCtxSwitchesStat{}
+		}
+		s.contextSwitches.Voluntary += other.contextSwitches.Voluntary
+		s.contextSwitches.Involuntary += other.contextSwitches.Involuntary
+	}
+	if other.pageFaults != nil {
+		if s.pageFaults == nil {
+			s.pageFaults = &process.PageFaultsStat{}
+		}
+		s.pageFaults.MajorFaults += other.pageFaults.MajorFaults
+		s.pageFaults.MinorFaults += other.pageFaults.MinorFaults
+	}
+	if other.connections != nil {
+		if s.connections == nil {
+			s.connections = make(map[string]int64, len(other.connections))
+		}
+		for state, count := range other.connections {
+			s.connections[state] += count
+		}
+	}
+}
+
+func addInt(sum, value *int64) *int64 {
+	if value == nil {
+		return sum
+	}
+	if sum == nil {
+		sum = new(int64)
+	}
+	*sum += *value
+	return sum
+}
+
+// cumulative returns a copy of the cumulative values of s, which only increase
+// during the lifetime of the process.
+func (s *processStats) cumulative() *processStats {
+	c := &processStats{}
+	if s.cpuTimes != nil {
+		c.cpuTimes = &cpu.TimesStat{User: s.cpuTimes.User, System: s.cpuTimes.System, Iowait: s.cpuTimes.Iowait}
+	}
+	if s.io != nil {
+		c.io = &process.IOCountersStat{ReadBytes: s.io.ReadBytes, WriteBytes: s.io.WriteBytes}
+	}
+	if s.contextSwitches != nil {
+		c.contextSwitches = &process.NumCtxSwitchesStat{Voluntary: s.contextSwitches.Voluntary, Involuntary: s.contextSwitches.Involuntary}
+	}
+	if s.pageFaults != nil {
+		c.pageFaults = &process.PageFaultsStat{MajorFaults: s.pageFaults.MajorFaults, MinorFaults: s.pageFaults.MinorFaults}
+	}
+	return c
+}
+
+// fillCumulative sets the cumulative values of s which could not be read to the last ones read.
+func (s *processStats) fillCumulative(last *processStats) {
+	if s.cpuTimes == nil {
+		s.cpuTimes = last.cpuTimes
+	}
+	if s.io == nil {
+		s.io = last.io
+	}
+	if s.contextSwitches == nil {
+		s.contextSwitches = last.contextSwitches
+	}
+	if s.pageFaults == nil {
+		s.pageFaults = last.pageFaults
+	}
+}
+
+// processKey identifies a process across scrapes, even when its pid is reused.
+type processKey struct {
+	pid        int32
+	createTime int64
+}
+
+// processGroup holds the processes sharing the same group name in a scrape.
+type processGroup struct {
+	name    string
+	by      string
+	members map[processKey]*processStats
+}
+
+func (g *processGroup) resourceOptions() []metadata.ResourceMetricsOption {
+	opts := []metadata.ResourceMetricsOption{metadata.WithProcessGroup(g.name)}
+	if g.by == groupByExecutableName {
+		opts = append(opts, metadata.WithProcessExecutableName(g.name))
+	}
+	return opts
+}
+
+// maxMissedScrapes is the number of consecutive scrapes without processes after
+// which the state of a group is dropped.
+const maxMissedScrapes = 5
+
+// groupState is kept across scrapes to keep the cumulative metrics of a group
+// monotonic when its processes exit.
+type groupState struct {
+	// missedScrapes is the number of consecutive scrapes in which the group had no processes.
+	missedScrapes int
+	// members are the cumulative values last read for the processes of the group.
+	members map[processKey]*processStats
+	// exited is the sum of the last cumulative values of the processes which exited.
+	exited processStats
+}
+
+// update replaces the processes of the group by members, and returns the values
+// of the group: the sum of the values of members, plus the cumulative values of
+// the processes which exited since the group was first scraped.
+func (g *groupState) update(members map[processKey]*processStats) *processStats {
+	g.missedScrapes = 0
+	for key, last := range g.members {
+		if _, ok := members[key]; !ok {
+			g.exited.add(last)
+		}
+	}
+
+	stats := &processStats{}
+	lastMembers := make(map[processKey]*processStats, len(members))
+	for key, member := range members {
+		if last, ok := g.members[key]; ok {
+			member.fillCumulative(last)
+		}
+		lastMembers[key] = member.cumulative()
+		stats.add(member)
+	}
+	g.members = lastMembers
+	stats.add(&g.exited)
+	return stats
+}
+
+// grouper assigns processes to groups according to the grouping configuration.
+type grouper struct {
+	by      string
+	pattern *regexp.Regexp
+}
+
+func newGrouper(cfg GroupingConfig) (*grouper, error) {
+	switch cfg.By {
+	case "":
+		return nil, nil
+	case groupByExecutableName:
+		return &grouper{by: cfg.By}, nil
+	case groupByCommandLine:
+		if cfg.CommandLinePattern == "" {
+			return nil, fmt.Errorf("grouping by %q requires a command_line_pattern", groupByCommandLine)
+		}
+		pattern, err := regexp.Compile(cfg.CommandLinePattern)
+		if err != nil {
+			return nil, fmt.Errorf("error compiling grouping command_line_pattern: %w", err)
+		}
+		return &grouper{by: cfg.By, pattern: pattern}, nil
+	default:
+		return nil, fmt.Errorf("unknown grouping %q, must be one of %q or %q", cfg.By, groupByExecutableName, groupByCommandLine)
+	}
+}
+
+// groupName returns the name of the group of the process, or false if the
+// process is not part of any group.
+func (g *grouper) groupName(md *processMetadata) (string, bool) {
+	if g.by == groupByExecutableName {
+		return md.executable.name, true
+	}
+
+	if md.command == nil {
+		return "", false
+	}
+	match := g.pattern.FindStringSubmatch(md.command.commandLineString())
+	switch {
+	case match == nil:
+		return "", false
+	case len(match) > 1:
+		return match[1], true
+	default:
+		return match[0], true
+	}
+}
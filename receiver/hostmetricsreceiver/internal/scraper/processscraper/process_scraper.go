@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/shirou/gopsutil/v3/host"
@@ -39,6 +40,13 @@ const (
 	threadMetricsLen = 1
 
 	metricsLen = cpuMetricsLen + memoryMetricsLen + diskMetricsLen + threadMetricsLen
+
+	fileDescriptorMetricsLen = 1
+	contextSwitchMetricsLen  = 1
+	pagingFaultMetricsLen    = 1
+	connectionMetricsLen     = 1
+
+	linuxMetricsLen = fileDescriptorMetricsLen + contextSwitchMetricsLen + pagingFaultMetricsLen + connectionMetricsLen
 )
 
 // scraper for Process Metrics
@@ -48,6 +56,8 @@ type scraper struct {
 	mb                 *metadata.MetricsBuilder
 	includeFS          filterset.FilterSet
 	excludeFS          filterset.FilterSet
+	grouper            *grouper
+	groupStates        map[string]*groupState
 	scrapeProcessDelay time.Duration
 	// for mocking
 	bootTime                             func() (uint64, error)
@@ -84,6 +94,14 @@ func newProcessScraper(settings component.ReceiverCreateSettings, cfg *Config) (
 		}
 	}
 
+	scraper.grouper, err = newGrouper(cfg.Grouping)
+	if err != nil {
+		return nil, err
+	}
+	if scraper.grouper != nil {
+		scraper.groupStates = map[string]*groupState{}
+	}
+
 	return scraper, nil
 }
 
@@ -110,29 +128,142 @@ func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
 		errs.AddPartial(partialErr.Failed, partialErr)
 	}
 
+	var groups []*processGroup
+	groupsByName := map[string]*processGroup{}
+
 	for _, md := range data {
 		now := pcommon.NewTimestampFromTime(time.Now())
-
-		if err = s.scrapeAndAppendCPUTimeMetric(now, md.handle); err != nil {
-			errs.AddPartial(cpuMetricsLen, fmt.Errorf("error reading cpu times for process %q (pid %v): %w", md.executable.name, md.pid, err))
+		stats := s.scrapeProcessStats(md, &errs)
+
+		if s.grouper != nil {
+			if name, ok := s.grouper.groupName(md); ok {
+				group, ok := groupsByName[name]
+				if !ok {
+					group = &processGroup{name: name, by: s.grouper.by, members: map[processKey]*processStats{}}
+					groupsByName[name] = group
+					groups = append(groups, group)
+				}
+				group.members[processKey{pid: md.pid, createTime: md.createTime}] = stats
+				continue
+			}
 		}
 
-		if err = s.scrapeAndAppendMemoryUsageMetrics(now, md.handle); err != nil {
-			errs.AddPartial(memoryMetricsLen, fmt.Errorf("error reading memory info for process %q (pid %v): %w", md.executable.name, md.pid, err))
-		}
+		s.recordProcessStats(now, stats)
+		s.mb.EmitForResource(md.resourceOptions()...)
+	}
 
-		if err = s.scrapeAndAppendDiskIOMetric(now, md.handle); err != nil {
-			errs.AddPartial(diskMetricsLen, fmt.Errorf("error reading disk usage for process %q (pid %v): %w", md.executable.name, md.pid, err))
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for _, group := range groups {
+		state, ok := s.groupStates[group.name]
+		if !ok {
+			state = &groupState{}
+			s.groupStates[group.name] = state
+		}
+		stats := state.update(group.members)
+		s.recordProcessStats(now, stats)
+		s.mb.RecordProcessCountDataPoint(now, int64(len(group.members)))
+		s.mb.EmitForResource(group.resourceOptions()...)
+	}
+	for name, state := range s.groupStates {
+		if _, ok := groupsByName[name]; ok {
+			continue
+		}
+		state.missedScrapes++
+		if state.missedScrapes >= maxMissedScrapes {
+			delete(s.groupStates, name)
 		}
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+// scrapeProcessStats reads the values of the enabled metrics of a process. The
+// values that could not be read are left unset and reported to errs.
+func (s *scraper) scrapeProcessStats(md *processMetadata, errs *scrapererror.ScrapeErrors) *processStats {
+	stats := &processStats{}
+	var err error
+
+	if stats.cpuTimes, err = md.handle.Times(); err != nil {
+		stats.cpuTimes = nil
+		errs.AddPartial(cpuMetricsLen, fmt.Errorf("error reading cpu times for process %q (pid %v): %w", md.executable.name, md.pid, err))
+	}
 
-		if err = s.scrapeAndAppendThreadsMetrics(now, md.handle); err != nil {
+	if stats.memory, err = md.handle.MemoryInfo(); err != nil {
+		stats.memory = nil
+		errs.AddPartial(memoryMetricsLen, fmt.Errorf("error reading memory info for process %q (pid %v): %w", md.executable.name, md.pid, err))
+	}
+
+	if stats.io, err = md.handle.IOCounters(); err != nil {
+		stats.io = nil
+		errs.AddPartial(diskMetricsLen, fmt.Errorf("error reading disk usage for process %q (pid %v): %w", md.executable.name, md.pid, err))
+	}
+
+	if s.config.Metrics.ProcessThreads.Enabled {
+		threads, err := md.handle.NumThreads()
+		if err != nil {
 			errs.AddPartial(threadMetricsLen, fmt.Errorf("error reading thread info for process %q (pid %v): %w", md.executable.name, md.pid, err))
+		} else {
+			stats.threads = int64Ptr(int64(threads))
 		}
+	}
 
-		s.mb.EmitForResource(md.resourceOptions()...)
+	s.scrapeLinuxProcessStats(md, stats, errs)
+	return stats
+}
+
+func (s *scraper) recordProcessStats(now pcommon.Timestamp, stats *processStats) {
+	if stats.cpuTimes != nil {
+		s.recordCPUTimeMetric(now, stats.cpuTimes)
 	}
 
-	return s.mb.Emit(), errs.Combine()
+	if stats.memory != nil {
+		s.mb.RecordProcessMemoryPhysicalUsageDataPoint(now, int64(stats.memory.RSS))
+		s.mb.RecordProcessMemoryVirtualUsageDataPoint(now, int64(stats.memory.VMS))
+	}
+
+	if stats.io != nil {
+		if s.emitMetricsWithoutDirectionAttribute {
+			s.mb.RecordProcessDiskIoReadDataPoint(now, int64(stats.io.ReadBytes))
+			s.mb.RecordProcessDiskIoWriteDataPoint(now, int64(stats.io.WriteBytes))
+		}
+		if s.emitMetricsWithDirectionAttribute {
+			s.mb.RecordProcessDiskIoDataPoint(now, int64(stats.io.ReadBytes), metadata.AttributeDirectionRead)
+			s.mb.RecordProcessDiskIoDataPoint(now, int64(stats.io.WriteBytes), metadata.AttributeDirectionWrite)
+		}
+	}
+
+	if stats.threads != nil {
+		s.mb.RecordProcessThreadsDataPoint(now, *stats.threads)
+	}
+
+	if stats.openFDs != nil {
+		s.mb.RecordProcessOpenFileDescriptorsDataPoint(now, *stats.openFDs)
+	}
+
+	if stats.contextSwitches != nil {
+		s.mb.RecordProcessContextSwitchesDataPoint(now, stats.contextSwitches.Involuntary, metadata.AttributeContextSwitchTypeInvoluntary)
+		s.mb.RecordProcessContextSwitchesDataPoint(now, stats.contextSwitches.Voluntary, metadata.AttributeContextSwitchTypeVoluntary)
+	}
+
+	if stats.pageFaults != nil {
+		s.mb.RecordProcessPagingFaultsDataPoint(now, int64(stats.pageFaults.MajorFaults), metadata.AttributePagingFaultTypeMajor)
+		s.mb.RecordProcessPagingFaultsDataPoint(now, int64(stats.pageFaults.MinorFaults), metadata.AttributePagingFaultTypeMinor)
+	}
+
+	if stats.connections != nil {
+		states := make([]string, 0, len(stats.connections))
+		for state := range stats.connections {
+			states = append(states, state)
+		}
+		sort.Strings(states)
+		for _, state := range states {
+			s.mb.RecordProcessNetworkConnectionsDataPoint(now, stats.connections[state], state)
+		}
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
 }
 
 // getProcessMetadata returns a slice of processMetadata, including handles,
@@ -185,6 +316,10 @@ func (s *scraper) getProcessMetadata() ([]*processMetadata, error) {
 		if s.scrapeProcessDelay.Milliseconds() > (time.Now().UnixMilli() - createTime) {
 			continue
 		}
+		if err != nil {
+			// without a create time, the process is identified by its pid only
+			createTime = 0
+		}
 
 		parentPid, err := parentPid(handle, pid)
 		if err != nil {
@@ -197,6 +332,7 @@ func (s *scraper) getProcessMetadata() ([]*processMetadata, error) {
 			executable: executable,
 			command:    command,
 			username:   username,
+			createTime: createTime,
 			handle:     handle,
 		}
 
@@ -205,55 +341,3 @@ func (s *scraper) getProcessMetadata() ([]*processMetadata, error) {
 
 	return data, errs.Combine()
 }
-
-func (s *scraper) scrapeAndAppendCPUTimeMetric(now pcommon.Timestamp, handle processHandle) error {
-	times, err := handle.Times()
-	if err != nil {
-		return err
-	}
-
-	s.recordCPUTimeMetric(now, times)
-	return nil
-}
-
-func (s *scraper) scrapeAndAppendMemoryUsageMetrics(now pcommon.Timestamp, handle processHandle) error {
-	mem, err := handle.MemoryInfo()
-	if err != nil {
-		return err
-	}
-
-	s.mb.RecordProcessMemoryPhysicalUsageDataPoint(now, int64(mem.RSS))
-	s.mb.RecordProcessMemoryVirtualUsageDataPoint(now, int64(mem.VMS))
-	return nil
-}
-
-func (s *scraper) scrapeAndAppendDiskIOMetric(now pcommon.Timestamp, handle processHandle) error {
-	io, err := handle.IOCounters()
-	if err != nil {
-		return err
-	}
-
-	if s.emitMetricsWithoutDirectionAttribute {
-		s.mb.RecordProcessDiskIoReadDataPoint(now, int64(io.ReadBytes))
-		s.mb.RecordProcessDiskIoWriteDataPoint(now, int64(io.WriteBytes))
-	}
-	if s.emitMetricsWithDirectionAttribute {
-		s.mb.RecordProcessDiskIoDataPoint(now, int64(io.ReadBytes), metadata.AttributeDirectionRead)
-		s.mb.RecordProcessDiskIoDataPoint(now, int64(io.WriteBytes), metadata.AttributeDirectionWrite)
-	}
-
-	return nil
-}
-
-func (s *scraper) scrapeAndAppendThreadsMetrics(now pcommon.Timestamp, handle processHandle) error {
-	if !s.config.Metrics.ProcessThreads.Enabled {
-		return nil
-	}
-	threads, err := handle.NumThreads()
-	if err != nil {
-		return err
-	}
-	s.mb.RecordProcessThreadsDataPoint(now, int64(threads))
-
-	return nil
-}
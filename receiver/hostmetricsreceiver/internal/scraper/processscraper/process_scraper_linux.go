@@ -18,8 +18,13 @@
 package processscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
 
 import (
+	"fmt"
+	"syscall"
+
 	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/net"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper/internal/metadata"
 )
@@ -59,3 +64,72 @@ func getProcessCommand(proc processHandle) (*commandMetadata, error) {
 	command := &commandMetadata{command: cmd, commandLineSlice: cmdline}
 	return command, nil
 }
+
+var allTCPStates = []string{
+	"CLOSE_WAIT",
+	"CLOSE",
+	"CLOSING",
+	"DELETE",
+	"ESTABLISHED",
+	"FIN_WAIT_1",
+	"FIN_WAIT_2",
+	"LAST_ACK",
+	"LISTEN",
+	"SYN_SENT",
+	"SYN_RECV",
+	"TIME_WAIT",
+}
+
+// scrapeLinuxProcessStats reads the values of the enabled metrics only available on Linux.
+func (s *scraper) scrapeLinuxProcessStats(md *processMetadata, stats *processStats, errs *scrapererror.ScrapeErrors) {
+	if s.config.Metrics.ProcessOpenFileDescriptors.Enabled {
+		fds, err := md.handle.NumFDs()
+		if err != nil {
+			errs.AddPartial(fileDescriptorMetricsLen, fmt.Errorf("error reading open file descriptor count for process %q (pid %v): %w", md.executable.name, md.pid, err))
+		} else {
+			stats.openFDs = int64Ptr(int64(fds))
+		}
+	}
+
+	if s.config.Metrics.ProcessContextSwitches.Enabled {
+		contextSwitches, err := md.handle.NumCtxSwitches()
+		if err != nil {
+			errs.AddPartial(contextSwitchMetricsLen, fmt.Errorf("error reading context switches for process %q (pid %v): %w", md.executable.name, md.pid, err))
+		} else {
+			stats.contextSwitches = contextSwitches
+		}
+	}
+
+	if s.config.Metrics.ProcessPagingFaults.Enabled {
+		pageFaults, err := md.handle.PageFaults()
+		if err != nil {
+			errs.AddPartial(pagingFaultMetricsLen, fmt.Errorf("error reading page faults for process %q (pid %v): %w", md.executable.name, md.pid, err))
+		} else {
+			stats.pageFaults = pageFaults
+		}
+	}
+
+	if s.config.Metrics.ProcessNetworkConnections.Enabled {
+		connections, err := md.handle.Connections()
+		if err != nil {
+			errs.AddPartial(connectionMetricsLen, fmt.Errorf("error reading network connections for process %q (pid %v): %w", md.executable.name, md.pid, err))
+		} else {
+			stats.connections = getTCPConnectionStateCounts(connections)
+		}
+	}
+}
+
+func getTCPConnectionStateCounts(connections []net.ConnectionStat) map[string]int64 {
+	counts := make(map[string]int64, len(allTCPStates))
+	for _, state := range allTCPStates {
+		counts[state] = 0
+	}
+
+	for _, connection := range connections {
+		if connection.Type != syscall.SOCK_STREAM || (connection.Family != syscall.AF_INET && connection.Family != syscall.AF_INET6) {
+			continue
+		}
+		counts[connection.Status]++
+	}
+	return counts
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package processscraper
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/net"
+	"github.com/shirou/gopsutil/v3/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper/internal/metadata"
+)
+
+func newLinuxMetricsSettings() metadata.MetricsSettings {
+	metricsSettings := metadata.DefaultMetricsSettings()
+	metricsSettings.ProcessOpenFileDescriptors.Enabled = true
+	metricsSettings.ProcessContextSwitches.Enabled = true
+	metricsSettings.ProcessPagingFaults.Enabled = true
+	metricsSettings.ProcessNetworkConnections.Enabled = true
+	return metricsSettings
+}
+
+func TestScrapeLinuxMetrics(t *testing.T) {
+	scraper, err := newProcessScraper(componenttest.NewNopReceiverCreateSettings(), &Config{Metrics: newLinuxMetricsSettings()})
+	require.NoError(t, err)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	handleMock := &processHandleMock{}
+	handleMock.On("Name").Return("test", nil)
+	handleMock.On("Exe").Return("test", nil)
+	handleMock.On("Username").Return("username", nil)
+	handleMock.On("CmdlineSlice").Return([]string{"test"}, nil)
+	handleMock.On("CreateTime").Return(int64(0), nil)
+	handleMock.On("Parent").Return(&process.Process{Pid: 2}, nil)
+	handleMock.On("Times").Return(&cpu.TimesStat{}, nil)
+	handleMock.On("MemoryInfo").Return(&process.MemoryInfoStat{}, nil)
+	handleMock.On("IOCounters").Return(&process.IOCountersStat{}, nil)
+	handleMock.On("NumFDs").Return(int32(42), nil)
+	handleMock.On("NumCtxSwitches").Return(&process.NumCtxSwitchesStat{Voluntary: 10, Involuntary: 3}, nil)
+	handleMock.On("PageFaults").Return(&process.PageFaultsStat{MajorFaults: 5, MinorFaults: 500}, nil)
+	handleMock.On("Connections").Return([]net.ConnectionStat{
+		{Family: syscall.AF_INET, Type: syscall.SOCK_STREAM, Status: "ESTABLISHED"},
+		{Family: syscall.AF_INET6, Type: syscall.SOCK_STREAM, Status: "ESTABLISHED"},
+		{Family: syscall.AF_INET, Type: syscall.SOCK_STREAM, Status: "LISTEN"},
+		{Family: syscall.AF_INET, Type: syscall.SOCK_DGRAM, Status: "NONE"},
+		{Family: syscall.AF_UNIX, Type: syscall.SOCK_STREAM, Status: "NONE"},
+	}, nil)
+
+	scraper.getProcessHandles = func() (processHandles, error) {
+		return &processHandlesMock{handles: []*processHandleMock{handleMock}}, nil
+	}
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+
+	fds := getMetric(t, "process.open_file_descriptors", md.ResourceMetrics())
+	assert.Equal(t, int64(42), fds.Sum().DataPoints().At(0).IntVal())
+
+	contextSwitches := getMetric(t, "process.context_switches", md.ResourceMetrics())
+	require.Equal(t, 2, contextSwitches.Sum().DataPoints().Len())
+	assert.Equal(t, int64(3), contextSwitches.Sum().DataPoints().At(0).IntVal())
+	internal.AssertSumMetricHasAttributeValue(t, contextSwitches, 0, "type", pcommon.NewValueString(metadata.AttributeContextSwitchTypeInvoluntary.String()))
+	assert.Equal(t, int64(10), contextSwitches.Sum().DataPoints().At(1).IntVal())
+	internal.AssertSumMetricHasAttributeValue(t, contextSwitches, 1, "type", pcommon.NewValueString(metadata.AttributeContextSwitchTypeVoluntary.String()))
+
+	pageFaults := getMetric(t, "process.paging.faults", md.ResourceMetrics())
+	require.Equal(t, 2, pageFaults.Sum().DataPoints().Len())
+	assert.Equal(t, int64(5), pageFaults.Sum().DataPoints().At(0).IntVal())
+	internal.AssertSumMetricHasAttributeValue(t, pageFaults, 0, "type", pcommon.NewValueString(metadata.AttributePagingFaultTypeMajor.String()))
+	assert.Equal(t, int64(500), pageFaults.Sum().DataPoints().At(1).IntVal())
+
+	connections := getMetric(t, "process.network.connections", md.ResourceMetrics())
+	require.Equal(t, len(allTCPStates), connections.Sum().DataPoints().Len())
+	counts := map[string]int64{}
+	for i := 0; i < connections.Sum().DataPoints().Len(); i++ {
+		dp := connections.Sum().DataPoints().At(i)
+		state, ok := dp.Attributes().Get("state")
+		require.True(t, ok)
+		counts[state.StringVal()] = dp.IntVal()
+	}
+	assert.Equal(t, int64(2), counts["ESTABLISHED"])
+	assert.Equal(t, int64(1), counts["LISTEN"])
+	assert.Equal(t, int64(0), counts["TIME_WAIT"])
+}
+
+func TestScrapeLinuxMetrics_Errors(t *testing.T) {
+	scraper, err := newProcessScraper(componenttest.NewNopReceiverCreateSettings(), &Config{Metrics: newLinuxMetricsSettings()})
+	require.NoError(t, err)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	handleMock := &processHandleMock{}
+	handleMock.On("Name").Return("test", nil)
+	handleMock.On("Exe").Return("test", nil)
+	handleMock.On("Username").Return("username", nil)
+	handleMock.On("CmdlineSlice").Return([]string{"test"}, nil)
+	handleMock.On("CreateTime").Return(int64(0), nil)
+	handleMock.On("Parent").Return(&process.Process{Pid: 2}, nil)
+	handleMock.On("Times").Return(&cpu.TimesStat{}, nil)
+	handleMock.On("MemoryInfo").Return(&process.MemoryInfoStat{}, nil)
+	handleMock.On("IOCounters").Return(&process.IOCountersStat{}, nil)
+	handleMock.On("NumFDs").Return(int32(0), errors.New("err1"))
+	handleMock.On("NumCtxSwitches").Return(&process.NumCtxSwitchesStat{}, errors.New("err2"))
+	handleMock.On("PageFaults").Return(&process.PageFaultsStat{}, errors.New("err3"))
+	handleMock.On("Connections").Return([]net.ConnectionStat{}, errors.New("err4"))
+
+	scraper.getProcessHandles = func() (processHandles, error) {
+		return &processHandlesMock{handles: []*processHandleMock{handleMock}}, nil
+	}
+
+	md, err := scraper.scrape(context.Background())
+	assert.EqualError(t, err, `error reading open file descriptor count for process "test" (pid 1): err1; `+
+		`error reading context switches for process "test" (pid 1): err2; `+
+		`error reading page faults for process "test" (pid 1): err3; `+
+		`error reading network connections for process "test" (pid 1): err4`)
+	var scraperErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &scraperErr)
+	assert.Equal(t, linuxMetricsLen, scraperErr.Failed)
+	assert.Equal(t, cpuMetricsLen+memoryMetricsLen+diskMetricsLen, md.MetricCount())
+}
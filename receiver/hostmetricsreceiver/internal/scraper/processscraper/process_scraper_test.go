@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/net"
 	"github.com/shirou/gopsutil/v3/process"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -283,10 +284,15 @@ func TestScrapeMetrics_GetProcessesError(t *testing.T) {
 
 type processHandlesMock struct {
 	handles []*processHandleMock
+	// pids are the pids of the handles, which default to their index plus one.
+	pids []int32
 }
 
-func (p *processHandlesMock) Pid(int) int32 {
-	return 1
+func (p *processHandlesMock) Pid(index int) int32 {
+	if p.pids != nil {
+		return p.pids[index]
+	}
+	return int32(index + 1)
 }
 
 func (p *processHandlesMock) At(index int) processHandle {
@@ -356,6 +362,26 @@ func (p *processHandleMock) Parent() (*process.Process, error) {
 	return args.Get(0).(*process.Process), args.Error(1)
 }
 
+func (p *processHandleMock) NumFDs() (int32, error) {
+	args := p.MethodCalled("NumFDs")
+	return args.Get(0).(int32), args.Error(1)
+}
+
+func (p *processHandleMock) NumCtxSwitches() (*process.NumCtxSwitchesStat, error) {
+	args := p.MethodCalled("NumCtxSwitches")
+	return args.Get(0).(*process.NumCtxSwitchesStat), args.Error(1)
+}
+
+func (p *processHandleMock) PageFaults() (*process.PageFaultsStat, error) {
+	args := p.MethodCalled("PageFaults")
+	return args.Get(0).(*process.PageFaultsStat), args.Error(1)
+}
+
+func (p *processHandleMock) Connections() ([]net.ConnectionStat, error) {
+	args := p.MethodCalled("Connections")
+	return args.Get(0).([]net.ConnectionStat), args.Error(1)
+}
+
 func newDefaultHandleMock() *processHandleMock {
 	handleMock := &processHandleMock{}
 	handleMock.On("Username").Return("username", nil)
@@ -366,6 +392,10 @@ func newDefaultHandleMock() *processHandleMock {
 	handleMock.On("IOCounters").Return(&process.IOCountersStat{}, nil)
 	handleMock.On("Parent").Return(&process.Process{Pid: 2}, nil)
 	handleMock.On("NumThreads").Return(int32(0), nil)
+	handleMock.On("NumFDs").Return(int32(0), nil)
+	handleMock.On("NumCtxSwitches").Return(&process.NumCtxSwitchesStat{}, nil)
+	handleMock.On("PageFaults").Return(&process.PageFaultsStat{}, nil)
+	handleMock.On("Connections").Return([]net.ConnectionStat{}, nil)
 	return handleMock
 }
 
@@ -729,3 +759,246 @@ func TestScrapeMetrics_MuteProcessNameError(t *testing.T) {
 		})
 	}
 }
+
+func TestScrapeMetrics_Grouping(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	type testProcess struct {
+		name    string
+		cmdline string
+		rss     uint64
+	}
+
+	processes := []testProcess{
+		{name: "php-fpm", cmdline: "php-fpm: pool www", rss: 100},
+		{name: "php-fpm", cmdline: "php-fpm: pool www", rss: 200},
+		{name: "php-fpm", cmdline: "php-fpm: pool api", rss: 300},
+		{name: "nginx", cmdline: "nginx: worker process", rss: 400},
+	}
+
+	type expectedGroup struct {
+		count int64
+		rss   int64
+	}
+
+	testCases := []struct {
+		name              string
+		grouping          GroupingConfig
+		expectedGroups    map[string]expectedGroup
+		expectedProcesses int
+	}{
+		{
+			name:     "By executable name",
+			grouping: GroupingConfig{By: "executable_name"},
+			expectedGroups: map[string]expectedGroup{
+				"php-fpm": {count: 3, rss: 600},
+				"nginx":   {count: 1, rss: 400},
+			},
+		},
+		{
+			name:     "By command line capturing group",
+			grouping: GroupingConfig{By: "command_line", CommandLinePattern: `^php-fpm: pool (\w+)`},
+			expectedGroups: map[string]expectedGroup{
+				"www": {count: 2, rss: 300},
+				"api": {count: 1, rss: 300},
+			},
+			expectedProcesses: 1,
+		},
+		{
+			name:     "By command line match",
+			grouping: GroupingConfig{By: "command_line", CommandLinePattern: `^php-fpm`},
+			expectedGroups: map[string]expectedGroup{
+				"php-fpm": {count: 3, rss: 600},
+			},
+			expectedProcesses: 1,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			scraper, err := newProcessScraper(componenttest.NewNopReceiverCreateSettings(), &Config{
+				Metrics:  metadata.DefaultMetricsSettings(),
+				Grouping: test.grouping,
+			})
+			require.NoError(t, err, "Failed to create process scraper: %v", err)
+			err = scraper.start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+
+			handles := make([]*processHandleMock, 0, len(processes))
+			for _, p := range processes {
+				handleMock := &processHandleMock{}
+				handleMock.On("Name").Return(p.name, nil)
+				handleMock.On("Exe").Return(p.name, nil)
+				handleMock.On("Username").Return("username", nil)
+				handleMock.On("Cmdline").Return(p.cmdline, nil)
+				handleMock.On("CmdlineSlice").Return([]string{p.cmdline}, nil)
+				handleMock.On("CreateTime").Return(int64(0), nil)
+				handleMock.On("Parent").Return(&process.Process{Pid: 2}, nil)
+				handleMock.On("Times").Return(&cpu.TimesStat{User: 1, System: 2}, nil)
+				handleMock.On("MemoryInfo").Return(&process.MemoryInfoStat{RSS: p.rss}, nil)
+				handleMock.On("IOCounters").Return(&process.IOCountersStat{}, nil)
+				handles = append(handles, handleMock)
+			}
+
+			scraper.getProcessHandles = func() (processHandles, error) {
+				return &processHandlesMock{handles: handles}, nil
+			}
+
+			md, err := scraper.scrape(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, len(test.expectedGroups)+test.expectedProcesses, md.ResourceMetrics().Len())
+
+			groups := map[string]expectedGroup{}
+			for i := 0; i < md.ResourceMetrics().Len(); i++ {
+				rm := md.ResourceMetrics().At(i)
+				group, ok := rm.Resource().Attributes().Get("process.group")
+				if !ok {
+					_, ok = rm.Resource().Attributes().Get(conventions.AttributeProcessPID)
+					assert.True(t, ok)
+					continue
+				}
+				_, ok = rm.Resource().Attributes().Get(conventions.AttributeProcessPID)
+				assert.False(t, ok)
+
+				metrics := getMetricSlice(t, rm)
+				var actual expectedGroup
+				var userTime float64
+				for j := 0; j < metrics.Len(); j++ {
+					metric := metrics.At(j)
+					switch metric.Name() {
+					case "process.count":
+						actual.count = metric.Sum().DataPoints().At(0).IntVal()
+					case "process.memory.physical_usage":
+						actual.rss = metric.Sum().DataPoints().At(0).IntVal()
+					case "process.cpu.time":
+						userTime = metric.Sum().DataPoints().At(0).DoubleVal()
+					}
+				}
+				// every process of the group spent 1s in user mode
+				assert.Equal(t, float64(actual.count), userTime)
+				groups[group.StringVal()] = actual
+			}
+			assert.Equal(t, test.expectedGroups, groups)
+		})
+	}
+}
+
+func TestScrapeMetrics_GroupingProcessExit(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	scraper, err := newProcessScraper(componenttest.NewNopReceiverCreateSettings(), &Config{
+		Metrics:  metadata.DefaultMetricsSettings(),
+		Grouping: GroupingConfig{By: "executable_name"},
+	})
+	require.NoError(t, err, "Failed to create process scraper: %v", err)
+	err = scraper.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+
+	newHandle := func(createTime int64, userTime float64, rss uint64) *processHandleMock {
+		handleMock := &processHandleMock{}
+		handleMock.On("Name").Return("php-fpm", nil)
+		handleMock.On("Exe").Return("php-fpm", nil)
+		handleMock.On("Username").Return("username", nil)
+		handleMock.On("Cmdline").Return("php-fpm: pool www", nil)
+		handleMock.On("CmdlineSlice").Return([]string{"php-fpm: pool www"}, nil)
+		handleMock.On("CreateTime").Return(createTime, nil)
+		handleMock.On("Parent").Return(&process.Process{Pid: 2}, nil)
+		handleMock.On("Times").Return(&cpu.TimesStat{User: userTime}, nil)
+		handleMock.On("MemoryInfo").Return(&process.MemoryInfoStat{RSS: rss}, nil)
+		handleMock.On("IOCounters").Return(&process.IOCountersStat{}, nil)
+		return handleMock
+	}
+
+	type expectedGroup struct {
+		count    int64
+		rss      int64
+		userTime float64
+	}
+	scrapeGroup := func(handles *processHandlesMock) expectedGroup {
+		scraper.getProcessHandles = func() (processHandles, error) {
+			return handles, nil
+		}
+		md, err := scraper.scrape(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 1, md.ResourceMetrics().Len())
+
+		var actual expectedGroup
+		metrics := getMetricSlice(t, md.ResourceMetrics().At(0))
+		for i := 0; i < metrics.Len(); i++ {
+			metric := metrics.At(i)
+			switch metric.Name() {
+			case "process.count":
+				actual.count = metric.Sum().DataPoints().At(0).IntVal()
+			case "process.memory.physical_usage":
+				actual.rss = metric.Sum().DataPoints().At(0).IntVal()
+			case "process.cpu.time":
+				actual.userTime = metric.Sum().DataPoints().At(0).DoubleVal()
+			}
+		}
+		return actual
+	}
+
+	assert.Equal(t, expectedGroup{count: 2, rss: 300, userTime: 3}, scrapeGroup(&processHandlesMock{
+		handles: []*processHandleMock{newHandle(1000, 1, 100), newHandle(2000, 2, 200)},
+		pids:    []int32{10, 20},
+	}))
+
+	// The cpu time of the process which exited is still counted, its memory is not.
+	assert.Equal(t, expectedGroup{count: 1, rss: 200, userTime: 4}, scrapeGroup(&processHandlesMock{
+		handles: []*processHandleMock{newHandle(2000, 3, 200)},
+		pids:    []int32{20},
+	}))
+
+	// A new process reusing the pid of the process which exited is counted apart.
+	assert.Equal(t, expectedGroup{count: 2, rss: 250, userTime: 5}, scrapeGroup(&processHandlesMock{
+		handles: []*processHandleMock{newHandle(3000, 0.5, 50), newHandle(2000, 3.5, 200)},
+		pids:    []int32{10, 20},
+	}))
+
+	// The state of the group is dropped once it has had no processes for maxMissedScrapes scrapes.
+	scraper.getProcessHandles = func() (processHandles, error) {
+		return &processHandlesMock{}, nil
+	}
+	for i := 0; i < maxMissedScrapes; i++ {
+		assert.Contains(t, scraper.groupStates, "php-fpm")
+		_, err = scraper.scrape(context.Background())
+		require.NoError(t, err)
+	}
+	assert.NotContains(t, scraper.groupStates, "php-fpm")
+
+	assert.Equal(t, expectedGroup{count: 1, rss: 100, userTime: 1}, scrapeGroup(&processHandlesMock{
+		handles: []*processHandleMock{newHandle(4000, 1, 100)},
+		pids:    []int32{30},
+	}))
+}
+
+func TestNewProcessScraper_GroupingErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		grouping      GroupingConfig
+		expectedError string
+	}{
+		{
+			name:          "Unknown grouping",
+			grouping:      GroupingConfig{By: "user"},
+			expectedError: `unknown grouping "user", must be one of "executable_name" or "command_line"`,
+		},
+		{
+			name:          "Missing pattern",
+			grouping:      GroupingConfig{By: "command_line"},
+			expectedError: `grouping by "command_line" requires a command_line_pattern`,
+		},
+		{
+			name:          "Invalid pattern",
+			grouping:      GroupingConfig{By: "command_line", CommandLinePattern: "("},
+			expectedError: "error compiling grouping command_line_pattern: error parsing regexp: missing closing ): `(`",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newProcessScraper(componenttest.NewNopReceiverCreateSettings(), &Config{Grouping: test.grouping})
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/shirou/gopsutil/v3/cpu"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper/internal/metadata"
 )
@@ -61,3 +62,6 @@ func getProcessCommand(proc processHandle) (*commandMetadata, error) {
 	command := &commandMetadata{command: cmd, commandLine: cmdline}
 	return command, nil
 }
+
+func (s *scraper) scrapeLinuxProcessStats(*processMetadata, *processStats, *scrapererror.ScrapeErrors) {
+}
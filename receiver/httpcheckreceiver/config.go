@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
+)
+
+const (
+	schemeHTTP  = "http"
+	schemeHTTPS = "https"
+	schemeTCP   = "tcp"
+)
+
+var errNoTargets = errors.New("no endpoint or targets configured")
+
+// Config defines the configuration of the HTTP check receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	// HTTPClientSettings configures the client shared by all the checks. Its
+	// endpoint, if set, is checked along with the targets, which allows to use
+	// the receiver as a receiver_creator template.
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+	// Method and BodyRegex apply to the checks of Endpoint.
+	Method    string `mapstructure:"method"`
+	BodyRegex string `mapstructure:"body_regex"`
+
+	// Targets are the endpoints to check.
+	Targets []TargetConfig `mapstructure:"targets"`
+
+	Metrics metadata.MetricsSettings `mapstructure:"metrics"`
+}
+
+// TargetConfig defines a checked endpoint.
+type TargetConfig struct {
+	// Endpoint is the URL of the target. The http and https schemes check the
+	// response of an HTTP request, the tcp scheme only opens a TCP connection
+	// to the host and port. Endpoints without a scheme are checked over http.
+	Endpoint string `mapstructure:"endpoint"`
+	// Method is the HTTP request method, GET by default.
+	Method string `mapstructure:"method"`
+	// BodyRegex is a regular expression the response body is matched against.
+	BodyRegex string `mapstructure:"body_regex"`
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	targets := cfg.targets()
+	if len(targets) == 0 {
+		return errNoTargets
+	}
+
+	var errs error
+	for _, target := range targets {
+		if _, err := target.parse(); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// targets returns all the targets to check, including the top level endpoint.
+func (cfg *Config) targets() []TargetConfig {
+	targets := make([]TargetConfig, 0, len(cfg.Targets)+1)
+	if cfg.Endpoint != "" {
+		targets = append(targets, TargetConfig{
+			Endpoint:  cfg.Endpoint,
+			Method:    cfg.Method,
+			BodyRegex: cfg.BodyRegex,
+		})
+	}
+	return append(targets, cfg.Targets...)
+}
+
+// target is a parsed TargetConfig.
+type target struct {
+	url       *url.URL
+	method    string
+	bodyRegex *regexp.Regexp
+}
+
+func (tc TargetConfig) parse() (*target, error) {
+	endpoint := tc.Endpoint
+	if !strings.Contains(endpoint, "://") {
+		endpoint = schemeHTTP + "://" + endpoint
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", tc.Endpoint, err)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("missing hostname in endpoint %q", tc.Endpoint)
+	}
+
+	t := &target{url: u, method: http.MethodGet}
+	switch u.Scheme {
+	case schemeHTTP, schemeHTTPS:
+	case schemeTCP:
+		if _, _, err = net.SplitHostPort(u.Host); err != nil {
+			return nil, fmt.Errorf("missing port in endpoint %q", tc.Endpoint)
+		}
+		if tc.Method != "" || tc.BodyRegex != "" {
+			return nil, fmt.Errorf("method and body_regex are not supported by the tcp endpoint %q", tc.Endpoint)
+		}
+		return t, nil
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q in endpoint %q, must be one of %q, %q or %q", u.Scheme, tc.Endpoint, schemeHTTP, schemeHTTPS, schemeTCP)
+	}
+
+	if tc.Method != "" {
+		t.method = strings.ToUpper(tc.Method)
+	}
+	if tc.BodyRegex != "" {
+		if t.bodyRegex, err = regexp.Compile(tc.BodyRegex); err != nil {
+			return nil, fmt.Errorf("invalid body_regex of endpoint %q: %w", tc.Endpoint, err)
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	require.Equal(t, len(cfg.Receivers), 2)
+
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.Endpoint = "localhost:8080"
+	require.Equal(t, expected, cfg.Receivers[config.NewComponentID(typeStr)])
+
+	expected = factory.CreateDefaultConfig().(*Config)
+	expected.SetIDName("targets")
+	expected.CollectionInterval = 30 * time.Second
+	expected.Timeout = 5 * time.Second
+	expected.Targets = []TargetConfig{
+		{Endpoint: "https://example.com/health", BodyRegex: `"status":\s*"ok"`},
+		{Endpoint: "http://localhost:9200", Method: "head"},
+		{Endpoint: "tcp://localhost:5432"},
+	}
+	require.Equal(t, expected, cfg.Receivers[config.NewComponentIDWithName(typeStr, "targets")])
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		endpoint    string
+		targets     []TargetConfig
+		expectedErr string
+	}{
+		{
+			name:        "no targets",
+			expectedErr: errNoTargets.Error(),
+		},
+		{
+			name:     "endpoint without scheme",
+			endpoint: "localhost:8080",
+		},
+		{
+			name:        "missing hostname",
+			endpoint:    "http://:8080",
+			expectedErr: `missing hostname in endpoint "http://:8080"`,
+		},
+		{
+			name:        "unsupported scheme",
+			targets:     []TargetConfig{{Endpoint: "udp://localhost:53"}},
+			expectedErr: `unsupported scheme "udp" in endpoint "udp://localhost:53", must be one of "http", "https" or "tcp"`,
+		},
+		{
+			name:        "tcp without port",
+			targets:     []TargetConfig{{Endpoint: "tcp://localhost"}},
+			expectedErr: `missing port in endpoint "tcp://localhost"`,
+		},
+		{
+			name:        "tcp with body regex",
+			targets:     []TargetConfig{{Endpoint: "tcp://localhost:5432", BodyRegex: "ok"}},
+			expectedErr: `method and body_regex are not supported by the tcp endpoint "tcp://localhost:5432"`,
+		},
+		{
+			name:        "invalid body regex",
+			targets:     []TargetConfig{{Endpoint: "http://localhost", BodyRegex: "("}},
+			expectedErr: "invalid body_regex of endpoint \"http://localhost\": error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name: "multiple errors",
+			targets: []TargetConfig{
+				{Endpoint: "tcp://localhost"},
+				{Endpoint: "ftp://localhost"},
+			},
+			expectedErr: `missing port in endpoint "tcp://localhost"; unsupported scheme "ftp" in endpoint "ftp://localhost", must be one of "http", "https" or "tcp"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = tc.endpoint
+			cfg.Targets = tc.targets
+
+			err := cfg.Validate()
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,592 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// MetricSettings provides common settings for a particular metric.
+type MetricSettings struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// MetricsSettings provides settings for httpcheckreceiver metrics.
+type MetricsSettings struct {
+	HttpcheckBodyMatch              MetricSettings `mapstructure:"httpcheck.body.match"`
+	HttpcheckDuration               MetricSettings `mapstructure:"httpcheck.duration"`
+	HttpcheckError                  MetricSettings `mapstructure:"httpcheck.error"`
+	HttpcheckResponseStatusCode     MetricSettings `mapstructure:"httpcheck.response.status_code"`
+	HttpcheckStatus                 MetricSettings `mapstructure:"httpcheck.status"`
+	HttpcheckTLSCertificateTimeLeft MetricSettings `mapstructure:"httpcheck.tls.certificate.time_left"`
+}
+
+func DefaultMetricsSettings() MetricsSettings {
+	return MetricsSettings{
+		HttpcheckBodyMatch: MetricSettings{
+			Enabled: true,
+		},
+		HttpcheckDuration: MetricSettings{
+			Enabled: true,
+		},
+		HttpcheckError: MetricSettings{
+			Enabled: true,
+		},
+		HttpcheckResponseStatusCode: MetricSettings{
+			Enabled: true,
+		},
+		HttpcheckStatus: MetricSettings{
+			Enabled: true,
+		},
+		HttpcheckTLSCertificateTimeLeft: MetricSettings{
+			Enabled: true,
+		},
+	}
+}
+
+// AttributeErrorType specifies the a value error.type attribute.
+type AttributeErrorType int
+
+const (
+	_ AttributeErrorType = iota
+	AttributeErrorTypeDns
+	AttributeErrorTypeConnect
+	AttributeErrorTypeTls
+	AttributeErrorTypeTimeout
+	AttributeErrorTypeStatus
+	AttributeErrorTypeBodyMismatch
+)
+
+// String returns the string representation of the AttributeErrorType.
+func (av AttributeErrorType) String() string {
+	switch av {
+	case AttributeErrorTypeDns:
+		return "dns"
+	case AttributeErrorTypeConnect:
+		return "connect"
+	case AttributeErrorTypeTls:
+		return "tls"
+	case AttributeErrorTypeTimeout:
+		return "timeout"
+	case AttributeErrorTypeStatus:
+		return "status"
+	case AttributeErrorTypeBodyMismatch:
+		return "body_mismatch"
+	}
+	return ""
+}
+
+// MapAttributeErrorType is a helper map of string to AttributeErrorType attribute value.
+var MapAttributeErrorType = map[string]AttributeErrorType{
+	"dns":           AttributeErrorTypeDns,
+	"connect":       AttributeErrorTypeConnect,
+	"tls":           AttributeErrorTypeTls,
+	"timeout":       AttributeErrorTypeTimeout,
+	"status":        AttributeErrorTypeStatus,
+	"body_mismatch": AttributeErrorTypeBodyMismatch,
+}
+
+// AttributePhase specifies the a value phase attribute.
+type AttributePhase int
+
+const (
+	_ AttributePhase = iota
+	AttributePhaseDns
+	AttributePhaseConnect
+	AttributePhaseTls
+	AttributePhaseTtfb
+	AttributePhaseTotal
+)
+
+// String returns the string representation of the AttributePhase.
+func (av AttributePhase) String() string {
+	switch av {
+	case AttributePhaseDns:
+		return "dns"
+	case AttributePhaseConnect:
+		return "connect"
+	case AttributePhaseTls:
+		return "tls"
+	case AttributePhaseTtfb:
+		return "ttfb"
+	case AttributePhaseTotal:
+		return "total"
+	}
+	return ""
+}
+
+// MapAttributePhase is a helper map of string to AttributePhase attribute value.
+var MapAttributePhase = map[string]AttributePhase{
+	"dns":     AttributePhaseDns,
+	"connect": AttributePhaseConnect,
+	"tls":     AttributePhaseTls,
+	"ttfb":    AttributePhaseTtfb,
+	"total":   AttributePhaseTotal,
+}
+
+type metricHttpcheckBodyMatch struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.body.match metric with initial data.
+func (m *metricHttpcheckBodyMatch) init() {
+	m.data.SetName("httpcheck.body.match")
+	m.data.SetDescription("1 if the response body matched the configured body_regex, 0 otherwise.")
+	m.data.SetUnit("1")
+	m.data.SetDataType(pmetric.MetricDataTypeGauge)
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckBodyMatch) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+	dp.Attributes().UpsertString("http.url", httpURLAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckBodyMatch) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckBodyMatch) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckBodyMatch(settings MetricSettings) metricHttpcheckBodyMatch {
+	m := metricHttpcheckBodyMatch{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricHttpcheckDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.duration metric with initial data.
+func (m *metricHttpcheckDuration) init() {
+	m.data.SetName("httpcheck.duration")
+	m.data.SetDescription("Time spent in each phase of the check.")
+	m.data.SetUnit("ms")
+	m.data.SetDataType(pmetric.MetricDataTypeGauge)
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, httpURLAttributeValue string, phaseAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleVal(val)
+	dp.Attributes().UpsertString("http.url", httpURLAttributeValue)
+	dp.Attributes().UpsertString("phase", phaseAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckDuration) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckDuration) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckDuration(settings MetricSettings) metricHttpcheckDuration {
+	m := metricHttpcheckDuration{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricHttpcheckError struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.error metric with initial data.
+func (m *metricHttpcheckError) init() {
+	m.data.SetName("httpcheck.error")
+	m.data.SetDescription("1 for the error of a failed check.")
+	m.data.SetUnit("1")
+	m.data.SetDataType(pmetric.MetricDataTypeGauge)
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckError) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, errorTypeAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+	dp.Attributes().UpsertString("http.url", httpURLAttributeValue)
+	dp.Attributes().UpsertString("error.type", errorTypeAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckError) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckError) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckError(settings MetricSettings) metricHttpcheckError {
+	m := metricHttpcheckError{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricHttpcheckResponseStatusCode struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.response.status_code metric with initial data.
+func (m *metricHttpcheckResponseStatusCode) init() {
+	m.data.SetName("httpcheck.response.status_code")
+	m.data.SetDescription("1 for the status code of the HTTP response.")
+	m.data.SetUnit("1")
+	m.data.SetDataType(pmetric.MetricDataTypeGauge)
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckResponseStatusCode) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpMethodAttributeValue string, httpStatusCodeAttributeValue int64) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+	dp.Attributes().UpsertString("http.url", httpURLAttributeValue)
+	dp.Attributes().UpsertString("http.method", httpMethodAttributeValue)
+	dp.Attributes().UpsertInt("http.status_code", httpStatusCodeAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckResponseStatusCode) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckResponseStatusCode) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckResponseStatusCode(settings MetricSettings) metricHttpcheckResponseStatusCode {
+	m := metricHttpcheckResponseStatusCode{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricHttpcheckStatus struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.status metric with initial data.
+func (m *metricHttpcheckStatus) init() {
+	m.data.SetName("httpcheck.status")
+	m.data.SetDescription("1 if the check succeeded, 0 otherwise.")
+	m.data.SetUnit("1")
+	m.data.SetDataType(pmetric.MetricDataTypeGauge)
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckStatus) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+	dp.Attributes().UpsertString("http.url", httpURLAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckStatus) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckStatus) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckStatus(settings MetricSettings) metricHttpcheckStatus {
+	m := metricHttpcheckStatus{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricHttpcheckTLSCertificateTimeLeft struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.tls.certificate.time_left metric with initial data.
+func (m *metricHttpcheckTLSCertificateTimeLeft) init() {
+	m.data.SetName("httpcheck.tls.certificate.time_left")
+	m.data.SetDescription("Time left before the earliest expiring certificate presented by the target expires. Negative once expired.")
+	m.data.SetUnit("s")
+	m.data.SetDataType(pmetric.MetricDataTypeGauge)
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckTLSCertificateTimeLeft) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+	dp.Attributes().UpsertString("http.url", httpURLAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckTLSCertificateTimeLeft) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckTLSCertificateTimeLeft) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckTLSCertificateTimeLeft(settings MetricSettings) metricHttpcheckTLSCertificateTimeLeft {
+	m := metricHttpcheckTLSCertificateTimeLeft{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+// MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
+// required to produce metric representation defined in metadata and user settings.
+type MetricsBuilder struct {
+	startTime                             pcommon.Timestamp   // start time that will be applied to all recorded data points.
+	metricsCapacity                       int                 // maximum observed number of metrics per resource.
+	resourceCapacity                      int                 // maximum observed number of resource attributes.
+	metricsBuffer                         pmetric.Metrics     // accumulates metrics data before emitting.
+	buildInfo                             component.BuildInfo // contains version information
+	metricHttpcheckBodyMatch              metricHttpcheckBodyMatch
+	metricHttpcheckDuration               metricHttpcheckDuration
+	metricHttpcheckError                  metricHttpcheckError
+	metricHttpcheckResponseStatusCode     metricHttpcheckResponseStatusCode
+	metricHttpcheckStatus                 metricHttpcheckStatus
+	metricHttpcheckTLSCertificateTimeLeft metricHttpcheckTLSCertificateTimeLeft
+}
+
+// metricBuilderOption applies changes to default metrics builder.
+type metricBuilderOption func(*MetricsBuilder)
+
+// WithStartTime sets startTime on the metrics builder.
+func WithStartTime(startTime pcommon.Timestamp) metricBuilderOption {
+	return func(mb *MetricsBuilder) {
+		mb.startTime = startTime
+	}
+}
+
+func NewMetricsBuilder(settings MetricsSettings, buildInfo component.BuildInfo, options ...metricBuilderOption) *MetricsBuilder {
+	mb := &MetricsBuilder{
+		startTime:                             pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                         pmetric.NewMetrics(),
+		buildInfo:                             buildInfo,
+		metricHttpcheckBodyMatch:              newMetricHttpcheckBodyMatch(settings.HttpcheckBodyMatch),
+		metricHttpcheckDuration:               newMetricHttpcheckDuration(settings.HttpcheckDuration),
+		metricHttpcheckError:                  newMetricHttpcheckError(settings.HttpcheckError),
+		metricHttpcheckResponseStatusCode:     newMetricHttpcheckResponseStatusCode(settings.HttpcheckResponseStatusCode),
+		metricHttpcheckStatus:                 newMetricHttpcheckStatus(settings.HttpcheckStatus),
+		metricHttpcheckTLSCertificateTimeLeft: newMetricHttpcheckTLSCertificateTimeLeft(settings.HttpcheckTLSCertificateTimeLeft),
+	}
+	for _, op := range options {
+		op(mb)
+	}
+	return mb
+}
+
+// updateCapacity updates max length of metrics and resource attributes that will be used for the slice capacity.
+func (mb *MetricsBuilder) updateCapacity(rm pmetric.ResourceMetrics) {
+	if mb.metricsCapacity < rm.ScopeMetrics().At(0).Metrics().Len() {
+		mb.metricsCapacity = rm.ScopeMetrics().At(0).Metrics().Len()
+	}
+	if mb.resourceCapacity < rm.Resource().Attributes().Len() {
+		mb.resourceCapacity = rm.Resource().Attributes().Len()
+	}
+}
+
+// ResourceMetricsOption applies changes to provided resource metrics.
+type ResourceMetricsOption func(pmetric.ResourceMetrics)
+
+// WithStartTimeOverride overrides start time for all the resource metrics data points.
+// This option should be only used if different start time has to be set on metrics coming from different resources.
+func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
+	return func(rm pmetric.ResourceMetrics) {
+		var dps pmetric.NumberDataPointSlice
+		metrics := rm.ScopeMetrics().At(0).Metrics()
+		for i := 0; i < metrics.Len(); i++ {
+			switch metrics.At(i).DataType() {
+			case pmetric.MetricDataTypeGauge:
+				dps = metrics.At(i).Gauge().DataPoints()
+			case pmetric.MetricDataTypeSum:
+				dps = metrics.At(i).Sum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dps.At(j).SetStartTimestamp(start)
+			}
+		}
+	}
+}
+
+// EmitForResource saves all the generated metrics under a new resource and updates the internal state to be ready for
+// recording another set of data points as part of another resource. This function can be helpful when one scraper
+// needs to emit metrics from several resources. Otherwise calling this function is not required,
+// just `Emit` function can be called instead.
+// Resource attributes should be provided as ResourceMetricsOption arguments.
+func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
+	rm := pmetric.NewResourceMetrics()
+	rm.Resource().Attributes().EnsureCapacity(mb.resourceCapacity)
+	ils := rm.ScopeMetrics().AppendEmpty()
+	ils.Scope().SetName("otelcol/httpcheckreceiver")
+	ils.Scope().SetVersion(mb.buildInfo.Version)
+	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricHttpcheckBodyMatch.emit(ils.Metrics())
+	mb.metricHttpcheckDuration.emit(ils.Metrics())
+	mb.metricHttpcheckError.emit(ils.Metrics())
+	mb.metricHttpcheckResponseStatusCode.emit(ils.Metrics())
+	mb.metricHttpcheckStatus.emit(ils.Metrics())
+	mb.metricHttpcheckTLSCertificateTimeLeft.emit(ils.Metrics())
+	for _, op := range rmo {
+		op(rm)
+	}
+	if ils.Metrics().Len() > 0 {
+		mb.updateCapacity(rm)
+		rm.MoveTo(mb.metricsBuffer.ResourceMetrics().AppendEmpty())
+	}
+}
+
+// Emit returns all the metrics accumulated by the metrics builder and updates the internal state to be ready for
+// recording another set of metrics. This function will be responsible for applying all the transformations required to
+// produce metric representation defined in metadata and user settings, e.g. delta or cumulative.
+func (mb *MetricsBuilder) Emit(rmo ...ResourceMetricsOption) pmetric.Metrics {
+	mb.EmitForResource(rmo...)
+	metrics := pmetric.NewMetrics()
+	mb.metricsBuffer.MoveTo(metrics)
+	return metrics
+}
+
+// RecordHttpcheckBodyMatchDataPoint adds a data point to httpcheck.body.match metric.
+func (mb *MetricsBuilder) RecordHttpcheckBodyMatchDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+	mb.metricHttpcheckBodyMatch.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue)
+}
+
+// RecordHttpcheckDurationDataPoint adds a data point to httpcheck.duration metric.
+func (mb *MetricsBuilder) RecordHttpcheckDurationDataPoint(ts pcommon.Timestamp, val float64, httpURLAttributeValue string, phaseAttributeValue AttributePhase) {
+	mb.metricHttpcheckDuration.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, phaseAttributeValue.String())
+}
+
+// RecordHttpcheckErrorDataPoint adds a data point to httpcheck.error metric.
+func (mb *MetricsBuilder) RecordHttpcheckErrorDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, errorTypeAttributeValue AttributeErrorType) {
+	mb.metricHttpcheckError.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, errorTypeAttributeValue.String())
+}
+
+// RecordHttpcheckResponseStatusCodeDataPoint adds a data point to httpcheck.response.status_code metric.
+func (mb *MetricsBuilder) RecordHttpcheckResponseStatusCodeDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpMethodAttributeValue string, httpStatusCodeAttributeValue int64) {
+	mb.metricHttpcheckResponseStatusCode.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, httpMethodAttributeValue, httpStatusCodeAttributeValue)
+}
+
+// RecordHttpcheckStatusDataPoint adds a data point to httpcheck.status metric.
+func (mb *MetricsBuilder) RecordHttpcheckStatusDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+	mb.metricHttpcheckStatus.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue)
+}
+
+// RecordHttpcheckTLSCertificateTimeLeftDataPoint adds a data point to httpcheck.tls.certificate.time_left metric.
+func (mb *MetricsBuilder) RecordHttpcheckTLSCertificateTimeLeftDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+	mb.metricHttpcheckTLSCertificateTimeLeft.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue)
+}
+
+// Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
+// and metrics builder should update its startTime and reset it's internal state accordingly.
+func (mb *MetricsBuilder) Reset(options ...metricBuilderOption) {
+	mb.startTime = pcommon.NewTimestampFromTime(time.Now())
+	for _, op := range options {
+		op(mb)
+	}
+}
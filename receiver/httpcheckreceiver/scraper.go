@@ -0,0 +1,313 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
+)
+
+// maxBodySize is the maximum number of bytes of the response body matched against body_regex.
+const maxBodySize = 1 << 20
+
+type httpCheckScraper struct {
+	settings component.TelemetrySettings
+	cfg      *Config
+	targets  []*target
+	client   *http.Client
+	dialer   *net.Dialer
+	mb       *metadata.MetricsBuilder
+}
+
+// checkResult is the outcome of the check of a target.
+type checkResult struct {
+	start      time.Time
+	phases     []phaseDuration
+	statusCode int
+	// certExpiry is the expiry of the earliest expiring certificate, zero if the
+	// connection didn't use TLS.
+	certExpiry time.Time
+	bodyMatch  bool
+	// err is the error of a failed check, and errType its type.
+	err     error
+	errType metadata.AttributeErrorType
+}
+
+func (r *checkResult) fail(errType metadata.AttributeErrorType, err error) {
+	r.errType = errType
+	r.err = err
+}
+
+// errorType returns the type of an error of a network operation: timeout or dns
+// when the error tells so, and fallback otherwise.
+func errorType(err error, fallback metadata.AttributeErrorType) metadata.AttributeErrorType {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return metadata.AttributeErrorTypeTimeout
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return metadata.AttributeErrorTypeDns
+	}
+	return fallback
+}
+
+type phaseDuration struct {
+	phase    metadata.AttributePhase
+	duration time.Duration
+}
+
+func newHTTPCheckScraper(settings component.ReceiverCreateSettings, cfg *Config) (*httpCheckScraper, error) {
+	targetConfigs := cfg.targets()
+	targets := make([]*target, 0, len(targetConfigs))
+	for _, tc := range targetConfigs {
+		t, err := tc.parse()
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, t)
+	}
+
+	return &httpCheckScraper{
+		settings: settings.TelemetrySettings,
+		cfg:      cfg,
+		targets:  targets,
+		dialer:   &net.Dialer{},
+		mb:       metadata.NewMetricsBuilder(cfg.Metrics, settings.BuildInfo),
+	}, nil
+}
+
+func (s *httpCheckScraper) start(_ context.Context, host component.Host) error {
+	client, err := s.cfg.ToClient(host, s.settings)
+	if err != nil {
+		return err
+	}
+	s.client = client
+	return nil
+}
+
+func (s *httpCheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	results := make([]*checkResult, len(s.targets))
+
+	var wg sync.WaitGroup
+	for i, t := range s.targets {
+		wg.Add(1)
+		go func(i int, t *target) {
+			defer wg.Done()
+			results[i] = s.check(ctx, t)
+		}(i, t)
+	}
+	wg.Wait()
+
+	for i, t := range s.targets {
+		s.record(t, results[i])
+	}
+
+	return s.mb.Emit(), nil
+}
+
+func (s *httpCheckScraper) check(ctx context.Context, t *target) *checkResult {
+	if s.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.Timeout)
+		defer cancel()
+	}
+
+	var res *checkResult
+	if t.url.Scheme == schemeTCP {
+		res = s.checkTCP(ctx, t)
+	} else {
+		res = s.checkHTTP(ctx, t)
+	}
+
+	if res.err != nil {
+		s.settings.Logger.Warn("Check failed",
+			zap.String("endpoint", t.url.String()),
+			zap.String("error.type", res.errType.String()),
+			zap.Error(res.err))
+	}
+	return res
+}
+
+func (s *httpCheckScraper) checkHTTP(ctx context.Context, t *target) *checkResult {
+	res := &checkResult{start: time.Now()}
+
+	// The trace hooks can be called concurrently when several addresses are dialed.
+	var mu sync.Mutex
+	var dnsStart, connectStart, tlsStart time.Time
+	var tlsFailed bool
+	addPhase := func(phase metadata.AttributePhase, start time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		res.phases = append(res.phases, phaseDuration{phase: phase, duration: time.Since(start)})
+	}
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { dnsStart = time.Now() },
+		DNSDone:  func(httptrace.DNSDoneInfo) { addPhase(metadata.AttributePhaseDns, dnsStart) },
+		ConnectStart: func(string, string) {
+			mu.Lock()
+			defer mu.Unlock()
+			connectStart = time.Now()
+		},
+		ConnectDone: func(_, _ string, err error) {
+			mu.Lock()
+			start := connectStart
+			mu.Unlock()
+			if err == nil {
+				addPhase(metadata.AttributePhaseConnect, start)
+			}
+		},
+		TLSHandshakeStart: func() { tlsStart = time.Now() },
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			if err != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				tlsFailed = true
+				return
+			}
+			addPhase(metadata.AttributePhaseTls, tlsStart)
+		},
+		GotFirstResponseByte: func() { addPhase(metadata.AttributePhaseTtfb, res.start) },
+	}
+
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), t.method, t.url.String(), nil)
+	if err != nil {
+		res.fail(metadata.AttributeErrorTypeConnect, err)
+		return res
+	}
+	// Each check opens a new connection, so that all the phases are measured.
+	req.Close = true
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		fallback := metadata.AttributeErrorTypeConnect
+		mu.Lock()
+		if tlsFailed {
+			fallback = metadata.AttributeErrorTypeTls
+		}
+		mu.Unlock()
+		res.fail(errorType(err, fallback), err)
+		return res
+	}
+	defer resp.Body.Close()
+
+	res.statusCode = resp.StatusCode
+	if resp.TLS != nil {
+		for _, cert := range resp.TLS.PeerCertificates {
+			if res.certExpiry.IsZero() || cert.NotAfter.Before(res.certExpiry) {
+				res.certExpiry = cert.NotAfter
+			}
+		}
+	}
+
+	if t.bodyRegex != nil {
+		var body []byte
+		if body, err = io.ReadAll(io.LimitReader(resp.Body, maxBodySize)); err != nil {
+			res.fail(errorType(err, metadata.AttributeErrorTypeConnect), fmt.Errorf("failed to read response body: %w", err))
+			return res
+		}
+		res.bodyMatch = t.bodyRegex.Match(body)
+	}
+	if _, err = io.Copy(io.Discard, resp.Body); err != nil {
+		res.fail(errorType(err, metadata.AttributeErrorTypeConnect), fmt.Errorf("failed to read response body: %w", err))
+		return res
+	}
+	addPhase(metadata.AttributePhaseTotal, res.start)
+
+	switch {
+	case resp.StatusCode >= http.StatusBadRequest:
+		res.fail(metadata.AttributeErrorTypeStatus, fmt.Errorf("unexpected response status %q", resp.Status))
+	case t.bodyRegex != nil && !res.bodyMatch:
+		res.fail(metadata.AttributeErrorTypeBodyMismatch, fmt.Errorf("response body does not match %q", t.bodyRegex))
+	}
+	return res
+}
+
+func (s *httpCheckScraper) checkTCP(ctx context.Context, t *target) *checkResult {
+	res := &checkResult{start: time.Now()}
+
+	host, port, err := net.SplitHostPort(t.url.Host)
+	if err != nil {
+		res.fail(metadata.AttributeErrorTypeConnect, err)
+		return res
+	}
+
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		res.fail(errorType(err, metadata.AttributeErrorTypeDns), err)
+		return res
+	}
+	res.phases = append(res.phases, phaseDuration{phase: metadata.AttributePhaseDns, duration: time.Since(res.start)})
+
+	connectStart := time.Now()
+	conn, err := s.dialer.DialContext(ctx, "tcp", net.JoinHostPort(addrs[0], port))
+	if err != nil {
+		res.fail(errorType(err, metadata.AttributeErrorTypeConnect), err)
+		return res
+	}
+	_ = conn.Close()
+	res.phases = append(res.phases,
+		phaseDuration{phase: metadata.AttributePhaseConnect, duration: time.Since(connectStart)},
+		phaseDuration{phase: metadata.AttributePhaseTotal, duration: time.Since(res.start)},
+	)
+	return res
+}
+
+func (s *httpCheckScraper) record(t *target, res *checkResult) {
+	now := pcommon.NewTimestampFromTime(res.start)
+	endpoint := t.url.String()
+
+	status := int64(1)
+	if res.err != nil {
+		status = 0
+		s.mb.RecordHttpcheckErrorDataPoint(now, 1, endpoint, res.errType)
+	}
+	s.mb.RecordHttpcheckStatusDataPoint(now, status, endpoint)
+
+	for _, p := range res.phases {
+		s.mb.RecordHttpcheckDurationDataPoint(now, float64(p.duration)/float64(time.Millisecond), endpoint, p.phase)
+	}
+
+	if res.statusCode != 0 {
+		s.mb.RecordHttpcheckResponseStatusCodeDataPoint(now, 1, endpoint, t.method, int64(res.statusCode))
+	}
+
+	if !res.certExpiry.IsZero() {
+		s.mb.RecordHttpcheckTLSCertificateTimeLeftDataPoint(now, int64(time.Until(res.certExpiry).Seconds()), endpoint)
+	}
+
+	if t.bodyRegex != nil && res.statusCode != 0 {
+		var match int64
+		if res.bodyMatch {
+			match = 1
+		}
+		s.mb.RecordHttpcheckBodyMatchDataPoint(now, match, endpoint)
+	}
+}
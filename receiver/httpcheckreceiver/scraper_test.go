@@ -0,0 +1,279 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newTestScraper(t *testing.T, cfg *Config) *httpCheckScraper {
+	require.NoError(t, cfg.Validate())
+	scraper, err := newHTTPCheckScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	return scraper
+}
+
+// dataPoints returns the data points of the metric with the given name for an endpoint.
+func dataPoints(md pmetric.Metrics, name string, endpoint string) []pmetric.NumberDataPoint {
+	var dps []pmetric.NumberDataPoint
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		if metric.Name() != name {
+			continue
+		}
+		for j := 0; j < metric.Gauge().DataPoints().Len(); j++ {
+			dp := metric.Gauge().DataPoints().At(j)
+			if url, ok := dp.Attributes().Get("http.url"); ok && url.StringVal() == endpoint {
+				dps = append(dps, dp)
+			}
+		}
+	}
+	return dps
+}
+
+func phases(dps []pmetric.NumberDataPoint) []string {
+	out := make([]string, 0, len(dps))
+	for _, dp := range dps {
+		phase, _ := dp.Attributes().Get("phase")
+		out = append(out, phase.StringVal())
+	}
+	return out
+}
+
+// checkErrorType returns the error type of the failed check of the endpoint.
+func checkErrorType(t *testing.T, md pmetric.Metrics, endpoint string) string {
+	dps := dataPoints(md, "httpcheck.error", endpoint)
+	require.Len(t, dps, 1)
+	errType, ok := dps[0].Attributes().Get("error.type")
+	require.True(t, ok)
+	return errType.StringVal()
+}
+
+func newTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/health":
+			_, _ = rw.Write([]byte(`{"status": "ok"}`))
+		case "/degraded":
+			_, _ = rw.Write([]byte(`{"status": "degraded"}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestScrapeHTTP(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []TargetConfig{
+		{Endpoint: server.URL + "/health", BodyRegex: `"status":\s*"ok"`},
+		{Endpoint: server.URL + "/degraded", BodyRegex: `"status":\s*"ok"`},
+		{Endpoint: server.URL + "/missing", Method: "head"},
+	}
+	scraper := newTestScraper(t, cfg)
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	healthy := server.URL + "/health"
+	status := dataPoints(md, "httpcheck.status", healthy)
+	require.Len(t, status, 1)
+	assert.Equal(t, int64(1), status[0].IntVal())
+	assert.Empty(t, dataPoints(md, "httpcheck.error", healthy))
+	assert.Equal(t, []string{"connect", "ttfb", "total"}, phases(dataPoints(md, "httpcheck.duration", healthy)))
+
+	statusCode := dataPoints(md, "httpcheck.response.status_code", healthy)
+	require.Len(t, statusCode, 1)
+	code, _ := statusCode[0].Attributes().Get("http.status_code")
+	assert.Equal(t, int64(http.StatusOK), code.IntVal())
+	method, _ := statusCode[0].Attributes().Get("http.method")
+	assert.Equal(t, http.MethodGet, method.StringVal())
+
+	bodyMatch := dataPoints(md, "httpcheck.body.match", healthy)
+	require.Len(t, bodyMatch, 1)
+	assert.Equal(t, int64(1), bodyMatch[0].IntVal())
+	assert.Empty(t, dataPoints(md, "httpcheck.tls.certificate.time_left", healthy))
+
+	degraded := server.URL + "/degraded"
+	assert.Equal(t, int64(0), dataPoints(md, "httpcheck.status", degraded)[0].IntVal())
+	assert.Equal(t, int64(0), dataPoints(md, "httpcheck.body.match", degraded)[0].IntVal())
+	assert.Equal(t, "body_mismatch", checkErrorType(t, md, degraded))
+
+	missing := server.URL + "/missing"
+	assert.Equal(t, int64(0), dataPoints(md, "httpcheck.status", missing)[0].IntVal())
+	statusCode = dataPoints(md, "httpcheck.response.status_code", missing)
+	require.Len(t, statusCode, 1)
+	code, _ = statusCode[0].Attributes().Get("http.status_code")
+	assert.Equal(t, int64(http.StatusNotFound), code.IntVal())
+	method, _ = statusCode[0].Attributes().Get("http.method")
+	assert.Equal(t, http.MethodHead, method.StringVal())
+	assert.Empty(t, dataPoints(md, "httpcheck.body.match", missing))
+	assert.Equal(t, "status", checkErrorType(t, md, missing))
+}
+
+func TestScrapeHTTPS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.TLSSetting = configtls.TLSClientSetting{InsecureSkipVerify: true}
+	scraper := newTestScraper(t, cfg)
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), dataPoints(md, "httpcheck.status", server.URL)[0].IntVal())
+	assert.Equal(t, []string{"connect", "tls", "ttfb", "total"}, phases(dataPoints(md, "httpcheck.duration", server.URL)))
+
+	timeLeft := dataPoints(md, "httpcheck.tls.certificate.time_left", server.URL)
+	require.Len(t, timeLeft, 1)
+	expected := time.Until(server.Certificate().NotAfter).Seconds()
+	assert.InDelta(t, expected, float64(timeLeft[0].IntVal()), 60)
+}
+
+func TestScrapeHTTPSUnknownAuthority(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	scraper := newTestScraper(t, cfg)
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(0), dataPoints(md, "httpcheck.status", server.URL)[0].IntVal())
+	assert.Equal(t, "tls", checkErrorType(t, md, server.URL))
+}
+
+func TestScrapeUnresolvableHost(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []TargetConfig{{Endpoint: "http://nonexistent.invalid"}, {Endpoint: "tcp://nonexistent.invalid:80"}}
+	scraper := newTestScraper(t, cfg)
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	for _, target := range cfg.Targets {
+		assert.Equal(t, int64(0), dataPoints(md, "httpcheck.status", target.Endpoint)[0].IntVal())
+		assert.Equal(t, "dns", checkErrorType(t, md, target.Endpoint))
+	}
+}
+
+func TestScrapeEndpointWithoutScheme(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.Listener.Addr().String()
+	scraper := newTestScraper(t, cfg)
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	// The root of the test server returns 404.
+	statusCode := dataPoints(md, "httpcheck.response.status_code", "http://"+cfg.Endpoint)
+	require.Len(t, statusCode, 1)
+	code, _ := statusCode[0].Attributes().Get("http.status_code")
+	assert.Equal(t, int64(http.StatusNotFound), code.IntVal())
+}
+
+func TestScrapeTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	// Reserve a port nothing listens on.
+	closed, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	require.NoError(t, closed.Close())
+
+	open := "tcp://" + listener.Addr().String()
+	refused := "tcp://" + closed.Addr().String()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []TargetConfig{{Endpoint: open}, {Endpoint: refused}}
+	scraper := newTestScraper(t, cfg)
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, listener.Close())
+
+	assert.Equal(t, int64(1), dataPoints(md, "httpcheck.status", open)[0].IntVal())
+	assert.Equal(t, []string{"dns", "connect", "total"}, phases(dataPoints(md, "httpcheck.duration", open)))
+	assert.Empty(t, dataPoints(md, "httpcheck.response.status_code", open))
+
+	assert.Equal(t, int64(0), dataPoints(md, "httpcheck.status", refused)[0].IntVal())
+	assert.Equal(t, []string{"dns"}, phases(dataPoints(md, "httpcheck.duration", refused)))
+	assert.Equal(t, "connect", checkErrorType(t, md, refused))
+}
+
+func TestScrapeTimeout(t *testing.T) {
+	block := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		<-block
+	}))
+	defer server.Close()
+	defer close(block)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.Timeout = 50 * time.Millisecond
+	scraper := newTestScraper(t, cfg)
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(0), dataPoints(md, "httpcheck.status", server.URL)[0].IntVal())
+	assert.Equal(t, "timeout", checkErrorType(t, md, server.URL))
+	assert.Empty(t, dataPoints(md, "httpcheck.response.status_code", server.URL))
+}
+
+func TestScraperFailedStart(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:8080"
+	cfg.TLSSetting = configtls.TLSClientSetting{
+		TLSSetting: configtls.TLSSetting{
+			CAFile: "/non/existent",
+		},
+	}
+	scraper, err := newHTTPCheckScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.Error(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httplogreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/service/servicetest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	httpinput "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/http"
+)
+
+func TestHTTP(t *testing.T) {
+	numLogs := 5
+
+	f := NewFactory()
+	sink := new(consumertest.LogsSink)
+	rcvr, err := f.CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), testdataConfigYaml(), sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+
+	var body strings.Builder
+	for i := 0; i < numLogs; i++ {
+		fmt.Fprintf(&body, "<86>1 2021-02-28T00:0%d:02.003Z test msg %d\n", i, i)
+	}
+	resp, err := http.Post("http://localhost:29019/logs", "text/plain", strings.NewReader(body.String()))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.Eventually(t, expectNLogs(sink, numLogs), 2*time.Second, time.Millisecond)
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+	require.Len(t, sink.AllLogs(), 1)
+
+	resourceLogs := sink.AllLogs()[0].ResourceLogs().At(0)
+	logs := resourceLogs.ScopeLogs().At(0).LogRecords()
+
+	for i := 0; i < numLogs; i++ {
+		log := logs.At(i)
+
+		msg := log.Body()
+		require.Equal(t, msg.StringVal(), fmt.Sprintf("<86>1 2021-02-28T00:0%d:02.003Z test msg %d", i, i))
+	}
+}
+
+type authHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h authHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func TestHTTPAuth(t *testing.T) {
+	authID := config.NewComponentID("testauth")
+	cfg := testdataConfigYaml()
+	cfg.InputConfig.Endpoint = "localhost:29020"
+	cfg.InputConfig.Auth = &configauth.Authentication{AuthenticatorID: authID}
+
+	host := authHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{
+			authID: configauth.NewServerAuthenticator(
+				configauth.WithAuthenticate(func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+					if len(headers["Authorization"]) == 0 {
+						return ctx, errors.New("missing authorization header")
+					}
+					return ctx, nil
+				}),
+			),
+		},
+	}
+
+	f := NewFactory()
+	sink := new(consumertest.LogsSink)
+	rcvr, err := f.CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, rcvr.Shutdown(context.Background()))
+	}()
+
+	resp, err := http.Post("http://localhost:29020/logs", "text/plain", strings.NewReader("unauthorized"))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodPost, "http://localhost:29020/logs", strings.NewReader("authorized"))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.Eventually(t, expectNLogs(sink, 1), 2*time.Second, time.Millisecond)
+	log := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	require.Equal(t, "authorized", log.Body().StringVal())
+}
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 1)
+	assert.Equal(t, testdataConfigYaml(), cfg.Receivers[config.NewComponentID(typeStr)])
+}
+
+func testdataConfigYaml() *HTTPLogConfig {
+	return &HTTPLogConfig{
+		BaseConfig: adapter.BaseConfig{
+			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+			Operators:        adapter.OperatorConfigs{},
+			Converter: adapter.ConverterConfig{
+				WorkerCount: 1,
+			},
+		},
+		InputConfig: func() httpinput.Config {
+			c := httpinput.NewConfig()
+			c.Endpoint = "0.0.0.0:29019"
+			c.Path = "/logs"
+			return *c
+		}(),
+	}
+}
+
+func TestDecodeInputConfigFailure(t *testing.T) {
+	factory := NewFactory()
+	badCfg := &HTTPLogConfig{
+		BaseConfig: adapter.BaseConfig{
+			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+			Operators:        adapter.OperatorConfigs{},
+		},
+		InputConfig: func() httpinput.Config {
+			c := httpinput.NewConfig()
+			c.Encoding.Encoding = "fake"
+			return *c
+		}(),
+	}
+	receiver, err := factory.CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), badCfg, consumertest.NewNop())
+	require.Error(t, err, "receiver creation should fail if input config isn't valid")
+	require.Nil(t, receiver, "receiver creation should fail if input config isn't valid")
+}
+
+func expectNLogs(sink *consumertest.LogsSink, expected int) func() bool {
+	return func() bool {
+		return sink.LogRecordCount() == expected
+	}
+}
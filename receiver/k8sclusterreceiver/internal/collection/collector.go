@@ -27,6 +27,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -51,12 +52,20 @@ const (
 	k8sKeyHPAUID                   = "k8s.hpa.uid"
 	k8sKeyResourceQuotaUID         = "k8s.resourcequota.uid"
 	k8sKeyClusterResourceQuotaUID  = "openshift.clusterquota.uid"
+	k8sKeyPersistentVolumeUID      = "k8s.persistentvolume.uid"
+	k8sKeyPersistentVolumeClaimUID = "k8s.persistentvolumeclaim.uid"
+	k8sKeyServiceUID               = "k8s.service.uid"
+	k8sKeyIngressUID               = "k8s.ingress.uid"
 
 	// Resource labels keys for Name.
 	k8sKeyReplicationControllerName = "k8s.replicationcontroller.name"
 	k8sKeyHPAName                   = "k8s.hpa.name"
 	k8sKeyResourceQuotaName         = "k8s.resourcequota.name"
 	k8sKeyClusterResourceQuotaName  = "openshift.clusterquota.name"
+	k8sKeyPersistentVolumeName      = "k8s.persistentvolume.name"
+	k8sKeyPersistentVolumeClaimName = "k8s.persistentvolumeclaim.name"
+	k8sKeyServiceName               = "k8s.service.name"
+	k8sKeyIngressName               = "k8s.ingress.name"
 
 	// Kubernetes resource kinds
 	k8sKindCronJob               = "CronJob"
@@ -137,6 +146,10 @@ func (dc *DataCollector) SyncMetrics(obj interface{}) {
 		rm = getMetricsForReplicationController(o)
 	case *corev1.ResourceQuota:
 		rm = getMetricsForResourceQuota(o)
+	case *corev1.PersistentVolume:
+		rm = getMetricsForPersistentVolume(o)
+	case *corev1.PersistentVolumeClaim:
+		rm = getMetricsForPersistentVolumeClaim(o)
 	case *appsv1.Deployment:
 		rm = getMetricsForDeployment(o)
 	case *appsv1.ReplicaSet:
@@ -176,6 +189,14 @@ func (dc *DataCollector) SyncMetadata(obj interface{}) map[metadata.ResourceID]*
 		km = getMetadataForNode(o)
 	case *corev1.ReplicationController:
 		km = getMetadataForReplicationController(o)
+	case *corev1.PersistentVolume:
+		km = getMetadataForPersistentVolume(o)
+	case *corev1.PersistentVolumeClaim:
+		km = getMetadataForPersistentVolumeClaim(o, dc.metadataStore, dc.logger)
+	case *corev1.Service:
+		km = getMetadataForService(o)
+	case *networkingv1.Ingress:
+		km = getMetadataForIngress(o)
 	case *appsv1.Deployment:
 		km = getMetadataForDeployment(o)
 	case *appsv1.ReplicaSet:
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/collection"
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/maps"
+	metadataPkg "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+)
+
+const (
+	// Keys for ingress metadata.
+	ingressCreationTime = "ingress.creation_timestamp"
+	k8sKeyIngressClass  = "k8s.ingress.class"
+	k8sKeyIngressHosts  = "k8s.ingress.hosts"
+
+	k8sIngressPrefix = "k8s.ingress."
+)
+
+// getMetadataForIngress returns all metadata associated with the ingress,
+// including the services it routes traffic to.
+func getMetadataForIngress(ing *networkingv1.Ingress) map[metadataPkg.ResourceID]*KubernetesMetadata {
+	metadata := maps.MergeStringMaps(map[string]string{}, ing.Labels)
+
+	metadata[k8sKeyIngressName] = ing.Name
+	metadata[conventions.AttributeK8SNamespaceName] = ing.Namespace
+	metadata[ingressCreationTime] = ing.GetCreationTimestamp().Format(time.RFC3339)
+	if ing.Spec.IngressClassName != nil {
+		metadata[k8sKeyIngressClass] = *ing.Spec.IngressClassName
+	}
+	if hosts := getIngressHosts(ing); len(hosts) > 0 {
+		metadata[k8sKeyIngressHosts] = strings.Join(hosts, ",")
+	}
+	for _, svc := range getIngressBackendServices(ing) {
+		metadata[fmt.Sprintf("%s%s", k8sServicePrefix, svc)] = ""
+	}
+
+	ingID := metadataPkg.ResourceID(ing.UID)
+	return map[metadataPkg.ResourceID]*KubernetesMetadata{
+		ingID: {
+			resourceIDKey: k8sKeyIngressUID,
+			resourceID:    ingID,
+			metadata:      metadata,
+		},
+	}
+}
+
+// getIngressHosts returns the sorted hosts of the rules of the ingress.
+func getIngressHosts(ing *networkingv1.Ingress) []string {
+	var hosts []string
+	seen := map[string]bool{}
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host != "" && !seen[rule.Host] {
+			seen[rule.Host] = true
+			hosts = append(hosts, rule.Host)
+		}
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// getIngressBackendServices returns the names of the services the ingress routes traffic to.
+func getIngressBackendServices(ing *networkingv1.Ingress) []string {
+	var services []string
+	seen := map[string]bool{}
+	add := func(backend *networkingv1.IngressBackend) {
+		if backend == nil || backend.Service == nil || seen[backend.Service.Name] {
+			return
+		}
+		seen[backend.Service.Name] = true
+		services = append(services, backend.Service.Name)
+	}
+
+	add(ing.Spec.DefaultBackend)
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			add(&rule.HTTP.Paths[i].Backend)
+		}
+	}
+	return services
+}
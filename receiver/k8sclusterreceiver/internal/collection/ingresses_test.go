@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	metadata "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+)
+
+func TestIngressMetadata(t *testing.T) {
+	ing := newIngress("1")
+
+	actualMetadata := getMetadataForIngress(ing)
+
+	require.Equal(t, 1, len(actualMetadata))
+	rm := actualMetadata[metadata.ResourceID("test-ingress-1-uid")]
+	require.NotNil(t, rm)
+	assert.Equal(t, "k8s.ingress.uid", rm.resourceIDKey)
+	assert.Equal(t, map[string]string{
+		"foo":                        "bar",
+		"k8s.ingress.name":           "test-ingress-1",
+		"k8s.namespace.name":         "test-namespace",
+		"ingress.creation_timestamp": "0001-01-01T00:00:00Z",
+		"k8s.ingress.class":          "nginx",
+		"k8s.ingress.hosts":          "a.example.com,b.example.com",
+		"k8s.service.default":        "",
+		"k8s.service.test-service-1": "",
+	}, rm.metadata)
+}
+
+func newIngress(id string) *networkingv1.Ingress {
+	class := "nginx"
+	backend := func(service string) networkingv1.IngressBackend {
+		return networkingv1.IngressBackend{
+			Service: &networkingv1.IngressServiceBackend{Name: service},
+		}
+	}
+	defaultBackend := backend("default")
+	return &networkingv1.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test-ingress-" + id,
+			Namespace: "test-namespace",
+			UID:       types.UID("test-ingress-" + id + "-uid"),
+			Labels: map[string]string{
+				"foo": "bar",
+			},
+			CreationTimestamp: v1.NewTime(time.Time{}),
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: &class,
+			DefaultBackend:   &defaultBackend,
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: "b.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{Path: "/", Backend: backend("test-service-" + id)},
+								{Path: "/api", Backend: backend("test-service-" + id)},
+							},
+						},
+					},
+				},
+				{
+					Host: "a.example.com",
+				},
+			},
+		},
+	}
+}
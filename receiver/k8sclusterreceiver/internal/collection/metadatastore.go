@@ -22,15 +22,17 @@ import (
 )
 
 // metadataStore keeps track of required caches exposed by informers.
-// This store is used while collecting metadata about Pods to be able
-// to correlate other Kubernetes objects with a Pod.
+// This store is used while collecting metadata about Pods, Services and
+// PersistentVolumeClaims to be able to correlate other Kubernetes objects
+// with them.
 type metadataStore struct {
-	services    cache.Store
-	jobs        cache.Store
-	replicaSets cache.Store
+	services          cache.Store
+	jobs              cache.Store
+	replicaSets       cache.Store
+	persistentVolumes cache.Store
 }
 
-// setupStore tracks metadata of services, jobs and replicasets.
+// setupStore tracks metadata of services, jobs, replicasets and persistentvolumes.
 func (ms *metadataStore) setupStore(kind schema.GroupVersionKind, store cache.Store) {
 	switch kind {
 	case gvk.Service:
@@ -39,5 +41,7 @@ func (ms *metadataStore) setupStore(kind schema.GroupVersionKind, store cache.St
 		ms.jobs = store
 	case gvk.ReplicaSet:
 		ms.replicaSets = store
+	case gvk.PersistentVolume:
+		ms.persistentVolumes = store
 	}
 }
@@ -33,6 +33,9 @@ import (
 const (
 	// Keys for node metadata.
 	nodeCreationTime = "node.creation_timestamp"
+
+	// allNodeConditions reports every condition type set on the node.
+	allNodeConditions = "*"
 )
 
 var allocatableDesciption = map[string]string{
@@ -43,6 +46,7 @@ var allocatableDesciption = map[string]string{
 }
 
 func getMetricsForNode(node *corev1.Node, nodeConditionTypesToReport, allocatableTypesToReport []string, logger *zap.Logger) []*resourceMetrics {
+	nodeConditionTypesToReport = getNodeConditionTypes(node, nodeConditionTypesToReport)
 	metrics := make([]*metricspb.Metric, 0, len(nodeConditionTypesToReport)+len(allocatableTypesToReport))
 	// Adding 'node condition type' metrics
 	for _, nodeConditionTypeValue := range nodeConditionTypesToReport {
@@ -99,6 +103,30 @@ func getMetricsForNode(node *corev1.Node, nodeConditionTypesToReport, allocatabl
 	}
 }
 
+// getNodeConditionTypes returns the condition types to report for the node,
+// replacing allNodeConditions by the condition types set on the node.
+func getNodeConditionTypes(node *corev1.Node, nodeConditionTypesToReport []string) []string {
+	out := make([]string, 0, len(nodeConditionTypesToReport))
+	seen := map[string]bool{}
+	add := func(conditionType string) {
+		if !seen[conditionType] {
+			seen[conditionType] = true
+			out = append(out, conditionType)
+		}
+	}
+
+	for _, conditionType := range nodeConditionTypesToReport {
+		if conditionType != allNodeConditions {
+			add(conditionType)
+			continue
+		}
+		for _, c := range node.Status.Conditions {
+			add(string(c.Type))
+		}
+	}
+	return out
+}
+
 func getNodeConditionMetric(nodeConditionTypeValue string) string {
 	return fmt.Sprintf("k8s.node.condition_%s", strcase.ToSnake(nodeConditionTypeValue))
 }
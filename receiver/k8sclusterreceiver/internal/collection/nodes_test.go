@@ -59,6 +59,32 @@ func TestNodeMetricsReportCPUMetrics(t *testing.T) {
 		metricspb.MetricDescriptor_GAUGE_INT64, 1234)
 }
 
+func TestNodeMetricsReportAllConditions(t *testing.T) {
+	n := newNode("1")
+	n.Status.Conditions = append(n.Status.Conditions, corev1.NodeCondition{
+		Type:   corev1.NodeDiskPressure,
+		Status: corev1.ConditionTrue,
+	})
+
+	actualResourceMetrics := getMetricsForNode(n, []string{"Ready", "*", "PIDPressure"}, []string{}, zap.NewNop())
+
+	require.Equal(t, 1, len(actualResourceMetrics))
+	require.Equal(t, 4, len(actualResourceMetrics[0].metrics))
+
+	testutils.AssertMetricsInt(t, actualResourceMetrics[0].metrics[0], "k8s.node.condition_ready",
+		metricspb.MetricDescriptor_GAUGE_INT64, 1)
+
+	testutils.AssertMetricsInt(t, actualResourceMetrics[0].metrics[1], "k8s.node.condition_memory_pressure",
+		metricspb.MetricDescriptor_GAUGE_INT64, 0)
+
+	testutils.AssertMetricsInt(t, actualResourceMetrics[0].metrics[2], "k8s.node.condition_disk_pressure",
+		metricspb.MetricDescriptor_GAUGE_INT64, 1)
+
+	// Conditions not set on the node are reported as unknown.
+	testutils.AssertMetricsInt(t, actualResourceMetrics[0].metrics[3], "k8s.node.condition_pid_pressure",
+		metricspb.MetricDescriptor_GAUGE_INT64, -1)
+}
+
 func newNode(id string) *corev1.Node {
 	return &corev1.Node{
 		ObjectMeta: v1.ObjectMeta{
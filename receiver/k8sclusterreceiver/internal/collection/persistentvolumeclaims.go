@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/collection"
+
+import (
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/maps"
+	metadataPkg "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/utils"
+)
+
+const (
+	// Keys for persistent volume claim metadata.
+	persistentVolumeClaimCreationTime = "persistentvolumeclaim.creation_timestamp"
+)
+
+var persistentVolumeClaimCapacityMetric = &metricspb.MetricDescriptor{
+	Name:        "k8s.persistentvolumeclaim.capacity",
+	Description: "The capacity of the volume bound to the persistent volume claim",
+	Unit:        "By",
+	Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+}
+
+var persistentVolumeClaimRequestMetric = &metricspb.MetricDescriptor{
+	Name:        "k8s.persistentvolumeclaim.request",
+	Description: "The storage requested by the persistent volume claim",
+	Unit:        "By",
+	Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+}
+
+var persistentVolumeClaimPhaseMetric = &metricspb.MetricDescriptor{
+	Name:        "k8s.persistentvolumeclaim.phase",
+	Description: "Current phase of the persistent volume claim (1 - Pending, 2 - Bound, 3 - Lost)",
+	Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+}
+
+func getMetricsForPersistentVolumeClaim(pvc *corev1.PersistentVolumeClaim) []*resourceMetrics {
+	metrics := []*metricspb.Metric{
+		{
+			MetricDescriptor: persistentVolumeClaimPhaseMetric,
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetInt64TimeSeries(int64(persistentVolumeClaimPhaseValues[pvc.Status.Phase])),
+			},
+		},
+	}
+
+	if request, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		metrics = append(metrics, &metricspb.Metric{
+			MetricDescriptor: persistentVolumeClaimRequestMetric,
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetInt64TimeSeries(request.Value()),
+			},
+		})
+	}
+
+	// The capacity is only known once the claim is bound to a volume.
+	if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+		metrics = append(metrics, &metricspb.Metric{
+			MetricDescriptor: persistentVolumeClaimCapacityMetric,
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetInt64TimeSeries(capacity.Value()),
+			},
+		})
+	}
+
+	return []*resourceMetrics{
+		{
+			resource: getResourceForPersistentVolumeClaim(pvc),
+			metrics:  metrics,
+		},
+	}
+}
+
+func getResourceForPersistentVolumeClaim(pvc *corev1.PersistentVolumeClaim) *resourcepb.Resource {
+	return &resourcepb.Resource{
+		Type: k8sType,
+		Labels: map[string]string{
+			k8sKeyPersistentVolumeClaimUID:        string(pvc.UID),
+			k8sKeyPersistentVolumeClaimName:       pvc.Name,
+			conventions.AttributeK8SNamespaceName: pvc.Namespace,
+		},
+	}
+}
+
+var persistentVolumeClaimPhaseValues = map[corev1.PersistentVolumeClaimPhase]int32{
+	corev1.ClaimPending: 1,
+	corev1.ClaimBound:   2,
+	corev1.ClaimLost:    3,
+}
+
+// getMetadataForPersistentVolumeClaim returns all metadata associated with the
+// persistent volume claim, including the volume it is bound to.
+func getMetadataForPersistentVolumeClaim(pvc *corev1.PersistentVolumeClaim, mc *metadataStore, logger *zap.Logger) map[metadataPkg.ResourceID]*KubernetesMetadata {
+	metadata := maps.MergeStringMaps(map[string]string{}, pvc.Labels)
+
+	metadata[k8sKeyPersistentVolumeClaimName] = pvc.Name
+	metadata[persistentVolumeClaimCreationTime] = pvc.GetCreationTimestamp().Format(time.RFC3339)
+	if pvc.Spec.StorageClassName != nil {
+		metadata[k8sKeyStorageClassName] = *pvc.Spec.StorageClassName
+	}
+	if pvc.Spec.VolumeName != "" {
+		metadata[k8sKeyPersistentVolumeName] = pvc.Spec.VolumeName
+	}
+
+	if mc.persistentVolumes != nil {
+		metadata = maps.MergeStringMaps(metadata,
+			collectPersistentVolumeClaimVolumeProperties(pvc, mc.persistentVolumes, logger),
+		)
+	}
+
+	pvcID := metadataPkg.ResourceID(pvc.UID)
+	return map[metadataPkg.ResourceID]*KubernetesMetadata{
+		pvcID: {
+			resourceIDKey: k8sKeyPersistentVolumeClaimUID,
+			resourceID:    pvcID,
+			metadata:      metadata,
+		},
+	}
+}
+
+// collectPersistentVolumeClaimVolumeProperties returns the properties of the
+// persistent volume bound to the claim, if it is cached.
+func collectPersistentVolumeClaimVolumeProperties(pvc *corev1.PersistentVolumeClaim, pvStore cache.Store, logger *zap.Logger) map[string]string {
+	if pvc.Spec.VolumeName == "" {
+		return nil
+	}
+
+	obj, exists, err := pvStore.GetByKey(pvc.Spec.VolumeName)
+	if err != nil {
+		logger.Error(
+			"Failed to get persistent volume from store, properties from it will not be synced.",
+			zap.String(k8sKeyPersistentVolumeClaimUID, string(pvc.UID)),
+			zap.String(k8sKeyPersistentVolumeName, pvc.Spec.VolumeName),
+			zap.Error(err),
+		)
+		return nil
+	} else if !exists {
+		logger.Debug(
+			"Persistent volume does not exist in store, properties from it will not be synced.",
+			zap.String(k8sKeyPersistentVolumeClaimUID, string(pvc.UID)),
+			zap.String(k8sKeyPersistentVolumeName, pvc.Spec.VolumeName),
+		)
+		return nil
+	}
+
+	pv := obj.(*corev1.PersistentVolume)
+	return map[string]string{
+		k8sKeyPersistentVolumeUID:     string(pv.UID),
+		k8sKeyPersistentVolumeReclaim: string(pv.Spec.PersistentVolumeReclaimPolicy),
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	"testing"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	metadata "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/testutils"
+)
+
+func TestPersistentVolumeClaimMetrics(t *testing.T) {
+	pvc := newPersistentVolumeClaim("1")
+
+	actualResourceMetrics := getMetricsForPersistentVolumeClaim(pvc)
+
+	require.Equal(t, 1, len(actualResourceMetrics))
+
+	require.Equal(t, 3, len(actualResourceMetrics[0].metrics))
+	testutils.AssertResource(t, actualResourceMetrics[0].resource, k8sType,
+		map[string]string{
+			"k8s.persistentvolumeclaim.uid":  "test-persistentvolumeclaim-1-uid",
+			"k8s.persistentvolumeclaim.name": "test-persistentvolumeclaim-1",
+			"k8s.namespace.name":             "test-namespace",
+		},
+	)
+
+	testutils.AssertMetricsInt(t, actualResourceMetrics[0].metrics[0], "k8s.persistentvolumeclaim.phase",
+		metricspb.MetricDescriptor_GAUGE_INT64, 2)
+
+	testutils.AssertMetricsInt(t, actualResourceMetrics[0].metrics[1], "k8s.persistentvolumeclaim.request",
+		metricspb.MetricDescriptor_GAUGE_INT64, 5*1024*1024*1024)
+
+	testutils.AssertMetricsInt(t, actualResourceMetrics[0].metrics[2], "k8s.persistentvolumeclaim.capacity",
+		metricspb.MetricDescriptor_GAUGE_INT64, 10*1024*1024*1024)
+
+	// Test a pending claim, which is not bound to a volume yet.
+	pvc.Status = corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending}
+	actualResourceMetrics = getMetricsForPersistentVolumeClaim(pvc)
+	require.Equal(t, 2, len(actualResourceMetrics[0].metrics))
+	testutils.AssertMetricsInt(t, actualResourceMetrics[0].metrics[0], "k8s.persistentvolumeclaim.phase",
+		metricspb.MetricDescriptor_GAUGE_INT64, 1)
+}
+
+func TestPersistentVolumeClaimMetadata(t *testing.T) {
+	pvc := newPersistentVolumeClaim("1")
+	expectedMetadata := map[string]string{
+		"foo":                            "bar",
+		"k8s.persistentvolumeclaim.name": "test-persistentvolumeclaim-1",
+		"persistentvolumeclaim.creation_timestamp": "0001-01-01T00:00:00Z",
+		"k8s.storageclass.name":                    "standard",
+		"k8s.persistentvolume.name":                "test-persistentvolume-1",
+	}
+
+	tests := []struct {
+		name          string
+		store         *metadataStore
+		extraMetadata map[string]string
+	}{
+		{
+			name:  "without_cache",
+			store: &metadataStore{},
+		},
+		{
+			name: "volume_not_cached",
+			store: &metadataStore{
+				persistentVolumes: &testutils.MockStore{Cache: map[string]interface{}{}},
+			},
+		},
+		{
+			name: "cache_error",
+			store: &metadataStore{
+				persistentVolumes: &testutils.MockStore{Cache: map[string]interface{}{}, WantErr: true},
+			},
+		},
+		{
+			name: "volume_cached",
+			store: &metadataStore{
+				persistentVolumes: &testutils.MockStore{Cache: map[string]interface{}{
+					"test-persistentvolume-1": newPersistentVolume("1"),
+				}},
+			},
+			extraMetadata: map[string]string{
+				"k8s.persistentvolume.uid":            "test-persistentvolume-1-uid",
+				"k8s.persistentvolume.reclaim_policy": "Retain",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualMetadata := getMetadataForPersistentVolumeClaim(pvc, tt.store, zap.NewNop())
+
+			require.Equal(t, 1, len(actualMetadata))
+			rm := actualMetadata[metadata.ResourceID("test-persistentvolumeclaim-1-uid")]
+			require.NotNil(t, rm)
+			assert.Equal(t, "k8s.persistentvolumeclaim.uid", rm.resourceIDKey)
+
+			expected := map[string]string{}
+			for k, v := range expectedMetadata {
+				expected[k] = v
+			}
+			for k, v := range tt.extraMetadata {
+				expected[k] = v
+			}
+			assert.Equal(t, expected, rm.metadata)
+		})
+	}
+}
+
+func newPersistentVolumeClaim(id string) *corev1.PersistentVolumeClaim {
+	storageClass := "standard"
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test-persistentvolumeclaim-" + id,
+			Namespace: "test-namespace",
+			UID:       types.UID("test-persistentvolumeclaim-" + id + "-uid"),
+			Labels: map[string]string{
+				"foo": "bar",
+			},
+			CreationTimestamp: v1.NewTime(time.Time{}),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: &storageClass,
+			VolumeName:       "test-persistentvolume-" + id,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("5Gi"),
+				},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase: corev1.ClaimBound,
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("10Gi"),
+			},
+		},
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/collection"
+
+import (
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/maps"
+	metadataPkg "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/utils"
+)
+
+const (
+	// Keys for persistent volume metadata.
+	persistentVolumeCreationTime  = "persistentvolume.creation_timestamp"
+	k8sKeyPersistentVolumeReclaim = "k8s.persistentvolume.reclaim_policy"
+	k8sKeyStorageClassName        = "k8s.storageclass.name"
+)
+
+var persistentVolumeCapacityMetric = &metricspb.MetricDescriptor{
+	Name:        "k8s.persistentvolume.capacity",
+	Description: "The capacity of the persistent volume",
+	Unit:        "By",
+	Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+}
+
+var persistentVolumePhaseMetric = &metricspb.MetricDescriptor{
+	Name:        "k8s.persistentvolume.phase",
+	Description: "Current phase of the persistent volume (1 - Pending, 2 - Available, 3 - Bound, 4 - Released, 5 - Failed)",
+	Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+}
+
+func getMetricsForPersistentVolume(pv *corev1.PersistentVolume) []*resourceMetrics {
+	metrics := []*metricspb.Metric{
+		{
+			MetricDescriptor: persistentVolumePhaseMetric,
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetInt64TimeSeries(int64(persistentVolumePhaseValues[pv.Status.Phase])),
+			},
+		},
+	}
+
+	if capacity, ok := pv.Spec.Capacity[corev1.ResourceStorage]; ok {
+		metrics = append(metrics, &metricspb.Metric{
+			MetricDescriptor: persistentVolumeCapacityMetric,
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetInt64TimeSeries(capacity.Value()),
+			},
+		})
+	}
+
+	return []*resourceMetrics{
+		{
+			resource: getResourceForPersistentVolume(pv),
+			metrics:  metrics,
+		},
+	}
+}
+
+func getResourceForPersistentVolume(pv *corev1.PersistentVolume) *resourcepb.Resource {
+	return &resourcepb.Resource{
+		Type: k8sType,
+		Labels: map[string]string{
+			k8sKeyPersistentVolumeUID:  string(pv.UID),
+			k8sKeyPersistentVolumeName: pv.Name,
+		},
+	}
+}
+
+var persistentVolumePhaseValues = map[corev1.PersistentVolumePhase]int32{
+	corev1.VolumePending:   1,
+	corev1.VolumeAvailable: 2,
+	corev1.VolumeBound:     3,
+	corev1.VolumeReleased:  4,
+	corev1.VolumeFailed:    5,
+}
+
+func getMetadataForPersistentVolume(pv *corev1.PersistentVolume) map[metadataPkg.ResourceID]*KubernetesMetadata {
+	metadata := maps.MergeStringMaps(map[string]string{}, pv.Labels)
+
+	metadata[k8sKeyPersistentVolumeName] = pv.Name
+	metadata[persistentVolumeCreationTime] = pv.GetCreationTimestamp().Format(time.RFC3339)
+	metadata[k8sKeyPersistentVolumeReclaim] = string(pv.Spec.PersistentVolumeReclaimPolicy)
+	if pv.Spec.StorageClassName != "" {
+		metadata[k8sKeyStorageClassName] = pv.Spec.StorageClassName
+	}
+	if claim := pv.Spec.ClaimRef; claim != nil {
+		metadata[k8sKeyPersistentVolumeClaimName] = claim.Name
+		metadata[conventions.AttributeK8SNamespaceName] = claim.Namespace
+	}
+
+	pvID := metadataPkg.ResourceID(pv.UID)
+	return map[metadataPkg.ResourceID]*KubernetesMetadata{
+		pvID: {
+			resourceIDKey: k8sKeyPersistentVolumeUID,
+			resourceID:    pvID,
+			metadata:      metadata,
+		},
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	"testing"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	metadata "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/testutils"
+)
+
+func TestPersistentVolumeMetrics(t *testing.T) {
+	pv := newPersistentVolume("1")
+
+	actualResourceMetrics := getMetricsForPersistentVolume(pv)
+
+	require.Equal(t, 1, len(actualResourceMetrics))
+
+	require.Equal(t, 2, len(actualResourceMetrics[0].metrics))
+	testutils.AssertResource(t, actualResourceMetrics[0].resource, k8sType,
+		map[string]string{
+			"k8s.persistentvolume.uid":  "test-persistentvolume-1-uid",
+			"k8s.persistentvolume.name": "test-persistentvolume-1",
+		},
+	)
+
+	testutils.AssertMetricsInt(t, actualResourceMetrics[0].metrics[0], "k8s.persistentvolume.phase",
+		metricspb.MetricDescriptor_GAUGE_INT64, 3)
+
+	testutils.AssertMetricsInt(t, actualResourceMetrics[0].metrics[1], "k8s.persistentvolume.capacity",
+		metricspb.MetricDescriptor_GAUGE_INT64, 10*1024*1024*1024)
+
+	// Test without capacity.
+	pv.Spec.Capacity = nil
+	pv.Status.Phase = corev1.VolumeReleased
+	actualResourceMetrics = getMetricsForPersistentVolume(pv)
+	require.Equal(t, 1, len(actualResourceMetrics[0].metrics))
+	testutils.AssertMetricsInt(t, actualResourceMetrics[0].metrics[0], "k8s.persistentvolume.phase",
+		metricspb.MetricDescriptor_GAUGE_INT64, 4)
+}
+
+func TestPersistentVolumeMetadata(t *testing.T) {
+	pv := newPersistentVolume("1")
+
+	actualMetadata := getMetadataForPersistentVolume(pv)
+
+	require.Equal(t, 1, len(actualMetadata))
+	rm := actualMetadata[metadata.ResourceID("test-persistentvolume-1-uid")]
+	require.NotNil(t, rm)
+	assert.Equal(t, "k8s.persistentvolume.uid", rm.resourceIDKey)
+	assert.Equal(t, map[string]string{
+		"foo":                                 "bar",
+		"k8s.persistentvolume.name":           "test-persistentvolume-1",
+		"persistentvolume.creation_timestamp": "0001-01-01T00:00:00Z",
+		"k8s.persistentvolume.reclaim_policy": "Retain",
+		"k8s.storageclass.name":               "standard",
+		"k8s.persistentvolumeclaim.name":      "test-persistentvolumeclaim-1",
+		"k8s.namespace.name":                  "test-namespace",
+	}, rm.metadata)
+}
+
+func newPersistentVolume(id string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test-persistentvolume-" + id,
+			UID:  types.UID("test-persistentvolume-" + id + "-uid"),
+			Labels: map[string]string{
+				"foo": "bar",
+			},
+			CreationTimestamp: v1.NewTime(time.Time{}),
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("10Gi"),
+			},
+			ClaimRef: &corev1.ObjectReference{
+				Name:      "test-persistentvolumeclaim-" + id,
+				Namespace: "test-namespace",
+			},
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+			StorageClassName:              "standard",
+		},
+		Status: corev1.PersistentVolumeStatus{
+			Phase: corev1.VolumeBound,
+		},
+	}
+}
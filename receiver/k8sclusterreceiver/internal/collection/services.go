@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/collection"
+
+import (
+	"time"
+
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/maps"
+	metadataPkg "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+)
+
+const (
+	// Keys for service metadata.
+	serviceCreationTime    = "service.creation_timestamp"
+	k8sKeyServiceType      = "k8s.service.type"
+	k8sKeyServiceClusterIP = "k8s.service.cluster_ip"
+)
+
+// getMetadataForService returns all metadata associated with the service.
+// The ingresses routing traffic to it are only reported on the ingresses,
+// so that the metadata of the service doesn't go stale when they change.
+func getMetadataForService(svc *corev1.Service) map[metadataPkg.ResourceID]*KubernetesMetadata {
+	metadata := maps.MergeStringMaps(map[string]string{}, svc.Labels)
+
+	metadata[k8sKeyServiceName] = svc.Name
+	metadata[conventions.AttributeK8SNamespaceName] = svc.Namespace
+	metadata[serviceCreationTime] = svc.GetCreationTimestamp().Format(time.RFC3339)
+	metadata[k8sKeyServiceType] = string(svc.Spec.Type)
+	if svc.Spec.ClusterIP != "" && svc.Spec.ClusterIP != corev1.ClusterIPNone {
+		metadata[k8sKeyServiceClusterIP] = svc.Spec.ClusterIP
+	}
+
+	svcID := metadataPkg.ResourceID(svc.UID)
+	return map[metadataPkg.ResourceID]*KubernetesMetadata{
+		svcID: {
+			resourceIDKey: k8sKeyServiceUID,
+			resourceID:    svcID,
+			metadata:      metadata,
+		},
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	metadata "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+)
+
+func TestServiceMetadata(t *testing.T) {
+	svc := newService("1")
+	expectedMetadata := map[string]string{
+		"foo":                        "bar",
+		"k8s.service.name":           "test-service-1",
+		"k8s.namespace.name":         "test-namespace",
+		"service.creation_timestamp": "0001-01-01T00:00:00Z",
+		"k8s.service.type":           "ClusterIP",
+		"k8s.service.cluster_ip":     "10.0.0.1",
+	}
+
+	actualMetadata := getMetadataForService(svc)
+	require.Equal(t, 1, len(actualMetadata))
+	rm := actualMetadata[metadata.ResourceID("test-service-1-uid")]
+	require.NotNil(t, rm)
+	assert.Equal(t, "k8s.service.uid", rm.resourceIDKey)
+	assert.Equal(t, expectedMetadata, rm.metadata)
+
+}
+
+func newService(id string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test-service-" + id,
+			Namespace: "test-namespace",
+			UID:       types.UID("test-service-" + id + "-uid"),
+			Labels: map[string]string{
+				"foo": "bar",
+			},
+			CreationTimestamp: v1.NewTime(time.Time{}),
+		},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: "10.0.0.1",
+		},
+	}
+}
@@ -257,6 +257,8 @@ func newFakeClientWithAllResources() *fake.Clientset {
 				gvkToAPIResource(gvk.ReplicationController),
 				gvkToAPIResource(gvk.ResourceQuota),
 				gvkToAPIResource(gvk.Service),
+				gvkToAPIResource(gvk.PersistentVolume),
+				gvkToAPIResource(gvk.PersistentVolumeClaim),
 			},
 		},
 		{
@@ -281,6 +283,12 @@ func newFakeClientWithAllResources() *fake.Clientset {
 				gvkToAPIResource(gvk.HorizontalPodAutoscaler),
 			},
 		},
+		{
+			GroupVersion: "networking.k8s.io/v1",
+			APIResources: []v1.APIResource{
+				gvkToAPIResource(gvk.Ingress),
+			},
+		},
 	}
 	return client
 }
@@ -96,6 +96,9 @@ func (rw *resourceWatcher) prepareSharedInformerFactory() error {
 		"ReplicationController":   {gvk.ReplicationController},
 		"ResourceQuota":           {gvk.ResourceQuota},
 		"Service":                 {gvk.Service},
+		"PersistentVolume":        {gvk.PersistentVolume},
+		"PersistentVolumeClaim":   {gvk.PersistentVolumeClaim},
+		"Ingress":                 {gvk.Ingress},
 		"DaemonSet":               {gvk.DaemonSet},
 		"Deployment":              {gvk.Deployment},
 		"ReplicaSet":              {gvk.ReplicaSet},
@@ -165,6 +168,12 @@ func (rw *resourceWatcher) setupInformerForKind(kind schema.GroupVersionKind, fa
 		rw.setupInformer(kind, factory.Core().V1().ResourceQuotas().Informer())
 	case gvk.Service:
 		rw.setupInformer(kind, factory.Core().V1().Services().Informer())
+	case gvk.PersistentVolume:
+		rw.setupInformer(kind, factory.Core().V1().PersistentVolumes().Informer())
+	case gvk.PersistentVolumeClaim:
+		rw.setupInformer(kind, factory.Core().V1().PersistentVolumeClaims().Informer())
+	case gvk.Ingress:
+		rw.setupInformer(kind, factory.Networking().V1().Ingresses().Informer())
 	case gvk.DaemonSet:
 		rw.setupInformer(kind, factory.Apps().V1().DaemonSets().Informer())
 	case gvk.Deployment:
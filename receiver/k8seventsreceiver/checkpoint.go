@@ -0,0 +1,210 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8seventsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8seventsreceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// checkpointKey is the storage key the checkpoint is saved under.
+const checkpointKey = "checkpoint"
+
+// emittedRetention is how long emitted events are remembered to deduplicate them.
+// It matches the default time to live of events in the API server.
+const emittedRetention = time.Hour
+
+// checkpointInterval is the time between two saves of the checkpoint.
+var checkpointInterval = 5 * time.Second
+
+// checkpoint is the state a receiver resumes from.
+type checkpoint struct {
+	// ResourceVersions are the last seen resource versions, by watched namespace.
+	ResourceVersions map[string]string `json:"resource_versions"`
+	// LastTimestamp is the timestamp of the most recent emitted event.
+	LastTimestamp time.Time `json:"last_timestamp"`
+	// Emitted are the recently emitted events, by UID.
+	Emitted map[string]emittedEvent `json:"emitted"`
+	// Term is the leadership term the checkpoint was saved in, when leader election is enabled.
+	Term *leaseTerm `json:"lease_term,omitempty"`
+
+	// dirty is set when the checkpoint changed since it was last saved.
+	dirty bool
+}
+
+type emittedEvent struct {
+	ResourceVersion string    `json:"resource_version"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// leaseTerm identifies a leadership term by the holder of the Lease and its
+// number of transitions. The API server counts a transition whenever the Lease is
+// acquired by a holder other than the last recorded one, including after a release.
+type leaseTerm struct {
+	Holder      string `json:"holder"`
+	Transitions int    `json:"transitions"`
+}
+
+// follows returns whether prev is the current term, or the one right before it,
+// i.e. whether the checkpoint saved in prev is up to date when leading in t.
+func (t *leaseTerm) follows(prev *leaseTerm) bool {
+	if t == nil || prev == nil {
+		return false
+	}
+	if prev.Transitions == t.Transitions {
+		return prev.Holder == t.Holder
+	}
+	return prev.Transitions == t.Transitions-1
+}
+
+func newCheckpoint() *checkpoint {
+	return &checkpoint{
+		ResourceVersions: make(map[string]string),
+		Emitted:          make(map[string]emittedEvent),
+	}
+}
+
+// markEmitted records the emission of the event and returns true, unless this
+// version of the event was already emitted.
+func (c *checkpoint) markEmitted(ev *corev1.Event, timestamp time.Time) bool {
+	uid := string(ev.UID)
+	if emitted, ok := c.Emitted[uid]; ok && emitted.ResourceVersion == ev.ResourceVersion {
+		return false
+	}
+	c.Emitted[uid] = emittedEvent{ResourceVersion: ev.ResourceVersion, Timestamp: timestamp}
+	if timestamp.After(c.LastTimestamp) {
+		c.LastTimestamp = timestamp
+	}
+	c.dirty = true
+	return true
+}
+
+func (c *checkpoint) setResourceVersion(ns string, resourceVersion string) {
+	if c.ResourceVersions[ns] != resourceVersion {
+		c.ResourceVersions[ns] = resourceVersion
+		c.dirty = true
+	}
+}
+
+// prune forgets the events emitted before the retention period, which the API server deleted.
+func (c *checkpoint) prune(now time.Time) {
+	for uid, emitted := range c.Emitted {
+		if now.Sub(emitted.Timestamp) > emittedRetention {
+			delete(c.Emitted, uid)
+		}
+	}
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID config.ComponentID, componentID config.ComponentID) (storage.Client, error) {
+	extension, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindReceiver, componentID, "")
+}
+
+// loadCheckpoint restores the checkpoint saved by a previous run. Events older
+// than the last event emitted by that run are not emitted again. When leading,
+// only a checkpoint saved by the previous leader is restored: any other one
+// misses the events emitted since by other replicas.
+func (kr *k8seventsReceiver) loadCheckpoint(ctx context.Context) error {
+	data, err := kr.storageClient.Get(ctx, checkpointKey)
+	if err != nil {
+		return fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	if data == nil {
+		return nil
+	}
+
+	restored := newCheckpoint()
+	if err = json.Unmarshal(data, restored); err != nil {
+		kr.settings.Logger.Warn("Discarding checkpoint which cannot be decoded", zap.Error(err))
+		return nil
+	}
+	if restored.ResourceVersions == nil {
+		restored.ResourceVersions = make(map[string]string)
+	}
+	if restored.Emitted == nil {
+		restored.Emitted = make(map[string]emittedEvent)
+	}
+
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	if kr.leading {
+		if !kr.term.follows(restored.Term) {
+			kr.settings.Logger.Debug("Discarding checkpoint which was not saved by the previous leader")
+			return nil
+		}
+		restored.Term = kr.term
+	}
+	kr.checkpoint = restored
+	if !restored.LastTimestamp.IsZero() {
+		kr.startTime = restored.LastTimestamp
+	}
+	kr.settings.Logger.Debug("Restored checkpoint", zap.Time("last_timestamp", restored.LastTimestamp))
+	return nil
+}
+
+func (kr *k8seventsReceiver) saveCheckpoint(ctx context.Context) error {
+	kr.mu.Lock()
+	kr.checkpoint.prune(time.Now())
+	data, err := json.Marshal(kr.checkpoint)
+	kr.checkpoint.dirty = false
+	kr.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	if err = kr.storageClient.Set(ctx, checkpointKey, data); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// checkpointer saves the checkpoint every checkpointInterval when it changed.
+func (kr *k8seventsReceiver) checkpointer(ctx context.Context) {
+	ticker := time.NewTicker(checkpointInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			kr.mu.Lock()
+			dirty := kr.checkpoint.dirty
+			kr.mu.Unlock()
+			if !dirty {
+				continue
+			}
+			if err := kr.saveCheckpoint(ctx); err != nil {
+				kr.settings.Logger.Warn("Failed to save checkpoint", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8seventsreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestMarkEmitted(t *testing.T) {
+	c := newCheckpoint()
+	ev := getEvent()
+	ev.ResourceVersion = "1"
+	ts := getEventTimestamp(ev)
+
+	assert.True(t, c.markEmitted(ev, ts))
+	assert.True(t, c.dirty)
+	assert.Equal(t, ts, c.LastTimestamp)
+
+	c.dirty = false
+	assert.False(t, c.markEmitted(ev, ts))
+	assert.False(t, c.dirty)
+
+	ev.ResourceVersion = "2"
+	assert.True(t, c.markEmitted(ev, ts.Add(-time.Minute)))
+	assert.Equal(t, ts, c.LastTimestamp)
+}
+
+func TestPruneCheckpoint(t *testing.T) {
+	c := newCheckpoint()
+	now := time.Now()
+	c.Emitted["old"] = emittedEvent{ResourceVersion: "1", Timestamp: now.Add(-2 * emittedRetention)}
+	c.Emitted["recent"] = emittedEvent{ResourceVersion: "2", Timestamp: now.Add(-time.Minute)}
+
+	c.prune(now)
+	assert.Equal(t, map[string]emittedEvent{"recent": c.Emitted["recent"]}, c.Emitted)
+}
+
+func TestLeaseTermFollows(t *testing.T) {
+	term := &leaseTerm{Holder: "first", Transitions: 2}
+	tests := []struct {
+		name string
+		prev *leaseTerm
+		want bool
+	}{
+		{name: "no term", prev: nil, want: false},
+		{name: "same term", prev: &leaseTerm{Holder: "first", Transitions: 2}, want: true},
+		{name: "previous term", prev: &leaseTerm{Holder: "second", Transitions: 1}, want: true},
+		{name: "previous term of the same holder", prev: &leaseTerm{Holder: "first", Transitions: 1}, want: true},
+		{name: "older term", prev: &leaseTerm{Holder: "first", Transitions: 0}, want: false},
+		{name: "other holder in the same term", prev: &leaseTerm{Holder: "second", Transitions: 2}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, term.follows(tt.prev))
+		})
+	}
+	assert.False(t, (*leaseTerm)(nil).follows(term))
+}
+
+func TestGetStorageClient(t *testing.T) {
+	id := config.NewComponentID(typeStr)
+
+	_, err := getStorageClient(context.Background(), componenttest.NewNopHost(), storagetest.NewStorageID("missing"), id)
+	assert.EqualError(t, err, "storage extension 'test_storage/missing' not found")
+
+	host := storagetest.NewStorageHost().WithNonStorageExtension("non")
+	_, err = getStorageClient(context.Background(), host, storagetest.NewNonStorageID("non"), id)
+	assert.EqualError(t, err, "non-storage extension 'non_storage/non' found")
+
+	host = storagetest.NewStorageHost().WithInMemoryStorageExtension("mem")
+	client, err := getStorageClient(context.Background(), host, storagetest.NewStorageID("mem"), id)
+	require.NoError(t, err)
+	creatorID, err := storagetest.CreatorID(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, storagetest.NewStorageID("mem"), creatorID)
+}
+
+func TestLoadUndecodableCheckpoint(t *testing.T) {
+	r := newTestReceiver(t, createDefaultConfig().(*Config), fake.NewSimpleClientset(), new(consumertest.LogsSink))
+	startTime := r.startTime
+	r.storageClient = storagetest.NewInMemoryClient(component.KindReceiver, r.config.ID(), "")
+	require.NoError(t, r.storageClient.Set(context.Background(), checkpointKey, []byte("{")))
+
+	require.NoError(t, r.loadCheckpoint(context.Background()))
+	assert.Equal(t, startTime, r.startTime)
+	assert.Equal(t, newCheckpoint(), r.checkpoint)
+}
+
+func TestResumeFromCheckpoint(t *testing.T) {
+	storageDir := t.TempDir()
+	storageID := storagetest.NewStorageID("file")
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("file", storageDir)
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.StorageID = &storageID
+
+	// The first run emits an event and saves the checkpoint on shutdown.
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, rCfg, fake.NewSimpleClientset(), sink)
+	emitted := newTestEvent("emitted", "1", time.Now())
+	_, err := r.client.CoreV1().Events("test").Create(context.Background(), emitted, v1.CreateOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, r.Start(context.Background(), host))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	// An event emitted while the collector was stopped is older than the start of
+	// the next run, which still emits it but not the event it already emitted.
+	missed := newTestEvent("missed", "2", time.Now())
+	time.Sleep(10 * time.Millisecond)
+
+	sink = new(consumertest.LogsSink)
+	r = newTestReceiver(t, rCfg, fake.NewSimpleClientset(emitted, missed), sink)
+	require.NoError(t, r.Start(context.Background(), host))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	require.Equal(t, 1, sink.LogRecordCount())
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	name, ok := lr.Attributes().Get("k8s.event.name")
+	require.True(t, ok)
+	assert.Equal(t, "missed", name.StringVal())
+}
+
+func newTestReceiver(t *testing.T, rCfg *Config, client *fake.Clientset, sink *consumertest.LogsSink) *k8seventsReceiver {
+	r, err := newReceiver(componenttest.NewNopReceiverCreateSettings(), rCfg, sink, client)
+	require.NoError(t, err)
+	return r.(*k8seventsReceiver)
+}
+
+func newTestEvent(name string, resourceVersion string, timestamp time.Time) *corev1.Event {
+	ev := getEvent()
+	ev.Name = name
+	ev.UID = types.UID(name)
+	ev.ResourceVersion = resourceVersion
+	ev.FirstTimestamp = v1.Time{Time: timestamp}
+	return ev
+}
@@ -15,6 +15,9 @@
 package k8seventsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8seventsreceiver"
 
 import (
+	"errors"
+	"time"
+
 	"go.opentelemetry.io/collector/config"
 	k8s "k8s.io/client-go/kubernetes"
 
@@ -29,17 +32,66 @@ type Config struct {
 	// List of ‘namespaces’ to collect events from.
 	Namespaces []string `mapstructure:"namespaces"`
 
+	// StorageID is the ID of a storage extension used to save the last seen resource
+	// versions and the recently emitted events. When set, a restarted receiver resumes
+	// from where it stopped instead of skipping the events emitted in between.
+	StorageID *config.ComponentID `mapstructure:"storage"`
+
+	// LeaderElection configures the election of the single replica emitting events.
+	LeaderElection LeaderElectionConfig `mapstructure:"leader_election"`
+
 	// For mocking
 	makeClient func(apiConf k8sconfig.APIConfig) (k8s.Interface, error)
 }
 
+// LeaderElectionConfig defines the Kubernetes Lease used to elect the replica
+// emitting events, when several replicas of the collector are deployed.
+type LeaderElectionConfig struct {
+	// Enabled makes the receiver emit events only while holding the Lease.
+	Enabled bool `mapstructure:"enabled"`
+	// LeaseName is the name of the Lease.
+	LeaseName string `mapstructure:"lease_name"`
+	// LeaseNamespace is the namespace of the Lease.
+	LeaseNamespace string `mapstructure:"lease_namespace"`
+	// Identity is the identity of the replica in the Lease. Defaults to the hostname.
+	Identity string `mapstructure:"identity"`
+	// LeaseDuration is the time other replicas wait before taking over a Lease which was not renewed.
+	LeaseDuration time.Duration `mapstructure:"lease_duration"`
+	// RenewDeadline is the time the leader retries renewing the Lease before giving it up.
+	RenewDeadline time.Duration `mapstructure:"renew_deadline"`
+	// RetryPeriod is the time between two attempts to acquire or renew the Lease.
+	RetryPeriod time.Duration `mapstructure:"retry_period"`
+}
+
 func (cfg *Config) Validate() error {
 	if err := cfg.ReceiverSettings.Validate(); err != nil {
 		return err
 	}
+	if err := cfg.LeaderElection.validate(); err != nil {
+		return err
+	}
 	return cfg.APIConfig.Validate()
 }
 
+func (cfg *LeaderElectionConfig) validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.LeaseName == "" || cfg.LeaseNamespace == "" {
+		return errors.New("leader_election requires lease_name and lease_namespace")
+	}
+	if cfg.RetryPeriod <= 0 {
+		return errors.New("leader_election retry_period must be positive")
+	}
+	if cfg.RenewDeadline <= cfg.RetryPeriod {
+		return errors.New("leader_election renew_deadline must be greater than retry_period")
+	}
+	if cfg.LeaseDuration <= cfg.RenewDeadline {
+		return errors.New("leader_election lease_duration must be greater than renew_deadline")
+	}
+	return nil
+}
+
 func (cfg *Config) getK8sClient() (k8s.Interface, error) {
 	if cfg.makeClient == nil {
 		cfg.makeClient = k8sconfig.MakeClient
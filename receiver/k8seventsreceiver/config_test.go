@@ -17,6 +17,7 @@ package k8seventsreceiver
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -39,7 +40,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	require.Equal(t, len(cfg.Receivers), 2)
+	require.Equal(t, len(cfg.Receivers), 3)
 
 	r1 := cfg.Receivers[config.NewComponentID(typeStr)]
 	assert.Equal(t, r1, factory.CreateDefaultConfig())
@@ -52,5 +53,103 @@ func TestLoadConfig(t *testing.T) {
 			APIConfig: k8sconfig.APIConfig{
 				AuthType: k8sconfig.AuthTypeServiceAccount,
 			},
+			LeaderElection: LeaderElectionConfig{
+				LeaseName:     defaultLeaseName,
+				LeaseDuration: defaultLeaseDuration,
+				RenewDeadline: defaultRenewDeadline,
+				RetryPeriod:   defaultRetryPeriod,
+			},
+		})
+
+	storageID := config.NewComponentID("file_storage")
+	r3 := cfg.Receivers[config.NewComponentIDWithName(typeStr, "resume")].(*Config)
+	assert.Equal(t, r3,
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "resume")),
+			APIConfig: k8sconfig.APIConfig{
+				AuthType: k8sconfig.AuthTypeServiceAccount,
+			},
+			StorageID: &storageID,
+			LeaderElection: LeaderElectionConfig{
+				Enabled:        true,
+				LeaseName:      defaultLeaseName,
+				LeaseNamespace: "monitoring",
+				Identity:       "collector-0",
+				LeaseDuration:  30 * time.Second,
+				RenewDeadline:  defaultRenewDeadline,
+				RetryPeriod:    defaultRetryPeriod,
+			},
+		})
+}
+
+func TestValidateLeaderElection(t *testing.T) {
+	tests := []struct {
+		name         string
+		modify       func(*LeaderElectionConfig)
+		errorMessage string
+	}{
+		{
+			name:   "disabled",
+			modify: func(cfg *LeaderElectionConfig) { cfg.LeaseNamespace = "" },
+		},
+		{
+			name:   "valid",
+			modify: func(cfg *LeaderElectionConfig) { cfg.Enabled = true },
+		},
+		{
+			name: "missing_lease_namespace",
+			modify: func(cfg *LeaderElectionConfig) {
+				cfg.Enabled = true
+				cfg.LeaseNamespace = ""
+			},
+			errorMessage: "leader_election requires lease_name and lease_namespace",
+		},
+		{
+			name: "missing_lease_name",
+			modify: func(cfg *LeaderElectionConfig) {
+				cfg.Enabled = true
+				cfg.LeaseName = ""
+			},
+			errorMessage: "leader_election requires lease_name and lease_namespace",
+		},
+		{
+			name: "zero_retry_period",
+			modify: func(cfg *LeaderElectionConfig) {
+				cfg.Enabled = true
+				cfg.RetryPeriod = 0
+			},
+			errorMessage: "leader_election retry_period must be positive",
+		},
+		{
+			name: "renew_deadline_not_greater_than_retry_period",
+			modify: func(cfg *LeaderElectionConfig) {
+				cfg.Enabled = true
+				cfg.RenewDeadline = cfg.RetryPeriod
+			},
+			errorMessage: "leader_election renew_deadline must be greater than retry_period",
+		},
+		{
+			name: "lease_duration_not_greater_than_renew_deadline",
+			modify: func(cfg *LeaderElectionConfig) {
+				cfg.Enabled = true
+				cfg.LeaseDuration = cfg.RenewDeadline
+			},
+			errorMessage: "leader_election lease_duration must be greater than renew_deadline",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.LeaderElection.LeaseNamespace = "default"
+			tt.modify(&cfg.LeaderElection)
+
+			err := cfg.Validate()
+			if tt.errorMessage == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.errorMessage)
 		})
+	}
 }
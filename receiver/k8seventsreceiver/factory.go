@@ -16,6 +16,7 @@ package k8seventsreceiver // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -29,6 +30,11 @@ const (
 	typeStr = "k8s_events"
 	// The stability level of the receiver.
 	stability = component.StabilityLevelAlpha
+
+	defaultLeaseName     = "k8s-events-receiver"
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
 )
 
 // NewFactory creates a factory for k8s_cluster receiver.
@@ -45,6 +51,12 @@ func createDefaultConfig() config.Receiver {
 		APIConfig: k8sconfig.APIConfig{
 			AuthType: k8sconfig.AuthTypeServiceAccount,
 		},
+		LeaderElection: LeaderElectionConfig{
+			LeaseName:     defaultLeaseName,
+			LeaseDuration: defaultLeaseDuration,
+			RenewDeadline: defaultRenewDeadline,
+			RetryPeriod:   defaultRetryPeriod,
+		},
 	}
 }
 
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8seventsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8seventsreceiver"
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// runWithLeaderElection watches the events only while holding the Lease, until ctx is done.
+func (kr *k8seventsReceiver) runWithLeaderElection(ctx context.Context) {
+	defer kr.wg.Done()
+
+	cfg := kr.config.LeaderElection
+	identity := cfg.Identity
+	if identity == "" {
+		var err error
+		if identity, err = os.Hostname(); err != nil {
+			kr.settings.Logger.Error("failed to get the hostname to identify the replica in the lease", zap.Error(err))
+			return
+		}
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      cfg.LeaseName,
+			Namespace: cfg.LeaseNamespace,
+		},
+		Client: kr.client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+
+	for {
+		kr.lead(ctx, leaderelection.LeaderElectionConfig{
+			Lock:            lock,
+			LeaseDuration:   cfg.LeaseDuration,
+			RenewDeadline:   cfg.RenewDeadline,
+			RetryPeriod:     cfg.RetryPeriod,
+			ReleaseOnCancel: true,
+			Name:            kr.config.ID().String(),
+		}, identity)
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// lead waits to acquire the Lease and watches the events until the Lease is lost
+// or ctx is done.
+func (kr *k8seventsReceiver) lead(ctx context.Context, electionConfig leaderelection.LeaderElectionConfig, identity string) {
+	// The elector calls OnStartedLeading in its own goroutine and does not wait for
+	// it to return, so the watches are tracked here to stop them before returning.
+	var (
+		mu      sync.Mutex
+		stopped bool
+		running sync.WaitGroup
+	)
+	electionConfig.Callbacks = leaderelection.LeaderCallbacks{
+		OnStartedLeading: func(leaderCtx context.Context) {
+			mu.Lock()
+			if stopped {
+				mu.Unlock()
+				return
+			}
+			running.Add(1)
+			mu.Unlock()
+			defer running.Done()
+
+			kr.settings.Logger.Info("acquired the lease, starting to emit events", zap.String("identity", identity))
+			kr.startLeading(kr.getLeaseTerm(leaderCtx))
+			kr.run(leaderCtx)
+		},
+		OnStoppedLeading: func() {
+			kr.settings.Logger.Info("not holding the lease, not emitting events", zap.String("identity", identity))
+		},
+	}
+
+	leaderelection.RunOrDie(ctx, electionConfig)
+
+	mu.Lock()
+	stopped = true
+	mu.Unlock()
+	running.Wait()
+}
+
+// getLeaseTerm returns the leadership term just acquired, or nil if the Lease cannot be read.
+// The Lease is read with the client, as the lock is not safe for concurrent use with the elector.
+func (kr *k8seventsReceiver) getLeaseTerm(ctx context.Context) *leaseTerm {
+	cfg := kr.config.LeaderElection
+	lease, err := kr.client.CoordinationV1().Leases(cfg.LeaseNamespace).Get(ctx, cfg.LeaseName, metav1.GetOptions{})
+	if err != nil {
+		kr.settings.Logger.Warn("failed to get the lease, not restoring the checkpoint", zap.Error(err))
+		return nil
+	}
+	term := &leaseTerm{}
+	if lease.Spec.HolderIdentity != nil {
+		term.Holder = *lease.Spec.HolderIdentity
+	}
+	if lease.Spec.LeaseTransitions != nil {
+		term.Transitions = int(*lease.Spec.LeaseTransitions)
+	}
+	return term
+}
+
+// startLeading resets the state of the receiver when it becomes the leader, as
+// another replica may have emitted events since this one last led. Events older
+// than the leadership are skipped, unless the checkpoint saved by the previous
+// leader before term is restored, e.g. from a shared storage.
+func (kr *k8seventsReceiver) startLeading(term *leaseTerm) {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	kr.startTime = time.Now()
+	kr.checkpoint = newCheckpoint()
+	kr.checkpoint.Term = term
+	kr.leading = true
+	kr.term = term
+}
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8seventsreceiver
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestLeaderElection(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	// The event is timestamped in the future to be emitted whenever a replica starts leading.
+	_, err := client.CoreV1().Events("test").Create(context.Background(), newTestEvent("1", "1", time.Now().Add(time.Hour)), v1.CreateOptions{})
+	require.NoError(t, err)
+
+	newReplica := func(identity string) (*k8seventsReceiver, *consumertest.LogsSink) {
+		rCfg := createDefaultConfig().(*Config)
+		rCfg.LeaderElection = LeaderElectionConfig{
+			Enabled:        true,
+			LeaseName:      defaultLeaseName,
+			LeaseNamespace: "default",
+			Identity:       identity,
+			LeaseDuration:  2 * time.Second,
+			RenewDeadline:  time.Second,
+			RetryPeriod:    100 * time.Millisecond,
+		}
+		require.NoError(t, rCfg.Validate())
+		sink := new(consumertest.LogsSink)
+		return newTestReceiver(t, rCfg, client, sink), sink
+	}
+	leaseHolder := func() string {
+		lease, err := client.CoordinationV1().Leases("default").Get(context.Background(), defaultLeaseName, v1.GetOptions{})
+		if err != nil || lease.Spec.HolderIdentity == nil {
+			return ""
+		}
+		return *lease.Spec.HolderIdentity
+	}
+
+	first, firstSink := newReplica("first")
+	require.NoError(t, first.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool { return firstSink.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "first", leaseHolder())
+
+	// The second replica does not emit events while the first one holds the lease.
+	second, secondSink := newReplica("second")
+	require.NoError(t, second.Start(context.Background(), componenttest.NewNopHost()))
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, 0, secondSink.LogRecordCount())
+
+	// The lease is released on shutdown, and the second replica takes over.
+	require.NoError(t, first.Shutdown(context.Background()))
+	require.Eventually(t, func() bool { return secondSink.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "second", leaseHolder())
+	require.NoError(t, second.Shutdown(context.Background()))
+
+	assert.Equal(t, 1, firstSink.LogRecordCount())
+}
+
+func TestLeaderElectionTakeoverWithLocalStorage(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	replayWatchedEvents(client)
+	storageID := storagetest.NewStorageID("file")
+
+	// Each replica saves its checkpoint to its own node-local storage.
+	newReplica := func(identity string) (*k8seventsReceiver, *consumertest.LogsSink) {
+		rCfg := createDefaultConfig().(*Config)
+		rCfg.StorageID = &storageID
+		rCfg.LeaderElection = LeaderElectionConfig{
+			Enabled:        true,
+			LeaseName:      defaultLeaseName,
+			LeaseNamespace: "default",
+			Identity:       identity,
+			LeaseDuration:  2 * time.Second,
+			RenewDeadline:  time.Second,
+			RetryPeriod:    100 * time.Millisecond,
+		}
+		require.NoError(t, rCfg.Validate())
+		sink := new(consumertest.LogsSink)
+		return newTestReceiver(t, rCfg, client, sink), sink
+	}
+	firstHost := storagetest.NewStorageHost().WithFileBackedStorageExtension("file", t.TempDir())
+	secondHost := storagetest.NewStorageHost().WithFileBackedStorageExtension("file", t.TempDir())
+	createEvent := func(name string, resourceVersion string) {
+		_, err := client.CoreV1().Events("test").Create(context.Background(), newTestEvent(name, resourceVersion, time.Now()), v1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	eventNames := func(sink *consumertest.LogsSink) []string {
+		var names []string
+		for _, logs := range sink.AllLogs() {
+			lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+			name, ok := lr.Attributes().Get("k8s.event.name")
+			require.True(t, ok)
+			names = append(names, name.StringVal())
+		}
+		return names
+	}
+	isLeading := func(r *k8seventsReceiver) func() bool {
+		return func() bool {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			return r.leading
+		}
+	}
+
+	// The first replica leads, emits an event and saves its checkpoint on shutdown.
+	first, firstSink := newReplica("first")
+	require.NoError(t, first.Start(context.Background(), firstHost))
+	require.Eventually(t, isLeading(first), 5*time.Second, 10*time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	createEvent("first", "1")
+	require.Eventually(t, func() bool { return firstSink.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, first.Shutdown(context.Background()))
+
+	// The second replica takes over and emits another event.
+	second, secondSink := newReplica("second")
+	require.NoError(t, second.Start(context.Background(), secondHost))
+	require.Eventually(t, isLeading(second), 5*time.Second, 10*time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	createEvent("second", "2")
+	require.Eventually(t, func() bool { return secondSink.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, second.Shutdown(context.Background()))
+
+	// When the first replica leads again, its checkpoint predates the events emitted
+	// by the second one, which are not emitted again.
+	first, firstSink = newReplica("first")
+	require.NoError(t, first.Start(context.Background(), firstHost))
+	require.Eventually(t, isLeading(first), 5*time.Second, 10*time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	createEvent("third", "3")
+	require.Eventually(t, func() bool { return firstSink.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, first.Shutdown(context.Background()))
+
+	assert.Equal(t, []string{"second"}, eventNames(secondSink))
+	assert.Equal(t, []string{"third"}, eventNames(firstSink))
+}
+
+// replayWatchedEvents makes the watches of events started from a resource version
+// first send the events with a newer resource version, as the API server does.
+func replayWatchedEvents(client *fake.Clientset) {
+	gvr := corev1.SchemeGroupVersion.WithResource("events")
+	gvk := corev1.SchemeGroupVersion.WithKind("Event")
+	client.PrependWatchReactor("events", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		from, err := strconv.Atoi(action.(k8stesting.WatchAction).GetWatchRestrictions().ResourceVersion)
+		if err != nil {
+			return false, nil, nil
+		}
+		watcher, err := client.Tracker().Watch(gvr, action.GetNamespace())
+		if err != nil {
+			return true, nil, err
+		}
+		list, err := client.Tracker().List(gvr, gvk, action.GetNamespace())
+		if err != nil {
+			return true, nil, err
+		}
+		replayed := &replayWatcher{RaceFreeFakeWatcher: watch.NewRaceFreeFake(), watcher: watcher}
+		go func() {
+			for i := range list.(*corev1.EventList).Items {
+				ev := &list.(*corev1.EventList).Items[i]
+				if rv, err := strconv.Atoi(ev.ResourceVersion); err == nil && rv > from {
+					replayed.Add(ev)
+				}
+			}
+			for watchEvent := range watcher.ResultChan() {
+				replayed.Action(watchEvent.Type, watchEvent.Object)
+			}
+		}()
+		return true, replayed, nil
+	})
+}
+
+// replayWatcher sends the replayed events before the ones of the watcher, which
+// is stopped with it.
+type replayWatcher struct {
+	*watch.RaceFreeFakeWatcher
+	watcher watch.Interface
+}
+
+func (w *replayWatcher) Stop() {
+	w.watcher.Stop()
+	w.RaceFreeFakeWatcher.Stop()
+}
@@ -16,27 +16,44 @@ package k8seventsreceiver // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/fields"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiWatch "k8s.io/apimachinery/pkg/watch"
 	k8s "k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/cache"
 )
 
+// watchRetryInterval is the time to wait before restarting a failed watch.
+var watchRetryInterval = 5 * time.Second
+
 type k8seventsReceiver struct {
-	config          *Config
-	settings        component.ReceiverCreateSettings
-	client          k8s.Interface
-	logsConsumer    consumer.Logs
-	stopperChanList []chan struct{}
-	startTime       time.Time
-	ctx             context.Context
-	cancel          context.CancelFunc
-	obsrecv         *obsreport.Receiver
+	config        *Config
+	settings      component.ReceiverCreateSettings
+	client        k8s.Interface
+	logsConsumer  consumer.Logs
+	storageClient storage.Client
+	ctx           context.Context
+	cancel        context.CancelFunc
+	wg            sync.WaitGroup
+	obsrecv       *obsreport.Receiver
+
+	// mu protects startTime and checkpoint, which are updated by the watch of every namespace.
+	mu         sync.Mutex
+	startTime  time.Time
+	checkpoint *checkpoint
+	// leading is set when running with leader election, in the leadership term
+	// term, which is nil when the Lease could not be read.
+	leading bool
+	term    *leaseTerm
 }
 
 // newReceiver creates the Kubernetes events receiver with the given configuration.
@@ -54,6 +71,7 @@ func newReceiver(
 		client:       client,
 		logsConsumer: consumer,
 		startTime:    time.Now(),
+		checkpoint:   newCheckpoint(),
 		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
 			ReceiverID:             config.ID(),
 			Transport:              transport,
@@ -63,49 +81,159 @@ func newReceiver(
 }
 
 func (kr *k8seventsReceiver) Start(ctx context.Context, host component.Host) error {
-	kr.ctx, kr.cancel = context.WithCancel(ctx)
-
-	kr.settings.Logger.Info("starting to watch namespaces for the events.")
-	if len(kr.config.Namespaces) == 0 {
-		kr.startWatch(corev1.NamespaceAll)
-	} else {
-		for _, ns := range kr.config.Namespaces {
-			kr.startWatch(ns)
+	if kr.config.StorageID != nil {
+		client, err := getStorageClient(ctx, host, *kr.config.StorageID, kr.config.ID())
+		if err != nil {
+			return err
 		}
+		kr.storageClient = client
 	}
 
+	kr.ctx, kr.cancel = context.WithCancel(context.Background())
+
+	kr.wg.Add(1)
+	if kr.config.LeaderElection.Enabled {
+		go kr.runWithLeaderElection(kr.ctx)
+	} else {
+		go func() {
+			defer kr.wg.Done()
+			kr.run(kr.ctx)
+		}()
+	}
 	return nil
 }
 
-func (kr *k8seventsReceiver) Shutdown(context.Context) error {
-	// Stop watching all the namespaces by closing all the stopper channels.
-	for _, stopperChan := range kr.stopperChanList {
-		close(stopperChan)
+func (kr *k8seventsReceiver) Shutdown(ctx context.Context) error {
+	if kr.cancel == nil {
+		return nil
 	}
+	// Stop watching all the namespaces, which saves the checkpoint.
 	kr.cancel()
+	kr.wg.Wait()
+
+	if kr.storageClient != nil {
+		return kr.storageClient.Close(ctx)
+	}
 	return nil
 }
 
-// Add the 'Event' handler and trigger the watch for a specific namespace.
+// run watches the namespaces for events until ctx is done. The checkpoint of a
+// previous run is restored first, and saved again when the watches stop.
+func (kr *k8seventsReceiver) run(ctx context.Context) {
+	if kr.storageClient != nil {
+		if err := kr.loadCheckpoint(ctx); err != nil {
+			kr.settings.Logger.Warn("failed to load checkpoint, resuming from the start time", zap.Error(err))
+		}
+	}
+
+	kr.settings.Logger.Info("starting to watch namespaces for the events.")
+	var wg sync.WaitGroup
+	namespaces := kr.config.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{corev1.NamespaceAll}
+	}
+	for _, ns := range namespaces {
+		wg.Add(1)
+		go func(ns string) {
+			defer wg.Done()
+			kr.watchNamespace(ctx, ns)
+		}(ns)
+	}
+	if kr.storageClient != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			kr.checkpointer(ctx)
+		}()
+	}
+	wg.Wait()
+
+	if kr.storageClient != nil {
+		if err := kr.saveCheckpoint(context.Background()); err != nil {
+			kr.settings.Logger.Warn("failed to save checkpoint", zap.Error(err))
+		}
+	}
+}
+
+// watchNamespace lists and then watches the events of a namespace until ctx is done.
+// When the watch ends, it is resumed from the last seen resource version.
+func (kr *k8seventsReceiver) watchNamespace(ctx context.Context, ns string) {
+	for {
+		err := kr.listAndWatch(ctx, ns)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			// The API server ended the watch, resume it right away.
+			continue
+		}
+
+		kr.settings.Logger.Warn("watch of the events failed, restarting", zap.String("namespace", ns), zap.Error(err))
+		if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+			// The resource version is too old to resume from, list the events again.
+			kr.setResourceVersion(ns, "")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryInterval):
+		}
+	}
+}
+
+// listAndWatch handles the events of a namespace until the watch ends. The events
+// are listed first unless the watch can resume from a known resource version.
 // For new and updated events, the code is relying on the following k8s code implementation:
 // https://github.com/kubernetes/kubernetes/blob/master/staging/src/k8s.io/client-go/tools/record/events_cache.go#L327
-func (kr *k8seventsReceiver) startWatch(ns string) {
-	stopperChan := make(chan struct{})
-	kr.stopperChanList = append(kr.stopperChanList, stopperChan)
-	kr.startWatchingNamespace(kr.client, cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			ev := obj.(*corev1.Event)
-			kr.handleEvent(ev)
-		},
-		UpdateFunc: func(_, obj interface{}) {
-			ev := obj.(*corev1.Event)
-			kr.handleEvent(ev)
-		},
-	}, ns, stopperChan)
+func (kr *k8seventsReceiver) listAndWatch(ctx context.Context, ns string) error {
+	events := kr.client.CoreV1().Events(ns)
+
+	resourceVersion := kr.resourceVersion(ns)
+	if resourceVersion == "" {
+		list, err := events.List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for i := range list.Items {
+			kr.handleEvent(&list.Items[i])
+		}
+		resourceVersion = list.ResourceVersion
+		kr.setResourceVersion(ns, resourceVersion)
+	}
+
+	watcher, err := events.Watch(ctx, metav1.ListOptions{
+		ResourceVersion:     resourceVersion,
+		AllowWatchBookmarks: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case watchEvent, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil
+			}
+			if watchEvent.Type == apiWatch.Error {
+				return apierrors.FromObject(watchEvent.Object)
+			}
+
+			if ev, ok := watchEvent.Object.(*corev1.Event); ok && (watchEvent.Type == apiWatch.Added || watchEvent.Type == apiWatch.Modified) {
+				kr.handleEvent(ev)
+			}
+			if accessor, err := meta.Accessor(watchEvent.Object); err == nil && accessor.GetResourceVersion() != "" {
+				kr.setResourceVersion(ns, accessor.GetResourceVersion())
+			}
+		}
+	}
 }
 
 func (kr *k8seventsReceiver) handleEvent(ev *corev1.Event) {
-	if kr.allowEvent(ev) {
+	if kr.allowEvent(ev) && kr.markEmitted(ev) {
 		ld := k8sEventToLogData(kr.settings.Logger, ev)
 
 		ctx := kr.obsrecv.StartLogsOp(kr.ctx)
@@ -114,26 +242,37 @@ func (kr *k8seventsReceiver) handleEvent(ev *corev1.Event) {
 	}
 }
 
-// startWatchingNamespace creates an informer and starts
-// watching a specific namespace for the events.
-func (kr *k8seventsReceiver) startWatchingNamespace(
-	clientset k8s.Interface,
-	handlers cache.ResourceEventHandlerFuncs,
-	ns string,
-	stopper chan struct{},
-) {
-	client := clientset.CoreV1().RESTClient()
-	watchList := cache.NewListWatchFromClient(client, "events", ns, fields.Everything())
-	_, controller := cache.NewInformer(watchList, &corev1.Event{}, 0, handlers)
-	go controller.Run(stopper)
-}
-
 // Allow events with eventTimestamp(EventTime/LastTimestamp/FirstTimestamp)
 // not older than the receiver start time so that
-// event flood can be avoided upon startup.
+// event flood can be avoided upon startup. When a checkpoint is restored, the
+// start time is the timestamp of the last event emitted before the restart.
 func (kr *k8seventsReceiver) allowEvent(ev *corev1.Event) bool {
+	kr.mu.Lock()
+	startTime := kr.startTime
+	kr.mu.Unlock()
+
 	eventTimestamp := getEventTimestamp(ev)
-	return !eventTimestamp.Before(kr.startTime)
+	return !eventTimestamp.Before(startTime)
+}
+
+// markEmitted records that the event is being emitted. It returns false if this
+// version of the event was already emitted, e.g. when the events are listed again.
+func (kr *k8seventsReceiver) markEmitted(ev *corev1.Event) bool {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	return kr.checkpoint.markEmitted(ev, getEventTimestamp(ev))
+}
+
+func (kr *k8seventsReceiver) resourceVersion(ns string) string {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	return kr.checkpoint.ResourceVersions[ns]
+}
+
+func (kr *k8seventsReceiver) setResourceVersion(ns string, resourceVersion string) {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	kr.checkpoint.setResourceVersion(ns, resourceVersion)
 }
 
 // Return the EventTimestamp based on the populated k8s event timestamps.
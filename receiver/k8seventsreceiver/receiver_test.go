@@ -78,6 +78,23 @@ func TestHandleEvent(t *testing.T) {
 	assert.Equal(t, sink.LogRecordCount(), 1)
 }
 
+func TestDeduplicateEvents(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	recv := newTestReceiver(t, createDefaultConfig().(*Config), fake.NewSimpleClientset(), sink)
+	recv.ctx = context.Background()
+
+	k8sEvent := newTestEvent("1", "1", time.Now())
+	recv.handleEvent(k8sEvent)
+	recv.handleEvent(k8sEvent)
+	assert.Equal(t, 1, sink.LogRecordCount())
+
+	// An updated event is emitted again.
+	updated := newTestEvent("1", "2", time.Now())
+	updated.Count = 3
+	recv.handleEvent(updated)
+	assert.Equal(t, 2, sink.LogRecordCount())
+}
+
 func TestDropEventsOlderThanStartupTime(t *testing.T) {
 	rCfg := createDefaultConfig().(*Config)
 	client := fake.NewSimpleClientset()
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/multierr"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+type mode string
+
+const (
+	// PullMode lists the objects periodically.
+	PullMode mode = "pull"
+	// WatchMode watches the objects for changes.
+	WatchMode mode = "watch"
+
+	defaultMode         mode = PullMode
+	defaultPullInterval      = time.Hour
+)
+
+// K8sObjectsConfig defines the API resource to collect objects from.
+type K8sObjectsConfig struct {
+	// Group is the API group of the resource. It is empty for the core group.
+	Group string `mapstructure:"group"`
+	// Version is the API version of the resource, e.g. v1.
+	Version string `mapstructure:"version"`
+	// Resource is the plural name of the resource, e.g. configmaps.
+	Resource string `mapstructure:"resource"`
+	// Mode is either pull or watch. Defaults to pull.
+	Mode mode `mapstructure:"mode"`
+	// Namespaces to collect objects from. All namespaces are used if empty.
+	Namespaces []string `mapstructure:"namespaces"`
+	// LabelSelector filters the objects by their labels.
+	LabelSelector string `mapstructure:"label_selector"`
+	// FieldSelector filters the objects by their fields.
+	FieldSelector string `mapstructure:"field_selector"`
+	// Interval is the time between two lists of the objects in pull mode. Defaults to 1h.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// Config defines configuration for kubernetes objects receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"`
+	k8sconfig.APIConfig     `mapstructure:",squash"`
+
+	// Objects are the API resources to collect.
+	Objects []*K8sObjectsConfig `mapstructure:"objects"`
+
+	// For mocking
+	makeDynamicClient func(apiConf k8sconfig.APIConfig) (dynamic.Interface, error)
+}
+
+func (cfg *Config) Validate() error {
+	if err := cfg.ReceiverSettings.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.APIConfig.Validate(); err != nil {
+		return err
+	}
+	if len(cfg.Objects) == 0 {
+		return errors.New("at least one object must be configured")
+	}
+
+	var errs error
+	for i, object := range cfg.Objects {
+		if object == nil {
+			errs = multierr.Append(errs, fmt.Errorf("objects[%d]: resource must be set", i))
+			continue
+		}
+		if err := object.validate(); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("objects[%d]: %w", i, err))
+		}
+	}
+	return errs
+}
+
+// Unmarshal sets the default mode and interval of the objects, which can't be
+// set by the default config as each object is configured in a list.
+func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
+	if componentParser == nil {
+		// Nothing to do if there is no config given.
+		return nil
+	}
+
+	if err := componentParser.UnmarshalExact(cfg); err != nil {
+		return err
+	}
+
+	for _, object := range cfg.Objects {
+		if object == nil {
+			continue
+		}
+		if object.Mode == "" {
+			object.Mode = defaultMode
+		}
+		if object.Mode == PullMode && object.Interval == 0 {
+			object.Interval = defaultPullInterval
+		}
+	}
+	return nil
+}
+
+// validate checks the object configuration.
+func (c *K8sObjectsConfig) validate() error {
+	if c.Resource == "" {
+		return errors.New("resource must be set")
+	}
+	if c.Version == "" {
+		return errors.New("version must be set")
+	}
+
+	switch c.Mode {
+	case PullMode:
+		if c.Interval <= 0 {
+			return errors.New("interval must be positive")
+		}
+	case WatchMode:
+		if c.Interval != 0 {
+			return errors.New("interval can only be set in pull mode")
+		}
+	default:
+		return fmt.Errorf("invalid mode %q, must be %q or %q", c.Mode, PullMode, WatchMode)
+	}
+
+	if _, err := labels.Parse(c.LabelSelector); err != nil {
+		return fmt.Errorf("invalid label_selector: %w", err)
+	}
+	if _, err := fields.ParseSelector(c.FieldSelector); err != nil {
+		return fmt.Errorf("invalid field_selector: %w", err)
+	}
+	return nil
+}
+
+func (c *K8sObjectsConfig) gvr() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: c.Group, Version: c.Version, Resource: c.Resource}
+}
+
+func (cfg *Config) getDynamicClient() (dynamic.Interface, error) {
+	if cfg.makeDynamicClient == nil {
+		cfg.makeDynamicClient = k8sconfig.MakeDynamicClient
+	}
+	return cfg.makeDynamicClient(cfg.APIConfig)
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobjectsreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id           config.ComponentID
+		expected     config.Receiver
+		errorMessage string
+	}{
+		{
+			id: config.NewComponentIDWithName(typeStr, ""),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				APIConfig:        k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				Objects: []*K8sObjectsConfig{
+					{
+						Version:       "v1",
+						Resource:      "configmaps",
+						Mode:          PullMode,
+						Namespaces:    []string{"default", "kube-system"},
+						LabelSelector: "app=web",
+						FieldSelector: "metadata.name!=kube-root-ca.crt",
+						Interval:      15 * time.Minute,
+					},
+					{
+						Group:    "networking.k8s.io",
+						Version:  "v1",
+						Resource: "ingresses",
+						Mode:     WatchMode,
+					},
+				},
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "defaults"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				APIConfig:        k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				Objects: []*K8sObjectsConfig{
+					{
+						Version:  "v1",
+						Resource: "pods",
+						Mode:     PullMode,
+						Interval: time.Hour,
+					},
+				},
+			},
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "no_objects"),
+			errorMessage: "at least one object must be configured",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "missing_resource"),
+			errorMessage: "objects[0]: resource must be set",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "missing_version"),
+			errorMessage: "objects[0]: version must be set",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "invalid_mode"),
+			errorMessage: `objects[0]: invalid mode "poll", must be "pull" or "watch"`,
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "watch_interval"),
+			errorMessage: "objects[0]: interval can only be set in pull mode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, cfg.Validate(), tt.errorMessage)
+				return
+			}
+			assert.Equal(t, tt.expected, cfg)
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg, "validation must not modify the config")
+		})
+	}
+}
+
+func TestInvalidLabelSelector(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	cfg := NewFactory().CreateDefaultConfig()
+	sub, err := cm.Sub(config.NewComponentIDWithName(typeStr, "invalid_label_selector").String())
+	require.NoError(t, err)
+	require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "objects[0]: invalid label_selector")
+}
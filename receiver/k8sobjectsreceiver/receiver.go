@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apiWatch "k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+// watchRetryInterval is the time to wait before restarting a failed watch.
+var watchRetryInterval = 5 * time.Second
+
+type k8sobjectsReceiver struct {
+	config       *Config
+	settings     component.ReceiverCreateSettings
+	client       dynamic.Interface
+	logsConsumer consumer.Logs
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+	obsrecv      *obsreport.Receiver
+}
+
+// newReceiver creates the Kubernetes objects receiver with the given configuration.
+func newReceiver(
+	set component.ReceiverCreateSettings,
+	config *Config,
+	consumer consumer.Logs,
+	client dynamic.Interface,
+) (component.LogsReceiver, error) {
+	transport := "http"
+
+	return &k8sobjectsReceiver{
+		settings:     set,
+		config:       config,
+		client:       client,
+		logsConsumer: consumer,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             config.ID(),
+			Transport:              transport,
+			ReceiverCreateSettings: set,
+		}),
+	}, nil
+}
+
+func (kr *k8sobjectsReceiver) Start(_ context.Context, _ component.Host) error {
+	var ctx context.Context
+	ctx, kr.cancel = context.WithCancel(context.Background())
+
+	for _, object := range kr.config.Objects {
+		resource := kr.client.Resource(object.gvr())
+		namespaces := object.Namespaces
+		if len(namespaces) == 0 {
+			namespaces = []string{metav1.NamespaceAll}
+		}
+
+		kr.settings.Logger.Info("starting to collect objects",
+			zap.String("resource", object.gvr().String()), zap.String("mode", string(object.Mode)))
+		for _, ns := range namespaces {
+			var ri dynamic.ResourceInterface = resource
+			if ns != metav1.NamespaceAll {
+				ri = resource.Namespace(ns)
+			}
+
+			kr.wg.Add(1)
+			switch object.Mode {
+			case PullMode:
+				go kr.startPull(ctx, object, ri)
+			case WatchMode:
+				go kr.startWatch(ctx, object, ri)
+			default:
+				kr.wg.Done()
+				kr.settings.Logger.Error("invalid mode, the objects won't be collected",
+					zap.String("resource", object.gvr().String()), zap.String("mode", string(object.Mode)))
+			}
+		}
+	}
+	return nil
+}
+
+func (kr *k8sobjectsReceiver) Shutdown(context.Context) error {
+	if kr.cancel != nil {
+		kr.cancel()
+	}
+	kr.wg.Wait()
+	return nil
+}
+
+// startPull lists the objects every interval and emits every object as a log record.
+func (kr *k8sobjectsReceiver) startPull(ctx context.Context, config *K8sObjectsConfig, ri dynamic.ResourceInterface) {
+	defer kr.wg.Done()
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+
+	for {
+		objects, err := ri.List(ctx, listOptions(config))
+		switch {
+		case err != nil:
+			if ctx.Err() == nil {
+				kr.settings.Logger.Error("error in pulling object", zap.String("resource", config.gvr().String()), zap.Error(err))
+			}
+		case len(objects.Items) > 0:
+			kr.consume(ctx, pullObjectsToLogData(objects, time.Now(), config), len(objects.Items))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// startWatch watches the objects and emits every watch event as a log record.
+// The watch is resumed from the last seen resource version when it ends.
+func (kr *k8sobjectsReceiver) startWatch(ctx context.Context, config *K8sObjectsConfig, ri dynamic.ResourceInterface) {
+	defer kr.wg.Done()
+
+	resourceVersion := ""
+	for {
+		var err error
+		resourceVersion, err = kr.watch(ctx, config, ri, resourceVersion)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			// The API server ended the watch, resume it right away.
+			continue
+		}
+
+		kr.settings.Logger.Warn("watch failed, restarting", zap.String("resource", config.gvr().String()), zap.Error(err))
+		if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+			// The resource version is too old to resume from, start over from the current objects.
+			resourceVersion = ""
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryInterval):
+		}
+	}
+}
+
+// watch emits the watch events of the objects until the watch ends, and returns
+// the last seen resource version. When resourceVersion is empty, the objects are
+// listed first so that only changes to the current objects are emitted.
+func (kr *k8sobjectsReceiver) watch(ctx context.Context, config *K8sObjectsConfig, ri dynamic.ResourceInterface, resourceVersion string) (string, error) {
+	if resourceVersion == "" {
+		objects, err := ri.List(ctx, listOptions(config))
+		if err != nil {
+			return "", err
+		}
+		resourceVersion = objects.GetResourceVersion()
+	}
+
+	opts := listOptions(config)
+	opts.ResourceVersion = resourceVersion
+	opts.AllowWatchBookmarks = true
+	watcher, err := ri.Watch(ctx, opts)
+	if err != nil {
+		return resourceVersion, err
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion, nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return resourceVersion, nil
+			}
+			if event.Type == apiWatch.Error {
+				return resourceVersion, apierrors.FromObject(event.Object)
+			}
+
+			object, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				kr.settings.Logger.Debug("skipping watch event with unexpected object", zap.String("type", string(event.Type)))
+				continue
+			}
+			if rv := object.GetResourceVersion(); rv != "" {
+				resourceVersion = rv
+			}
+			if event.Type == apiWatch.Bookmark {
+				continue
+			}
+			kr.consume(ctx, watchEventToLogData(event.Type, object, time.Now(), config), 1)
+		}
+	}
+}
+
+func (kr *k8sobjectsReceiver) consume(ctx context.Context, ld plog.Logs, count int) {
+	obsCtx := kr.obsrecv.StartLogsOp(ctx)
+	consumerErr := kr.logsConsumer.ConsumeLogs(obsCtx, ld)
+	kr.obsrecv.EndLogsOp(obsCtx, typeStr, count, consumerErr)
+}
+
+func listOptions(config *K8sObjectsConfig) metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector: config.LabelSelector,
+		FieldSelector: config.FieldSelector,
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobjectsreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apiWatch "k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var configMapsGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+func newConfigMap(namespace, name string, labels map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"namespace": namespace,
+				"name":      name,
+				"labels":    labels,
+			},
+			"data": map[string]interface{}{
+				"key": "value",
+			},
+		},
+	}
+}
+
+func newFakeClient(objects ...runtime.Object) *fake.FakeDynamicClient {
+	return fake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{configMapsGVR: "ConfigMapList"},
+		objects...,
+	)
+}
+
+func newTestReceiver(t *testing.T, client *fake.FakeDynamicClient, sink *consumertest.LogsSink, objects ...*K8sObjectsConfig) *k8sobjectsReceiver {
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.Objects = objects
+
+	r, err := newReceiver(componenttest.NewNopReceiverCreateSettings(), rCfg, sink, client)
+	require.NoError(t, err)
+	return r.(*k8sobjectsReceiver)
+}
+
+func allLogRecords(sink *consumertest.LogsSink) []plog.LogRecord {
+	var records []plog.LogRecord
+	for _, ld := range sink.AllLogs() {
+		for i := 0; i < ld.ResourceLogs().Len(); i++ {
+			sls := ld.ResourceLogs().At(i).ScopeLogs()
+			for j := 0; j < sls.Len(); j++ {
+				lrs := sls.At(j).LogRecords()
+				for k := 0; k < lrs.Len(); k++ {
+					records = append(records, lrs.At(k))
+				}
+			}
+		}
+	}
+	return records
+}
+
+func TestPullObjects(t *testing.T) {
+	client := newFakeClient(
+		newConfigMap("default", "web", map[string]interface{}{"app": "web"}),
+		newConfigMap("default", "db", map[string]interface{}{"app": "db"}),
+		newConfigMap("other", "web", map[string]interface{}{"app": "web"}),
+	)
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, client, sink, &K8sObjectsConfig{
+		Version:       "v1",
+		Resource:      "configmaps",
+		Mode:          PullMode,
+		LabelSelector: "app=web",
+		Interval:      10 * time.Millisecond,
+	})
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool {
+		return len(sink.AllLogs()) >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	// Every pull emits the selected objects of all namespaces, grouped by namespace.
+	ld := sink.AllLogs()[0]
+	require.Equal(t, 2, ld.ResourceLogs().Len())
+	require.Equal(t, 2, ld.LogRecordCount())
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		ns, ok := rl.Resource().Attributes().Get("k8s.namespace.name")
+		require.True(t, ok)
+		lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+		body := lr.Body().MapVal().AsRaw()
+		assert.Equal(t, "ConfigMap", body["kind"])
+		assert.Equal(t, ns.StringVal(), body["metadata"].(map[string]interface{})["namespace"])
+		assert.Equal(t, "web", body["metadata"].(map[string]interface{})["name"])
+		assert.Equal(t, map[string]interface{}{"event.domain": "k8s", "event.name": "configmaps"}, lr.Attributes().AsRaw())
+		assert.NotZero(t, lr.ObservedTimestamp())
+	}
+}
+
+func TestInvalidModeShutdown(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, newFakeClient(), sink, &K8sObjectsConfig{
+		Version:    "v1",
+		Resource:   "configmaps",
+		Namespaces: []string{"default", "other"},
+	})
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "shutdown didn't complete")
+	}
+	assert.Empty(t, sink.AllLogs())
+}
+
+func TestPullObjectsFromNamespaces(t *testing.T) {
+	client := newFakeClient(
+		newConfigMap("default", "web", nil),
+		newConfigMap("other", "web", nil),
+		newConfigMap("ignored", "web", nil),
+	)
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, client, sink, &K8sObjectsConfig{
+		Version:    "v1",
+		Resource:   "configmaps",
+		Mode:       PullMode,
+		Namespaces: []string{"default", "other"},
+		Interval:   time.Hour,
+	})
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool {
+		return len(sink.AllLogs()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	namespaces := map[string]bool{}
+	for _, ld := range sink.AllLogs() {
+		require.Equal(t, 1, ld.LogRecordCount())
+		ns, _ := ld.ResourceLogs().At(0).Resource().Attributes().Get("k8s.namespace.name")
+		namespaces[ns.StringVal()] = true
+	}
+	assert.Equal(t, map[string]bool{"default": true, "other": true}, namespaces)
+}
+
+func TestWatchObjects(t *testing.T) {
+	client := newFakeClient(newConfigMap("default", "existing", nil))
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, client, sink, &K8sObjectsConfig{
+		Version:  "v1",
+		Resource: "configmaps",
+		Mode:     WatchMode,
+	})
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+	waitForWatch(t, client)
+
+	configMaps := client.Resource(configMapsGVR).Namespace("default")
+	_, err := configMaps.Create(context.Background(), newConfigMap("default", "created", nil), metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, configMaps.Delete(context.Background(), "existing", metav1.DeleteOptions{}))
+
+	assert.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Existing objects are not emitted, only the changes.
+	records := allLogRecords(sink)
+	expected := []struct {
+		eventType string
+		name      string
+	}{
+		{eventType: "ADDED", name: "created"},
+		{eventType: "DELETED", name: "existing"},
+	}
+	for i, lr := range records {
+		body := lr.Body().MapVal().AsRaw()
+		assert.Equal(t, expected[i].eventType, body["type"])
+		object := body["object"].(map[string]interface{})
+		assert.Equal(t, expected[i].name, object["metadata"].(map[string]interface{})["name"])
+		assert.Equal(t, map[string]interface{}{"event.domain": "k8s", "event.name": "configmaps"}, lr.Attributes().AsRaw())
+	}
+}
+
+func TestWatchRestarts(t *testing.T) {
+	defer func(interval time.Duration) { watchRetryInterval = interval }(watchRetryInterval)
+	watchRetryInterval = time.Millisecond
+
+	client := newFakeClient()
+	// Fail the first watch.
+	failed := false
+	client.PrependWatchReactor("configmaps", func(action k8stesting.Action) (bool, apiWatch.Interface, error) {
+		if !failed {
+			failed = true
+			return true, nil, assert.AnError
+		}
+		return false, nil, nil
+	})
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, client, sink, &K8sObjectsConfig{
+		Version:  "v1",
+		Resource: "configmaps",
+		Mode:     WatchMode,
+	})
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+	assert.Eventually(t, func() bool {
+		return countActions(client, "watch") >= 2
+	}, 5*time.Second, time.Millisecond)
+
+	_, err := client.Resource(configMapsGVR).Namespace("default").Create(context.Background(), newConfigMap("default", "created", nil), metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// waitForWatch waits until the receiver watches the objects, so that no change is missed.
+func waitForWatch(t *testing.T, client *fake.FakeDynamicClient) {
+	require.Eventually(t, func() bool {
+		return countActions(client, "watch") > 0
+	}, 5*time.Second, time.Millisecond)
+}
+
+func countActions(client *fake.FakeDynamicClient, verb string) int {
+	count := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == verb {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	semconv "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apiWatch "k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	// Keys of the attributes of the log records.
+	eventDomainKey = "event.domain"
+	eventNameKey   = "event.name"
+
+	eventDomain = "k8s"
+)
+
+// pullObjectsToLogData converts the listed objects to logs, with one log record
+// per object. The body of every log record is the object.
+func pullObjectsToLogData(objects *unstructured.UnstructuredList, observedAt time.Time, config *K8sObjectsConfig) plog.Logs {
+	out := plog.NewLogs()
+	byNamespace := make(map[string]plog.LogRecordSlice)
+	for i := range objects.Items {
+		object := objects.Items[i]
+		lr := appendLogRecord(out, byNamespace, object.GetNamespace(), observedAt, config)
+		pcommon.NewMapFromRaw(object.Object).CopyTo(lr.Body().SetEmptyMapVal())
+	}
+	return out
+}
+
+// watchEventToLogData converts a watch event to logs with a single log record.
+// The body of the log record holds the type of the event and the object.
+func watchEventToLogData(eventType apiWatch.EventType, object *unstructured.Unstructured, observedAt time.Time, config *K8sObjectsConfig) plog.Logs {
+	out := plog.NewLogs()
+	lr := appendLogRecord(out, map[string]plog.LogRecordSlice{}, object.GetNamespace(), observedAt, config)
+	pcommon.NewMapFromRaw(map[string]interface{}{
+		"type":   string(eventType),
+		"object": object.Object,
+	}).CopyTo(lr.Body().SetEmptyMapVal())
+	return out
+}
+
+// appendLogRecord appends a log record to the resource logs of the namespace, creating them if needed.
+func appendLogRecord(out plog.Logs, byNamespace map[string]plog.LogRecordSlice, namespace string, observedAt time.Time, config *K8sObjectsConfig) plog.LogRecord {
+	lrs, ok := byNamespace[namespace]
+	if !ok {
+		rl := out.ResourceLogs().AppendEmpty()
+		if namespace != "" {
+			rl.Resource().Attributes().UpsertString(semconv.AttributeK8SNamespaceName, namespace)
+		}
+		lrs = rl.ScopeLogs().AppendEmpty().LogRecords()
+		byNamespace[namespace] = lrs
+	}
+
+	lr := lrs.AppendEmpty()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(observedAt))
+	lr.Attributes().UpsertString(eventDomainKey, eventDomain)
+	lr.Attributes().UpsertString(eventNameKey, config.Resource)
+	return lr
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobjectsreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apiWatch "k8s.io/apimachinery/pkg/watch"
+)
+
+func TestPullObjectsToLogData(t *testing.T) {
+	objects := &unstructured.UnstructuredList{
+		Items: []unstructured.Unstructured{
+			*newConfigMap("default", "a", nil),
+			*newConfigMap("other", "b", nil),
+			*newConfigMap("default", "c", nil),
+		},
+	}
+	// Cluster scoped objects have no namespace.
+	objects.Items = append(objects.Items, unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":     "Namespace",
+		"metadata": map[string]interface{}{"name": "default"},
+	}})
+
+	observedAt := time.Unix(100, 0)
+	ld := pullObjectsToLogData(objects, observedAt, &K8sObjectsConfig{Resource: "configmaps"})
+	assert.Equal(t, 4, ld.LogRecordCount())
+	require.Equal(t, 3, ld.ResourceLogs().Len())
+
+	expected := []struct {
+		namespace string
+		names     []string
+	}{
+		{namespace: "default", names: []string{"a", "c"}},
+		{namespace: "other", names: []string{"b"}},
+		{names: []string{"default"}},
+	}
+	for i, e := range expected {
+		rl := ld.ResourceLogs().At(i)
+		if e.namespace == "" {
+			assert.Equal(t, 0, rl.Resource().Attributes().Len())
+		} else {
+			assert.Equal(t, map[string]interface{}{"k8s.namespace.name": e.namespace}, rl.Resource().Attributes().AsRaw())
+		}
+		lrs := rl.ScopeLogs().At(0).LogRecords()
+		require.Equal(t, len(e.names), lrs.Len())
+		for j, name := range e.names {
+			lr := lrs.At(j)
+			assert.Equal(t, pcommon.NewTimestampFromTime(observedAt), lr.ObservedTimestamp())
+			metadata := lr.Body().MapVal().AsRaw()["metadata"].(map[string]interface{})
+			assert.Equal(t, name, metadata["name"])
+		}
+	}
+}
+
+func TestWatchEventToLogData(t *testing.T) {
+	observedAt := time.Unix(100, 0)
+	ld := watchEventToLogData(apiWatch.Modified, newConfigMap("default", "a", nil), observedAt, &K8sObjectsConfig{Resource: "configmaps"})
+	require.Equal(t, 1, ld.LogRecordCount())
+
+	rl := ld.ResourceLogs().At(0)
+	assert.Equal(t, map[string]interface{}{"k8s.namespace.name": "default"}, rl.Resource().Attributes().AsRaw())
+	lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, pcommon.NewTimestampFromTime(observedAt), lr.ObservedTimestamp())
+	assert.Equal(t, map[string]interface{}{"event.domain": "k8s", "event.name": "configmaps"}, lr.Attributes().AsRaw())
+
+	body := lr.Body().MapVal().AsRaw()
+	assert.Equal(t, "MODIFIED", body["type"])
+	assert.Equal(t, map[string]interface{}{"key": "value"}, body["object"].(map[string]interface{})["data"])
+}
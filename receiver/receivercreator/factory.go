@@ -62,6 +62,12 @@ func createDefaultConfig() config.Receiver {
 				conventions.AttributeK8SNodeName: "`name`",
 				conventions.AttributeK8SNodeUID:  "`uid`",
 			},
+			observer.K8sServiceType: map[string]string{
+				conventions.AttributeK8SNamespaceName: "`namespace`",
+			},
+			observer.K8sIngressType: map[string]string{
+				conventions.AttributeK8SNamespaceName: "`namespace`",
+			},
 		},
 		receiverTemplates: map[string]receiverTemplate{},
 	}
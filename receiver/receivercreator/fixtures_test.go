@@ -56,6 +56,7 @@ var hostportEndpoint = observer.Endpoint{
 		Command:     "./splunk",
 		Port:        1234,
 		Transport:   observer.ProtocolTCP,
+		Labels:      map[string]string{"job": "splunk"},
 	},
 }
 
@@ -103,6 +104,53 @@ var k8sNodeEndpoint = observer.Endpoint{
 	},
 }
 
+var k8sServiceEndpoint = observer.Endpoint{
+	ID:     "k8s.service-1",
+	Target: "service-1.default.svc",
+	Details: &observer.K8sService{
+		Name:      "service-1",
+		Namespace: "default",
+		UID:       "service-uid-1",
+		Annotations: map[string]string{
+			"prometheus.io/scrape": "true",
+		},
+		ServiceType: "ClusterIP",
+		ClusterIP:   "10.0.0.12",
+		Ports: []observer.K8sServicePort{
+			{Name: "metrics", Port: 9090, TargetPort: "9090", Transport: observer.ProtocolTCP},
+		},
+	},
+}
+
+var k8sIngressEndpoint = observer.Endpoint{
+	ID:     "k8s.ingress-1",
+	Target: "https://example.com/",
+	Details: &observer.K8sIngress{
+		Name:        "ingress-1",
+		Namespace:   "default",
+		UID:         "ingress-uid-1",
+		Scheme:      "https",
+		Host:        "example.com",
+		Path:        "/",
+		ServiceName: "service-1",
+		ServicePort: "9090",
+	},
+}
+
+var consulServiceEndpoint = observer.Endpoint{
+	ID:     "(consul_observer)vm-1/node-exporter-1",
+	Target: "10.0.0.1:9100",
+	Details: &observer.ConsulService{
+		Name:       "node-exporter",
+		ServiceID:  "node-exporter-1",
+		Node:       "vm-1",
+		Datacenter: "dc1",
+		Port:       9100,
+		Tags:       []string{"metrics"},
+		Meta:       map[string]string{"metrics_path": "/metrics"},
+	},
+}
+
 var unsupportedEndpoint = observer.Endpoint{
 	ID:      "endpoint-1",
 	Target:  "localhost:1234",
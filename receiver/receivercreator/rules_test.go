@@ -50,6 +50,10 @@ func Test_ruleEval(t *testing.T) {
 		{"annotations", args{`type == "pod" && annotations["scrape"] == "true"`, podEndpoint}, true, false},
 		{"basic container", args{`type == "container" && labels["region"] == "east-1"`, containerEndpoint}, true, false},
 		{"basic k8s.node", args{`type == "k8s.node" && kubelet_endpoint_port == 10250`, k8sNodeEndpoint}, true, false},
+		{"basic k8s.service", args{`type == "k8s.service" && annotations["prometheus.io/scrape"] == "true" && ports[0].port == 9090`, k8sServiceEndpoint}, true, false},
+		{"hostport labels", args{`type == "hostport" && labels["job"] == "splunk"`, hostportEndpoint}, true, false},
+		{"basic consul.service", args{`type == "consul.service" && name == "node-exporter" && "metrics" in tags && meta["metrics_path"] == "/metrics"`, consulServiceEndpoint}, true, false},
+		{"basic k8s.ingress", args{`type == "k8s.ingress" && scheme == "https" && host == "example.com"`, k8sIngressEndpoint}, true, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {